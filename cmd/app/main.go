@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"strconv"
 	"strings"
+	"time"
+	_ "time/tzdata"
 
 	_ "github.com/lib/pq"
 
@@ -23,15 +28,161 @@ func main() {
 
 	log.Println("Database connection established successfully")
 
+	tx := repositories.NewTransactor(db)
 	repo := repositories.NewEmployeeRepository(db)
 
 	deptRepo := repositories.NewDepartmentRepository(db)
-	deptService := services.NewDepartmentService(deptRepo)
+	deptService := services.NewDepartmentService(deptRepo, repo)
 	deptHandler := handlers.NewDepartmentHandler(deptService)
 
-	employeeService := services.NewEmployeeService(repo, deptRepo)
+	rateRepo := repositories.NewExchangeRateRepository(db)
+	rateService := services.NewExchangeRateService(rateRepo)
+	rateHandler := handlers.NewExchangeRateHandler(rateService)
+
+	reportRepo := repositories.NewReportRepository(db)
+	fiscalStartMonth := 1
+	if v := os.Getenv("FISCAL_YEAR_START_MONTH"); v != "" {
+		if fiscalStartMonth, err = strconv.Atoi(v); err != nil || fiscalStartMonth < 1 || fiscalStartMonth > 12 {
+			log.Fatal("FISCAL_YEAR_START_MONTH must be a month number from 1 to 12")
+		}
+	}
+	salaryStatsMinGroupSize := services.DefaultSalaryStatsMinGroupSize
+	if v := os.Getenv("SALARY_STATS_MIN_GROUP_SIZE"); v != "" {
+		if salaryStatsMinGroupSize, err = strconv.Atoi(v); err != nil || salaryStatsMinGroupSize <= 0 {
+			log.Fatal("SALARY_STATS_MIN_GROUP_SIZE must be a positive number of employees")
+		}
+	}
+	reportService := services.NewReportService(reportRepo, rateService, time.Month(fiscalStartMonth), salaryStatsMinGroupSize)
+	reportHandler := handlers.NewReportHandler(reportService)
+
+	compRepo := repositories.NewCompensationRepository(db)
+	compService := services.NewCompensationService(compRepo, repo)
+	compHandler := handlers.NewCompensationHandler(compService)
+
+	positionRepo := repositories.NewPositionRepository(db)
+	positionService := services.NewPositionService(positionRepo)
+	positionHandler := handlers.NewPositionHandler(positionService)
+
+	bandRepo := repositories.NewSalaryBandRepository(db)
+	bandService := services.NewSalaryBandService(bandRepo, positionRepo, deptRepo, rateService, os.Getenv("SALARY_BAND_POLICY"))
+	bandHandler := handlers.NewSalaryBandHandler(bandService)
+
+	calendarRepo := repositories.NewCalendarRepository(db)
+	calendarService := services.NewCalendarService(calendarRepo, deptRepo, repo)
+	calendarHandler := handlers.NewCalendarHandler(calendarService)
+
+	payrollCfg, err := services.LoadPayrollConfig(os.Getenv("PAYROLL_CONFIG"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	overtimeRules, err := services.LoadOvertimeRules(os.Getenv("OVERTIME_RULES"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	overtimeRepo := repositories.NewOvertimeRepository(db)
+	payrollRepo := repositories.NewPayrollRepository(db)
+	detailsRepo := repositories.NewPersonalDetailsRepository(db)
+	detailsService := services.NewPersonalDetailsService(detailsRepo, repo)
+	detailsHandler := handlers.NewPersonalDetailsHandler(detailsService)
+	payrollService := services.NewPayrollService(payrollRepo, repo, compRepo, overtimeRepo, detailsRepo, rateService, calendarService, payrollCfg, overtimeRules)
+	payrollHandler := handlers.NewPayrollHandler(payrollService)
+
+	payslipService := services.NewPayslipService(payrollRepo, services.CompanyInfo{
+		Name:    os.Getenv("COMPANY_NAME"),
+		Address: os.Getenv("COMPANY_ADDRESS"),
+	})
+	payslipHandler := handlers.NewPayslipHandler(payslipService)
+
+	loc := time.Local
+	if tz := os.Getenv("TIMEZONE"); tz != "" {
+		if loc, err = time.LoadLocation(tz); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	shiftRepo := repositories.NewShiftRepository(db)
+	shiftService := services.NewShiftService(shiftRepo, deptRepo)
+	shiftHandler := handlers.NewShiftHandler(shiftService)
+
+	leaveRepo := repositories.NewLeaveRepository(db)
+
+	minRestHours := 0
+	if v := os.Getenv("ROSTER_MIN_REST_HOURS"); v != "" {
+		if minRestHours, err = strconv.Atoi(v); err != nil {
+			log.Fatal("ROSTER_MIN_REST_HOURS must be a number of hours")
+		}
+	}
+	rosterRepo := repositories.NewRosterRepository(db)
+	rosterService := services.NewRosterService(rosterRepo, shiftRepo, repo, deptRepo, leaveRepo, loc, minRestHours)
+	rosterHandler := handlers.NewRosterHandler(rosterService)
+
+	attendanceRepo := repositories.NewAttendanceRepository(db)
+	attendanceService := services.NewAttendanceService(attendanceRepo, repo, deptRepo, shiftRepo, rosterRepo, loc)
+	attendanceHandler := handlers.NewAttendanceHandler(attendanceService)
+
+	overtimeService := services.NewOvertimeService(overtimeRepo, repo, deptRepo, payrollRepo, attendanceService, calendarService, overtimeRules)
+	overtimeHandler := handlers.NewOvertimeHandler(overtimeService)
+
+	leaveService := services.NewLeaveService(leaveRepo, repo, deptRepo, calendarService)
+	leaveHandler := handlers.NewLeaveHandler(leaveService)
+
+	notificationRepo := repositories.NewNotificationRepository(db)
+	notificationService := services.NewNotificationService(notificationRepo)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+
+	assetRepo := repositories.NewAssetRepository(db)
+	assetService := services.NewAssetService(assetRepo, repo, notificationService, os.Getenv("ASSET_TERMINATION_POLICY"))
+	assetHandler := handlers.NewAssetHandler(assetService)
+
+	skillRepo := repositories.NewSkillRepository(db)
+	skillService := services.NewSkillService(skillRepo, repo)
+	skillHandler := handlers.NewSkillHandler(skillService)
+
+	checklistRepo := repositories.NewChecklistRepository(db)
+	checklistService := services.NewChecklistService(checklistRepo, repo, deptRepo)
+	checklistHandler := handlers.NewChecklistHandler(checklistService)
+
+	eventRepo := repositories.NewEmploymentEventRepository(db)
+	customFieldRepo := repositories.NewCustomFieldRepository(db)
+	customFieldService := services.NewCustomFieldService(customFieldRepo)
+	customFieldHandler := handlers.NewCustomFieldHandler(customFieldService)
+	employeeService := services.NewEmployeeService(repo, deptRepo, compRepo, positionRepo, eventRepo, rateService, bandService, checklistService, assetService, customFieldService, tx)
 	employeeHandler := handlers.NewEmployeeHandler(employeeService)
 
+	// Applies transfers and terminations scheduled for a later date.
+	go employeeService.RunScheduler(context.Background(), time.Hour)
+
+	contractNoticeDays := services.DefaultContractNoticeDays
+	if v := os.Getenv("CONTRACT_EXPIRY_NOTICE_DAYS"); v != "" {
+		if contractNoticeDays, err = strconv.Atoi(v); err != nil || contractNoticeDays <= 0 {
+			log.Fatal("CONTRACT_EXPIRY_NOTICE_DAYS must be a positive number of days")
+		}
+	}
+	contractRepo := repositories.NewContractRepository(db)
+	contractService := services.NewContractService(contractRepo, repo, notificationService, contractNoticeDays)
+	contractHandler := handlers.NewContractHandler(contractService)
+
+	// Raises notifications for contracts about to lapse.
+	go contractService.RunExpiryCheck(context.Background(), 24*time.Hour)
+
+	certificationNoticeDays := services.DefaultCertificationNoticeDays
+	if v := os.Getenv("CERTIFICATION_EXPIRY_NOTICE_DAYS"); v != "" {
+		if certificationNoticeDays, err = strconv.Atoi(v); err != nil || certificationNoticeDays <= 0 {
+			log.Fatal("CERTIFICATION_EXPIRY_NOTICE_DAYS must be a positive number of days")
+		}
+	}
+	trainingRepo := repositories.NewTrainingRepository(db)
+	trainingService := services.NewTrainingService(trainingRepo, repo, deptRepo, certificationNoticeDays)
+	trainingHandler := handlers.NewTrainingHandler(trainingService)
+
+	reviewRepo := repositories.NewReviewRepository(db)
+	reviewService := services.NewReviewService(reviewRepo, repo)
+	reviewHandler := handlers.NewReviewHandler(reviewService)
+
+	recruitingRepo := repositories.NewRecruitingRepository(db)
+	recruitingService := services.NewRecruitingService(recruitingRepo, repo, deptRepo, positionRepo, employeeService, detailsService, tx)
+	recruitingHandler := handlers.NewRecruitingHandler(recruitingService)
+
 
 	mux := http.NewServeMux()
 
@@ -52,7 +203,7 @@ func main() {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 	})
 
-	// /employees: GET=list, POST=create
+	// /employees: GET=list (?asOf=YYYY-MM-DD for a past date), POST=create
 	mux.HandleFunc("/employees", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodGet {
 			employeeHandler.ListEmployees(w, r)
@@ -65,41 +216,878 @@ func main() {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 	})
 
-	mux.HandleFunc("/employees/export_csv", func(w http.ResponseWriter, r *http.Request) {
+	// /exchange-rates: GET=list, POST=create or replace one rate
+	mux.HandleFunc("/exchange-rates", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			rateHandler.ListRates(w, r)
+		case http.MethodPost:
+			rateHandler.CreateRate(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/exchange-rates/import", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodPost {
-			employeeHandler.ExportCSV(w, r)
+			rateHandler.ImportRates(w, r)
 			return
 		}
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 	})
 
-	// GET /departments/{id}/employees -> reuse employeeHandler.ListEmployees with departmentId injected
-	mux.HandleFunc("/departments/", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/reports/salary-summary", reportHandler.SalarySummary)
+	mux.HandleFunc("/reports/compensation-bands", reportHandler.CompensationBands)
+
+	// GET /reports/headcount-budget?year=&departmentId= -> plan vs actual and forecast
+	mux.HandleFunc("/reports/headcount-budget", reportHandler.HeadcountBudget)
+
+	// GET /reports/headcount|turnover?from=YYYY-MM&to=YYYY-MM&departmentId=
+	// GET /reports/tenure|age-distribution?asOf=YYYY-MM-DD&departmentId=
+	mux.HandleFunc("/reports/headcount", reportHandler.Headcount)
+	mux.HandleFunc("/reports/turnover", reportHandler.Turnover)
+	mux.HandleFunc("/reports/tenure", reportHandler.Tenure)
+	mux.HandleFunc("/reports/age-distribution", reportHandler.AgeDistribution)
+
+	// GET /reports/salary-statistics?groupBy=department|position|status|age|tenure&currency=&departmentId=&minGroupSize=
+	// GET /reports/pay-gap?attribute=...&reference={group key}, same filters
+	mux.HandleFunc("/reports/salary-statistics", reportHandler.SalaryStatistics)
+	mux.HandleFunc("/reports/pay-gap", reportHandler.PayGap)
+
+	// GET /reports/certification-compliance?departmentId=&days=&all=true
+	mux.HandleFunc("/reports/certification-compliance", trainingHandler.CertificationCompliance)
+
+	// /positions: GET=list, POST=create
+	mux.HandleFunc("/positions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			positionHandler.ListPositions(w, r)
+		case http.MethodPost:
+			positionHandler.CreatePosition(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// /positions/{id}: GET, PUT, DELETE
+	// /positions/{id}/merge: POST folds duplicate positions into {id}
+	mux.HandleFunc("/positions/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/positions/"), "/"), "/")
+		if len(parts) == 2 && parts[1] == "merge" && r.Method == http.MethodPost {
+			positionHandler.MergePositions(w, r)
+			return
+		}
+		if len(parts) != 1 {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			positionHandler.GetPosition(w, r)
+		case http.MethodPut:
+			positionHandler.UpdatePosition(w, r)
+		case http.MethodDelete:
+			positionHandler.DeletePosition(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// /salary-bands: GET=list (?positionId=), POST=create or replace
+	mux.HandleFunc("/salary-bands", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			bandHandler.ListBands(w, r)
+		case http.MethodPost:
+			bandHandler.SaveBand(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// /payroll/runs: GET=list, POST=run payroll for a period
+	mux.HandleFunc("/payroll/runs", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			payrollHandler.ListRuns(w, r)
+		case http.MethodPost:
+			payrollHandler.CreateRun(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// GET /payroll/runs/{id}, GET /payroll/runs/{id}/register?format=csv|json
+	mux.HandleFunc("/payroll/runs/", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/payroll/runs/"), "/"), "/")
+		switch {
+		case len(parts) == 1:
+			payrollHandler.GetRun(w, r)
+		case len(parts) == 2 && parts[1] == "register":
+			payrollHandler.Register(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	// /calendars: GET=list, POST=create
+	mux.HandleFunc("/calendars", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			calendarHandler.ListCalendars(w, r)
+		case http.MethodPost:
+			calendarHandler.CreateCalendar(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// /calendars/{id}: GET (?year=), PUT
+	// /calendars/{id}/days: POST=add or replace days; /calendars/{id}/days/{date}: DELETE
+	// /calendars/{id}/import: POST an iCalendar file
+	mux.HandleFunc("/calendars/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/calendars/"), "/"), "/")
+		switch {
+		case len(parts) == 1 && r.Method == http.MethodGet:
+			calendarHandler.GetCalendar(w, r)
+		case len(parts) == 1 && r.Method == http.MethodPut:
+			calendarHandler.UpdateCalendar(w, r)
+		case len(parts) == 2 && parts[1] == "days" && r.Method == http.MethodPost:
+			calendarHandler.SaveDays(w, r)
+		case len(parts) == 3 && parts[1] == "days" && r.Method == http.MethodDelete:
+			calendarHandler.DeleteDay(w, r)
+		case len(parts) == 2 && parts[1] == "import" && r.Method == http.MethodPost:
+			calendarHandler.ImportICal(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	// /shifts: GET=list, POST=create; /shifts/{id}: PUT
+	mux.HandleFunc("/shifts", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			shiftHandler.ListShifts(w, r)
+		case http.MethodPost:
+			shiftHandler.CreateShift(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/shifts/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		shiftHandler.UpdateShift(w, r)
+	})
+
+	// DELETE /attendance/{id} removes a manual timesheet entry
+	mux.HandleFunc("/attendance/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		attendanceHandler.DeleteEntry(w, r)
+	})
+
+	// DELETE /roster-assignments/{id} removes a shift from the roster
+	mux.HandleFunc("/roster-assignments/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		rosterHandler.DeleteAssignment(w, r)
+	})
+
+	// GET /overtime?approverId= lists overtime awaiting that approver
+	mux.HandleFunc("/overtime", overtimeHandler.PendingOvertime)
+
+	// GET /overtime/rules, GET /overtime/{id}, POST /overtime/{id}/approve|reject
+	mux.HandleFunc("/overtime/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/overtime/"), "/"), "/")
+		switch {
+		case len(parts) == 1 && parts[0] == "rules" && r.Method == http.MethodGet:
+			overtimeHandler.Rules(w, r)
+		case len(parts) == 1 && r.Method == http.MethodGet:
+			overtimeHandler.GetOvertime(w, r)
+		case len(parts) == 2 && r.Method == http.MethodPost:
+			overtimeHandler.DecideOvertime(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	// GET /contracts/expiring?days= lists contracts ending soon
+	// POST /contracts/expiry-check raises their notifications now
+	// /contracts/{id}: GET, PUT, DELETE
+	mux.HandleFunc("/contracts/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/contracts/"), "/"), "/")
+		switch {
+		case len(parts) != 1:
+			http.NotFound(w, r)
+		case parts[0] == "expiring" && r.Method == http.MethodGet:
+			contractHandler.Expiring(w, r)
+		case parts[0] == "expiry-check" && r.Method == http.MethodPost:
+			contractHandler.CheckExpiring(w, r)
+		case r.Method == http.MethodGet:
+			contractHandler.GetContract(w, r)
+		case r.Method == http.MethodPut:
+			contractHandler.UpdateContract(w, r)
+		case r.Method == http.MethodDelete:
+			contractHandler.DeleteContract(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// GET /notifications?unread=true, POST /notifications/{id}/read
+	mux.HandleFunc("/notifications", notificationHandler.ListNotifications)
+	mux.HandleFunc("/notifications/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/notifications/"), "/"), "/")
+		if len(parts) == 2 && parts[1] == "read" && r.Method == http.MethodPost {
+			notificationHandler.MarkRead(w, r)
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	// /assets: GET=list (?kind=&status=&keyword=), POST=create
+	mux.HandleFunc("/assets", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assetHandler.ListAssets(w, r)
+		case http.MethodPost:
+			assetHandler.CreateAsset(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// GET /assets/export?format=csv|json inventory with current holders
+	// /assets/{id}: GET, PUT; /assets/{id}/history: GET
+	// /assets/{id}/assign, /assets/{id}/return: POST
+	mux.HandleFunc("/assets/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/assets/"), "/"), "/")
+		switch {
+		case len(parts) == 1 && parts[0] == "export" && r.Method == http.MethodGet:
+			assetHandler.ExportInventory(w, r)
+		case len(parts) == 1 && r.Method == http.MethodGet:
+			assetHandler.GetAsset(w, r)
+		case len(parts) == 1 && r.Method == http.MethodPut:
+			assetHandler.UpdateAsset(w, r)
+		case len(parts) == 2 && parts[1] == "history" && r.Method == http.MethodGet:
+			assetHandler.AssetHistory(w, r)
+		case len(parts) == 2 && parts[1] == "assign" && r.Method == http.MethodPost:
+			assetHandler.AssignAsset(w, r)
+		case len(parts) == 2 && parts[1] == "return" && r.Method == http.MethodPost:
+			assetHandler.ReturnAsset(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	// /checklist-templates: GET=list (?departmentId=&kind=), POST=create
+	mux.HandleFunc("/checklist-templates", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			checklistHandler.ListTemplates(w, r)
+		case http.MethodPost:
+			checklistHandler.CreateTemplate(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// /checklist-templates/{id}: GET, PUT, DELETE
+	mux.HandleFunc("/checklist-templates/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			checklistHandler.GetTemplate(w, r)
+		case http.MethodPut:
+			checklistHandler.UpdateTemplate(w, r)
+		case http.MethodDelete:
+			checklistHandler.DeleteTemplate(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// GET /checklists?kind=&departmentId= lists open checklists with progress
+	mux.HandleFunc("/checklists", checklistHandler.OpenChecklists)
+
+	// GET /checklist-tasks/overdue?assigneeId=&departmentId=, POST /checklist-tasks/{id}/complete
+	mux.HandleFunc("/checklist-tasks/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/checklist-tasks/"), "/"), "/")
+		switch {
+		case len(parts) == 1 && parts[0] == "overdue" && r.Method == http.MethodGet:
+			checklistHandler.OverdueTasks(w, r)
+		case len(parts) == 2 && parts[1] == "complete" && r.Method == http.MethodPost:
+			checklistHandler.CompleteTask(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	mux.HandleFunc("/leave-types", leaveHandler.ListLeaveTypes)
+
+	// GET /leave-requests?approverId= lists requests awaiting that approver
+	mux.HandleFunc("/leave-requests", leaveHandler.PendingLeaveRequests)
+
+	// GET /leave-requests/{id}, POST /leave-requests/{id}/approve|reject|cancel
+	mux.HandleFunc("/leave-requests/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/leave-requests/"), "/"), "/")
+		switch {
+		case len(parts) == 1 && r.Method == http.MethodGet:
+			leaveHandler.GetLeaveRequest(w, r)
+		case len(parts) == 2 && r.Method == http.MethodPost:
+			leaveHandler.DecideLeaveRequest(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	// /skills: GET=catalog (?keyword=), POST=add a skill
+	mux.HandleFunc("/skills", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			skillHandler.ListSkills(w, r)
+		case http.MethodPost:
+			skillHandler.CreateSkill(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// /custom-fields: GET=list, POST=define a field (string|number|date|enum)
+	mux.HandleFunc("/custom-fields", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			customFieldHandler.ListCustomFields(w, r)
+		case http.MethodPost:
+			customFieldHandler.CreateCustomField(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// /custom-fields/{id}: GET, PUT (label, options, required), DELETE (also drops stored values)
+	mux.HandleFunc("/custom-fields/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			customFieldHandler.GetCustomField(w, r)
+		case http.MethodPut:
+			customFieldHandler.UpdateCustomField(w, r)
+		case http.MethodDelete:
+			customFieldHandler.DeleteCustomField(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// /certifications: GET=list, POST=add a certification type
+	mux.HandleFunc("/certifications", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			trainingHandler.ListCertificationTypes(w, r)
+		case http.MethodPost:
+			trainingHandler.CreateCertificationType(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// /courses: GET=list, POST=create
+	mux.HandleFunc("/courses", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			trainingHandler.ListCourses(w, r)
+		case http.MethodPost:
+			trainingHandler.CreateCourse(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// GET /courses/{id}; /courses/{id}/enrolments: GET=list, POST=enrol an employee
+	mux.HandleFunc("/courses/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/courses/"), "/"), "/")
+		switch {
+		case len(parts) == 1 && r.Method == http.MethodGet:
+			trainingHandler.GetCourse(w, r)
+		case len(parts) == 2 && parts[1] == "enrolments" && r.Method == http.MethodGet:
+			trainingHandler.ListEnrolments(w, r)
+		case len(parts) == 2 && parts[1] == "enrolments" && r.Method == http.MethodPost:
+			trainingHandler.Enrol(w, r)
+		default:
 			http.NotFound(w, r)
+		}
+	})
+
+	// POST /enrolments/{id}/complete|cancel
+	mux.HandleFunc("/enrolments/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/enrolments/"), "/"), "/")
+		switch {
+		case len(parts) == 2 && parts[1] == "complete" && r.Method == http.MethodPost:
+			trainingHandler.CompleteEnrolment(w, r)
+		case len(parts) == 2 && parts[1] == "cancel" && r.Method == http.MethodPost:
+			trainingHandler.CancelEnrolment(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	// /review-cycles: GET=list, POST=create a draft cycle
+	mux.HandleFunc("/review-cycles", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			reviewHandler.ListCycles(w, r)
+		case http.MethodPost:
+			reviewHandler.CreateCycle(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// GET /review-cycles/{id}, POST /review-cycles/{id}/open|close
+	// GET /review-cycles/{id}/dashboard -> completion per department
+	// GET /review-cycles/{id}/reviews?reviewerId=&departmentId=
+	mux.HandleFunc("/review-cycles/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/review-cycles/"), "/"), "/")
+		switch {
+		case len(parts) == 1 && r.Method == http.MethodGet:
+			reviewHandler.GetCycle(w, r)
+		case len(parts) == 2 && parts[1] == "open" && r.Method == http.MethodPost:
+			reviewHandler.OpenCycle(w, r)
+		case len(parts) == 2 && parts[1] == "close" && r.Method == http.MethodPost:
+			reviewHandler.CloseCycle(w, r)
+		case len(parts) == 2 && parts[1] == "dashboard" && r.Method == http.MethodGet:
+			reviewHandler.Dashboard(w, r)
+		case len(parts) == 2 && parts[1] == "reviews" && r.Method == http.MethodGet:
+			reviewHandler.ListReviews(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	// GET /reviews/{id}, POST /reviews/{id}/self|manager|calibrate, PUT /reviews/{id}/reviewer
+	mux.HandleFunc("/reviews/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/reviews/"), "/"), "/")
+		switch {
+		case len(parts) == 1 && r.Method == http.MethodGet:
+			reviewHandler.GetReview(w, r)
+		case len(parts) == 2 && parts[1] == "reviewer" && r.Method == http.MethodPut:
+			reviewHandler.SetReviewer(w, r)
+		case len(parts) == 2 && r.Method == http.MethodPost:
+			reviewHandler.SubmitReview(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	// /goals/{id}: PUT (including progress), DELETE
+	mux.HandleFunc("/goals/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			reviewHandler.UpdateGoal(w, r)
+		case http.MethodDelete:
+			reviewHandler.DeleteGoal(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// /pipeline-stages: GET=ordered list, POST=add; /pipeline-stages/{id}: PUT, DELETE
+	mux.HandleFunc("/pipeline-stages", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			recruitingHandler.ListStages(w, r)
+		case http.MethodPost:
+			recruitingHandler.CreateStage(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/pipeline-stages/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			recruitingHandler.UpdateStage(w, r)
+		case http.MethodDelete:
+			recruitingHandler.DeleteStage(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// /requisitions: GET=list (?departmentId=&status=), POST=open a requisition
+	mux.HandleFunc("/requisitions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			recruitingHandler.ListRequisitions(w, r)
+		case http.MethodPost:
+			recruitingHandler.CreateRequisition(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// /requisitions/{id}: GET, PUT
+	// /requisitions/{id}/candidates: GET (?stageId=&status=), POST=add a candidate
+	mux.HandleFunc("/requisitions/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/requisitions/"), "/"), "/")
+		switch {
+		case len(parts) == 1 && r.Method == http.MethodGet:
+			recruitingHandler.GetRequisition(w, r)
+		case len(parts) == 1 && r.Method == http.MethodPut:
+			recruitingHandler.UpdateRequisition(w, r)
+		case len(parts) == 2 && parts[1] == "candidates" && r.Method == http.MethodGet:
+			recruitingHandler.ListCandidates(w, r)
+		case len(parts) == 2 && parts[1] == "candidates" && r.Method == http.MethodPost:
+			recruitingHandler.AddCandidate(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	// /candidates/{id}: GET (with history and feedback), PUT
+	// POST /candidates/{id}/stage|reject|withdraw|feedback|accept-offer
+	mux.HandleFunc("/candidates/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/candidates/"), "/"), "/")
+		if len(parts) == 1 {
+			switch r.Method {
+			case http.MethodGet:
+				recruitingHandler.GetCandidate(w, r)
+			case http.MethodPut:
+				recruitingHandler.UpdateCandidate(w, r)
+			default:
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			}
 			return
 		}
-		if !strings.HasSuffix(r.URL.Path, "/employees") {
+		if len(parts) != 2 || r.Method != http.MethodPost {
 			http.NotFound(w, r)
 			return
 		}
+		switch parts[1] {
+		case "stage":
+			recruitingHandler.MoveCandidate(w, r)
+		case "reject", "withdraw":
+			recruitingHandler.CloseCandidate(w, r)
+		case "feedback":
+			recruitingHandler.AddFeedback(w, r)
+		case "accept-offer":
+			recruitingHandler.AcceptOffer(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
 
+	// GET /employees/search?skill=Go:3&skill=PostgreSQL:2&departmentId=
+	mux.HandleFunc("/employees/search", skillHandler.SearchEmployees)
+
+	mux.HandleFunc("/employees/export_csv", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			employeeHandler.ExportCSV(w, r)
+			return
+		}
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	})
+
+	// GET /departments/{id}/employees -> reuse employeeHandler.ListEmployees with departmentId injected (?skill= and ?asOf= filters too)
+	// GET /departments/{id}/payslips/{period} -> zip of the department's payslip PDFs
+	// PUT /departments/{id}/head -> appoint or clear the department head
+	// PUT /departments/{id}/calendar -> assign the working calendar
+	// PUT /departments/{id}/shift -> default shift for late/early flags
+	// GET /departments/{id}/timesheet/{month} -> monthly timesheet CSV
+	// GET /departments/{id}/roster?week= -> weekly roster with conflicts, POST -> assign shifts
+	// POST /departments/{id}/overtime/{period} -> recalculate members' overtime
+	// GET /departments/{id}/required-certifications, PUT -> replace the list
+	// GET /departments/{id}/headcount -> staff by status and open requisitions
+	// GET /departments/{id} -> department with its yearly plans
+	// GET /departments/{id}/plans, PUT|DELETE /departments/{id}/plans/{year}
+	mux.HandleFunc("/departments/", func(w http.ResponseWriter, r *http.Request) {
 		prefix := "/departments/"
 		p := strings.TrimPrefix(r.URL.Path, prefix)
-		parts := strings.SplitN(p, "/", 2)
-		if len(parts) < 2 || parts[1] != "employees" {
+		parts := strings.Split(strings.Trim(p, "/"), "/")
+		if len(parts) == 1 && parts[0] != "" && r.Method == http.MethodGet {
+			deptHandler.GetDepartment(w, r)
+			return
+		}
+		if len(parts) < 2 {
 			http.NotFound(w, r)
 			return
 		}
-		idStr := parts[0]
-		q := r.URL.Query()
-		q.Set("departmentId", idStr)
-		r.URL.RawQuery = q.Encode()
-		employeeHandler.ListEmployees(w, r)
+
+		if len(parts) == 2 && parts[1] == "head" && r.Method == http.MethodPut {
+			deptHandler.SetHead(w, r)
+			return
+		}
+		if len(parts) == 2 && parts[1] == "calendar" && r.Method == http.MethodPut {
+			calendarHandler.SetDepartmentCalendar(w, r)
+			return
+		}
+		if len(parts) == 2 && parts[1] == "shift" && r.Method == http.MethodPut {
+			shiftHandler.SetDepartmentShift(w, r)
+			return
+		}
+		if len(parts) == 2 && parts[1] == "roster" && r.Method == http.MethodPost {
+			rosterHandler.AssignShifts(w, r)
+			return
+		}
+		if len(parts) == 3 && parts[1] == "overtime" && r.Method == http.MethodPost {
+			overtimeHandler.CalculateDepartment(w, r)
+			return
+		}
+		if len(parts) == 2 && parts[1] == "required-certifications" && r.Method == http.MethodPut {
+			trainingHandler.SetRequiredCertifications(w, r)
+			return
+		}
+		if len(parts) == 3 && parts[1] == "plans" && r.Method == http.MethodPut {
+			deptHandler.SavePlan(w, r)
+			return
+		}
+		if len(parts) == 3 && parts[1] == "plans" && r.Method == http.MethodDelete {
+			deptHandler.DeletePlan(w, r)
+			return
+		}
+		if r.Method != http.MethodGet {
+			http.NotFound(w, r)
+			return
+		}
+
+		switch {
+		case parts[1] == "employees" && len(parts) == 2:
+			idStr := parts[0]
+			q := r.URL.Query()
+			q.Set("departmentId", idStr)
+			r.URL.RawQuery = q.Encode()
+			employeeHandler.ListEmployees(w, r)
+		case parts[1] == "payslips" && len(parts) == 3:
+			payslipHandler.DepartmentPayslips(w, r)
+		case parts[1] == "timesheet" && len(parts) == 3:
+			attendanceHandler.DepartmentTimesheet(w, r)
+		case parts[1] == "roster" && len(parts) == 2:
+			rosterHandler.GetRoster(w, r)
+		case parts[1] == "required-certifications" && len(parts) == 2:
+			trainingHandler.RequiredCertifications(w, r)
+		case parts[1] == "headcount" && len(parts) == 2:
+			recruitingHandler.DepartmentHeadcount(w, r)
+		case parts[1] == "plans" && len(parts) == 2:
+			deptHandler.ListPlans(w, r)
+		default:
+			http.NotFound(w, r)
+		}
 	})
 
 	// /employees/{id}: GET, PUT, DELETE
+	// /employees/{id}/compensation: GET=history, POST=record or schedule a change
+	// /employees/{id}/payslips[/{period}]: GET=list, or the payslip PDF of a period
+	// /employees/{id}/leave-requests: GET=list (?status=), POST=submit
+	// /employees/{id}/leave-balances: GET (?year=)
+	// /employees/{id}/working-days: GET (?from=&to=) on the department's calendar
+	// /employees/{id}/clock-in, /employees/{id}/clock-out: POST
+	// /employees/{id}/attendance: GET=daily/weekly totals (?from=&to=), POST=manual entry
+	// /employees/{id}/overtime/{period}: GET=items, POST=recalculate from attendance
+	// /employees/{id}/hire|status|transfer|terminate|rehire: POST lifecycle changes
+	// /employees/{id}/history: GET employment events
+	// /employees/{id}/contracts: GET=list, POST=add or renew a contract
+	// /employees/{id}/checklists: GET onboarding and offboarding checklists
+	// /employees/{id}/assets: GET assets held (?all=true for the history)
+	// /employees/{id}/skills: GET, POST=set a level; /employees/{id}/skills/{skillId}: DELETE
+	// /employees/{id}/skills/{skillId}/endorse: POST
+	// /employees/{id}/training: GET course enrolments
+	// /employees/{id}/certifications: GET (?days=), POST=record a certificate
+	// /employees/{id}/reviews: GET performance reviews
+	// /employees/{id}/goals: GET (?cycleId=), POST=add a goal
+	// /employees/{id}/addresses|phones|emergency-contacts|dependents: GET=list, POST=add
+	// /employees/{id}/addresses|phones|emergency-contacts|dependents/{detailId}: PUT, DELETE
 	mux.HandleFunc("/employees/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/employees/"), "/"), "/")
+		if len(parts) > 1 {
+			switch {
+			case parts[1] == "compensation" && len(parts) == 2:
+				switch r.Method {
+				case http.MethodGet:
+					compHandler.GetCompensation(w, r)
+				case http.MethodPost:
+					compHandler.RecordCompensation(w, r)
+				default:
+					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				}
+			case parts[1] == "payslips" && len(parts) == 2 && r.Method == http.MethodGet:
+				payslipHandler.ListEmployeePayslips(w, r)
+			case parts[1] == "payslips" && len(parts) == 3 && r.Method == http.MethodGet:
+				payslipHandler.GetEmployeePayslip(w, r)
+			case parts[1] == "leave-requests" && len(parts) == 2:
+				switch r.Method {
+				case http.MethodGet:
+					leaveHandler.ListEmployeeLeaveRequests(w, r)
+				case http.MethodPost:
+					leaveHandler.SubmitLeaveRequest(w, r)
+				default:
+					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				}
+			case parts[1] == "leave-balances" && len(parts) == 2 && r.Method == http.MethodGet:
+				leaveHandler.LeaveBalances(w, r)
+			case parts[1] == "working-days" && len(parts) == 2 && r.Method == http.MethodGet:
+				calendarHandler.WorkingDays(w, r)
+			case parts[1] == "clock-in" && len(parts) == 2 && r.Method == http.MethodPost:
+				attendanceHandler.ClockIn(w, r)
+			case parts[1] == "clock-out" && len(parts) == 2 && r.Method == http.MethodPost:
+				attendanceHandler.ClockOut(w, r)
+			case parts[1] == "attendance" && len(parts) == 2:
+				switch r.Method {
+				case http.MethodGet:
+					attendanceHandler.EmployeeTimesheet(w, r)
+				case http.MethodPost:
+					attendanceHandler.AddEntry(w, r)
+				default:
+					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				}
+			case parts[1] == "overtime" && len(parts) == 3:
+				overtimeHandler.EmployeeOvertime(w, r)
+			case parts[1] == "history" && len(parts) == 2 && r.Method == http.MethodGet:
+				employeeHandler.History(w, r)
+			case parts[1] == "hire" && len(parts) == 2 && r.Method == http.MethodPost:
+				employeeHandler.Hire(w, r)
+			case parts[1] == "status" && len(parts) == 2 && r.Method == http.MethodPost:
+				employeeHandler.ChangeStatus(w, r)
+			case parts[1] == "transfer" && len(parts) == 2 && r.Method == http.MethodPost:
+				employeeHandler.Transfer(w, r)
+			case parts[1] == "terminate" && len(parts) == 2 && r.Method == http.MethodPost:
+				employeeHandler.Terminate(w, r)
+			case parts[1] == "rehire" && len(parts) == 2 && r.Method == http.MethodPost:
+				employeeHandler.Rehire(w, r)
+			case parts[1] == "skills" && len(parts) == 2:
+				switch r.Method {
+				case http.MethodGet:
+					skillHandler.EmployeeSkills(w, r)
+				case http.MethodPost:
+					skillHandler.SetEmployeeSkill(w, r)
+				default:
+					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				}
+			case parts[1] == "skills" && len(parts) == 3 && r.Method == http.MethodDelete:
+				skillHandler.RemoveEmployeeSkill(w, r)
+			case parts[1] == "skills" && len(parts) == 4 && parts[3] == "endorse" && r.Method == http.MethodPost:
+				skillHandler.Endorse(w, r)
+			case parts[1] == "reviews" && len(parts) == 2 && r.Method == http.MethodGet:
+				reviewHandler.EmployeeReviews(w, r)
+			case parts[1] == "goals" && len(parts) == 2:
+				switch r.Method {
+				case http.MethodGet:
+					reviewHandler.ListGoals(w, r)
+				case http.MethodPost:
+					reviewHandler.CreateGoal(w, r)
+				default:
+					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				}
+			case parts[1] == "training" && len(parts) == 2 && r.Method == http.MethodGet:
+				trainingHandler.EmployeeTraining(w, r)
+			case parts[1] == "certifications" && len(parts) == 2:
+				switch r.Method {
+				case http.MethodGet:
+					trainingHandler.EmployeeCertifications(w, r)
+				case http.MethodPost:
+					trainingHandler.AddCertification(w, r)
+				default:
+					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				}
+			case parts[1] == "assets" && len(parts) == 2 && r.Method == http.MethodGet:
+				assetHandler.EmployeeAssets(w, r)
+			case parts[1] == "checklists" && len(parts) == 2 && r.Method == http.MethodGet:
+				checklistHandler.EmployeeChecklists(w, r)
+			case parts[1] == "contracts" && len(parts) == 2:
+				switch r.Method {
+				case http.MethodGet:
+					contractHandler.ListEmployeeContracts(w, r)
+				case http.MethodPost:
+					contractHandler.CreateContract(w, r)
+				default:
+					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				}
+			case parts[1] == "addresses" && len(parts) == 2:
+				switch r.Method {
+				case http.MethodGet:
+					detailsHandler.ListAddresses(w, r)
+				case http.MethodPost:
+					detailsHandler.CreateAddress(w, r)
+				default:
+					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				}
+			case parts[1] == "addresses" && len(parts) == 3:
+				switch r.Method {
+				case http.MethodPut:
+					detailsHandler.UpdateAddress(w, r)
+				case http.MethodDelete:
+					detailsHandler.DeleteAddress(w, r)
+				default:
+					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				}
+			case parts[1] == "phones" && len(parts) == 2:
+				switch r.Method {
+				case http.MethodGet:
+					detailsHandler.ListPhones(w, r)
+				case http.MethodPost:
+					detailsHandler.CreatePhone(w, r)
+				default:
+					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				}
+			case parts[1] == "phones" && len(parts) == 3:
+				switch r.Method {
+				case http.MethodPut:
+					detailsHandler.UpdatePhone(w, r)
+				case http.MethodDelete:
+					detailsHandler.DeletePhone(w, r)
+				default:
+					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				}
+			case parts[1] == "emergency-contacts" && len(parts) == 2:
+				switch r.Method {
+				case http.MethodGet:
+					detailsHandler.ListEmergencyContacts(w, r)
+				case http.MethodPost:
+					detailsHandler.CreateEmergencyContact(w, r)
+				default:
+					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				}
+			case parts[1] == "emergency-contacts" && len(parts) == 3:
+				switch r.Method {
+				case http.MethodPut:
+					detailsHandler.UpdateEmergencyContact(w, r)
+				case http.MethodDelete:
+					detailsHandler.DeleteEmergencyContact(w, r)
+				default:
+					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				}
+			case parts[1] == "dependents" && len(parts) == 2:
+				switch r.Method {
+				case http.MethodGet:
+					detailsHandler.ListDependents(w, r)
+				case http.MethodPost:
+					detailsHandler.CreateDependent(w, r)
+				default:
+					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				}
+			case parts[1] == "dependents" && len(parts) == 3:
+				switch r.Method {
+				case http.MethodPut:
+					detailsHandler.UpdateDependent(w, r)
+				case http.MethodDelete:
+					detailsHandler.DeleteDependent(w, r)
+				default:
+					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				}
+			default:
+				http.NotFound(w, r)
+			}
+			return
+		}
+
 		switch r.Method {
 		case http.MethodGet:
 			employeeHandler.GetByID(w, r)
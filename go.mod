@@ -2,4 +2,4 @@ module app
 
 go 1.22.12
 
-require github.com/lib/pq v1.10.9 // indirect
+require github.com/lib/pq v1.10.9
@@ -0,0 +1,382 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type AssetHandler struct {
+	service *services.AssetService
+}
+
+func NewAssetHandler(service *services.AssetService) *AssetHandler {
+	return &AssetHandler{
+		service: service,
+	}
+}
+
+type AssetResponse struct {
+	ID           int64   `json:"id"`
+	AssetTag     string  `json:"assetTag"`
+	Kind         string  `json:"kind"`
+	Name         string  `json:"name"`
+	SerialNumber *string `json:"serialNumber"`
+	Status       string  `json:"status"`
+	PurchaseDate *string `json:"purchaseDate"`
+	Note         *string `json:"note"`
+	HolderID     *int64  `json:"holderId"`
+	HolderName   *string `json:"holderName"`
+	AssignedOn   *string `json:"assignedOn"`
+	CreatedAt    string  `json:"createdAt"`
+	UpdatedAt    string  `json:"updatedAt"`
+}
+
+func toAssetResponse(a *models.Asset) AssetResponse {
+	return AssetResponse{
+		ID:           a.ID,
+		AssetTag:     a.AssetTag,
+		Kind:         a.Kind,
+		Name:         a.Name,
+		SerialNumber: a.SerialNumber,
+		Status:       a.Status,
+		PurchaseDate: formatDatePtr(a.PurchaseDate),
+		Note:         a.Note,
+		HolderID:     a.HolderID,
+		HolderName:   a.HolderName,
+		AssignedOn:   formatDatePtr(a.AssignedOn),
+		CreatedAt:    a.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    a.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type AssetAssignmentResponse struct {
+	ID           int64   `json:"id"`
+	AssetID      int64   `json:"assetId"`
+	AssetTag     string  `json:"assetTag"`
+	Kind         string  `json:"kind"`
+	Name         string  `json:"name"`
+	SerialNumber *string `json:"serialNumber"`
+	EmployeeID   int64   `json:"employeeId"`
+	EmployeeName string  `json:"employeeName"`
+	AssignedOn   string  `json:"assignedOn"`
+	ReturnedOn   *string `json:"returnedOn"`
+	ConditionOut *string `json:"conditionOut"`
+	ConditionIn  *string `json:"conditionIn"`
+	Note         *string `json:"note"`
+}
+
+func toAssetAssignmentResponse(as *models.AssetAssignment) AssetAssignmentResponse {
+	return AssetAssignmentResponse{
+		ID:           as.ID,
+		AssetID:      as.AssetID,
+		AssetTag:     as.AssetTag,
+		Kind:         as.AssetKind,
+		Name:         as.AssetName,
+		SerialNumber: as.SerialNumber,
+		EmployeeID:   as.EmployeeID,
+		EmployeeName: as.EmployeeName,
+		AssignedOn:   as.AssignedOn.Format(dateLayout),
+		ReturnedOn:   formatDatePtr(as.ReturnedOn),
+		ConditionOut: as.ConditionOut,
+		ConditionIn:  as.ConditionIn,
+		Note:         as.Note,
+	}
+}
+
+func toAssetAssignmentResponses(assignments []*models.AssetAssignment) []AssetAssignmentResponse {
+	out := []AssetAssignmentResponse{}
+	for _, as := range assignments {
+		out = append(out, toAssetAssignmentResponse(as))
+	}
+	return out
+}
+
+// assetRequest is the body of both POST /assets and PUT /assets/{id}.
+type assetRequest struct {
+	AssetTag     string  `json:"assetTag"`
+	Kind         string  `json:"kind"`
+	Name         string  `json:"name"`
+	SerialNumber *string `json:"serialNumber"`
+	Status       string  `json:"status"`
+	PurchaseDate *string `json:"purchaseDate"`
+	Note         *string `json:"note"`
+}
+
+func (req assetRequest) toAsset() (*models.Asset, error) {
+	purchased, err := parseOptionalDate(req.PurchaseDate, "purchaseDate")
+	if err != nil {
+		return nil, err
+	}
+	return &models.Asset{
+		AssetTag:     req.AssetTag,
+		Kind:         req.Kind,
+		Name:         req.Name,
+		SerialNumber: req.SerialNumber,
+		Status:       req.Status,
+		PurchaseDate: purchased,
+		Note:         req.Note,
+	}, nil
+}
+
+func writeAssetError(w http.ResponseWriter, err error, notFound string) {
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, notFound)
+		return
+	}
+	writeError(w, http.StatusBadRequest, err.Error())
+}
+
+// ListAssets handles GET /assets?kind=&status=&keyword=.
+func (h *AssetHandler) ListAssets(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	assets, err := h.service.List(r.Context(), q.Get("kind"), q.Get("status"), q.Get("keyword"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	out := []AssetResponse{}
+	for _, a := range assets {
+		out = append(out, toAssetResponse(a))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *AssetHandler) CreateAsset(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreateAsset handler called")
+
+	var req assetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	a, err := req.toAsset()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.Create(r.Context(), a); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, toAssetResponse(a))
+}
+
+func (h *AssetHandler) GetAsset(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/assets/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	a, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeAssetError(w, err, "asset not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toAssetResponse(a))
+}
+
+// UpdateAsset handles PUT /assets/{id}; an empty status keeps the current
+// one.
+func (h *AssetHandler) UpdateAsset(w http.ResponseWriter, r *http.Request) {
+	log.Println("UpdateAsset handler called")
+
+	id, err := pathID(r, "/assets/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req assetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	a, err := req.toAsset()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	a.ID = id
+	if err := h.service.Update(r.Context(), a); err != nil {
+		writeAssetError(w, err, "asset not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toAssetResponse(a))
+}
+
+// AssetHistory handles GET /assets/{id}/history, every hand-over oldest
+// first.
+func (h *AssetHandler) AssetHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/assets/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	assignments, err := h.service.History(r.Context(), id)
+	if err != nil {
+		writeAssetError(w, err, "asset not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toAssetAssignmentResponses(assignments))
+}
+
+// AssignAsset handles POST /assets/{id}/assign with {"employeeId",
+// "assignedOn", "condition", "note"}; assignedOn defaults to today.
+func (h *AssetHandler) AssignAsset(w http.ResponseWriter, r *http.Request) {
+	log.Println("AssignAsset handler called")
+
+	id, err := pathID(r, "/assets/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		EmployeeID int64   `json:"employeeId"`
+		AssignedOn *string `json:"assignedOn"`
+		Condition  *string `json:"condition"`
+		Note       *string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.EmployeeID == 0 {
+		writeError(w, http.StatusBadRequest, "employeeId is required")
+		return
+	}
+	on := time.Now()
+	if req.AssignedOn != nil {
+		if on, err = parseDate(*req.AssignedOn); err != nil {
+			writeError(w, http.StatusBadRequest, "assignedOn must be YYYY-MM-DD")
+			return
+		}
+	}
+
+	as, err := h.service.Assign(r.Context(), id, req.EmployeeID, on, req.Condition, req.Note)
+	if err != nil {
+		writeAssetError(w, err, "asset not found")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toAssetAssignmentResponse(as))
+}
+
+// ReturnAsset handles POST /assets/{id}/return with {"returnedOn",
+// "condition", "status", "note"}; status is available (default), retired
+// or lost.
+func (h *AssetHandler) ReturnAsset(w http.ResponseWriter, r *http.Request) {
+	log.Println("ReturnAsset handler called")
+
+	id, err := pathID(r, "/assets/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		ReturnedOn *string `json:"returnedOn"`
+		Condition  *string `json:"condition"`
+		Status     string  `json:"status"`
+		Note       *string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	on := time.Now()
+	if req.ReturnedOn != nil {
+		if on, err = parseDate(*req.ReturnedOn); err != nil {
+			writeError(w, http.StatusBadRequest, "returnedOn must be YYYY-MM-DD")
+			return
+		}
+	}
+
+	as, err := h.service.Return(r.Context(), id, on, req.Status, req.Condition, req.Note)
+	if err != nil {
+		writeAssetError(w, err, "asset not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toAssetAssignmentResponse(as))
+}
+
+// EmployeeAssets handles GET /employees/{id}/assets, the assets the
+// employee holds, or ?all=true for everything they ever had.
+func (h *AssetHandler) EmployeeAssets(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	assignments, err := h.service.ByEmployee(r.Context(), id, r.URL.Query().Get("all") == "true")
+	if err != nil {
+		writeAssetError(w, err, "employee not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toAssetAssignmentResponses(assignments))
+}
+
+var assetInventoryHeader = []string{
+	"AssetTag", "Kind", "Name", "SerialNumber", "Status", "PurchaseDate", "HolderID", "HolderName", "AssignedOn", "Note",
+}
+
+// ExportInventory handles GET /assets/export?format=csv|json&kind=&status=
+// and downloads the inventory with each asset's current holder.
+func (h *AssetHandler) ExportInventory(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	assets, err := h.service.List(r.Context(), q.Get("kind"), q.Get("status"), q.Get("keyword"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	name := "asset_inventory_" + time.Now().Format(dateLayout)
+	if q.Get("format") == "json" {
+		out := []AssetResponse{}
+		for _, a := range assets {
+			out = append(out, toAssetResponse(a))
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		serveDownload(w, r, name+".json", "application/json", data)
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	wtr := csv.NewWriter(buf)
+	wtr.Write(assetInventoryHeader)
+	for _, a := range assets {
+		holderID := ""
+		if a.HolderID != nil {
+			holderID = strconv.FormatInt(*a.HolderID, 10)
+		}
+		wtr.Write([]string{
+			a.AssetTag,
+			a.Kind,
+			a.Name,
+			derefString(a.SerialNumber),
+			a.Status,
+			derefString(formatDatePtr(a.PurchaseDate)),
+			holderID,
+			derefString(a.HolderName),
+			derefString(formatDatePtr(a.AssignedOn)),
+			derefString(a.Note),
+		})
+	}
+	wtr.Flush()
+	if err := wtr.Error(); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	serveDownload(w, r, name+".csv", "text/csv", buf.Bytes())
+}
@@ -0,0 +1,393 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type AttendanceHandler struct {
+	service *services.AttendanceService
+}
+
+func NewAttendanceHandler(service *services.AttendanceService) *AttendanceHandler {
+	return &AttendanceHandler{
+		service: service,
+	}
+}
+
+type AttendanceRecordResponse struct {
+	ID         int64   `json:"id"`
+	EmployeeID int64   `json:"employeeId"`
+	WorkDate   string  `json:"workDate"`
+	ClockIn    string  `json:"clockIn"`
+	ClockOut   *string `json:"clockOut"`
+	Source     string  `json:"source"`
+	ShiftID    *int64  `json:"shiftId"`
+	Note       *string `json:"note"`
+}
+
+func (h *AttendanceHandler) toRecordResponse(a *models.AttendanceRecord) AttendanceRecordResponse {
+	loc := h.service.Location()
+	var clockOut *string
+	if a.ClockOut != nil {
+		s := a.ClockOut.In(loc).Format(time.RFC3339)
+		clockOut = &s
+	}
+	return AttendanceRecordResponse{
+		ID:         a.ID,
+		EmployeeID: a.EmployeeID,
+		WorkDate:   a.WorkDate.Format(dateLayout),
+		ClockIn:    a.ClockIn.In(loc).Format(time.RFC3339),
+		ClockOut:   clockOut,
+		Source:     a.Source,
+		ShiftID:    a.ShiftID,
+		Note:       a.Note,
+	}
+}
+
+func minutesToHours(m int) string {
+	return strconv.FormatFloat(float64(m)/60, 'f', 2, 64)
+}
+
+// writeClockError answers 409 for conflict, the clock state the request
+// did not expect, and 500 for failures that are not the client's.
+func writeClockError(w http.ResponseWriter, err error, conflict error) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, http.StatusNotFound, "employee not found")
+	case errors.Is(err, conflict):
+		writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrNotStarted), errors.Is(err, services.ErrNoLongerEmployed):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		log.Printf("clock in/out: %v", err)
+		writeError(w, http.StatusInternalServerError, "failed to record attendance")
+	}
+}
+
+// ClockIn handles POST /employees/{id}/clock-in with an optional {"note"}.
+func (h *AttendanceHandler) ClockIn(w http.ResponseWriter, r *http.Request) {
+	log.Println("ClockIn handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req struct {
+		Note *string `json:"note"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+
+	a, err := h.service.ClockIn(r.Context(), id, req.Note)
+	if err != nil {
+		writeClockError(w, err, services.ErrAlreadyClockedIn)
+		return
+	}
+	writeJSON(w, http.StatusCreated, h.toRecordResponse(a))
+}
+
+// ClockOut handles POST /employees/{id}/clock-out.
+func (h *AttendanceHandler) ClockOut(w http.ResponseWriter, r *http.Request) {
+	log.Println("ClockOut handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	a, err := h.service.ClockOut(r.Context(), id)
+	if err != nil {
+		writeClockError(w, err, services.ErrNotClockedIn)
+		return
+	}
+	writeJSON(w, http.StatusOK, h.toRecordResponse(a))
+}
+
+// AddEntry handles POST /employees/{id}/attendance, a manual timesheet entry
+// {"date", "clockIn": "HH:MM", "clockOut": "HH:MM", "note"} in company time.
+// A clockOut at or before clockIn falls on the next day.
+func (h *AttendanceHandler) AddEntry(w http.ResponseWriter, r *http.Request) {
+	log.Println("AddEntry handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req struct {
+		Date     string  `json:"date"`
+		ClockIn  string  `json:"clockIn"`
+		ClockOut string  `json:"clockOut"`
+		Note     *string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	date, err := parseDate(req.Date)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "date must be YYYY-MM-DD")
+		return
+	}
+	if _, err := models.ParseShiftClock(req.ClockIn); err != nil {
+		writeError(w, http.StatusBadRequest, "clockIn must be HH:MM")
+		return
+	}
+	if _, err := models.ParseShiftClock(req.ClockOut); err != nil {
+		writeError(w, http.StatusBadRequest, "clockOut must be HH:MM")
+		return
+	}
+	// same overnight rule as shifts
+	span := models.Shift{StartTime: req.ClockIn, EndTime: req.ClockOut}
+	in, out := span.Bounds(date, h.service.Location())
+
+	a := &models.AttendanceRecord{
+		EmployeeID: id,
+		ClockIn:    in,
+		ClockOut:   &out,
+		Note:       req.Note,
+	}
+	if err := h.service.AddEntry(r.Context(), a); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "employee not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, h.toRecordResponse(a))
+}
+
+// DeleteEntry handles DELETE /attendance/{id} for manual entries.
+func (h *AttendanceHandler) DeleteEntry(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/attendance/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.service.DeleteEntry(r.Context(), id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "attendance record not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EmployeeTimesheet handles GET /employees/{id}/attendance?from=&to=,
+// defaulting to the current month.
+func (h *AttendanceHandler) EmployeeTimesheet(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	now := time.Now().In(h.service.Location())
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, -1)
+	q := r.URL.Query()
+	if v := q.Get("from"); v != "" {
+		if from, err = parseDate(v); err != nil {
+			writeError(w, http.StatusBadRequest, "from must be YYYY-MM-DD")
+			return
+		}
+	}
+	if v := q.Get("to"); v != "" {
+		if to, err = parseDate(v); err != nil {
+			writeError(w, http.StatusBadRequest, "to must be YYYY-MM-DD")
+			return
+		}
+	}
+	if to.Before(from) {
+		writeError(w, http.StatusBadRequest, "to must not be before from")
+		return
+	}
+
+	ts, err := h.service.EmployeeTimesheet(r.Context(), id, from, to)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "employee not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	type respDay struct {
+		Date          string                     `json:"date"`
+		ShiftID       *int64                     `json:"shiftId"`
+		WorkedMinutes int                        `json:"workedMinutes"`
+		WorkedHours   string                     `json:"workedHours"`
+		Late          bool                       `json:"late"`
+		LateMinutes   int                        `json:"lateMinutes"`
+		LeftEarly     bool                       `json:"leftEarly"`
+		EarlyMinutes  int                        `json:"earlyMinutes"`
+		Open          bool                       `json:"open"`
+		Records       []AttendanceRecordResponse `json:"records"`
+	}
+	type respWeek struct {
+		WeekStart     string `json:"weekStart"`
+		DaysWorked    int    `json:"daysWorked"`
+		WorkedMinutes int    `json:"workedMinutes"`
+		WorkedHours   string `json:"workedHours"`
+		LateDays      int    `json:"lateDays"`
+		EarlyDays     int    `json:"earlyDays"`
+	}
+
+	days := []respDay{}
+	total := 0
+	for _, d := range ts.Days {
+		var shiftID *int64
+		if d.Shift != nil {
+			shiftID = &d.Shift.ID
+		}
+		records := []AttendanceRecordResponse{}
+		for _, a := range d.Records {
+			records = append(records, h.toRecordResponse(a))
+		}
+		days = append(days, respDay{
+			Date:          d.Date.Format(dateLayout),
+			ShiftID:       shiftID,
+			WorkedMinutes: d.WorkedMinutes,
+			WorkedHours:   minutesToHours(d.WorkedMinutes),
+			Late:          d.LateMinutes > 0,
+			LateMinutes:   d.LateMinutes,
+			LeftEarly:     d.EarlyMinutes > 0,
+			EarlyMinutes:  d.EarlyMinutes,
+			Open:          d.Open,
+			Records:       records,
+		})
+		total += d.WorkedMinutes
+	}
+	weeks := []respWeek{}
+	for _, wk := range ts.Weeks {
+		weeks = append(weeks, respWeek{
+			WeekStart:     wk.WeekStart.Format(dateLayout),
+			DaysWorked:    wk.DaysWorked,
+			WorkedMinutes: wk.WorkedMinutes,
+			WorkedHours:   minutesToHours(wk.WorkedMinutes),
+			LateDays:      wk.LateDays,
+			EarlyDays:     wk.EarlyDays,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		EmployeeID    int64      `json:"employeeId"`
+		From          string     `json:"from"`
+		To            string     `json:"to"`
+		WorkedMinutes int        `json:"workedMinutes"`
+		WorkedHours   string     `json:"workedHours"`
+		Days          []respDay  `json:"days"`
+		Weeks         []respWeek `json:"weeks"`
+	}{id, from.Format(dateLayout), to.Format(dateLayout), total, minutesToHours(total), days, weeks})
+}
+
+var timesheetHeader = []string{"employeeId", "employeeName", "date", "shift", "firstIn", "lastOut", "workedHours", "lateMinutes", "earlyMinutes"}
+
+// DepartmentTimesheet handles GET /departments/{id}/timesheet/{YYYY-MM}: a
+// CSV with one row per employee and day worked, plus a total row per
+// employee. It is saved under EXPORT_DIR unless download=true.
+func (h *AttendanceHandler) DepartmentTimesheet(w http.ResponseWriter, r *http.Request) {
+	log.Println("DepartmentTimesheet handler called")
+
+	deptID, err := pathID(r, "/departments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	month := pathSegment(r, "/departments/", 2)
+	start, _, err := services.ParsePeriod(month)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sheets, err := h.service.DepartmentTimesheets(r.Context(), deptID, start)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "department not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	loc := h.service.Location()
+	buf := &bytes.Buffer{}
+	wtr := csv.NewWriter(buf)
+	wtr.Write(timesheetHeader)
+	for _, ts := range sheets {
+		empID := strconv.FormatInt(ts.Employee.ID, 10)
+		total, late, early := 0, 0, 0
+		for _, d := range ts.Days {
+			shift := ""
+			if d.Shift != nil {
+				shift = d.Shift.Name
+			}
+			lastOut := ""
+			if last := d.Records[len(d.Records)-1].ClockOut; last != nil && !d.Open {
+				lastOut = last.In(loc).Format("15:04")
+			}
+			wtr.Write([]string{
+				empID,
+				ts.Employee.Name,
+				d.Date.Format(dateLayout),
+				shift,
+				d.Records[0].ClockIn.In(loc).Format("15:04"),
+				lastOut,
+				minutesToHours(d.WorkedMinutes),
+				strconv.Itoa(d.LateMinutes),
+				strconv.Itoa(d.EarlyMinutes),
+			})
+			total += d.WorkedMinutes
+			late += d.LateMinutes
+			early += d.EarlyMinutes
+		}
+		wtr.Write([]string{empID, ts.Employee.Name, "TOTAL", "", "", "", minutesToHours(total), strconv.Itoa(late), strconv.Itoa(early)})
+	}
+	wtr.Flush()
+	if err := wtr.Error(); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	name := fmt.Sprintf("timesheet_department_%d_%s.csv", deptID, month)
+	if r.URL.Query().Get("download") == "true" {
+		serveDownload(w, r, name, "text/csv", buf.Bytes())
+		return
+	}
+	if err := saveExport(name, buf.Bytes()); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"file":      name,
+		"employees": len(sheets),
+		"exportDir": exportDir(),
+	})
+}
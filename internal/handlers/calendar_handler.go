@@ -0,0 +1,386 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type CalendarHandler struct {
+	service *services.CalendarService
+}
+
+func NewCalendarHandler(service *services.CalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		service: service,
+	}
+}
+
+type CalendarResponse struct {
+	ID          int64   `json:"id"`
+	Name        string  `json:"name"`
+	Country     *string `json:"country"`
+	WeekendDays []int   `json:"weekendDays"`
+	IsDefault   bool    `json:"isDefault"`
+	CreatedAt   string  `json:"createdAt"`
+	UpdatedAt   string  `json:"updatedAt"`
+}
+
+func toCalendarResponse(c *models.WorkCalendar) CalendarResponse {
+	weekend := []int{}
+	for _, d := range c.WeekendDays {
+		weekend = append(weekend, int(d))
+	}
+	return CalendarResponse{
+		ID:          c.ID,
+		Name:        c.Name,
+		Country:     c.Country,
+		WeekendDays: weekend,
+		IsDefault:   c.IsDefault,
+		CreatedAt:   c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   c.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type CalendarDayResponse struct {
+	Date string `json:"date"`
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+func toCalendarDayResponses(days []*models.CalendarDay) []CalendarDayResponse {
+	out := []CalendarDayResponse{}
+	for _, d := range days {
+		out = append(out, CalendarDayResponse{Date: d.Date.Format(dateLayout), Kind: d.Kind, Name: d.Name})
+	}
+	return out
+}
+
+type calendarRequest struct {
+	Name        string  `json:"name"`
+	Country     *string `json:"country"`
+	WeekendDays *[]int  `json:"weekendDays"`
+	IsDefault   bool    `json:"isDefault"`
+}
+
+func (req calendarRequest) apply(c *models.WorkCalendar) {
+	c.Name = req.Name
+	c.Country = req.Country
+	c.IsDefault = req.IsDefault
+	if req.WeekendDays != nil {
+		c.WeekendDays = nil
+		for _, d := range *req.WeekendDays {
+			c.WeekendDays = append(c.WeekendDays, time.Weekday(d))
+		}
+	}
+}
+
+func (h *CalendarHandler) ListCalendars(w http.ResponseWriter, r *http.Request) {
+	calendars, err := h.service.List(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	out := []CalendarResponse{}
+	for _, c := range calendars {
+		out = append(out, toCalendarResponse(c))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// CreateCalendar handles POST /calendars. weekendDays defaults to Saturday
+// and Sunday ([6, 0]).
+func (h *CalendarHandler) CreateCalendar(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreateCalendar handler called")
+
+	var req calendarRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	c := &models.WorkCalendar{WeekendDays: []time.Weekday{time.Saturday, time.Sunday}}
+	req.apply(c)
+	if err := h.service.Create(r.Context(), c); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, toCalendarResponse(c))
+}
+
+// GetCalendar handles GET /calendars/{id}?year=, returning the calendar with
+// its holidays and days off in that year (the current one by default).
+func (h *CalendarHandler) GetCalendar(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/calendars/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	year := time.Now().Year()
+	if y := r.URL.Query().Get("year"); y != "" {
+		v, err := strconv.Atoi(y)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid year")
+			return
+		}
+		year = v
+	}
+
+	c, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "calendar not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	days, err := h.service.Days(r.Context(), id, year)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		CalendarResponse
+		Year int                   `json:"year"`
+		Days []CalendarDayResponse `json:"days"`
+	}{toCalendarResponse(c), year, toCalendarDayResponses(days)})
+}
+
+func (h *CalendarHandler) UpdateCalendar(w http.ResponseWriter, r *http.Request) {
+	log.Println("UpdateCalendar handler called")
+
+	id, err := pathID(r, "/calendars/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "calendar not found")
+		return
+	}
+
+	req := calendarRequest{Name: c.Name, Country: c.Country, IsDefault: c.IsDefault}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.apply(c)
+
+	if err := h.service.Update(r.Context(), c); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, toCalendarResponse(c))
+}
+
+// SaveDays handles POST /calendars/{id}/days with a list of
+// {"date", "kind", "name"}; kind is holiday, day_off or working_day.
+func (h *CalendarHandler) SaveDays(w http.ResponseWriter, r *http.Request) {
+	log.Println("SaveDays handler called")
+
+	id, err := pathID(r, "/calendars/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req []struct {
+		Date string `json:"date"`
+		Kind string `json:"kind"`
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	days := make([]*models.CalendarDay, 0, len(req))
+	for _, d := range req {
+		date, err := parseDate(d.Date)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "date must be YYYY-MM-DD")
+			return
+		}
+		days = append(days, &models.CalendarDay{Date: date, Kind: d.Kind, Name: d.Name})
+	}
+
+	if err := h.service.SaveDays(r.Context(), id, days); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "calendar not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, toCalendarDayResponses(days))
+}
+
+// DeleteDay handles DELETE /calendars/{id}/days/{date}.
+func (h *CalendarHandler) DeleteDay(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/calendars/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	date, err := parseDate(pathSegment(r, "/calendars/", 2))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "date must be YYYY-MM-DD")
+		return
+	}
+
+	if err := h.service.DeleteDay(r.Context(), id, date); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "day not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ImportICal handles POST /calendars/{id}/import?kind=, taking an .ics file
+// either as the raw body or as the multipart field "file".
+func (h *CalendarHandler) ImportICal(w http.ResponseWriter, r *http.Request) {
+	log.Println("ImportICal handler called")
+
+	id, err := pathID(r, "/calendars/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var src io.Reader = r.Body
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		f, _, err := r.FormFile("file")
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "file is required")
+			return
+		}
+		defer f.Close()
+		src = f
+	}
+
+	days, err := h.service.ImportICal(r.Context(), id, src, r.URL.Query().Get("kind"))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "calendar not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Imported int                   `json:"imported"`
+		Days     []CalendarDayResponse `json:"days"`
+	}{len(days), toCalendarDayResponses(days)})
+}
+
+// SetDepartmentCalendar handles PUT /departments/{id}/calendar. A null
+// calendarId makes the department use the default calendar.
+func (h *CalendarHandler) SetDepartmentCalendar(w http.ResponseWriter, r *http.Request) {
+	log.Println("SetDepartmentCalendar handler called")
+
+	id, err := pathID(r, "/departments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid department id")
+		return
+	}
+
+	var req struct {
+		CalendarID *int64 `json:"calendarId"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.service.SetDepartmentCalendar(r.Context(), id, req.CalendarID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "department not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"departmentId": id, "calendarId": req.CalendarID})
+}
+
+// WorkingDays handles GET /employees/{id}/working-days?from=&to=, counting
+// working days on the calendar of the employee's department.
+func (h *CalendarHandler) WorkingDays(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	q := r.URL.Query()
+	from, err := parseDate(q.Get("from"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "from must be YYYY-MM-DD")
+		return
+	}
+	to, err := parseDate(q.Get("to"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "to must be YYYY-MM-DD")
+		return
+	}
+	if to.Before(from) {
+		writeError(w, http.StatusBadRequest, "to must not be before from")
+		return
+	}
+	if to.Sub(from) > 366*24*time.Hour {
+		writeError(w, http.StatusBadRequest, "range must not exceed one year")
+		return
+	}
+
+	cal, err := h.service.ForEmployee(r.Context(), id, from, to)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "employee not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var calendarID *int64
+	calendarName := "Monday-Friday"
+	if cal.Calendar != nil {
+		calendarID = &cal.Calendar.ID
+		calendarName = cal.Calendar.Name
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		EmployeeID   int64                 `json:"employeeId"`
+		CalendarID   *int64                `json:"calendarId"`
+		Calendar     string                `json:"calendar"`
+		From         string                `json:"from"`
+		To           string                `json:"to"`
+		WorkingDays  int                   `json:"workingDays"`
+		CalendarDays []CalendarDayResponse `json:"calendarDays"`
+	}{
+		EmployeeID:   id,
+		CalendarID:   calendarID,
+		Calendar:     calendarName,
+		From:         from.Format(dateLayout),
+		To:           to.Format(dateLayout),
+		WorkingDays:  cal.WorkingDays(from, to),
+		CalendarDays: toCalendarDayResponses(cal.Days),
+	})
+}
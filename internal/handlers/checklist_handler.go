@@ -0,0 +1,382 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type ChecklistHandler struct {
+	service *services.ChecklistService
+}
+
+func NewChecklistHandler(service *services.ChecklistService) *ChecklistHandler {
+	return &ChecklistHandler{
+		service: service,
+	}
+}
+
+type ChecklistTemplateTaskResponse struct {
+	Title         string  `json:"title"`
+	AssigneeRole  *string `json:"assigneeRole"`
+	AssigneeID    *int64  `json:"assigneeId"`
+	DueOffsetDays int     `json:"dueOffsetDays"`
+}
+
+type ChecklistTemplateResponse struct {
+	ID           int64                           `json:"id"`
+	DepartmentID *int64                          `json:"departmentId"`
+	Kind         string                          `json:"kind"`
+	Name         string                          `json:"name"`
+	Tasks        []ChecklistTemplateTaskResponse `json:"tasks"`
+	CreatedAt    string                          `json:"createdAt"`
+	UpdatedAt    string                          `json:"updatedAt"`
+}
+
+func toChecklistTemplateResponse(t *models.ChecklistTemplate) ChecklistTemplateResponse {
+	tasks := []ChecklistTemplateTaskResponse{}
+	for _, task := range t.Tasks {
+		tasks = append(tasks, ChecklistTemplateTaskResponse{
+			Title:         task.Title,
+			AssigneeRole:  task.AssigneeRole,
+			AssigneeID:    task.AssigneeID,
+			DueOffsetDays: task.DueOffsetDays,
+		})
+	}
+	return ChecklistTemplateResponse{
+		ID:           t.ID,
+		DepartmentID: t.DepartmentID,
+		Kind:         t.Kind,
+		Name:         t.Name,
+		Tasks:        tasks,
+		CreatedAt:    t.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    t.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type ChecklistTaskResponse struct {
+	ID          int64   `json:"id"`
+	ChecklistID int64   `json:"checklistId"`
+	Title       string  `json:"title"`
+	AssigneeID  *int64  `json:"assigneeId"`
+	DueDate     string  `json:"dueDate"`
+	Done        bool    `json:"done"`
+	CompletedAt *string `json:"completedAt"`
+	CompletedBy *int64  `json:"completedBy"`
+	Note        *string `json:"note"`
+}
+
+func toChecklistTaskResponse(t *models.ChecklistTask) ChecklistTaskResponse {
+	var completedAt *string
+	if t.CompletedAt != nil {
+		s := t.CompletedAt.Format(time.RFC3339)
+		completedAt = &s
+	}
+	return ChecklistTaskResponse{
+		ID:          t.ID,
+		ChecklistID: t.ChecklistID,
+		Title:       t.Title,
+		AssigneeID:  t.AssigneeID,
+		DueDate:     t.DueDate.Format(dateLayout),
+		Done:        t.CompletedAt != nil,
+		CompletedAt: completedAt,
+		CompletedBy: t.CompletedBy,
+		Note:        t.Note,
+	}
+}
+
+type ChecklistResponse struct {
+	ID         int64                   `json:"id"`
+	EmployeeID int64                   `json:"employeeId"`
+	TemplateID *int64                  `json:"templateId"`
+	Kind       string                  `json:"kind"`
+	AnchorDate string                  `json:"anchorDate"`
+	Completed  bool                    `json:"completed"`
+	Tasks      []ChecklistTaskResponse `json:"tasks"`
+	CreatedAt  string                  `json:"createdAt"`
+}
+
+func toChecklistResponse(c *models.Checklist) ChecklistResponse {
+	tasks := []ChecklistTaskResponse{}
+	for _, t := range c.Tasks {
+		tasks = append(tasks, toChecklistTaskResponse(t))
+	}
+	return ChecklistResponse{
+		ID:         c.ID,
+		EmployeeID: c.EmployeeID,
+		TemplateID: c.TemplateID,
+		Kind:       c.Kind,
+		AnchorDate: c.AnchorDate.Format(dateLayout),
+		Completed:  c.CompletedAt != nil,
+		Tasks:      tasks,
+		CreatedAt:  c.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// checklistTemplateRequest is the body of both POST /checklist-templates and
+// PUT /checklist-templates/{id}; departmentId and kind are fixed once created.
+type checklistTemplateRequest struct {
+	DepartmentID *int64 `json:"departmentId"`
+	Kind         string `json:"kind"`
+	Name         string `json:"name"`
+	Tasks        []struct {
+		Title         string  `json:"title"`
+		AssigneeRole  *string `json:"assigneeRole"`
+		AssigneeID    *int64  `json:"assigneeId"`
+		DueOffsetDays int     `json:"dueOffsetDays"`
+	} `json:"tasks"`
+}
+
+func (req checklistTemplateRequest) toTemplate() *models.ChecklistTemplate {
+	t := &models.ChecklistTemplate{
+		DepartmentID: req.DepartmentID,
+		Kind:         req.Kind,
+		Name:         req.Name,
+	}
+	for _, task := range req.Tasks {
+		t.Tasks = append(t.Tasks, &models.ChecklistTemplateTask{
+			Title:         task.Title,
+			AssigneeRole:  task.AssigneeRole,
+			AssigneeID:    task.AssigneeID,
+			DueOffsetDays: task.DueOffsetDays,
+		})
+	}
+	return t
+}
+
+func writeChecklistError(w http.ResponseWriter, err error, notFound string) {
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, notFound)
+		return
+	}
+	writeError(w, http.StatusBadRequest, err.Error())
+}
+
+// ListTemplates handles GET /checklist-templates?departmentId=&kind=.
+func (h *ChecklistHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	departmentID, err := queryInt64(r, "departmentId")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	templates, err := h.service.ListTemplates(r.Context(), departmentID, r.URL.Query().Get("kind"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	out := []ChecklistTemplateResponse{}
+	for _, t := range templates {
+		out = append(out, toChecklistTemplateResponse(t))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// CreateTemplate handles POST /checklist-templates. Without departmentId the
+// template applies to departments that have none of their own.
+func (h *ChecklistHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreateTemplate handler called")
+
+	var req checklistTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	t := req.toTemplate()
+	if err := h.service.CreateTemplate(r.Context(), t); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, toChecklistTemplateResponse(t))
+}
+
+func (h *ChecklistHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/checklist-templates/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	t, err := h.service.GetTemplate(r.Context(), id)
+	if err != nil {
+		writeChecklistError(w, err, "checklist template not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toChecklistTemplateResponse(t))
+}
+
+// UpdateTemplate handles PUT /checklist-templates/{id}, replacing the name
+// and tasks. Checklists already started keep their tasks.
+func (h *ChecklistHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	log.Println("UpdateTemplate handler called")
+
+	id, err := pathID(r, "/checklist-templates/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req checklistTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	t := req.toTemplate()
+	t.ID = id
+	if err := h.service.UpdateTemplate(r.Context(), t); err != nil {
+		writeChecklistError(w, err, "checklist template not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toChecklistTemplateResponse(t))
+}
+
+func (h *ChecklistHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	log.Println("DeleteTemplate handler called")
+
+	id, err := pathID(r, "/checklist-templates/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.DeleteTemplate(r.Context(), id); err != nil {
+		writeChecklistError(w, err, "checklist template not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EmployeeChecklists handles GET /employees/{id}/checklists.
+func (h *ChecklistHandler) EmployeeChecklists(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	checklists, err := h.service.ListByEmployee(r.Context(), id)
+	if err != nil {
+		writeChecklistError(w, err, "employee not found")
+		return
+	}
+	out := []ChecklistResponse{}
+	for _, c := range checklists {
+		out = append(out, toChecklistResponse(c))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+type ChecklistSummaryResponse struct {
+	ID           int64  `json:"id"`
+	EmployeeID   int64  `json:"employeeId"`
+	EmployeeName string `json:"employeeName"`
+	DepartmentID int64  `json:"departmentId"`
+	Kind         string `json:"kind"`
+	AnchorDate   string `json:"anchorDate"`
+	TotalTasks   int    `json:"totalTasks"`
+	DoneTasks    int    `json:"doneTasks"`
+	OverdueTasks int    `json:"overdueTasks"`
+}
+
+// OpenChecklists handles GET /checklists?kind=&departmentId=, who is
+// mid-onboarding or offboarding and how far along they are.
+func (h *ChecklistHandler) OpenChecklists(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	departmentID, err := queryInt64(r, "departmentId")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	summaries, err := h.service.ListOpen(r.Context(), r.URL.Query().Get("kind"), departmentID, time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	out := []ChecklistSummaryResponse{}
+	for _, s := range summaries {
+		out = append(out, ChecklistSummaryResponse{
+			ID:           s.ID,
+			EmployeeID:   s.EmployeeID,
+			EmployeeName: s.EmployeeName,
+			DepartmentID: s.DepartmentID,
+			Kind:         s.Kind,
+			AnchorDate:   s.AnchorDate.Format(dateLayout),
+			TotalTasks:   s.TotalTasks,
+			DoneTasks:    s.DoneTasks,
+			OverdueTasks: s.OverdueTasks,
+		})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+type OverdueTaskResponse struct {
+	ChecklistTaskResponse
+	Kind         string `json:"kind"`
+	EmployeeID   int64  `json:"employeeId"`
+	EmployeeName string `json:"employeeName"`
+	DaysOverdue  int    `json:"daysOverdue"`
+}
+
+// OverdueTasks handles GET /checklist-tasks/overdue?assigneeId=&departmentId=.
+func (h *ChecklistHandler) OverdueTasks(w http.ResponseWriter, r *http.Request) {
+	assigneeID, err := queryInt64(r, "assigneeId")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	departmentID, err := queryInt64(r, "departmentId")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	tasks, err := h.service.Overdue(r.Context(), today, assigneeID, departmentID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	out := []OverdueTaskResponse{}
+	for _, t := range tasks {
+		out = append(out, OverdueTaskResponse{
+			ChecklistTaskResponse: toChecklistTaskResponse(&t.ChecklistTask),
+			Kind:                  t.Kind,
+			EmployeeID:            t.EmployeeID,
+			EmployeeName:          t.EmployeeName,
+			DaysOverdue:           int(today.Sub(t.DueDate).Hours() / 24),
+		})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// CompleteTask handles POST /checklist-tasks/{id}/complete with
+// {"completedBy", "note"}.
+func (h *ChecklistHandler) CompleteTask(w http.ResponseWriter, r *http.Request) {
+	log.Println("CompleteTask handler called")
+
+	id, err := pathID(r, "/checklist-tasks/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		CompletedBy *int64  `json:"completedBy"`
+		Note        *string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	t, err := h.service.CompleteTask(r.Context(), id, req.CompletedBy, req.Note)
+	if err != nil {
+		writeChecklistError(w, err, "checklist task not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toChecklistTaskResponse(t))
+}
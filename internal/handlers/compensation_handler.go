@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type CompensationHandler struct {
+	service *services.CompensationService
+}
+
+func NewCompensationHandler(service *services.CompensationService) *CompensationHandler {
+	return &CompensationHandler{
+		service: service,
+	}
+}
+
+type CompensationResponse struct {
+	ID            int64          `json:"id"`
+	EffectiveDate string         `json:"effectiveDate"`
+	Amount        models.Decimal `json:"amount"`
+	Currency      string         `json:"currency"`
+	Reason        string         `json:"reason"`
+	ApprovedBy    *int64         `json:"approvedBy"`
+	Note          *string        `json:"note"`
+	CreatedAt     string         `json:"createdAt"`
+}
+
+func toCompensationResponse(c *models.Compensation) CompensationResponse {
+	return CompensationResponse{
+		ID:            c.ID,
+		EffectiveDate: c.EffectiveDate.Format(dateLayout),
+		Amount:        c.Amount,
+		Currency:      c.Currency,
+		Reason:        c.Reason,
+		ApprovedBy:    c.ApprovedBy,
+		Note:          c.Note,
+		CreatedAt:     c.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func toCompensationResponses(list []*models.Compensation) []CompensationResponse {
+	out := []CompensationResponse{}
+	for _, c := range list {
+		out = append(out, toCompensationResponse(c))
+	}
+	return out
+}
+
+// GetCompensation handles GET /employees/{id}/compensation.
+func (h *CompensationHandler) GetCompensation(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	overview, err := h.service.Overview(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "employee not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var current *CompensationResponse
+	if overview.Current != nil {
+		c := toCompensationResponse(overview.Current)
+		current = &c
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		EmployeeID int64                  `json:"employeeId"`
+		Current    *CompensationResponse  `json:"current"`
+		History    []CompensationResponse `json:"history"`
+		Scheduled  []CompensationResponse `json:"scheduled"`
+	}{
+		EmployeeID: id,
+		Current:    current,
+		History:    toCompensationResponses(overview.History),
+		Scheduled:  toCompensationResponses(overview.Scheduled),
+	})
+}
+
+// RecordCompensation handles POST /employees/{id}/compensation. A future
+// effectiveDate schedules the raise instead of applying it immediately.
+func (h *CompensationHandler) RecordCompensation(w http.ResponseWriter, r *http.Request) {
+	log.Println("RecordCompensation handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req struct {
+		EffectiveDate string         `json:"effectiveDate"`
+		Amount        models.Decimal `json:"amount"`
+		Currency      string         `json:"currency"`
+		Reason        string         `json:"reason"`
+		ApprovedBy    *int64         `json:"approvedBy"`
+		Note          *string        `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	effective, err := parseDate(req.EffectiveDate)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "effectiveDate must be YYYY-MM-DD")
+		return
+	}
+
+	c := &models.Compensation{
+		EmployeeID:    id,
+		EffectiveDate: effective,
+		Amount:        req.Amount,
+		Currency:      req.Currency,
+		Reason:        req.Reason,
+		ApprovedBy:    req.ApprovedBy,
+		Note:          req.Note,
+	}
+	if err := h.service.Record(r.Context(), c); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toCompensationResponse(c))
+}
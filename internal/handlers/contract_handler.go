@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type ContractHandler struct {
+	service *services.ContractService
+}
+
+func NewContractHandler(service *services.ContractService) *ContractHandler {
+	return &ContractHandler{
+		service: service,
+	}
+}
+
+type ContractResponse struct {
+	ID             int64   `json:"id"`
+	EmployeeID     int64   `json:"employeeId"`
+	ContractNumber *string `json:"contractNumber"`
+	Type           string  `json:"type"`
+	StartDate      string  `json:"startDate"`
+	EndDate        *string `json:"endDate"`
+	SignedDate     *string `json:"signedDate"`
+	DocumentRef    *string `json:"documentRef"`
+	Note           *string `json:"note"`
+	CreatedAt      string  `json:"createdAt"`
+	UpdatedAt      string  `json:"updatedAt"`
+}
+
+func toContractResponse(c *models.Contract) ContractResponse {
+	return ContractResponse{
+		ID:             c.ID,
+		EmployeeID:     c.EmployeeID,
+		ContractNumber: c.ContractNumber,
+		Type:           c.Type,
+		StartDate:      c.StartDate.Format(dateLayout),
+		EndDate:        formatDatePtr(c.EndDate),
+		SignedDate:     formatDatePtr(c.SignedDate),
+		DocumentRef:    c.DocumentRef,
+		Note:           c.Note,
+		CreatedAt:      c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      c.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// contractRequest is the body of both POST /employees/{id}/contracts and
+// PUT /contracts/{id}.
+type contractRequest struct {
+	ContractNumber *string `json:"contractNumber"`
+	Type           string  `json:"type"`
+	StartDate      string  `json:"startDate"`
+	EndDate        *string `json:"endDate"`
+	SignedDate     *string `json:"signedDate"`
+	DocumentRef    *string `json:"documentRef"`
+	Note           *string `json:"note"`
+}
+
+func (req contractRequest) toContract() (*models.Contract, error) {
+	start, err := parseDate(req.StartDate)
+	if err != nil {
+		return nil, errors.New("startDate must be YYYY-MM-DD")
+	}
+	end, err := parseOptionalDate(req.EndDate, "endDate")
+	if err != nil {
+		return nil, err
+	}
+	signed, err := parseOptionalDate(req.SignedDate, "signedDate")
+	if err != nil {
+		return nil, err
+	}
+	return &models.Contract{
+		ContractNumber: req.ContractNumber,
+		Type:           req.Type,
+		StartDate:      start,
+		EndDate:        end,
+		SignedDate:     signed,
+		DocumentRef:    req.DocumentRef,
+		Note:           req.Note,
+	}, nil
+}
+
+func writeContractError(w http.ResponseWriter, err error, notFound string) {
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, notFound)
+		return
+	}
+	writeError(w, http.StatusBadRequest, err.Error())
+}
+
+// ListEmployeeContracts handles GET /employees/{id}/contracts.
+func (h *ContractHandler) ListEmployeeContracts(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	contracts, err := h.service.ListByEmployee(r.Context(), id)
+	if err != nil {
+		writeContractError(w, err, "employee not found")
+		return
+	}
+	out := []ContractResponse{}
+	for _, c := range contracts {
+		out = append(out, toContractResponse(c))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// CreateContract handles POST /employees/{id}/contracts. A renewal is a new
+// contract starting after the previous one ends.
+func (h *ContractHandler) CreateContract(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreateContract handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req contractRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	c, err := req.toContract()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	c.EmployeeID = id
+
+	if err := h.service.Create(r.Context(), c); err != nil {
+		writeContractError(w, err, "employee not found")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toContractResponse(c))
+}
+
+func (h *ContractHandler) GetContract(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/contracts/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	c, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeContractError(w, err, "contract not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toContractResponse(c))
+}
+
+// UpdateContract handles PUT /contracts/{id} with the same body as create,
+// e.g. to attach the signed document.
+func (h *ContractHandler) UpdateContract(w http.ResponseWriter, r *http.Request) {
+	log.Println("UpdateContract handler called")
+
+	id, err := pathID(r, "/contracts/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req contractRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	c, err := req.toContract()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	c.ID = id
+
+	if err := h.service.Update(r.Context(), c); err != nil {
+		writeContractError(w, err, "contract not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toContractResponse(c))
+}
+
+func (h *ContractHandler) DeleteContract(w http.ResponseWriter, r *http.Request) {
+	log.Println("DeleteContract handler called")
+
+	id, err := pathID(r, "/contracts/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		writeContractError(w, err, "contract not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type ContractExpiryResponse struct {
+	ContractResponse
+	EmployeeName string `json:"employeeName"`
+	DepartmentID int64  `json:"departmentId"`
+	DaysLeft     int    `json:"daysLeft"`
+}
+
+// Expiring handles GET /contracts/expiring?days=N, the contracts ending in
+// the next N days (CONTRACT_EXPIRY_NOTICE_DAYS by default) that have not
+// been renewed.
+func (h *ContractHandler) Expiring(w http.ResponseWriter, r *http.Request) {
+	days := h.service.NoticeDays()
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "days must be a positive number")
+			return
+		}
+		days = n
+	}
+
+	today := time.Now()
+	expiring, err := h.service.Expiring(r.Context(), today, days)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	out := []ContractExpiryResponse{}
+	for _, x := range expiring {
+		out = append(out, ContractExpiryResponse{
+			ContractResponse: toContractResponse(&x.Contract),
+			EmployeeName:     x.EmployeeName,
+			DepartmentID:     x.DepartmentID,
+			DaysLeft:         services.DaysLeft(&x.Contract, today),
+		})
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Days      int                      `json:"days"`
+		Contracts []ContractExpiryResponse `json:"contracts"`
+	}{days, out})
+}
+
+// CheckExpiring handles POST /contracts/expiry-check, running the background
+// check now. It answers how many notifications were raised.
+func (h *ContractHandler) CheckExpiring(w http.ResponseWriter, r *http.Request) {
+	log.Println("CheckExpiring handler called")
+
+	n, err := h.service.CheckExpiring(r.Context(), time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"notifications": n})
+}
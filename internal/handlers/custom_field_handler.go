@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type CustomFieldHandler struct {
+	service *services.CustomFieldService
+}
+
+func NewCustomFieldHandler(service *services.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{
+		service: service,
+	}
+}
+
+type CustomFieldResponse struct {
+	ID        int64    `json:"id"`
+	Key       string   `json:"key"`
+	Label     string   `json:"label"`
+	Type      string   `json:"type"`
+	Options   []string `json:"options,omitempty"`
+	Required  bool     `json:"required"`
+	CreatedAt string   `json:"createdAt"`
+	UpdatedAt string   `json:"updatedAt"`
+}
+
+func toCustomFieldResponse(d *models.CustomFieldDefinition) CustomFieldResponse {
+	return CustomFieldResponse{
+		ID:        d.ID,
+		Key:       d.Key,
+		Label:     d.Label,
+		Type:      d.Type,
+		Options:   d.Options,
+		Required:  d.Required,
+		CreatedAt: d.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: d.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func writeCustomFieldError(w http.ResponseWriter, err error, notFound string) {
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, notFound)
+		return
+	}
+	writeError(w, http.StatusBadRequest, err.Error())
+}
+
+// ListCustomFields handles GET /custom-fields.
+func (h *CustomFieldHandler) ListCustomFields(w http.ResponseWriter, r *http.Request) {
+	defs, err := h.service.List(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	out := []CustomFieldResponse{}
+	for _, d := range defs {
+		out = append(out, toCustomFieldResponse(d))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// CreateCustomField handles POST /custom-fields with {"key", "label",
+// "type", "options", "required"}.
+func (h *CustomFieldHandler) CreateCustomField(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreateCustomField handler called")
+
+	var req struct {
+		Key      string   `json:"key"`
+		Label    string   `json:"label"`
+		Type     string   `json:"type"`
+		Options  []string `json:"options"`
+		Required bool     `json:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	d := &models.CustomFieldDefinition{
+		Key:      req.Key,
+		Label:    req.Label,
+		Type:     req.Type,
+		Options:  req.Options,
+		Required: req.Required,
+	}
+	if err := h.service.Create(r.Context(), d); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, toCustomFieldResponse(d))
+}
+
+// GetCustomField handles GET /custom-fields/{id}.
+func (h *CustomFieldHandler) GetCustomField(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/custom-fields/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid custom field id")
+		return
+	}
+	d, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		writeCustomFieldError(w, err, "custom field not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toCustomFieldResponse(d))
+}
+
+// UpdateCustomField handles PUT /custom-fields/{id}. Only the label, options
+// and required flag can change.
+func (h *CustomFieldHandler) UpdateCustomField(w http.ResponseWriter, r *http.Request) {
+	log.Println("UpdateCustomField handler called")
+
+	id, err := pathID(r, "/custom-fields/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid custom field id")
+		return
+	}
+	d, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		writeCustomFieldError(w, err, "custom field not found")
+		return
+	}
+
+	var req struct {
+		Label    *string  `json:"label"`
+		Options  []string `json:"options"`
+		Required *bool    `json:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Label != nil {
+		d.Label = *req.Label
+	}
+	if req.Options != nil {
+		d.Options = req.Options
+	}
+	if req.Required != nil {
+		d.Required = *req.Required
+	}
+	if err := h.service.Update(r.Context(), d); err != nil {
+		writeCustomFieldError(w, err, "custom field not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toCustomFieldResponse(d))
+}
+
+// DeleteCustomField handles DELETE /custom-fields/{id}, which also removes
+// the field's values from all employees.
+func (h *CustomFieldHandler) DeleteCustomField(w http.ResponseWriter, r *http.Request) {
+	log.Println("DeleteCustomField handler called")
+
+	id, err := pathID(r, "/custom-fields/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid custom field id")
+		return
+	}
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		writeCustomFieldError(w, err, "custom field not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"database/sql"
+	"errors"
 	"log"
 	"encoding/json"
 	"net/http"
@@ -85,15 +87,17 @@ func (h *DepartmentHandler) ListDepartments(w http.ResponseWriter, r *http.Reque
 	}
 
 	type respDept struct {
-		ID        int64  `json:"id"`
-		Name      string `json:"name"`
-		CreatedAt string `json:"createdAt"`
-		UpdatedAt string `json:"updatedAt"`
+		ID             int64  `json:"id"`
+		Name           string `json:"name"`
+		HeadEmployeeID *int64 `json:"headEmployeeId"`
+		CalendarID     *int64 `json:"calendarId"`
+		CreatedAt      string `json:"createdAt"`
+		UpdatedAt      string `json:"updatedAt"`
 	}
 
 	var out []respDept
 	for _, d := range depts {
-		out = append(out, respDept{ID: d.ID, Name: d.Name, CreatedAt: d.CreatedAt.Format(time.RFC3339), UpdatedAt: d.UpdatedAt.Format(time.RFC3339)})
+		out = append(out, respDept{ID: d.ID, Name: d.Name, HeadEmployeeID: d.HeadEmployeeID, CalendarID: d.CalendarID, CreatedAt: d.CreatedAt.Format(time.RFC3339), UpdatedAt: d.UpdatedAt.Format(time.RFC3339)})
 	}
 
 	resp := struct {
@@ -104,3 +108,197 @@ func (h *DepartmentHandler) ListDepartments(w http.ResponseWriter, r *http.Reque
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
 }
+
+// SetHead handles PUT /departments/{id}/head. A null employeeId removes the
+// current head.
+func (h *DepartmentHandler) SetHead(w http.ResponseWriter, r *http.Request) {
+	log.Println("SetHead handler called")
+
+	if r.Method != http.MethodPut {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	id, err := pathID(r, "/departments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid department id")
+		return
+	}
+
+	var req struct {
+		EmployeeID *int64 `json:"employeeId"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.service.SetHead(r.Context(), id, req.EmployeeID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "department not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dept, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		ID             int64  `json:"id"`
+		Name           string `json:"name"`
+		HeadEmployeeID *int64 `json:"headEmployeeId"`
+	}{dept.ID, dept.Name, dept.HeadEmployeeID})
+}
+
+type DepartmentPlanResponse struct {
+	FiscalYear       int            `json:"fiscalYear"`
+	PlannedHeadcount int            `json:"plannedHeadcount"`
+	SalaryBudget     models.Decimal `json:"salaryBudget"`
+	Currency         string         `json:"currency"`
+	Note             *string        `json:"note"`
+	UpdatedAt        string         `json:"updatedAt"`
+}
+
+func toDepartmentPlanResponse(p *models.DepartmentPlan) DepartmentPlanResponse {
+	return DepartmentPlanResponse{
+		FiscalYear:       p.FiscalYear,
+		PlannedHeadcount: p.PlannedHeadcount,
+		SalaryBudget:     p.SalaryBudget,
+		Currency:         p.Currency,
+		Note:             p.Note,
+		UpdatedAt:        p.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toDepartmentPlanResponses(plans []*models.DepartmentPlan) []DepartmentPlanResponse {
+	out := []DepartmentPlanResponse{}
+	for _, p := range plans {
+		out = append(out, toDepartmentPlanResponse(p))
+	}
+	return out
+}
+
+// GetDepartment handles GET /departments/{id}, including the yearly
+// headcount and budget plans.
+func (h *DepartmentHandler) GetDepartment(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/departments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid department id")
+		return
+	}
+
+	d, err := h.service.GetWithPlans(r.Context(), id)
+	if err != nil {
+		writeDepartmentError(w, err, "department not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		ID             int64                    `json:"id"`
+		Name           string                   `json:"name"`
+		HeadEmployeeID *int64                   `json:"headEmployeeId"`
+		CalendarID     *int64                   `json:"calendarId"`
+		DefaultShiftID *int64                   `json:"defaultShiftId"`
+		Plans          []DepartmentPlanResponse `json:"plans"`
+		CreatedAt      string                   `json:"createdAt"`
+		UpdatedAt      string                   `json:"updatedAt"`
+	}{d.ID, d.Name, d.HeadEmployeeID, d.CalendarID, d.DefaultShiftID, toDepartmentPlanResponses(d.Plans),
+		d.CreatedAt.Format(time.RFC3339), d.UpdatedAt.Format(time.RFC3339)})
+}
+
+// ListPlans handles GET /departments/{id}/plans.
+func (h *DepartmentHandler) ListPlans(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/departments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid department id")
+		return
+	}
+
+	d, err := h.service.GetWithPlans(r.Context(), id)
+	if err != nil {
+		writeDepartmentError(w, err, "department not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Plans []DepartmentPlanResponse `json:"plans"`
+	}{toDepartmentPlanResponses(d.Plans)})
+}
+
+// SavePlan handles PUT /departments/{id}/plans/{year}, creating or
+// replacing the plan for that fiscal year.
+func (h *DepartmentHandler) SavePlan(w http.ResponseWriter, r *http.Request) {
+	log.Println("SavePlan handler called")
+
+	id, err := pathID(r, "/departments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid department id")
+		return
+	}
+	year, err := strconv.Atoi(pathSegment(r, "/departments/", 2))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid fiscal year")
+		return
+	}
+
+	var req struct {
+		PlannedHeadcount *int           `json:"plannedHeadcount"`
+		SalaryBudget     models.Decimal `json:"salaryBudget"`
+		Currency         string         `json:"currency"`
+		Note             *string        `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.PlannedHeadcount == nil {
+		writeError(w, http.StatusBadRequest, "plannedHeadcount is required")
+		return
+	}
+
+	plan := &models.DepartmentPlan{
+		DepartmentID:     id,
+		FiscalYear:       year,
+		PlannedHeadcount: *req.PlannedHeadcount,
+		SalaryBudget:     req.SalaryBudget,
+		Currency:         req.Currency,
+		Note:             req.Note,
+	}
+	if err := h.service.SavePlan(r.Context(), plan); err != nil {
+		writeDepartmentError(w, err, "department not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toDepartmentPlanResponse(plan))
+}
+
+// DeletePlan handles DELETE /departments/{id}/plans/{year}.
+func (h *DepartmentHandler) DeletePlan(w http.ResponseWriter, r *http.Request) {
+	log.Println("DeletePlan handler called")
+
+	id, err := pathID(r, "/departments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid department id")
+		return
+	}
+	year, err := strconv.Atoi(pathSegment(r, "/departments/", 2))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid fiscal year")
+		return
+	}
+
+	if err := h.service.DeletePlan(r.Context(), id, year); err != nil {
+		writeDepartmentError(w, err, "plan not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeDepartmentError(w http.ResponseWriter, err error, notFound string) {
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, notFound)
+		return
+	}
+	writeError(w, http.StatusBadRequest, err.Error())
+}
@@ -12,6 +12,7 @@ import (
 	"encoding/csv"
 	"sync"
 	"fmt"
+	"errors"
 	"path/filepath"
 	"bytes"
 
@@ -23,38 +24,161 @@ type EmployeeHandler struct {
 	service *services.EmployeeService
 }
 
+
 type EmployeeResponse struct {
-	ID           int64   `json:"id"`
-	Name         string  `json:"name"`
-	Age          int     `json:"age"`
-	Position     string  `json:"position"`
-	DepartmentID int64   `json:"departmentId"`
-	Salary       float64 `json:"salary"`
-	CreatedAt    string  `json:"createdAt"`
-	UpdatedAt    string  `json:"updatedAt"`
+	ID                   int64                  `json:"id"`
+	Name                 string                 `json:"name"`
+	DateOfBirth          *string                `json:"dateOfBirth"`
+	DateOfBirthEstimated bool                   `json:"dateOfBirthEstimated"`
+	Age                  *int                   `json:"age"`
+	Position             string                 `json:"position"`
+	PositionID           *int64                 `json:"positionId"`
+	DepartmentID         int64                  `json:"departmentId"`
+	ManagerID            *int64                 `json:"managerId"`
+	Salary               models.Decimal         `json:"salary"`
+	SalaryCurrency       string                 `json:"salaryCurrency"`
+	NormalizedSalary     *models.Decimal        `json:"normalizedSalary,omitempty"`
+	NormalizedCurrency   string                 `json:"normalizedCurrency,omitempty"`
+	Status               string                 `json:"status"`
+	HireDate             string                 `json:"hireDate"`
+	ProbationEndDate     *string                `json:"probationEndDate"`
+	TerminationDate      *string                `json:"terminationDate"`
+	TerminationReason    *string                `json:"terminationReason"`
+	CustomFields         map[string]interface{} `json:"customFields"`
+	CreatedAt            string                 `json:"createdAt"`
+	UpdatedAt            string                 `json:"updatedAt"`
 }
 
-func derefInt(v *int) int {
+func derefString(v *string) string {
 	if v == nil {
-		return 0
+		return ""
 	}
 	return *v
 }
 
-func derefString(v *string) string {
+func customFieldsOrEmpty(v models.CustomFieldValues) map[string]interface{} {
 	if v == nil {
-		return ""
+		return map[string]interface{}{}
 	}
-	return *v
+	return v
 }
 
-func derefFloat(v *float64) float64 {
+func derefDecimal(v *models.Decimal) models.Decimal {
 	if v == nil {
-		return 0
+		return models.Decimal{}
 	}
 	return *v
 }
 
+// setWarning surfaces a problem the policy lets through, such as an
+// out-of-band salary (SALARY_BAND_POLICY=warn), as an HTTP Warning header
+// while still accepting the change.
+func setWarning(w http.ResponseWriter, msg string) {
+	if msg != "" {
+		w.Header().Set("Warning", "199 - "+strconv.Quote(msg))
+	}
+}
+
+// parseCurrencyParam reads the optional ?currency= used to normalize salaries.
+func parseCurrencyParam(r *http.Request) (string, error) {
+	c := strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("currency")))
+	if c != "" && !models.IsSupportedCurrency(c) {
+		return "", fmt.Errorf("unsupported currency %s", c)
+	}
+	return c, nil
+}
+
+// parseEmployeeFilter reads the listing filters ?departmentId=, ?keyword=,
+// any number of ?skill=Name:minLevel (the level defaults to 1),
+// ?asOf=YYYY-MM-DD for the staff as they were on a past day and
+// ?cf.<key>=value for custom field values.
+func parseEmployeeFilter(r *http.Request) (models.EmployeeFilter, error) {
+	var filter models.EmployeeFilter
+	q := r.URL.Query()
+	if d := q.Get("departmentId"); d != "" {
+		v, err := strconv.ParseInt(d, 10, 64)
+		if err != nil {
+			return filter, errors.New("invalid departmentId")
+		}
+		filter.DepartmentID = &v
+	}
+	if k := q.Get("keyword"); k != "" {
+		filter.Keyword = &k
+	}
+	if v := q.Get("asOf"); v != "" {
+		asOf, err := parseDate(v)
+		if err != nil {
+			return filter, errors.New("asOf must be YYYY-MM-DD")
+		}
+		if asOf.After(time.Now()) {
+			return filter, errors.New("asOf must not be in the future")
+		}
+		filter.AsOf = &asOf
+	}
+	for name, values := range q {
+		if key := strings.TrimPrefix(name, "cf."); key != name && len(values) > 0 {
+			if filter.CustomFields == nil {
+				filter.CustomFields = map[string]string{}
+			}
+			filter.CustomFields[key] = values[0]
+		}
+	}
+	for _, v := range q["skill"] {
+		req := models.SkillRequirement{Skill: strings.TrimSpace(v), MinLevel: models.MinSkillLevel}
+		if i := strings.LastIndex(v, ":"); i >= 0 {
+			level, err := strconv.Atoi(v[i+1:])
+			if err != nil || level < models.MinSkillLevel || level > models.MaxSkillLevel {
+				return filter, fmt.Errorf("skill %q: level must be between %d and %d", v, models.MinSkillLevel, models.MaxSkillLevel)
+			}
+			req.Skill, req.MinLevel = strings.TrimSpace(v[:i]), level
+		}
+		if req.Skill == "" {
+			return filter, errors.New("skill name is required")
+		}
+		filter.Skills = append(filter.Skills, req)
+	}
+	return filter, nil
+}
+
+// writeListError answers 400 for a filter the service rejected and 500
+// when the listing itself failed.
+func writeListError(w http.ResponseWriter, err error) {
+	var invalid *services.ValidationError
+	if errors.As(err, &invalid) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
+
+func toEmployeeResponse(e *models.Employee) EmployeeResponse {
+	salaryCurrency := ""
+	if e.Salary != nil {
+		salaryCurrency = derefString(e.SalaryCurrency)
+	}
+	return EmployeeResponse{
+		ID:                   e.ID,
+		Name:                 e.Name,
+		DateOfBirth:          formatDatePtr(e.DateOfBirth),
+		DateOfBirthEstimated: e.DateOfBirthEstimated,
+		Age:                  e.AgeOn(time.Now()),
+		Position:             derefString(e.Position),
+		PositionID:           e.PositionID,
+		DepartmentID:         e.DepartmentID,
+		ManagerID:            e.ManagerID,
+		Salary:               derefDecimal(e.Salary),
+		SalaryCurrency:       salaryCurrency,
+		Status:               e.Status,
+		HireDate:             e.HireDate.Format(dateLayout),
+		ProbationEndDate:     formatDatePtr(e.ProbationEndDate),
+		TerminationDate:      formatDatePtr(e.TerminationDate),
+		TerminationReason:    e.TerminationReason,
+		CustomFields:         customFieldsOrEmpty(e.CustomFields),
+		CreatedAt:            e.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:            e.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
 func NewEmployeeHandler(service *services.EmployeeService) *EmployeeHandler {
 	return &EmployeeHandler{
 		service: service,
@@ -84,39 +208,45 @@ func (h *EmployeeHandler) ListEmployees(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	var deptID *int64
-	if d := q.Get("departmentId"); d != "" {
-		if v, err := strconv.ParseInt(d, 10, 64); err == nil {
-			deptID = &v
-		} else {
-			writeError(w, http.StatusBadRequest, "invalid departmentId")
-			return
-		}
+	filter, err := parseEmployeeFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	var keyword *string
-	if k := q.Get("keyword"); k != "" {
-		keyword = &k
+	currency, err := parseCurrencyParam(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	employees, total, err := h.service.List(r.Context(), limit, offset, deptID, keyword)
+	employees, total, err := h.service.List(r.Context(), limit, offset, filter)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeListError(w, err)
 		return
 	}
 
+	var normalized map[int64]models.Decimal
+	if currency != "" {
+		on := time.Now()
+		if filter.AsOf != nil {
+			on = *filter.AsOf
+		}
+		normalized, err = h.service.NormalizeSalaries(r.Context(), employees, currency, on)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+	}
+
 	var out []EmployeeResponse
 	for _, e := range employees {
-		out = append(out, EmployeeResponse{
-			ID:           e.ID,
-			Name:         e.Name,
-			Age:          derefInt(e.Age),
-			Position:     derefString(e.Position),
-			DepartmentID: e.DepartmentID,
-			Salary:       derefFloat(e.Salary),
-			CreatedAt:    e.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:    e.UpdatedAt.Format(time.RFC3339),
-		})
+		resp := toEmployeeResponse(e)
+		if v, ok := normalized[e.ID]; ok {
+			resp.NormalizedSalary = &v
+			resp.NormalizedCurrency = currency
+		}
+		out = append(out, resp)
 	}
 
 	resp := struct {
@@ -140,29 +270,81 @@ func (h *EmployeeHandler) CreateEmployee(w http.ResponseWriter, r *http.Request)
 	}
 
 	var req struct {
-    Name         string   `json:"name"`
-    Email        *string  `json:"email"`
-    DepartmentID int64    `json:"departmentId"`
-    Age          *int     `json:"age"`
-    Position     *string  `json:"position"`
-    Salary       *float64 `json:"salary"`
-  }
+		Name             string                   `json:"name"`
+		Email            *string                  `json:"email"`
+		DepartmentID     int64                    `json:"departmentId"`
+		ManagerID        *int64                   `json:"managerId"`
+		DateOfBirth      *string                  `json:"dateOfBirth"`
+		Age              *int                     `json:"age"`
+		Position         *string                  `json:"position"`
+		PositionID       *int64                   `json:"positionId"`
+		Salary           *models.Decimal          `json:"salary"`
+		SalaryCurrency   *string                  `json:"salaryCurrency"`
+		Status           string                   `json:"status"`
+		HireDate         *string                  `json:"hireDate"`
+		ProbationEndDate *string                  `json:"probationEndDate"`
+		CustomFields     models.CustomFieldValues `json:"customFields"`
+	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
+	if req.Age != nil {
+		writeError(w, http.StatusBadRequest, "age is derived from dateOfBirth; send dateOfBirth instead")
+		return
+	}
+	dateOfBirth, err := parseOptionalDate(req.DateOfBirth, "dateOfBirth")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var hireDate time.Time
+	if req.HireDate != nil {
+		d, err := parseDate(*req.HireDate)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "hireDate must be YYYY-MM-DD")
+			return
+		}
+		hireDate = d
+	}
+	var probationEnd *time.Time
+	if req.ProbationEndDate != nil {
+		d, err := parseDate(*req.ProbationEndDate)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "probationEndDate must be YYYY-MM-DD")
+			return
+		}
+		probationEnd = &d
+	}
+
+	if req.SalaryCurrency != nil {
+		c := strings.ToUpper(*req.SalaryCurrency)
+		req.SalaryCurrency = &c
+	}
+
 	employee := &models.Employee{
-		Name:         req.Name,
-		Email:        req.Email,
-		DepartmentID: req.DepartmentID,
+		Name:             req.Name,
+		Email:            req.Email,
+		DepartmentID:     req.DepartmentID,
+		ManagerID:        req.ManagerID,
+		DateOfBirth:      dateOfBirth,
+		Position:         req.Position,
+		PositionID:       req.PositionID,
+		Salary:           req.Salary,
+		SalaryCurrency:   req.SalaryCurrency,
+		Status:           req.Status,
+		HireDate:         hireDate,
+		ProbationEndDate: probationEnd,
+		CustomFields:     req.CustomFields,
 	}
 
 	if err := h.service.CreateEmployee(r.Context(), employee); err != nil {
-    writeError(w, http.StatusBadRequest, err.Error())
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	setWarning(w, h.service.SalaryBandWarning(r.Context(), employee))
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -201,16 +383,7 @@ func (h *EmployeeHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := EmployeeResponse{
-    ID:           employee.ID,
-    Name:         employee.Name,
-    Age:          derefInt(employee.Age),
-    Position:     derefString(employee.Position),
-    DepartmentID: employee.DepartmentID,
-    Salary:       derefFloat(employee.Salary),
-    CreatedAt:    employee.CreatedAt.Format(time.RFC3339),
-    UpdatedAt:    employee.UpdatedAt.Format(time.RFC3339),
-  }
+	resp := toEmployeeResponse(employee)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
@@ -236,12 +409,20 @@ func (h *EmployeeHandler) UpdateEmployee(w http.ResponseWriter, r *http.Request)
 
 
 	var req struct {
-		Name         *string  `json:"name"`
-		Email        *string  `json:"email"`
-		DepartmentID *int64   `json:"departmentId"`
-		Age          *int     `json:"age"`
-		Position     *string  `json:"position"`
-		Salary       *float64 `json:"salary"`
+		Name             *string         `json:"name"`
+		Email            *string         `json:"email"`
+		DepartmentID     *int64          `json:"departmentId"`
+		ManagerID        *int64          `json:"managerId"`
+		DateOfBirth      *string         `json:"dateOfBirth"`
+		Age              *int            `json:"age"`
+		Position         *string         `json:"position"`
+		PositionID       *int64          `json:"positionId"`
+		Salary           *models.Decimal `json:"salary"`
+		SalaryCurrency   *string         `json:"salaryCurrency"`
+		HireDate         *string         `json:"hireDate"`
+		ProbationEndDate *string         `json:"probationEndDate"`
+		// merged into the stored values; null removes a field
+		CustomFields models.CustomFieldValues `json:"customFields"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -264,20 +445,87 @@ func (h *EmployeeHandler) UpdateEmployee(w http.ResponseWriter, r *http.Request)
 	if req.DepartmentID != nil {
 		existing.DepartmentID = *req.DepartmentID
 	}
+	if req.ManagerID != nil {
+		// managerId 0 removes the manager
+		existing.ManagerID = req.ManagerID
+		if *req.ManagerID == 0 {
+			existing.ManagerID = nil
+		}
+	}
 	if req.Age != nil {
-		existing.Age = req.Age
+		writeError(w, http.StatusBadRequest, "age is derived from dateOfBirth; send dateOfBirth instead")
+		return
+	}
+	if req.DateOfBirth != nil {
+		d, err := parseDate(*req.DateOfBirth)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "dateOfBirth must be YYYY-MM-DD")
+			return
+		}
+		existing.DateOfBirth = &d
+		// sending the date, even unchanged, confirms it
+		existing.DateOfBirthEstimated = false
 	}
 	if req.Position != nil {
+		// resolved against the catalog by the service
 		existing.Position = req.Position
+		existing.PositionID = nil
+	}
+	if req.PositionID != nil {
+		existing.PositionID = req.PositionID
 	}
 	if req.Salary != nil {
 		existing.Salary = req.Salary
 	}
+	if req.SalaryCurrency != nil {
+		c := strings.ToUpper(*req.SalaryCurrency)
+		// the stored amount is in the old currency, so it cannot be kept
+		old := models.DefaultCurrency
+		if existing.SalaryCurrency != nil {
+			old = *existing.SalaryCurrency
+		}
+		if req.Salary == nil && existing.Salary != nil && c != old {
+			writeError(w, http.StatusBadRequest, "salary is required when changing salaryCurrency")
+			return
+		}
+		existing.SalaryCurrency = &c
+	}
+	if req.HireDate != nil {
+		d, err := parseDate(*req.HireDate)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "hireDate must be YYYY-MM-DD")
+			return
+		}
+		existing.HireDate = d
+	}
+	if req.ProbationEndDate != nil {
+		d, err := parseDate(*req.ProbationEndDate)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "probationEndDate must be YYYY-MM-DD")
+			return
+		}
+		existing.ProbationEndDate = &d
+	}
+	if len(req.CustomFields) > 0 {
+		merged := models.CustomFieldValues{}
+		for k, v := range existing.CustomFields {
+			merged[k] = v
+		}
+		for k, v := range req.CustomFields {
+			if v == nil {
+				delete(merged, k)
+				continue
+			}
+			merged[k] = v
+		}
+		existing.CustomFields = merged
+	}
 
 	if err := h.service.Update(r.Context(), existing); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	setWarning(w, h.service.SalaryBandWarning(r.Context(), existing))
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(existing)
@@ -302,21 +550,26 @@ func (h *EmployeeHandler) DeleteEmployee(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := h.service.Delete(r.Context(), id); err != nil {
-		if err == sql.ErrNoRows {
+		var historyErr *services.EmployeeHistoryError
+		switch {
+		case err == sql.ErrNoRows:
 			writeError(w, http.StatusNotFound, "employee not found")
-			return
+		case errors.As(err, &historyErr):
+			writeError(w, http.StatusConflict, historyErr.Error())
+		default:
+			log.Printf("delete employee %d: %v", id, err)
+			writeError(w, http.StatusInternalServerError, "failed to delete employee")
 		}
-		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func employeeToCSVRow(e *models.Employee) []string {
+func employeeToCSVRow(e *models.Employee, fields []*models.CustomFieldDefinition) []string {
 	age := ""
-	if e.Age != nil {
-		age = strconv.Itoa(*e.Age)
+	if a := e.AgeOn(time.Now()); a != nil {
+		age = strconv.Itoa(*a)
 	}
 	position := ""
 	if e.Position != nil {
@@ -327,29 +580,49 @@ func employeeToCSVRow(e *models.Employee) []string {
 		email = *e.Email
 	}
 	salary := ""
+	salaryCurrency := ""
 	if e.Salary != nil {
-		salary = fmt.Sprintf("%v", *e.Salary)
+		salary = e.Salary.String()
+		salaryCurrency = derefString(e.SalaryCurrency)
 	}
-	return []string{
+	row := []string{
 		fmt.Sprintf("%d", e.ID),
 		e.Name,
 		email,
 		fmt.Sprintf("%d", e.DepartmentID),
+		derefString(formatDatePtr(e.DateOfBirth)),
 		age,
 		position,
 		salary,
+		salaryCurrency,
+		e.HireDate.Format(dateLayout),
+		derefString(formatDatePtr(e.TerminationDate)),
 		e.CreatedAt.Format(time.RFC3339),
 		e.UpdatedAt.Format(time.RFC3339),
 	}
+	for _, f := range fields {
+		v, ok := e.CustomFields[f.Key]
+		if !ok {
+			row = append(row, "")
+			continue
+		}
+		row = append(row, fmt.Sprint(v))
+	}
+	return row
 }
 
-func writeCSV(wtr *csv.Writer, employees []*models.Employee) error {
-	header := []string{"id", "name", "email", "departmentId", "age", "position", "salary", "createdAt", "updatedAt"}
+// writeCSV writes one column per custom field, headed by its key, after
+// the fixed columns.
+func writeCSV(wtr *csv.Writer, employees []*models.Employee, fields []*models.CustomFieldDefinition) error {
+	header := []string{"id", "name", "email", "departmentId", "dateOfBirth", "age", "position", "salary", "salaryCurrency", "hireDate", "terminationDate", "createdAt", "updatedAt"}
+	for _, f := range fields {
+		header = append(header, f.Key)
+	}
 	if err := wtr.Write(header); err != nil {
 		return err
 	}
 	for _, e := range employees {
-		if err := wtr.Write(employeeToCSVRow(e)); err != nil {
+		if err := wtr.Write(employeeToCSVRow(e, fields)); err != nil {
 			return err
 		}
 	}
@@ -379,22 +652,18 @@ func (h *EmployeeHandler) ExportCSV(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	var deptID *int64
-	if d := q.Get("departmentId"); d != "" {
-		if v, err := strconv.ParseInt(d, 10, 64); err == nil {
-			deptID = &v
-		} else {
-			writeError(w, http.StatusBadRequest, "invalid departmentId")
-			return
-		}
+	filter, err := parseEmployeeFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	var keyword *string
-	if k := q.Get("keyword"); k != "" {
-		keyword = &k
+	employees, _, err := h.service.List(r.Context(), limit, offset, filter)
+	if err != nil {
+		writeListError(w, err)
+		return
 	}
-
-	employees, _, err := h.service.List(r.Context(), limit, offset, deptID, keyword)
+	fields, err := h.service.CustomFieldDefinitions(r.Context())
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -408,10 +677,7 @@ func (h *EmployeeHandler) ExportCSV(w http.ResponseWriter, r *http.Request) {
 	ts := time.Now().Unix()
 	jsonFile := fmt.Sprintf("employees_%d.json", ts)
 	csvFile := fmt.Sprintf("employees_%d.csv", ts)
-	exportDir := os.Getenv("EXPORT_DIR")
-	if exportDir == "" {
-		exportDir = "."
-	}
+	exportDir := exportDir()
 
 	var wg sync.WaitGroup
 	var mu sync.Mutex
@@ -449,7 +715,7 @@ func (h *EmployeeHandler) ExportCSV(w http.ResponseWriter, r *http.Request) {
 		if download && format == "csv" {
 			buf := &bytes.Buffer{}
 			wtr := csv.NewWriter(buf)
-			if err := writeCSV(wtr, employees); err != nil {
+			if err := writeCSV(wtr, employees, fields); err != nil {
 				log.Println(err)
 				return
 			}
@@ -466,7 +732,7 @@ func (h *EmployeeHandler) ExportCSV(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		defer f.Close()
-		writeCSV(csv.NewWriter(f), employees)
+		writeCSV(csv.NewWriter(f), employees, fields)
 	}()
 
 	wg.Wait()
@@ -477,18 +743,14 @@ func (h *EmployeeHandler) ExportCSV(w http.ResponseWriter, r *http.Request) {
 				writeError(w, http.StatusInternalServerError, "json generation failed")
 				return
 			}
-			w.Header().Set("Content-Type", "application/json")
-			w.Header().Set("Content-Disposition", "attachment; filename=\""+jsonFile+"\"")
-			http.ServeContent(w, r, jsonFile, time.Now(), bytes.NewReader(jsonBuf.Bytes()))
+			serveDownload(w, r, jsonFile, "application/json", jsonBuf.Bytes())
 			return
 		}
 		if csvBuf == nil {
 			writeError(w, http.StatusInternalServerError, "csv generation failed")
 			return
 		}
-		w.Header().Set("Content-Type", "text/csv")
-		w.Header().Set("Content-Disposition", "attachment; filename=\""+csvFile+"\"")
-		http.ServeContent(w, r, csvFile, time.Now(), bytes.NewReader(csvBuf.Bytes()))
+		serveDownload(w, r, csvFile, "text/csv", csvBuf.Bytes())
 		return
 	}
 
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"app/internal/models"
+)
+
+type EmploymentEventResponse struct {
+	ID               int64   `json:"id"`
+	Kind             string  `json:"kind"`
+	EffectiveDate    string  `json:"effectiveDate"`
+	FromStatus       *string `json:"fromStatus"`
+	ToStatus         *string `json:"toStatus"`
+	FromDepartmentID *int64  `json:"fromDepartmentId"`
+	ToDepartmentID   *int64  `json:"toDepartmentId"`
+	ToManagerID      *int64  `json:"toManagerId"`
+	ToPositionID     *int64  `json:"toPositionId"`
+	Reason           *string `json:"reason"`
+	Applied          bool    `json:"applied"`
+	CreatedAt        string  `json:"createdAt"`
+}
+
+func toEmploymentEventResponse(ev *models.EmploymentEvent) EmploymentEventResponse {
+	return EmploymentEventResponse{
+		ID:               ev.ID,
+		Kind:             ev.Kind,
+		EffectiveDate:    ev.EffectiveDate.Format(dateLayout),
+		FromStatus:       ev.FromStatus,
+		ToStatus:         ev.ToStatus,
+		FromDepartmentID: ev.FromDepartmentID,
+		ToDepartmentID:   ev.ToDepartmentID,
+		ToManagerID:      ev.ToManagerID,
+		ToPositionID:     ev.ToPositionID,
+		Reason:           ev.Reason,
+		Applied:          ev.AppliedAt != nil,
+		CreatedAt:        ev.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func writeLifecycleError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, "employee not found")
+		return
+	}
+	writeError(w, http.StatusBadRequest, err.Error())
+}
+
+// parseOptionalDate parses an optional YYYY-MM-DD request field.
+func parseOptionalDate(v *string, field string) (*time.Time, error) {
+	if v == nil {
+		return nil, nil
+	}
+	d, err := parseDate(*v)
+	if err != nil {
+		return nil, errors.New(field + " must be YYYY-MM-DD")
+	}
+	return &d, nil
+}
+
+// History handles GET /employees/{id}/history, the employment events in
+// date order, including scheduled ones.
+func (h *EmployeeHandler) History(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	events, err := h.service.History(r.Context(), id)
+	if err != nil {
+		writeLifecycleError(w, err)
+		return
+	}
+	out := []EmploymentEventResponse{}
+	for _, ev := range events {
+		out = append(out, toEmploymentEventResponse(ev))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// Hire handles POST /employees/{id}/hire for candidates, with
+// {"hireDate", "probationEndDate"}.
+func (h *EmployeeHandler) Hire(w http.ResponseWriter, r *http.Request) {
+	log.Println("Hire handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		HireDate         string  `json:"hireDate"`
+		ProbationEndDate *string `json:"probationEndDate"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	hireDate, err := parseDate(req.HireDate)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "hireDate must be YYYY-MM-DD")
+		return
+	}
+	probationEnd, err := parseOptionalDate(req.ProbationEndDate, "probationEndDate")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	e, err := h.service.Hire(r.Context(), id, hireDate, probationEnd)
+	if err != nil {
+		writeLifecycleError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toEmployeeResponse(e))
+}
+
+// ChangeStatus handles POST /employees/{id}/status with
+// {"status": "active"|"on_leave", "reason"}: passing probation and leaves of
+// absence.
+func (h *EmployeeHandler) ChangeStatus(w http.ResponseWriter, r *http.Request) {
+	log.Println("ChangeStatus handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		Status string  `json:"status"`
+		Reason *string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	e, err := h.service.ChangeStatus(r.Context(), id, req.Status, req.Reason)
+	if err != nil {
+		writeLifecycleError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toEmployeeResponse(e))
+}
+
+// Transfer handles POST /employees/{id}/transfer with {"departmentId",
+// "effectiveDate", "managerId", "reason"}. A future effectiveDate schedules
+// the transfer and answers 202.
+func (h *EmployeeHandler) Transfer(w http.ResponseWriter, r *http.Request) {
+	log.Println("Transfer handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		DepartmentID  int64   `json:"departmentId"`
+		EffectiveDate *string `json:"effectiveDate"`
+		ManagerID     *int64  `json:"managerId"`
+		Reason        *string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.DepartmentID == 0 {
+		writeError(w, http.StatusBadRequest, "departmentId is required")
+		return
+	}
+	effective := time.Now()
+	if req.EffectiveDate != nil {
+		if effective, err = parseDate(*req.EffectiveDate); err != nil {
+			writeError(w, http.StatusBadRequest, "effectiveDate must be YYYY-MM-DD")
+			return
+		}
+	}
+
+	e, ev, err := h.service.Transfer(r.Context(), id, req.DepartmentID, req.ManagerID, effective, req.Reason)
+	if err != nil {
+		writeLifecycleError(w, err)
+		return
+	}
+	status := http.StatusOK
+	if ev.AppliedAt == nil {
+		status = http.StatusAccepted
+	}
+	writeJSON(w, status, struct {
+		Employee EmployeeResponse        `json:"employee"`
+		Event    EmploymentEventResponse `json:"event"`
+	}{toEmployeeResponse(e), toEmploymentEventResponse(ev)})
+}
+
+// Terminate handles POST /employees/{id}/terminate with
+// {"terminationDate", "reason"}; terminationDate is the last working day.
+// Assets still held come back in a Warning header under the warn policy.
+func (h *EmployeeHandler) Terminate(w http.ResponseWriter, r *http.Request) {
+	log.Println("Terminate handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		TerminationDate string `json:"terminationDate"`
+		Reason          string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	lastDay, err := parseDate(req.TerminationDate)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "terminationDate must be YYYY-MM-DD")
+		return
+	}
+
+	e, warning, err := h.service.Terminate(r.Context(), id, lastDay, req.Reason)
+	if err != nil {
+		writeLifecycleError(w, err)
+		return
+	}
+	setWarning(w, warning)
+	writeJSON(w, http.StatusOK, toEmployeeResponse(e))
+}
+
+// Rehire handles POST /employees/{id}/rehire with {"hireDate",
+// "probationEndDate", "departmentId"}.
+func (h *EmployeeHandler) Rehire(w http.ResponseWriter, r *http.Request) {
+	log.Println("Rehire handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		HireDate         string  `json:"hireDate"`
+		ProbationEndDate *string `json:"probationEndDate"`
+		DepartmentID     *int64  `json:"departmentId"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	hireDate, err := parseDate(req.HireDate)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "hireDate must be YYYY-MM-DD")
+		return
+	}
+	probationEnd, err := parseOptionalDate(req.ProbationEndDate, "probationEndDate")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	e, err := h.service.Rehire(r.Context(), id, hireDate, probationEnd, req.DepartmentID)
+	if err != nil {
+		writeLifecycleError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toEmployeeResponse(e))
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type ExchangeRateHandler struct {
+	service *services.ExchangeRateService
+}
+
+func NewExchangeRateHandler(service *services.ExchangeRateService) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		service: service,
+	}
+}
+
+type ExchangeRateResponse struct {
+	ID            int64          `json:"id"`
+	BaseCurrency  string         `json:"baseCurrency"`
+	QuoteCurrency string         `json:"quoteCurrency"`
+	Rate          models.Decimal `json:"rate"`
+	EffectiveDate string         `json:"effectiveDate"`
+	CreatedAt     string         `json:"createdAt"`
+}
+
+func toExchangeRateResponse(rate *models.ExchangeRate) ExchangeRateResponse {
+	return ExchangeRateResponse{
+		ID:            rate.ID,
+		BaseCurrency:  rate.BaseCurrency,
+		QuoteCurrency: rate.QuoteCurrency,
+		Rate:          rate.Rate,
+		EffectiveDate: rate.EffectiveDate.Format(dateLayout),
+		CreatedAt:     rate.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (h *ExchangeRateHandler) ListRates(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit := 100
+	offset := 0
+	if l := q.Get("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			limit = v
+		}
+	}
+	if o := q.Get("offset"); o != "" {
+		if v, err := strconv.Atoi(o); err == nil && v >= 0 {
+			offset = v
+		}
+	}
+
+	var base, quote *string
+	if b := strings.ToUpper(q.Get("base")); b != "" {
+		base = &b
+	}
+	if qc := strings.ToUpper(q.Get("quote")); qc != "" {
+		quote = &qc
+	}
+
+	rates, total, err := h.service.List(r.Context(), limit, offset, base, quote)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	out := []ExchangeRateResponse{}
+	for _, rate := range rates {
+		out = append(out, toExchangeRateResponse(rate))
+	}
+	writeJSON(w, http.StatusOK, struct {
+		TotalCount int64                  `json:"totalCount"`
+		Rates      []ExchangeRateResponse `json:"rates"`
+	}{TotalCount: total, Rates: out})
+}
+
+func (h *ExchangeRateHandler) CreateRate(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreateRate handler called")
+
+	var req struct {
+		BaseCurrency  string         `json:"baseCurrency"`
+		QuoteCurrency string         `json:"quoteCurrency"`
+		Rate          models.Decimal `json:"rate"`
+		EffectiveDate string         `json:"effectiveDate"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	effective, err := parseDate(req.EffectiveDate)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "effectiveDate must be YYYY-MM-DD")
+		return
+	}
+
+	rate := &models.ExchangeRate{
+		BaseCurrency:  req.BaseCurrency,
+		QuoteCurrency: req.QuoteCurrency,
+		Rate:          req.Rate,
+		EffectiveDate: effective,
+	}
+	if err := h.service.Save(r.Context(), rate); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, toExchangeRateResponse(rate))
+}
+
+// ImportRates handles POST /exchange-rates/import. The CSV is either the raw
+// request body or a multipart upload in the "file" field.
+func (h *ExchangeRateHandler) ImportRates(w http.ResponseWriter, r *http.Request) {
+	log.Println("ImportRates handler called")
+
+	var src io.Reader = r.Body
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		f, _, err := r.FormFile("file")
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "file is required")
+			return
+		}
+		defer f.Close()
+		src = f
+	}
+
+	n, err := h.service.ImportCSV(r.Context(), src)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"imported": n})
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// exportDir is where exports are written when they are not downloaded
+// directly (EXPORT_DIR, default the working directory).
+func exportDir() string {
+	if dir := os.Getenv("EXPORT_DIR"); dir != "" {
+		return dir
+	}
+	return "."
+}
+
+func saveExport(name string, data []byte) error {
+	return os.WriteFile(filepath.Join(exportDir(), name), data, 0o644)
+}
+
+// serveDownload sends data as a file attachment.
+func serveDownload(w http.ResponseWriter, r *http.Request, name, contentType string, data []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+name+"\"")
+	http.ServeContent(w, r, name, time.Now(), bytes.NewReader(data))
+}
@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type LeaveHandler struct {
+	service *services.LeaveService
+}
+
+func NewLeaveHandler(service *services.LeaveService) *LeaveHandler {
+	return &LeaveHandler{
+		service: service,
+	}
+}
+
+type LeaveRequestResponse struct {
+	ID           int64          `json:"id"`
+	EmployeeID   int64          `json:"employeeId"`
+	LeaveTypeID  int64          `json:"leaveTypeId"`
+	StartDate    string         `json:"startDate"`
+	EndDate      string         `json:"endDate"`
+	Days         models.Decimal `json:"days"`
+	Status       string         `json:"status"`
+	Reason       *string        `json:"reason"`
+	ApproverID   *int64         `json:"approverId"`
+	DecidedAt    *string        `json:"decidedAt"`
+	DecisionNote *string        `json:"decisionNote"`
+	CreatedAt    string         `json:"createdAt"`
+	UpdatedAt    string         `json:"updatedAt"`
+}
+
+func toLeaveRequestResponse(l *models.LeaveRequest) LeaveRequestResponse {
+	var decidedAt *string
+	if l.DecidedAt != nil {
+		s := l.DecidedAt.Format(time.RFC3339)
+		decidedAt = &s
+	}
+	return LeaveRequestResponse{
+		ID:           l.ID,
+		EmployeeID:   l.EmployeeID,
+		LeaveTypeID:  l.LeaveTypeID,
+		StartDate:    l.StartDate.Format(dateLayout),
+		EndDate:      l.EndDate.Format(dateLayout),
+		Days:         l.Days,
+		Status:       l.Status,
+		Reason:       l.Reason,
+		ApproverID:   l.ApproverID,
+		DecidedAt:    decidedAt,
+		DecisionNote: l.DecisionNote,
+		CreatedAt:    l.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    l.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toLeaveRequestResponses(list []*models.LeaveRequest) []LeaveRequestResponse {
+	out := []LeaveRequestResponse{}
+	for _, l := range list {
+		out = append(out, toLeaveRequestResponse(l))
+	}
+	return out
+}
+
+// ListLeaveTypes handles GET /leave-types.
+func (h *LeaveHandler) ListLeaveTypes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	types, err := h.service.ListTypes(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	type respPolicy struct {
+		MinYearsOfService int            `json:"minYearsOfService"`
+		DaysPerYear       models.Decimal `json:"daysPerYear"`
+	}
+	type respType struct {
+		ID              int64        `json:"id"`
+		Code            string       `json:"code"`
+		Name            string       `json:"name"`
+		Paid            bool         `json:"paid"`
+		RequiresBalance bool         `json:"requiresBalance"`
+		Policies        []respPolicy `json:"policies"`
+	}
+
+	out := []respType{}
+	for _, t := range types {
+		policies := []respPolicy{}
+		for _, p := range t.Policies {
+			policies = append(policies, respPolicy{MinYearsOfService: p.MinYearsOfService, DaysPerYear: p.DaysPerYear})
+		}
+		out = append(out, respType{ID: t.ID, Code: t.Code, Name: t.Name, Paid: t.Paid, RequiresBalance: t.RequiresBalance, Policies: policies})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// SubmitLeaveRequest handles POST /employees/{id}/leave-requests. The type is
+// given either as leaveType (a code such as ANNUAL) or leaveTypeId.
+func (h *LeaveHandler) SubmitLeaveRequest(w http.ResponseWriter, r *http.Request) {
+	log.Println("SubmitLeaveRequest handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req struct {
+		LeaveType   string  `json:"leaveType"`
+		LeaveTypeID int64   `json:"leaveTypeId"`
+		StartDate   string  `json:"startDate"`
+		EndDate     string  `json:"endDate"`
+		HalfDay     bool    `json:"halfDay"`
+		Reason      *string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.LeaveType == "" && req.LeaveTypeID == 0 {
+		writeError(w, http.StatusBadRequest, "leaveType is required")
+		return
+	}
+
+	start, err := parseDate(req.StartDate)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "startDate must be YYYY-MM-DD")
+		return
+	}
+	end := start
+	if req.EndDate != "" {
+		end, err = parseDate(req.EndDate)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "endDate must be YYYY-MM-DD")
+			return
+		}
+	}
+
+	l := &models.LeaveRequest{
+		EmployeeID:  id,
+		LeaveTypeID: req.LeaveTypeID,
+		StartDate:   start,
+		EndDate:     end,
+		Reason:      req.Reason,
+	}
+	if err := h.service.Submit(r.Context(), l, strings.TrimSpace(req.LeaveType), req.HalfDay); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "employee not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toLeaveRequestResponse(l))
+}
+
+// ListEmployeeLeaveRequests handles GET /employees/{id}/leave-requests?status=.
+func (h *LeaveHandler) ListEmployeeLeaveRequests(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var status *string
+	if v := r.URL.Query().Get("status"); v != "" {
+		status = &v
+	}
+
+	list, err := h.service.ListByEmployee(r.Context(), id, status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "employee not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, toLeaveRequestResponses(list))
+}
+
+// LeaveBalances handles GET /employees/{id}/leave-balances?year=, defaulting
+// to the current year.
+func (h *LeaveHandler) LeaveBalances(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	year := time.Now().Year()
+	if y := r.URL.Query().Get("year"); y != "" {
+		v, err := strconv.Atoi(y)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid year")
+			return
+		}
+		year = v
+	}
+
+	balances, err := h.service.Balances(r.Context(), id, year)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "employee not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	type respBalance struct {
+		LeaveTypeID int64          `json:"leaveTypeId"`
+		LeaveType   string         `json:"leaveType"`
+		Entitlement models.Decimal `json:"entitlement"`
+		Used        models.Decimal `json:"used"`
+		Pending     models.Decimal `json:"pending"`
+		Available   models.Decimal `json:"available"`
+	}
+
+	out := []respBalance{}
+	for _, b := range balances {
+		out = append(out, respBalance{
+			LeaveTypeID: b.LeaveType.ID,
+			LeaveType:   b.LeaveType.Code,
+			Entitlement: b.Entitlement,
+			Used:        b.Used,
+			Pending:     b.Pending,
+			Available:   b.Available,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		EmployeeID int64         `json:"employeeId"`
+		Year       int           `json:"year"`
+		Balances   []respBalance `json:"balances"`
+	}{EmployeeID: id, Year: year, Balances: out})
+}
+
+// PendingLeaveRequests handles GET /leave-requests?approverId=, the queue of
+// requests waiting for that manager or department head.
+func (h *LeaveHandler) PendingLeaveRequests(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	approverID, err := strconv.ParseInt(r.URL.Query().Get("approverId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "approverId is required")
+		return
+	}
+
+	list, err := h.service.PendingForApprover(r.Context(), approverID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, toLeaveRequestResponses(list))
+}
+
+// GetLeaveRequest handles GET /leave-requests/{id}.
+func (h *LeaveHandler) GetLeaveRequest(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/leave-requests/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	l, err := h.service.GetRequest(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "leave request not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, toLeaveRequestResponse(l))
+}
+
+// DecideLeaveRequest handles POST /leave-requests/{id}/approve|reject|cancel.
+// Approvals and rejections carry {"approverId": ..., "note": ...}.
+func (h *LeaveHandler) DecideLeaveRequest(w http.ResponseWriter, r *http.Request) {
+	log.Println("DecideLeaveRequest handler called")
+
+	id, err := pathID(r, "/leave-requests/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	action := pathSegment(r, "/leave-requests/", 1)
+
+	var req struct {
+		ApproverID int64   `json:"approverId"`
+		Note       *string `json:"note"`
+	}
+	if action != "cancel" {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		if req.ApproverID == 0 {
+			writeError(w, http.StatusBadRequest, "approverId is required")
+			return
+		}
+	}
+
+	var l *models.LeaveRequest
+	switch action {
+	case "approve":
+		l, err = h.service.Approve(r.Context(), id, req.ApproverID, req.Note)
+	case "reject":
+		l, err = h.service.Reject(r.Context(), id, req.ApproverID, req.Note)
+	case "cancel":
+		l, err = h.service.Cancel(r.Context(), id)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			writeError(w, http.StatusNotFound, "leave request not found")
+		case errors.Is(err, services.ErrNotLeaveApprover):
+			writeError(w, http.StatusForbidden, err.Error())
+		default:
+			writeError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, toLeaveRequestResponse(l))
+}
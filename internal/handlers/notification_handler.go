@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type NotificationHandler struct {
+	service *services.NotificationService
+}
+
+func NewNotificationHandler(service *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		service: service,
+	}
+}
+
+type NotificationResponse struct {
+	ID         int64   `json:"id"`
+	Kind       string  `json:"kind"`
+	EmployeeID *int64  `json:"employeeId"`
+	Message    string  `json:"message"`
+	ReadAt     *string `json:"readAt"`
+	CreatedAt  string  `json:"createdAt"`
+}
+
+func toNotificationResponse(n *models.Notification) NotificationResponse {
+	var readAt *string
+	if n.ReadAt != nil {
+		s := n.ReadAt.Format(time.RFC3339)
+		readAt = &s
+	}
+	return NotificationResponse{
+		ID:         n.ID,
+		Kind:       n.Kind,
+		EmployeeID: n.EmployeeID,
+		Message:    n.Message,
+		ReadAt:     readAt,
+		CreatedAt:  n.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// ListNotifications handles GET /notifications?unread=true&limit=&offset=,
+// newest first.
+func (h *NotificationHandler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	q := r.URL.Query()
+	limit := 50
+	offset := 0
+	if l := q.Get("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			limit = v
+		}
+	}
+	if o := q.Get("offset"); o != "" {
+		if v, err := strconv.Atoi(o); err == nil && v >= 0 {
+			offset = v
+		}
+	}
+	unreadOnly := q.Get("unread") == "true"
+
+	notifications, err := h.service.List(r.Context(), unreadOnly, limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	out := []NotificationResponse{}
+	for _, n := range notifications {
+		out = append(out, toNotificationResponse(n))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// MarkRead handles POST /notifications/{id}/read.
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/notifications/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	n, err := h.service.MarkRead(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "notification not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, toNotificationResponse(n))
+}
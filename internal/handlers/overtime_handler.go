@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type OvertimeHandler struct {
+	service *services.OvertimeService
+}
+
+func NewOvertimeHandler(service *services.OvertimeService) *OvertimeHandler {
+	return &OvertimeHandler{
+		service: service,
+	}
+}
+
+type OvertimeItemResponse struct {
+	ID            int64          `json:"id"`
+	EmployeeID    int64          `json:"employeeId"`
+	Period        string         `json:"period"`
+	Date          string         `json:"date"`
+	DayKind       string         `json:"dayKind"`
+	WorkedMinutes int            `json:"workedMinutes"`
+	Minutes       int            `json:"minutes"`
+	CappedMinutes int            `json:"cappedMinutes"`
+	Rate          models.Decimal `json:"rate"`
+	Status        string         `json:"status"`
+	ApproverID    *int64         `json:"approverId"`
+	DecidedAt     *string        `json:"decidedAt"`
+	DecisionNote  *string        `json:"decisionNote"`
+}
+
+func toOvertimeItemResponse(o *models.OvertimeItem) OvertimeItemResponse {
+	var decidedAt *string
+	if o.DecidedAt != nil {
+		s := o.DecidedAt.Format(time.RFC3339)
+		decidedAt = &s
+	}
+	return OvertimeItemResponse{
+		ID:            o.ID,
+		EmployeeID:    o.EmployeeID,
+		Period:        o.Period,
+		Date:          o.WorkDate.Format(dateLayout),
+		DayKind:       o.DayKind,
+		WorkedMinutes: o.WorkedMinutes,
+		Minutes:       o.Minutes,
+		CappedMinutes: o.CappedMinutes,
+		Rate:          o.Rate,
+		Status:        o.Status,
+		ApproverID:    o.ApproverID,
+		DecidedAt:     decidedAt,
+		DecisionNote:  o.DecisionNote,
+	}
+}
+
+func toOvertimeItemResponses(list []*models.OvertimeItem) []OvertimeItemResponse {
+	out := []OvertimeItemResponse{}
+	for _, o := range list {
+		out = append(out, toOvertimeItemResponse(o))
+	}
+	return out
+}
+
+func writeOvertimeError(w http.ResponseWriter, err error, notFound string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, http.StatusNotFound, notFound)
+	case errors.Is(err, services.ErrNotOvertimeApprover):
+		writeError(w, http.StatusForbidden, err.Error())
+	default:
+		writeError(w, http.StatusBadRequest, err.Error())
+	}
+}
+
+// Rules handles GET /overtime/rules, the rules in effect.
+func (h *OvertimeHandler) Rules(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.service.Rules())
+}
+
+// EmployeeOvertime handles GET /employees/{id}/overtime/{period} to list the
+// month's items and POST to recalculate them from attendance.
+func (h *OvertimeHandler) EmployeeOvertime(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	period := pathSegment(r, "/employees/", 2)
+
+	var list []*models.OvertimeItem
+	switch r.Method {
+	case http.MethodGet:
+		list, err = h.service.ListByEmployee(r.Context(), id, period)
+	case http.MethodPost:
+		log.Println("CalculateOvertime handler called")
+		list, err = h.service.Calculate(r.Context(), id, period)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if err != nil {
+		writeOvertimeError(w, err, "employee not found")
+		return
+	}
+
+	var payable, capped, approved int
+	for _, o := range list {
+		payable += o.Minutes
+		capped += o.CappedMinutes
+		if o.Status == models.OvertimeStatusApproved {
+			approved += o.Minutes
+		}
+	}
+	writeJSON(w, http.StatusOK, struct {
+		EmployeeID      int64                  `json:"employeeId"`
+		Period          string                 `json:"period"`
+		Items           []OvertimeItemResponse `json:"items"`
+		PayableMinutes  int                    `json:"payableMinutes"`
+		CappedMinutes   int                    `json:"cappedMinutes"`
+		ApprovedMinutes int                    `json:"approvedMinutes"`
+	}{id, period, toOvertimeItemResponses(list), payable, capped, approved})
+}
+
+// CalculateDepartment handles POST /departments/{id}/overtime/{period}.
+func (h *OvertimeHandler) CalculateDepartment(w http.ResponseWriter, r *http.Request) {
+	log.Println("CalculateDepartmentOvertime handler called")
+
+	id, err := pathID(r, "/departments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	list, err := h.service.CalculateDepartment(r.Context(), id, pathSegment(r, "/departments/", 2))
+	if err != nil {
+		writeOvertimeError(w, err, "department not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toOvertimeItemResponses(list))
+}
+
+// PendingOvertime handles GET /overtime?approverId=.
+func (h *OvertimeHandler) PendingOvertime(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	approverID, err := strconv.ParseInt(r.URL.Query().Get("approverId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "approverId is required")
+		return
+	}
+
+	list, err := h.service.PendingForApprover(r.Context(), approverID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, toOvertimeItemResponses(list))
+}
+
+// GetOvertime handles GET /overtime/{id}.
+func (h *OvertimeHandler) GetOvertime(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/overtime/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	o, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "overtime not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, toOvertimeItemResponse(o))
+}
+
+// DecideOvertime handles POST /overtime/{id}/approve|reject with
+// {"approverId": ..., "note": ...}.
+func (h *OvertimeHandler) DecideOvertime(w http.ResponseWriter, r *http.Request) {
+	log.Println("DecideOvertime handler called")
+
+	id, err := pathID(r, "/overtime/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req struct {
+		ApproverID int64   `json:"approverId"`
+		Note       *string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.ApproverID == 0 {
+		writeError(w, http.StatusBadRequest, "approverId is required")
+		return
+	}
+
+	var o *models.OvertimeItem
+	switch pathSegment(r, "/overtime/", 1) {
+	case "approve":
+		o, err = h.service.Approve(r.Context(), id, req.ApproverID, req.Note)
+	case "reject":
+		o, err = h.service.Reject(r.Context(), id, req.ApproverID, req.Note)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		writeOvertimeError(w, err, "overtime not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toOvertimeItemResponse(o))
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// pathID parses the numeric id right after prefix, so pathID(r, "/employees/")
+// on "/employees/7/compensation" returns 7.
+func pathID(r *http.Request, prefix string) (int64, error) {
+	p := strings.TrimPrefix(r.URL.Path, prefix)
+	idStr := strings.SplitN(p, "/", 2)[0]
+	if idStr == "" {
+		return 0, errors.New("missing id")
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid id")
+	}
+	return id, nil
+}
+
+// pathSegment returns the n-th slash separated segment after prefix, or "".
+func pathSegment(r *http.Request, prefix string, n int) string {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/"), "/")
+	if n < len(parts) {
+		return parts[n]
+	}
+	return ""
+}
+
+// queryInt64 parses the optional numeric query parameter name, returning
+// nil when it is absent.
+func queryInt64(r *http.Request, name string) (*int64, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid " + name)
+	}
+	return &n, nil
+}
+
+func parseDate(s string) (time.Time, error) {
+	return time.Parse(dateLayout, s)
+}
+
+func formatDatePtr(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(dateLayout)
+	return &s
+}
+
+func formatTimestampPtr(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(time.RFC3339)
+	return &s
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type PayrollHandler struct {
+	service *services.PayrollService
+}
+
+func NewPayrollHandler(service *services.PayrollService) *PayrollHandler {
+	return &PayrollHandler{
+		service: service,
+	}
+}
+
+type PayrollRunResponse struct {
+	ID            int64          `json:"id"`
+	Period        string         `json:"period"`
+	Currency      string         `json:"currency"`
+	EmployeeCount int            `json:"employeeCount"`
+	TotalGross    models.Decimal `json:"totalGross"`
+	TotalNet      models.Decimal `json:"totalNet"`
+	CreatedAt     string         `json:"createdAt"`
+}
+
+type PayslipLineResponse struct {
+	Kind   string         `json:"kind"`
+	Code   string         `json:"code"`
+	Label  string         `json:"label"`
+	Amount models.Decimal `json:"amount"`
+}
+
+type PayslipResponse struct {
+	ID              int64                 `json:"id"`
+	PayrollRunID    int64                 `json:"payrollRunId"`
+	EmployeeID      int64                 `json:"employeeId"`
+	EmployeeName    string                `json:"employeeName"`
+	DepartmentID    int64                 `json:"departmentId"`
+	Period          string                `json:"period"`
+	Currency        string                `json:"currency"`
+	BaseSalary      models.Decimal        `json:"baseSalary"`
+	WorkingDays     int                   `json:"workingDays"`
+	PaidDays        int                   `json:"paidDays"`
+	Gross           models.Decimal        `json:"gross"`
+	SocialInsurance models.Decimal        `json:"socialInsurance"`
+	TaxableIncome   models.Decimal        `json:"taxableIncome"`
+	IncomeTax       models.Decimal        `json:"incomeTax"`
+	Deductions      models.Decimal        `json:"deductions"`
+	Net             models.Decimal        `json:"net"`
+	Lines           []PayslipLineResponse `json:"lines"`
+	CreatedAt       string                `json:"createdAt"`
+}
+
+func toPayrollRunResponse(run *models.PayrollRun) PayrollRunResponse {
+	return PayrollRunResponse{
+		ID:            run.ID,
+		Period:        run.Period,
+		Currency:      run.Currency,
+		EmployeeCount: run.EmployeeCount,
+		TotalGross:    run.TotalGross,
+		TotalNet:      run.TotalNet,
+		CreatedAt:     run.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func toPayslipResponse(p *models.Payslip) PayslipResponse {
+	lines := []PayslipLineResponse{}
+	for _, l := range p.Lines {
+		lines = append(lines, PayslipLineResponse{Kind: l.Kind, Code: l.Code, Label: l.Label, Amount: l.Amount})
+	}
+	return PayslipResponse{
+		ID:              p.ID,
+		PayrollRunID:    p.PayrollRunID,
+		EmployeeID:      p.EmployeeID,
+		EmployeeName:    p.EmployeeName,
+		DepartmentID:    p.DepartmentID,
+		Period:          p.Period,
+		Currency:        p.Currency,
+		BaseSalary:      p.BaseSalary,
+		WorkingDays:     p.WorkingDays,
+		PaidDays:        p.PaidDays,
+		Gross:           p.Gross,
+		SocialInsurance: p.SocialInsurance,
+		TaxableIncome:   p.TaxableIncome,
+		IncomeTax:       p.IncomeTax,
+		Deductions:      p.Deductions,
+		Net:             p.Net,
+		Lines:           lines,
+		CreatedAt:       p.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func writePayrollRun(w http.ResponseWriter, status int, run *models.PayrollRun, payslips []*models.Payslip) {
+	out := []PayslipResponse{}
+	for _, p := range payslips {
+		out = append(out, toPayslipResponse(p))
+	}
+	writeJSON(w, status, struct {
+		PayrollRunResponse
+		Payslips []PayslipResponse `json:"payslips"`
+	}{PayrollRunResponse: toPayrollRunResponse(run), Payslips: out})
+}
+
+// CreateRun handles POST /payroll/runs with {"period": "2026-10"}.
+func (h *PayrollHandler) CreateRun(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreatePayrollRun handler called")
+
+	var req struct {
+		Period string `json:"period"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	run, payslips, err := h.service.Run(r.Context(), req.Period)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writePayrollRun(w, http.StatusCreated, run, payslips)
+}
+
+func (h *PayrollHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit := 24
+	offset := 0
+	if l := q.Get("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			limit = v
+		}
+	}
+	if o := q.Get("offset"); o != "" {
+		if v, err := strconv.Atoi(o); err == nil && v >= 0 {
+			offset = v
+		}
+	}
+
+	runs, total, err := h.service.ListRuns(r.Context(), limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	out := []PayrollRunResponse{}
+	for _, run := range runs {
+		out = append(out, toPayrollRunResponse(run))
+	}
+	writeJSON(w, http.StatusOK, struct {
+		TotalCount int64                `json:"totalCount"`
+		Runs       []PayrollRunResponse `json:"runs"`
+	}{TotalCount: total, Runs: out})
+}
+
+func (h *PayrollHandler) GetRun(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/payroll/runs/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	run, payslips, err := h.service.GetRun(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "payroll run not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writePayrollRun(w, http.StatusOK, run, payslips)
+}
+
+var payrollRegisterHeader = []string{
+	"employeeId", "employeeName", "departmentId", "period", "currency", "baseSalary", "workingDays", "paidDays",
+	"gross", "socialInsurance", "taxableIncome", "incomeTax", "deductions", "net",
+}
+
+func payslipToRegisterRow(p *models.Payslip) []string {
+	return []string{
+		fmt.Sprintf("%d", p.EmployeeID),
+		p.EmployeeName,
+		fmt.Sprintf("%d", p.DepartmentID),
+		p.Period,
+		p.Currency,
+		p.BaseSalary.String(),
+		strconv.Itoa(p.WorkingDays),
+		strconv.Itoa(p.PaidDays),
+		p.Gross.String(),
+		p.SocialInsurance.String(),
+		p.TaxableIncome.String(),
+		p.IncomeTax.String(),
+		p.Deductions.String(),
+		p.Net.String(),
+	}
+}
+
+// Register handles GET /payroll/runs/{id}/register?format=csv|json and
+// downloads the payroll register of the run.
+func (h *PayrollHandler) Register(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/payroll/runs/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	run, payslips, err := h.service.GetRun(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "payroll run not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	name := "payroll_register_" + run.Period
+	if r.URL.Query().Get("format") == "json" {
+		out := []PayslipResponse{}
+		for _, p := range payslips {
+			out = append(out, toPayslipResponse(p))
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		serveDownload(w, r, name+".json", "application/json", data)
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	wtr := csv.NewWriter(buf)
+	wtr.Write(payrollRegisterHeader)
+	for _, p := range payslips {
+		wtr.Write(payslipToRegisterRow(p))
+	}
+	wtr.Write([]string{"", "TOTAL", "", run.Period, run.Currency, "", "", "", run.TotalGross.String(), "", "", "", "", run.TotalNet.String()})
+	wtr.Flush()
+	if err := wtr.Error(); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	serveDownload(w, r, name+".csv", "text/csv", buf.Bytes())
+}
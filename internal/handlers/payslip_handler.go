@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type PayslipHandler struct {
+	service *services.PayslipService
+}
+
+func NewPayslipHandler(service *services.PayslipService) *PayslipHandler {
+	return &PayslipHandler{
+		service: service,
+	}
+}
+
+func payslipFileName(p *models.Payslip) string {
+	return fmt.Sprintf("payslip_%d_%s.pdf", p.EmployeeID, p.Period)
+}
+
+// ListEmployeePayslips handles GET /employees/{id}/payslips.
+func (h *PayslipHandler) ListEmployeePayslips(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	payslips, err := h.service.ListByEmployee(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	out := []PayslipResponse{}
+	for _, p := range payslips {
+		out = append(out, toPayslipResponse(p))
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Payslips []PayslipResponse `json:"payslips"`
+	}{Payslips: out})
+}
+
+// GetEmployeePayslip handles GET /employees/{id}/payslips/{period} and
+// downloads the PDF, or returns the figures with ?format=json.
+func (h *PayslipHandler) GetEmployeePayslip(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	period := pathSegment(r, "/employees/", 2)
+	if _, _, err := services.ParsePeriod(period); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	p, ytd, err := h.service.Get(r.Context(), id, period)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "payslip not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if r.URL.Query().Get("format") == "json" {
+		type respTotals struct {
+			Gross           models.Decimal `json:"gross"`
+			SocialInsurance models.Decimal `json:"socialInsurance"`
+			IncomeTax       models.Decimal `json:"incomeTax"`
+			Deductions      models.Decimal `json:"deductions"`
+			Net             models.Decimal `json:"net"`
+		}
+		writeJSON(w, http.StatusOK, struct {
+			PayslipResponse
+			YearToDate respTotals `json:"yearToDate"`
+		}{
+			PayslipResponse: toPayslipResponse(p),
+			YearToDate: respTotals{
+				Gross:           ytd.Gross,
+				SocialInsurance: ytd.SocialInsurance,
+				IncomeTax:       ytd.IncomeTax,
+				Deductions:      ytd.Deductions,
+				Net:             ytd.Net,
+			},
+		})
+		return
+	}
+	serveDownload(w, r, payslipFileName(p), "application/pdf", h.service.RenderPDF(p, ytd))
+}
+
+// DepartmentPayslips handles GET /departments/{id}/payslips/{period}: every
+// payslip of the department as PDFs in one zip. Like ExportCSV, the zip is
+// written to EXPORT_DIR unless download=true.
+func (h *PayslipHandler) DepartmentPayslips(w http.ResponseWriter, r *http.Request) {
+	log.Println("DepartmentPayslips handler called")
+
+	deptID, err := pathID(r, "/departments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	period := pathSegment(r, "/departments/", 2)
+	if _, _, err := services.ParsePeriod(period); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	payslips, err := h.service.ListByDepartment(r.Context(), deptID, period)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if len(payslips) == 0 {
+		writeError(w, http.StatusNotFound, "no payslips for this department and period")
+		return
+	}
+
+	// Render the PDFs concurrently; each needs its own YTD lookup.
+	files := make([][]byte, len(payslips))
+	errs := make([]error, len(payslips))
+	var wg sync.WaitGroup
+	for i, p := range payslips {
+		wg.Add(1)
+		go func(i int, p *models.Payslip) {
+			defer wg.Done()
+			ytd, err := h.service.YearToDate(r.Context(), p)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			files[i] = h.service.RenderPDF(p, ytd)
+		}(i, p)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for i, p := range payslips {
+		f, err := zw.Create(payslipFileName(p))
+		if err == nil {
+			_, err = f.Write(files[i])
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	if err := zw.Close(); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	zipFile := fmt.Sprintf("payslips_department_%d_%s.zip", deptID, period)
+	if r.URL.Query().Get("download") == "true" {
+		serveDownload(w, r, zipFile, "application/zip", buf.Bytes())
+		return
+	}
+	if err := saveExport(zipFile, buf.Bytes()); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"zipFile":   zipFile,
+		"payslips":  len(payslips),
+		"exportDir": exportDir(),
+	})
+}
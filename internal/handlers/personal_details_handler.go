@@ -0,0 +1,597 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type PersonalDetailsHandler struct {
+	service *services.PersonalDetailsService
+}
+
+func NewPersonalDetailsHandler(service *services.PersonalDetailsService) *PersonalDetailsHandler {
+	return &PersonalDetailsHandler{
+		service: service,
+	}
+}
+
+type AddressResponse struct {
+	ID         int64   `json:"id"`
+	EmployeeID int64   `json:"employeeId"`
+	Kind       string  `json:"kind"`
+	Line1      string  `json:"line1"`
+	Line2      *string `json:"line2"`
+	City       string  `json:"city"`
+	Region     *string `json:"region"`
+	PostalCode *string `json:"postalCode"`
+	Country    string  `json:"country"`
+	IsPrimary  bool    `json:"isPrimary"`
+	CreatedAt  string  `json:"createdAt"`
+	UpdatedAt  string  `json:"updatedAt"`
+}
+
+func toAddressResponse(a *models.EmployeeAddress) AddressResponse {
+	return AddressResponse{
+		ID:         a.ID,
+		EmployeeID: a.EmployeeID,
+		Kind:       a.Kind,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+		IsPrimary:  a.IsPrimary,
+		CreatedAt:  a.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  a.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type PhoneResponse struct {
+	ID         int64  `json:"id"`
+	EmployeeID int64  `json:"employeeId"`
+	Kind       string `json:"kind"`
+	Number     string `json:"number"`
+	IsPrimary  bool   `json:"isPrimary"`
+	CreatedAt  string `json:"createdAt"`
+	UpdatedAt  string `json:"updatedAt"`
+}
+
+func toPhoneResponse(p *models.EmployeePhone) PhoneResponse {
+	return PhoneResponse{
+		ID:         p.ID,
+		EmployeeID: p.EmployeeID,
+		Kind:       p.Kind,
+		Number:     p.Number,
+		IsPrimary:  p.IsPrimary,
+		CreatedAt:  p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  p.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type EmergencyContactResponse struct {
+	ID           int64   `json:"id"`
+	EmployeeID   int64   `json:"employeeId"`
+	Name         string  `json:"name"`
+	Relationship string  `json:"relationship"`
+	Phone        string  `json:"phone"`
+	Email        *string `json:"email"`
+	Priority     int     `json:"priority"`
+	CreatedAt    string  `json:"createdAt"`
+	UpdatedAt    string  `json:"updatedAt"`
+}
+
+func toEmergencyContactResponse(c *models.EmergencyContact) EmergencyContactResponse {
+	return EmergencyContactResponse{
+		ID:           c.ID,
+		EmployeeID:   c.EmployeeID,
+		Name:         c.Name,
+		Relationship: c.Relationship,
+		Phone:        c.Phone,
+		Email:        c.Email,
+		Priority:     c.Priority,
+		CreatedAt:    c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    c.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type DependentResponse struct {
+	ID           int64   `json:"id"`
+	EmployeeID   int64   `json:"employeeId"`
+	Name         string  `json:"name"`
+	Relationship string  `json:"relationship"`
+	DateOfBirth  *string `json:"dateOfBirth"`
+	TaxID        *string `json:"taxId"`
+	ReliefFrom   *string `json:"reliefFrom"`
+	ReliefTo     *string `json:"reliefTo"`
+	CreatedAt    string  `json:"createdAt"`
+	UpdatedAt    string  `json:"updatedAt"`
+}
+
+func toDependentResponse(d *models.Dependent) DependentResponse {
+	return DependentResponse{
+		ID:           d.ID,
+		EmployeeID:   d.EmployeeID,
+		Name:         d.Name,
+		Relationship: d.Relationship,
+		DateOfBirth:  formatDatePtr(d.DateOfBirth),
+		TaxID:        d.TaxID,
+		ReliefFrom:   formatDatePtr(d.ReliefFrom),
+		ReliefTo:     formatDatePtr(d.ReliefTo),
+		CreatedAt:    d.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    d.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// The request bodies below are shared by POST /employees/{id}/<details> and
+// PUT /employees/{id}/<details>/{detailId}; a PUT replaces the record.
+
+type addressRequest struct {
+	Kind       string  `json:"kind"`
+	Line1      string  `json:"line1"`
+	Line2      *string `json:"line2"`
+	City       string  `json:"city"`
+	Region     *string `json:"region"`
+	PostalCode *string `json:"postalCode"`
+	Country    string  `json:"country"`
+	IsPrimary  bool    `json:"isPrimary"`
+}
+
+func (req addressRequest) toAddress() *models.EmployeeAddress {
+	return &models.EmployeeAddress{
+		Kind:       req.Kind,
+		Line1:      req.Line1,
+		Line2:      req.Line2,
+		City:       req.City,
+		Region:     req.Region,
+		PostalCode: req.PostalCode,
+		Country:    req.Country,
+		IsPrimary:  req.IsPrimary,
+	}
+}
+
+type phoneRequest struct {
+	Kind      string `json:"kind"`
+	Number    string `json:"number"`
+	IsPrimary bool   `json:"isPrimary"`
+}
+
+func (req phoneRequest) toPhone() *models.EmployeePhone {
+	return &models.EmployeePhone{
+		Kind:      req.Kind,
+		Number:    req.Number,
+		IsPrimary: req.IsPrimary,
+	}
+}
+
+type emergencyContactRequest struct {
+	Name         string  `json:"name"`
+	Relationship string  `json:"relationship"`
+	Phone        string  `json:"phone"`
+	Email        *string `json:"email"`
+	Priority     int     `json:"priority"`
+}
+
+func (req emergencyContactRequest) toEmergencyContact() *models.EmergencyContact {
+	return &models.EmergencyContact{
+		Name:         req.Name,
+		Relationship: req.Relationship,
+		Phone:        req.Phone,
+		Email:        req.Email,
+		Priority:     req.Priority,
+	}
+}
+
+type dependentRequest struct {
+	Name         string  `json:"name"`
+	Relationship string  `json:"relationship"`
+	DateOfBirth  *string `json:"dateOfBirth"`
+	TaxID        *string `json:"taxId"`
+	ReliefFrom   *string `json:"reliefFrom"`
+	ReliefTo     *string `json:"reliefTo"`
+}
+
+func (req dependentRequest) toDependent() (*models.Dependent, error) {
+	dob, err := parseOptionalDate(req.DateOfBirth, "dateOfBirth")
+	if err != nil {
+		return nil, err
+	}
+	from, err := parseOptionalDate(req.ReliefFrom, "reliefFrom")
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseOptionalDate(req.ReliefTo, "reliefTo")
+	if err != nil {
+		return nil, err
+	}
+	return &models.Dependent{
+		Name:         req.Name,
+		Relationship: req.Relationship,
+		DateOfBirth:  dob,
+		TaxID:        req.TaxID,
+		ReliefFrom:   from,
+		ReliefTo:     to,
+	}, nil
+}
+
+// writePersonalDetailsError answers 400 only for input the service
+// rejected; database failures are logged rather than shown to the client.
+func writePersonalDetailsError(w http.ResponseWriter, err error, notFound string) {
+	var invalid *services.ValidationError
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, http.StatusNotFound, notFound)
+	case errors.As(err, &invalid):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrDetailConflict):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		log.Printf("personal details: %v", err)
+		writeError(w, http.StatusInternalServerError, "failed to process personal details")
+	}
+}
+
+// detailPathIDs parses the employee and record ids in
+// /employees/{id}/<details>/{detailId}; name is used in the error message.
+func detailPathIDs(r *http.Request, name string) (int64, int64, error) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		return 0, 0, err
+	}
+	detailID, err := strconv.ParseInt(pathSegment(r, "/employees/", 2), 10, 64)
+	if err != nil {
+		return 0, 0, errors.New("invalid " + name + " id")
+	}
+	return id, detailID, nil
+}
+
+// ListAddresses handles GET /employees/{id}/addresses, primary first.
+func (h *PersonalDetailsHandler) ListAddresses(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	addresses, err := h.service.ListAddresses(r.Context(), id)
+	if err != nil {
+		writePersonalDetailsError(w, err, "employee not found")
+		return
+	}
+	out := []AddressResponse{}
+	for _, a := range addresses {
+		out = append(out, toAddressResponse(a))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// CreateAddress handles POST /employees/{id}/addresses.
+func (h *PersonalDetailsHandler) CreateAddress(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreateAddress handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req addressRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	a := req.toAddress()
+	a.EmployeeID = id
+
+	if err := h.service.CreateAddress(r.Context(), a); err != nil {
+		writePersonalDetailsError(w, err, "employee not found")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toAddressResponse(a))
+}
+
+// UpdateAddress handles PUT /employees/{id}/addresses/{addressId}.
+func (h *PersonalDetailsHandler) UpdateAddress(w http.ResponseWriter, r *http.Request) {
+	log.Println("UpdateAddress handler called")
+
+	id, addressID, err := detailPathIDs(r, "address")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req addressRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	a := req.toAddress()
+	a.ID, a.EmployeeID = addressID, id
+
+	if err := h.service.UpdateAddress(r.Context(), a); err != nil {
+		writePersonalDetailsError(w, err, "address not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toAddressResponse(a))
+}
+
+// DeleteAddress handles DELETE /employees/{id}/addresses/{addressId}.
+func (h *PersonalDetailsHandler) DeleteAddress(w http.ResponseWriter, r *http.Request) {
+	log.Println("DeleteAddress handler called")
+
+	id, addressID, err := detailPathIDs(r, "address")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.DeleteAddress(r.Context(), id, addressID); err != nil {
+		writePersonalDetailsError(w, err, "address not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListPhones handles GET /employees/{id}/phones, primary first.
+func (h *PersonalDetailsHandler) ListPhones(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	phones, err := h.service.ListPhones(r.Context(), id)
+	if err != nil {
+		writePersonalDetailsError(w, err, "employee not found")
+		return
+	}
+	out := []PhoneResponse{}
+	for _, p := range phones {
+		out = append(out, toPhoneResponse(p))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// CreatePhone handles POST /employees/{id}/phones.
+func (h *PersonalDetailsHandler) CreatePhone(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreatePhone handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req phoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	p := req.toPhone()
+	p.EmployeeID = id
+
+	if err := h.service.CreatePhone(r.Context(), p); err != nil {
+		writePersonalDetailsError(w, err, "employee not found")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toPhoneResponse(p))
+}
+
+// UpdatePhone handles PUT /employees/{id}/phones/{phoneId}.
+func (h *PersonalDetailsHandler) UpdatePhone(w http.ResponseWriter, r *http.Request) {
+	log.Println("UpdatePhone handler called")
+
+	id, phoneID, err := detailPathIDs(r, "phone")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req phoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	p := req.toPhone()
+	p.ID, p.EmployeeID = phoneID, id
+
+	if err := h.service.UpdatePhone(r.Context(), p); err != nil {
+		writePersonalDetailsError(w, err, "phone not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toPhoneResponse(p))
+}
+
+// DeletePhone handles DELETE /employees/{id}/phones/{phoneId}.
+func (h *PersonalDetailsHandler) DeletePhone(w http.ResponseWriter, r *http.Request) {
+	log.Println("DeletePhone handler called")
+
+	id, phoneID, err := detailPathIDs(r, "phone")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.DeletePhone(r.Context(), id, phoneID); err != nil {
+		writePersonalDetailsError(w, err, "phone not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListEmergencyContacts handles GET /employees/{id}/emergency-contacts in
+// the order they should be called.
+func (h *PersonalDetailsHandler) ListEmergencyContacts(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	contacts, err := h.service.ListEmergencyContacts(r.Context(), id)
+	if err != nil {
+		writePersonalDetailsError(w, err, "employee not found")
+		return
+	}
+	out := []EmergencyContactResponse{}
+	for _, c := range contacts {
+		out = append(out, toEmergencyContactResponse(c))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// CreateEmergencyContact handles POST /employees/{id}/emergency-contacts.
+func (h *PersonalDetailsHandler) CreateEmergencyContact(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreateEmergencyContact handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req emergencyContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	c := req.toEmergencyContact()
+	c.EmployeeID = id
+
+	if err := h.service.CreateEmergencyContact(r.Context(), c); err != nil {
+		writePersonalDetailsError(w, err, "employee not found")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toEmergencyContactResponse(c))
+}
+
+// UpdateEmergencyContact handles PUT /employees/{id}/emergency-contacts/{contactId}.
+func (h *PersonalDetailsHandler) UpdateEmergencyContact(w http.ResponseWriter, r *http.Request) {
+	log.Println("UpdateEmergencyContact handler called")
+
+	id, contactID, err := detailPathIDs(r, "emergency contact")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req emergencyContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	c := req.toEmergencyContact()
+	c.ID, c.EmployeeID = contactID, id
+
+	if err := h.service.UpdateEmergencyContact(r.Context(), c); err != nil {
+		writePersonalDetailsError(w, err, "emergency contact not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toEmergencyContactResponse(c))
+}
+
+// DeleteEmergencyContact handles DELETE /employees/{id}/emergency-contacts/{contactId}.
+func (h *PersonalDetailsHandler) DeleteEmergencyContact(w http.ResponseWriter, r *http.Request) {
+	log.Println("DeleteEmergencyContact handler called")
+
+	id, contactID, err := detailPathIDs(r, "emergency contact")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.DeleteEmergencyContact(r.Context(), id, contactID); err != nil {
+		writePersonalDetailsError(w, err, "emergency contact not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDependents handles GET /employees/{id}/dependents.
+func (h *PersonalDetailsHandler) ListDependents(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	dependents, err := h.service.ListDependents(r.Context(), id)
+	if err != nil {
+		writePersonalDetailsError(w, err, "employee not found")
+		return
+	}
+	out := []DependentResponse{}
+	for _, d := range dependents {
+		out = append(out, toDependentResponse(d))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// CreateDependent handles POST /employees/{id}/dependents. A reliefFrom date
+// registers the dependent for the payroll dependent relief.
+func (h *PersonalDetailsHandler) CreateDependent(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreateDependent handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req dependentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	d, err := req.toDependent()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	d.EmployeeID = id
+
+	if err := h.service.CreateDependent(r.Context(), d); err != nil {
+		writePersonalDetailsError(w, err, "employee not found")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toDependentResponse(d))
+}
+
+// UpdateDependent handles PUT /employees/{id}/dependents/{dependentId}, e.g.
+// to set reliefTo when the dependent no longer qualifies.
+func (h *PersonalDetailsHandler) UpdateDependent(w http.ResponseWriter, r *http.Request) {
+	log.Println("UpdateDependent handler called")
+
+	id, dependentID, err := detailPathIDs(r, "dependent")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req dependentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	d, err := req.toDependent()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	d.ID, d.EmployeeID = dependentID, id
+
+	if err := h.service.UpdateDependent(r.Context(), d); err != nil {
+		writePersonalDetailsError(w, err, "dependent not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toDependentResponse(d))
+}
+
+// DeleteDependent handles DELETE /employees/{id}/dependents/{dependentId}.
+func (h *PersonalDetailsHandler) DeleteDependent(w http.ResponseWriter, r *http.Request) {
+	log.Println("DeleteDependent handler called")
+
+	id, dependentID, err := detailPathIDs(r, "dependent")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.DeleteDependent(r.Context(), id, dependentID); err != nil {
+		writePersonalDetailsError(w, err, "dependent not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
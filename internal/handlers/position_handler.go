@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type PositionHandler struct {
+	service *services.PositionService
+}
+
+func NewPositionHandler(service *services.PositionService) *PositionHandler {
+	return &PositionHandler{
+		service: service,
+	}
+}
+
+type PositionResponse struct {
+	ID        int64   `json:"id"`
+	Code      string  `json:"code"`
+	Title     string  `json:"title"`
+	Level     *int    `json:"level"`
+	JobFamily *string `json:"jobFamily"`
+	CreatedAt string  `json:"createdAt"`
+	UpdatedAt string  `json:"updatedAt"`
+}
+
+func toPositionResponse(p *models.Position) PositionResponse {
+	return PositionResponse{
+		ID:        p.ID,
+		Code:      p.Code,
+		Title:     p.Title,
+		Level:     p.Level,
+		JobFamily: p.JobFamily,
+		CreatedAt: p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: p.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (h *PositionHandler) ListPositions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit := 100
+	offset := 0
+	if l := q.Get("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			limit = v
+		}
+	}
+	if o := q.Get("offset"); o != "" {
+		if v, err := strconv.Atoi(o); err == nil && v >= 0 {
+			offset = v
+		}
+	}
+
+	var keyword, jobFamily *string
+	if k := q.Get("keyword"); k != "" {
+		keyword = &k
+	}
+	if f := q.Get("jobFamily"); f != "" {
+		jobFamily = &f
+	}
+
+	positions, total, err := h.service.FindAll(r.Context(), limit, offset, keyword, jobFamily)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	out := []PositionResponse{}
+	for _, p := range positions {
+		out = append(out, toPositionResponse(p))
+	}
+	writeJSON(w, http.StatusOK, struct {
+		TotalCount int64              `json:"totalCount"`
+		Positions  []PositionResponse `json:"positions"`
+	}{TotalCount: total, Positions: out})
+}
+
+func (h *PositionHandler) CreatePosition(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreatePosition handler called")
+
+	var req struct {
+		Code      string  `json:"code"`
+		Title     string  `json:"title"`
+		Level     *int    `json:"level"`
+		JobFamily *string `json:"jobFamily"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	p := &models.Position{
+		Code:      req.Code,
+		Title:     req.Title,
+		Level:     req.Level,
+		JobFamily: req.JobFamily,
+	}
+	if err := h.service.Create(r.Context(), p); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, toPositionResponse(p))
+}
+
+func (h *PositionHandler) GetPosition(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/positions/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	p, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "position not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toPositionResponse(p))
+}
+
+func (h *PositionHandler) UpdatePosition(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/positions/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req struct {
+		Code      *string `json:"code"`
+		Title     *string `json:"title"`
+		Level     *int    `json:"level"`
+		JobFamily *string `json:"jobFamily"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	existing, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "position not found")
+		return
+	}
+	if req.Code != nil {
+		existing.Code = *req.Code
+	}
+	if req.Title != nil {
+		existing.Title = *req.Title
+	}
+	if req.Level != nil {
+		existing.Level = req.Level
+	}
+	if req.JobFamily != nil {
+		existing.JobFamily = req.JobFamily
+	}
+
+	if err := h.service.Update(r.Context(), existing); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, toPositionResponse(existing))
+}
+
+func (h *PositionHandler) DeletePosition(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/positions/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "position not found")
+			return
+		}
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MergePositions handles POST /positions/{id}/merge with {"sourceIds": [...]}.
+func (h *PositionHandler) MergePositions(w http.ResponseWriter, r *http.Request) {
+	log.Println("MergePositions handler called")
+
+	id, err := pathID(r, "/positions/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req struct {
+		SourceIDs []int64 `json:"sourceIds"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.service.Merge(r.Context(), id, req.SourceIDs); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	p, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, toPositionResponse(p))
+}
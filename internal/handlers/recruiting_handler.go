@@ -0,0 +1,701 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type RecruitingHandler struct {
+	service *services.RecruitingService
+}
+
+func NewRecruitingHandler(service *services.RecruitingService) *RecruitingHandler {
+	return &RecruitingHandler{
+		service: service,
+	}
+}
+
+type PipelineStageResponse struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+}
+
+func toPipelineStageResponse(s *models.PipelineStage) PipelineStageResponse {
+	return PipelineStageResponse{
+		ID:       s.ID,
+		Name:     s.Name,
+		Position: s.Position,
+	}
+}
+
+type RequisitionResponse struct {
+	ID              int64           `json:"id"`
+	DepartmentID    int64           `json:"departmentId"`
+	PositionID      *int64          `json:"positionId"`
+	Title           string          `json:"title"`
+	Openings        int             `json:"openings"`
+	Hired           int             `json:"hired"`
+	Status          string          `json:"status"`
+	HiringManagerID *int64          `json:"hiringManagerId"`
+	SalaryMin       *models.Decimal `json:"salaryMin"`
+	SalaryMax       *models.Decimal `json:"salaryMax"`
+	SalaryCurrency  *string         `json:"salaryCurrency"`
+	Description     *string         `json:"description"`
+	OpenedOn        string          `json:"openedOn"`
+	ClosedOn        *string         `json:"closedOn"`
+	CreatedAt       string          `json:"createdAt"`
+	UpdatedAt       string          `json:"updatedAt"`
+}
+
+func toRequisitionResponse(jr *models.JobRequisition) RequisitionResponse {
+	return RequisitionResponse{
+		ID:              jr.ID,
+		DepartmentID:    jr.DepartmentID,
+		PositionID:      jr.PositionID,
+		Title:           jr.Title,
+		Openings:        jr.Openings,
+		Hired:           jr.Hired,
+		Status:          jr.Status,
+		HiringManagerID: jr.HiringManagerID,
+		SalaryMin:       jr.SalaryMin,
+		SalaryMax:       jr.SalaryMax,
+		SalaryCurrency:  jr.SalaryCurrency,
+		Description:     jr.Description,
+		OpenedOn:        jr.OpenedOn.Format(dateLayout),
+		ClosedOn:        formatDatePtr(jr.ClosedOn),
+		CreatedAt:       jr.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:       jr.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type CandidateResponse struct {
+	ID                int64           `json:"id"`
+	RequisitionID     int64           `json:"requisitionId"`
+	Name              string          `json:"name"`
+	Email             *string         `json:"email"`
+	Phone             *string         `json:"phone"`
+	Source            *string         `json:"source"`
+	StageID           int64           `json:"stageId"`
+	StageName         string          `json:"stageName"`
+	Status            string          `json:"status"`
+	OfferedSalary     *models.Decimal `json:"offeredSalary"`
+	OfferedCurrency   *string         `json:"offeredCurrency"`
+	ExpectedStartDate *string         `json:"expectedStartDate"`
+	EmployeeID        *int64          `json:"employeeId"`
+	Note              *string         `json:"note"`
+	CreatedAt         string          `json:"createdAt"`
+	UpdatedAt         string          `json:"updatedAt"`
+}
+
+func toCandidateResponse(c *models.Candidate) CandidateResponse {
+	return CandidateResponse{
+		ID:                c.ID,
+		RequisitionID:     c.RequisitionID,
+		Name:              c.Name,
+		Email:             c.Email,
+		Phone:             c.Phone,
+		Source:            c.Source,
+		StageID:           c.StageID,
+		StageName:         c.StageName,
+		Status:            c.Status,
+		OfferedSalary:     c.OfferedSalary,
+		OfferedCurrency:   c.OfferedCurrency,
+		ExpectedStartDate: formatDatePtr(c.ExpectedStartDate),
+		EmployeeID:        c.EmployeeID,
+		Note:              c.Note,
+		CreatedAt:         c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         c.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type CandidateStageChangeResponse struct {
+	FromStageID *int64  `json:"fromStageId"`
+	ToStageID   int64   `json:"toStageId"`
+	ToStageName string  `json:"toStageName"`
+	Note        *string `json:"note"`
+	ChangedAt   string  `json:"changedAt"`
+}
+
+type InterviewFeedbackResponse struct {
+	ID              int64   `json:"id"`
+	CandidateID     int64   `json:"candidateId"`
+	StageID         int64   `json:"stageId"`
+	StageName       string  `json:"stageName"`
+	InterviewerID   int64   `json:"interviewerId"`
+	InterviewerName string  `json:"interviewerName"`
+	Rating          int     `json:"rating"`
+	Recommendation  string  `json:"recommendation"`
+	Comment         *string `json:"comment"`
+	InterviewedOn   string  `json:"interviewedOn"`
+}
+
+func toInterviewFeedbackResponse(f *models.InterviewFeedback) InterviewFeedbackResponse {
+	return InterviewFeedbackResponse{
+		ID:              f.ID,
+		CandidateID:     f.CandidateID,
+		StageID:         f.StageID,
+		StageName:       f.StageName,
+		InterviewerID:   f.InterviewerID,
+		InterviewerName: f.InterviewerName,
+		Rating:          f.Rating,
+		Recommendation:  f.Recommendation,
+		Comment:         f.Comment,
+		InterviewedOn:   f.InterviewedOn.Format(dateLayout),
+	}
+}
+
+func writeRecruitingError(w http.ResponseWriter, err error, notFound string) {
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, notFound)
+		return
+	}
+	writeError(w, http.StatusBadRequest, err.Error())
+}
+
+func upperCurrency(c *string) *string {
+	if c == nil {
+		return nil
+	}
+	u := strings.ToUpper(*c)
+	return &u
+}
+
+func (h *RecruitingHandler) ListStages(w http.ResponseWriter, r *http.Request) {
+	stages, err := h.service.ListStages(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	out := []PipelineStageResponse{}
+	for _, s := range stages {
+		out = append(out, toPipelineStageResponse(s))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+type stageRequest struct {
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+}
+
+func (h *RecruitingHandler) CreateStage(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreateStage handler called")
+
+	var req stageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	st := &models.PipelineStage{Name: req.Name, Position: req.Position}
+	if err := h.service.CreateStage(r.Context(), st); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, toPipelineStageResponse(st))
+}
+
+// UpdateStage handles PUT /pipeline-stages/{id}; position reorders the
+// pipeline and must stay unique.
+func (h *RecruitingHandler) UpdateStage(w http.ResponseWriter, r *http.Request) {
+	log.Println("UpdateStage handler called")
+
+	id, err := pathID(r, "/pipeline-stages/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req stageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	st := &models.PipelineStage{ID: id, Name: req.Name, Position: req.Position}
+	if err := h.service.UpdateStage(r.Context(), st); err != nil {
+		writeRecruitingError(w, err, "stage not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toPipelineStageResponse(st))
+}
+
+func (h *RecruitingHandler) DeleteStage(w http.ResponseWriter, r *http.Request) {
+	log.Println("DeleteStage handler called")
+
+	id, err := pathID(r, "/pipeline-stages/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.DeleteStage(r.Context(), id); err != nil {
+		writeRecruitingError(w, err, "stage not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListRequisitions handles GET /requisitions?departmentId=&status=.
+func (h *RecruitingHandler) ListRequisitions(w http.ResponseWriter, r *http.Request) {
+	departmentID, err := queryInt64(r, "departmentId")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	requisitions, err := h.service.ListRequisitions(r.Context(), departmentID, r.URL.Query().Get("status"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	out := []RequisitionResponse{}
+	for _, jr := range requisitions {
+		out = append(out, toRequisitionResponse(jr))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// requisitionRequest is the body of both POST /requisitions and
+// PUT /requisitions/{id}; departmentId and openedOn are only read on
+// create, status only on update.
+type requisitionRequest struct {
+	DepartmentID    int64           `json:"departmentId"`
+	PositionID      *int64          `json:"positionId"`
+	Title           string          `json:"title"`
+	Openings        int             `json:"openings"`
+	Status          string          `json:"status"`
+	HiringManagerID *int64          `json:"hiringManagerId"`
+	SalaryMin       *models.Decimal `json:"salaryMin"`
+	SalaryMax       *models.Decimal `json:"salaryMax"`
+	SalaryCurrency  *string         `json:"salaryCurrency"`
+	Description     *string         `json:"description"`
+	OpenedOn        *string         `json:"openedOn"`
+}
+
+func (req requisitionRequest) toRequisition() (*models.JobRequisition, error) {
+	jr := &models.JobRequisition{
+		DepartmentID:    req.DepartmentID,
+		PositionID:      req.PositionID,
+		Title:           req.Title,
+		Openings:        req.Openings,
+		Status:          req.Status,
+		HiringManagerID: req.HiringManagerID,
+		SalaryMin:       req.SalaryMin,
+		SalaryMax:       req.SalaryMax,
+		SalaryCurrency:  upperCurrency(req.SalaryCurrency),
+		Description:     req.Description,
+	}
+	if jr.Openings == 0 {
+		jr.Openings = 1
+	}
+	opened, err := parseOptionalDate(req.OpenedOn, "openedOn")
+	if err != nil {
+		return nil, err
+	}
+	if opened != nil {
+		jr.OpenedOn = *opened
+	}
+	return jr, nil
+}
+
+func (h *RecruitingHandler) CreateRequisition(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreateRequisition handler called")
+
+	var req requisitionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	jr, err := req.toRequisition()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.CreateRequisition(r.Context(), jr); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, toRequisitionResponse(jr))
+}
+
+func (h *RecruitingHandler) GetRequisition(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/requisitions/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	jr, err := h.service.GetRequisition(r.Context(), id)
+	if err != nil {
+		writeRecruitingError(w, err, "requisition not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toRequisitionResponse(jr))
+}
+
+// UpdateRequisition handles PUT /requisitions/{id}, also used to put it
+// on hold ("status": "on_hold"), reopen or cancel it.
+func (h *RecruitingHandler) UpdateRequisition(w http.ResponseWriter, r *http.Request) {
+	log.Println("UpdateRequisition handler called")
+
+	id, err := pathID(r, "/requisitions/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req requisitionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	jr, err := req.toRequisition()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	jr.ID = id
+
+	if err := h.service.UpdateRequisition(r.Context(), jr); err != nil {
+		writeRecruitingError(w, err, "requisition not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toRequisitionResponse(jr))
+}
+
+// ListCandidates handles GET /requisitions/{id}/candidates?stageId=&status=,
+// the furthest along first.
+func (h *RecruitingHandler) ListCandidates(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/requisitions/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	stageID, err := queryInt64(r, "stageId")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	candidates, err := h.service.ListCandidates(r.Context(), id, stageID, r.URL.Query().Get("status"))
+	if err != nil {
+		writeRecruitingError(w, err, "requisition not found")
+		return
+	}
+	out := []CandidateResponse{}
+	for _, c := range candidates {
+		out = append(out, toCandidateResponse(c))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// candidateRequest is the body of both POST /requisitions/{id}/candidates
+// and PUT /candidates/{id}.
+type candidateRequest struct {
+	Name              string          `json:"name"`
+	Email             *string         `json:"email"`
+	Phone             *string         `json:"phone"`
+	Source            *string         `json:"source"`
+	OfferedSalary     *models.Decimal `json:"offeredSalary"`
+	OfferedCurrency   *string         `json:"offeredCurrency"`
+	ExpectedStartDate *string         `json:"expectedStartDate"`
+	Note              *string         `json:"note"`
+}
+
+func (req candidateRequest) toCandidate() (*models.Candidate, error) {
+	start, err := parseOptionalDate(req.ExpectedStartDate, "expectedStartDate")
+	if err != nil {
+		return nil, err
+	}
+	return &models.Candidate{
+		Name:              req.Name,
+		Email:             req.Email,
+		Phone:             req.Phone,
+		Source:            req.Source,
+		OfferedSalary:     req.OfferedSalary,
+		OfferedCurrency:   upperCurrency(req.OfferedCurrency),
+		ExpectedStartDate: start,
+		Note:              req.Note,
+	}, nil
+}
+
+func (h *RecruitingHandler) AddCandidate(w http.ResponseWriter, r *http.Request) {
+	log.Println("AddCandidate handler called")
+
+	id, err := pathID(r, "/requisitions/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req candidateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	c, err := req.toCandidate()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	c.RequisitionID = id
+
+	if err := h.service.AddCandidate(r.Context(), c); err != nil {
+		writeRecruitingError(w, err, "requisition not found")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toCandidateResponse(c))
+}
+
+// GetCandidate handles GET /candidates/{id} with the candidate's stage
+// history and interview feedback.
+func (h *RecruitingHandler) GetCandidate(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/candidates/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	c, history, feedback, err := h.service.GetCandidate(r.Context(), id)
+	if err != nil {
+		writeRecruitingError(w, err, "candidate not found")
+		return
+	}
+
+	resp := struct {
+		CandidateResponse
+		History  []CandidateStageChangeResponse `json:"history"`
+		Feedback []InterviewFeedbackResponse    `json:"feedback"`
+	}{
+		CandidateResponse: toCandidateResponse(c),
+		History:           []CandidateStageChangeResponse{},
+		Feedback:          []InterviewFeedbackResponse{},
+	}
+	for _, ch := range history {
+		resp.History = append(resp.History, CandidateStageChangeResponse{
+			FromStageID: ch.FromStageID,
+			ToStageID:   ch.ToStageID,
+			ToStageName: ch.ToStageName,
+			Note:        ch.Note,
+			ChangedAt:   ch.ChangedAt.Format(time.RFC3339),
+		})
+	}
+	for _, f := range feedback {
+		resp.Feedback = append(resp.Feedback, toInterviewFeedbackResponse(f))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// UpdateCandidate handles PUT /candidates/{id}, e.g. to record the offer.
+func (h *RecruitingHandler) UpdateCandidate(w http.ResponseWriter, r *http.Request) {
+	log.Println("UpdateCandidate handler called")
+
+	id, err := pathID(r, "/candidates/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req candidateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	c, err := req.toCandidate()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	c.ID = id
+
+	if err := h.service.UpdateCandidate(r.Context(), c); err != nil {
+		writeRecruitingError(w, err, "candidate not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toCandidateResponse(c))
+}
+
+// MoveCandidate handles POST /candidates/{id}/stage with {"stageId", "note"}.
+func (h *RecruitingHandler) MoveCandidate(w http.ResponseWriter, r *http.Request) {
+	log.Println("MoveCandidate handler called")
+
+	id, err := pathID(r, "/candidates/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		StageID int64   `json:"stageId"`
+		Note    *string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	c, err := h.service.MoveToStage(r.Context(), id, req.StageID, req.Note)
+	if err != nil {
+		writeRecruitingError(w, err, "candidate not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toCandidateResponse(c))
+}
+
+// CloseCandidate handles POST /candidates/{id}/reject and
+// /candidates/{id}/withdraw with an optional {"note"}.
+func (h *RecruitingHandler) CloseCandidate(w http.ResponseWriter, r *http.Request) {
+	log.Println("CloseCandidate handler called")
+
+	id, err := pathID(r, "/candidates/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		Note *string `json:"note"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+
+	status := models.CandidateStatusRejected
+	if pathSegment(r, "/candidates/", 1) == "withdraw" {
+		status = models.CandidateStatusWithdrawn
+	}
+	c, err := h.service.Close(r.Context(), id, status, req.Note)
+	if err != nil {
+		writeRecruitingError(w, err, "candidate not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toCandidateResponse(c))
+}
+
+// AddFeedback handles POST /candidates/{id}/feedback with {"interviewerId",
+// "rating", "recommendation", "comment", "interviewedOn"}, recorded against
+// the candidate's current stage.
+func (h *RecruitingHandler) AddFeedback(w http.ResponseWriter, r *http.Request) {
+	log.Println("AddFeedback handler called")
+
+	id, err := pathID(r, "/candidates/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		InterviewerID  int64   `json:"interviewerId"`
+		Rating         int     `json:"rating"`
+		Recommendation string  `json:"recommendation"`
+		Comment        *string `json:"comment"`
+		InterviewedOn  *string `json:"interviewedOn"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	interviewed, err := parseOptionalDate(req.InterviewedOn, "interviewedOn")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	f := &models.InterviewFeedback{
+		CandidateID:    id,
+		InterviewerID:  req.InterviewerID,
+		Rating:         req.Rating,
+		Recommendation: req.Recommendation,
+		Comment:        req.Comment,
+	}
+	if interviewed != nil {
+		f.InterviewedOn = *interviewed
+	}
+	if err := h.service.AddFeedback(r.Context(), f); err != nil {
+		writeRecruitingError(w, err, "candidate not found")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toInterviewFeedbackResponse(f))
+}
+
+// AcceptOffer handles POST /candidates/{id}/accept-offer. The body may
+// override the hire date, probation end, manager, email and salary the
+// candidate and requisition recorded, and must carry any required custom
+// fields. It answers with the new employee.
+func (h *RecruitingHandler) AcceptOffer(w http.ResponseWriter, r *http.Request) {
+	log.Println("AcceptOffer handler called")
+
+	id, err := pathID(r, "/candidates/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		HireDate         *string                  `json:"hireDate"`
+		ProbationEndDate *string                  `json:"probationEndDate"`
+		ManagerID        *int64                   `json:"managerId"`
+		Email            *string                  `json:"email"`
+		Salary           *models.Decimal          `json:"salary"`
+		SalaryCurrency   *string                  `json:"salaryCurrency"`
+		CustomFields     models.CustomFieldValues `json:"customFields"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+	hireDate, err := parseOptionalDate(req.HireDate, "hireDate")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	probationEnd, err := parseOptionalDate(req.ProbationEndDate, "probationEndDate")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c, e, warning, err := h.service.AcceptOffer(r.Context(), id, services.OfferAcceptance{
+		HireDate:         hireDate,
+		ProbationEndDate: probationEnd,
+		ManagerID:        req.ManagerID,
+		Email:            req.Email,
+		Salary:           req.Salary,
+		SalaryCurrency:   upperCurrency(req.SalaryCurrency),
+		CustomFields:     req.CustomFields,
+	})
+	if err != nil {
+		writeRecruitingError(w, err, "candidate not found")
+		return
+	}
+	setWarning(w, warning)
+	writeJSON(w, http.StatusCreated, struct {
+		Candidate CandidateResponse `json:"candidate"`
+		Employee  EmployeeResponse  `json:"employee"`
+	}{toCandidateResponse(c), toEmployeeResponse(e)})
+}
+
+// DepartmentHeadcount handles GET /departments/{id}/headcount: current
+// staff by status and the open requisitions still to fill.
+func (h *RecruitingHandler) DepartmentHeadcount(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/departments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	hc, err := h.service.Headcount(r.Context(), id)
+	if err != nil {
+		writeRecruitingError(w, err, "department not found")
+		return
+	}
+	requisitions := []RequisitionResponse{}
+	for _, jr := range hc.OpenRequisitions {
+		requisitions = append(requisitions, toRequisitionResponse(jr))
+	}
+	writeJSON(w, http.StatusOK, struct {
+		DepartmentID     int64                 `json:"departmentId"`
+		Employed         int                   `json:"employed"`
+		ByStatus         map[string]int        `json:"byStatus"`
+		OpenSlots        int                   `json:"openSlots"`
+		OpenRequisitions []RequisitionResponse `json:"openRequisitions"`
+	}{hc.DepartmentID, hc.Employed, hc.ByStatus, hc.OpenSlots, requisitions})
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type MonthlyHeadcountResponse struct {
+	DepartmentID   int64  `json:"departmentId"`
+	DepartmentName string `json:"departmentName"`
+	Opening        int64  `json:"openingHeadcount"`
+	Hires          int64  `json:"hires"`
+	Leavers        int64  `json:"leavers"`
+	Closing        int64  `json:"closingHeadcount"`
+}
+
+type MonthHeadcountResponse struct {
+	Month         string                     `json:"month"`
+	Opening       int64                      `json:"openingHeadcount"`
+	Hires         int64                      `json:"hires"`
+	Leavers       int64                      `json:"leavers"`
+	Closing       int64                      `json:"closingHeadcount"`
+	AttritionRate models.Decimal             `json:"attritionRate"`
+	Departments   []MonthlyHeadcountResponse `json:"departments"`
+}
+
+func toMonthHeadcountResponses(months []*services.MonthHeadcount) []MonthHeadcountResponse {
+	out := []MonthHeadcountResponse{}
+	for _, m := range months {
+		resp := MonthHeadcountResponse{
+			Month:         m.Month.Format("2006-01"),
+			Opening:       m.Opening,
+			Hires:         m.Hires,
+			Leavers:       m.Leavers,
+			Closing:       m.Closing,
+			AttritionRate: m.Attrition,
+			Departments:   []MonthlyHeadcountResponse{},
+		}
+		for _, d := range m.Departments {
+			resp.Departments = append(resp.Departments, MonthlyHeadcountResponse{
+				DepartmentID:   d.DepartmentID,
+				DepartmentName: d.DepartmentName,
+				Opening:        d.Opening,
+				Hires:          d.Hires,
+				Leavers:        d.Leavers,
+				Closing:        d.Closing,
+			})
+		}
+		out = append(out, resp)
+	}
+	return out
+}
+
+type DistributionResponse struct {
+	DepartmentID   int64            `json:"departmentId"`
+	DepartmentName string           `json:"departmentName"`
+	Employees      int64            `json:"employees"`
+	Average        models.Decimal   `json:"average"`
+	Buckets        map[string]int64 `json:"buckets"`
+}
+
+func toDistributionResponses(dists []*services.Distribution) []DistributionResponse {
+	out := []DistributionResponse{}
+	for _, d := range dists {
+		out = append(out, DistributionResponse{
+			DepartmentID:   d.DepartmentID,
+			DepartmentName: d.DepartmentName,
+			Employees:      d.Employees,
+			Average:        d.Average,
+			Buckets:        d.Buckets,
+		})
+	}
+	return out
+}
+
+// parseMonthRange reads ?from=YYYY-MM&to=YYYY-MM. Without them the report
+// covers the twelve months up to and including the current one.
+func parseMonthRange(r *http.Request) (time.Time, time.Time, error) {
+	q := r.URL.Query()
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if v := q.Get("to"); v != "" {
+		start, _, err := services.ParsePeriod(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("to must be YYYY-MM")
+		}
+		to = start
+	}
+	from := to.AddDate(0, -11, 0)
+	if v := q.Get("from"); v != "" {
+		start, _, err := services.ParsePeriod(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("from must be YYYY-MM")
+		}
+		from = start
+	}
+	return from, to, nil
+}
+
+// parseAsOf reads ?asOf=YYYY-MM-DD, defaulting to today.
+func parseAsOf(r *http.Request) (time.Time, error) {
+	v := r.URL.Query().Get("asOf")
+	if v == "" {
+		return time.Now(), nil
+	}
+	d, err := parseDate(v)
+	if err != nil {
+		return time.Time{}, errors.New("asOf must be YYYY-MM-DD")
+	}
+	return d, nil
+}
+
+// Headcount handles GET /reports/headcount?from=2026-01&to=2026-06, the
+// staff per department at the end of each month.
+func (h *ReportHandler) Headcount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	from, to, err := parseMonthRange(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	deptID, err := queryInt64(r, "departmentId")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	months, err := h.service.Headcount(r.Context(), from, to, deptID)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		From   string                   `json:"from"`
+		To     string                   `json:"to"`
+		Months []MonthHeadcountResponse `json:"months"`
+	}{from.Format("2006-01"), to.Format("2006-01"), toMonthHeadcountResponses(months)})
+}
+
+// Turnover handles GET /reports/turnover?from=2026-01&to=2026-12: hires
+// against leavers and the attrition rate in percent, overall and per month.
+func (h *ReportHandler) Turnover(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	from, to, err := parseMonthRange(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	deptID, err := queryInt64(r, "departmentId")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	t, err := h.service.Turnover(r.Context(), from, to, deptID)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		From             string                   `json:"from"`
+		To               string                   `json:"to"`
+		Hires            int64                    `json:"hires"`
+		Leavers          int64                    `json:"leavers"`
+		AverageHeadcount models.Decimal           `json:"averageHeadcount"`
+		AttritionRate    models.Decimal           `json:"attritionRate"`
+		Months           []MonthHeadcountResponse `json:"months"`
+	}{t.From.Format("2006-01"), t.To.Format("2006-01"), t.Hires, t.Leavers, t.AverageHeadcount, t.Attrition, toMonthHeadcountResponses(t.Months)})
+}
+
+// Tenure handles GET /reports/tenure?asOf=2026-06-30. Buckets are years of
+// service and the average is in years.
+func (h *ReportHandler) Tenure(w http.ResponseWriter, r *http.Request) {
+	h.distribution(w, r, h.service.Tenure)
+}
+
+// AgeDistribution handles GET /reports/age-distribution?asOf=2026-06-30.
+func (h *ReportHandler) AgeDistribution(w http.ResponseWriter, r *http.Request) {
+	h.distribution(w, r, h.service.AgeDistribution)
+}
+
+func (h *ReportHandler) distribution(w http.ResponseWriter, r *http.Request, report func(ctx context.Context, asOf time.Time, departmentID *int64) ([]*services.Distribution, error)) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	asOf, err := parseAsOf(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	deptID, err := queryInt64(r, "departmentId")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dists, err := report(r.Context(), asOf, deptID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		AsOf        string                 `json:"asOf"`
+		Departments []DistributionResponse `json:"departments"`
+	}{asOf.Format(dateLayout), toDistributionResponses(dists)})
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type ReportHandler struct {
+	service *services.ReportService
+}
+
+func NewReportHandler(service *services.ReportService) *ReportHandler {
+	return &ReportHandler{
+		service: service,
+	}
+}
+
+// SalarySummary handles GET /reports/salary-summary?currency=USD.
+func (h *ReportHandler) SalarySummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	currency, err := parseCurrencyParam(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+
+	summaries, err := h.service.SalarySummary(r.Context(), currency)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	type respDept struct {
+		DepartmentID   int64                     `json:"departmentId"`
+		DepartmentName string                    `json:"departmentName"`
+		Employees      int64                     `json:"employees"`
+		Total          models.Decimal            `json:"total"`
+		Average        models.Decimal            `json:"average"`
+		ByCurrency     map[string]models.Decimal `json:"byCurrency"`
+	}
+
+	out := []respDept{}
+	grand := models.Decimal{}
+	for _, s := range summaries {
+		grand = grand.Add(s.Total)
+		out = append(out, respDept{
+			DepartmentID:   s.DepartmentID,
+			DepartmentName: s.DepartmentName,
+			Employees:      s.Employees,
+			Total:          s.Total,
+			Average:        s.Average,
+			ByCurrency:     s.ByCurrency,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		Currency    string         `json:"currency"`
+		Total       models.Decimal `json:"total"`
+		Departments []respDept     `json:"departments"`
+	}{Currency: currency, Total: grand, Departments: out})
+}
+
+// CompensationBands handles GET /reports/compensation-bands. Only employees
+// outside their band are listed unless all=true.
+func (h *ReportHandler) CompensationBands(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	var deptID *int64
+	if d := q.Get("departmentId"); d != "" {
+		v, err := strconv.ParseInt(d, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid departmentId")
+			return
+		}
+		deptID = &v
+	}
+
+	placements, err := h.service.CompensationBands(r.Context(), deptID, q.Get("all") == "true")
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	type respPlacement struct {
+		EmployeeID     int64          `json:"employeeId"`
+		EmployeeName   string         `json:"employeeName"`
+		DepartmentID   int64          `json:"departmentId"`
+		PositionID     int64          `json:"positionId"`
+		PositionTitle  string         `json:"positionTitle"`
+		Salary         models.Decimal `json:"salary"`
+		SalaryCurrency string         `json:"salaryCurrency"`
+		BandSalary     models.Decimal `json:"bandSalary"`
+		BandMin        models.Decimal `json:"bandMin"`
+		BandMid        models.Decimal `json:"bandMid"`
+		BandMax        models.Decimal `json:"bandMax"`
+		BandCurrency   string         `json:"bandCurrency"`
+		Status         string         `json:"status"`
+		CompaRatio     models.Decimal `json:"compaRatio"`
+	}
+
+	out := []respPlacement{}
+	for _, p := range placements {
+		out = append(out, respPlacement{
+			EmployeeID:     p.EmployeeID,
+			EmployeeName:   p.EmployeeName,
+			DepartmentID:   p.DepartmentID,
+			PositionID:     p.PositionID,
+			PositionTitle:  p.PositionTitle,
+			Salary:         p.Salary,
+			SalaryCurrency: p.SalaryCurrency,
+			BandSalary:     p.BandSalary,
+			BandMin:        p.Band.Min,
+			BandMid:        p.Band.Mid,
+			BandMax:        p.Band.Max,
+			BandCurrency:   p.Band.Currency,
+			Status:         p.Status,
+			CompaRatio:     p.CompaRatio,
+		})
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Employees []respPlacement `json:"employees"`
+	}{Employees: out})
+}
+
+// HeadcountBudget handles GET /reports/headcount-budget?year=2025. The year
+// defaults to the current one; departmentId narrows it to one department.
+func (h *ReportHandler) HeadcountBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	year := time.Now().Year()
+	if v := r.URL.Query().Get("year"); v != "" {
+		y, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid year")
+			return
+		}
+		year = y
+	}
+	deptID, err := queryInt64(r, "departmentId")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	budgets, err := h.service.HeadcountBudget(r.Context(), year, deptID)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	type respDept struct {
+		DepartmentID              int64           `json:"departmentId"`
+		DepartmentName            string          `json:"departmentName"`
+		Currency                  string          `json:"currency"`
+		PlannedHeadcount          *int            `json:"plannedHeadcount"`
+		SalaryBudget              *models.Decimal `json:"salaryBudget"`
+		ActualHeadcount           int             `json:"actualHeadcount"`
+		ActualSalaryCost          models.Decimal  `json:"actualSalaryCost"`
+		HeadcountVariance         *int            `json:"headcountVariance"`
+		SalaryVariance            *models.Decimal `json:"salaryVariance"`
+		ScheduledHires            int             `json:"scheduledHires"`
+		ScheduledLeavers          int             `json:"scheduledLeavers"`
+		TransfersIn               int             `json:"transfersIn"`
+		TransfersOut              int             `json:"transfersOut"`
+		ForecastHeadcount         int             `json:"forecastHeadcount"`
+		ForecastSalaryCost        models.Decimal  `json:"forecastSalaryCost"`
+		ForecastHeadcountVariance *int            `json:"forecastHeadcountVariance"`
+		ForecastSalaryVariance    *models.Decimal `json:"forecastSalaryVariance"`
+	}
+
+	out := []respDept{}
+	var start, end, asOf string
+	for _, b := range budgets {
+		start, end, asOf = b.PeriodStart.Format(dateLayout), b.PeriodEnd.Format(dateLayout), b.AsOf.Format(dateLayout)
+		d := respDept{
+			DepartmentID:              b.DepartmentID,
+			DepartmentName:            b.DepartmentName,
+			Currency:                  b.Currency,
+			ActualHeadcount:           b.ActualHeadcount,
+			ActualSalaryCost:          b.ActualSalaryCost,
+			HeadcountVariance:         b.HeadcountVariance,
+			SalaryVariance:            b.SalaryVariance,
+			ScheduledHires:            b.ScheduledHires,
+			ScheduledLeavers:          b.ScheduledLeavers,
+			TransfersIn:               b.TransfersIn,
+			TransfersOut:              b.TransfersOut,
+			ForecastHeadcount:         b.ForecastHeadcount,
+			ForecastSalaryCost:        b.ForecastSalaryCost,
+			ForecastHeadcountVariance: b.ForecastHeadcountVariance,
+			ForecastSalaryVariance:    b.ForecastSalaryVariance,
+		}
+		if b.Plan != nil {
+			d.PlannedHeadcount = &b.Plan.PlannedHeadcount
+			d.SalaryBudget = &b.Plan.SalaryBudget
+		}
+		out = append(out, d)
+	}
+	if deptID != nil && len(budgets) == 0 {
+		writeError(w, http.StatusNotFound, "department not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		FiscalYear  int        `json:"fiscalYear"`
+		PeriodStart string     `json:"periodStart,omitempty"`
+		PeriodEnd   string     `json:"periodEnd,omitempty"`
+		AsOf        string     `json:"asOf,omitempty"`
+		Departments []respDept `json:"departments"`
+	}{year, start, end, asOf, out})
+}
@@ -0,0 +1,546 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type ReviewHandler struct {
+	service *services.ReviewService
+}
+
+func NewReviewHandler(service *services.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		service: service,
+	}
+}
+
+type ReviewCycleResponse struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	PeriodStart string `json:"periodStart"`
+	PeriodEnd   string `json:"periodEnd"`
+	Status      string `json:"status"`
+	CreatedAt   string `json:"createdAt"`
+	UpdatedAt   string `json:"updatedAt"`
+}
+
+func toReviewCycleResponse(c *models.ReviewCycle) ReviewCycleResponse {
+	return ReviewCycleResponse{
+		ID:          c.ID,
+		Name:        c.Name,
+		PeriodStart: c.PeriodStart.Format(dateLayout),
+		PeriodEnd:   c.PeriodEnd.Format(dateLayout),
+		Status:      c.Status,
+		CreatedAt:   c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   c.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type PerformanceReviewResponse struct {
+	ID                 int64                `json:"id"`
+	CycleID            int64                `json:"cycleId"`
+	EmployeeID         int64                `json:"employeeId"`
+	EmployeeName       string               `json:"employeeName"`
+	DepartmentID       int64                `json:"departmentId"`
+	ReviewerID         *int64               `json:"reviewerId"`
+	ReviewerName       *string              `json:"reviewerName"`
+	Stage              string               `json:"stage"`
+	SelfRating         *int                 `json:"selfRating"`
+	SelfComment        *string              `json:"selfComment"`
+	SelfSubmittedAt    *string              `json:"selfSubmittedAt"`
+	ManagerRating      *int                 `json:"managerRating"`
+	ManagerComment     *string              `json:"managerComment"`
+	ManagerSubmittedAt *string              `json:"managerSubmittedAt"`
+	CalibratedRating   *int                 `json:"calibratedRating"`
+	CalibrationNote    *string              `json:"calibrationNote"`
+	CalibratedAt       *string              `json:"calibratedAt"`
+	GoalProgress       *int                 `json:"goalProgress,omitempty"`
+	Goals              []ReviewGoalResponse `json:"goals,omitempty"`
+}
+
+func toPerformanceReviewResponse(pr *models.PerformanceReview) PerformanceReviewResponse {
+	return PerformanceReviewResponse{
+		ID:                 pr.ID,
+		CycleID:            pr.CycleID,
+		EmployeeID:         pr.EmployeeID,
+		EmployeeName:       pr.EmployeeName,
+		DepartmentID:       pr.DepartmentID,
+		ReviewerID:         pr.ReviewerID,
+		ReviewerName:       pr.ReviewerName,
+		Stage:              pr.Stage(),
+		SelfRating:         pr.SelfRating,
+		SelfComment:        pr.SelfComment,
+		SelfSubmittedAt:    formatTimestampPtr(pr.SelfSubmittedAt),
+		ManagerRating:      pr.ManagerRating,
+		ManagerComment:     pr.ManagerComment,
+		ManagerSubmittedAt: formatTimestampPtr(pr.ManagerSubmittedAt),
+		CalibratedRating:   pr.CalibratedRating,
+		CalibrationNote:    pr.CalibrationNote,
+		CalibratedAt:       formatTimestampPtr(pr.CalibratedAt),
+	}
+}
+
+type ReviewGoalResponse struct {
+	ID          int64   `json:"id"`
+	CycleID     int64   `json:"cycleId"`
+	EmployeeID  int64   `json:"employeeId"`
+	Title       string  `json:"title"`
+	Description *string `json:"description"`
+	Weight      int     `json:"weight"`
+	Progress    int     `json:"progress"`
+	DueDate     *string `json:"dueDate"`
+	UpdatedAt   string  `json:"updatedAt"`
+}
+
+func toReviewGoalResponse(g *models.ReviewGoal) ReviewGoalResponse {
+	return ReviewGoalResponse{
+		ID:          g.ID,
+		CycleID:     g.CycleID,
+		EmployeeID:  g.EmployeeID,
+		Title:       g.Title,
+		Description: g.Description,
+		Weight:      g.Weight,
+		Progress:    g.Progress,
+		DueDate:     formatDatePtr(g.DueDate),
+		UpdatedAt:   g.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func writeReviewError(w http.ResponseWriter, err error, notFound string) {
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, notFound)
+		return
+	}
+	writeError(w, http.StatusBadRequest, err.Error())
+}
+
+func (h *ReviewHandler) ListCycles(w http.ResponseWriter, r *http.Request) {
+	cycles, err := h.service.ListCycles(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	out := []ReviewCycleResponse{}
+	for _, c := range cycles {
+		out = append(out, toReviewCycleResponse(c))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// CreateCycle handles POST /review-cycles with {"name", "periodStart",
+// "periodEnd"}. The cycle starts as a draft.
+func (h *ReviewHandler) CreateCycle(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreateCycle handler called")
+
+	var req struct {
+		Name        string `json:"name"`
+		PeriodStart string `json:"periodStart"`
+		PeriodEnd   string `json:"periodEnd"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	start, err := parseDate(req.PeriodStart)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "periodStart must be YYYY-MM-DD")
+		return
+	}
+	end, err := parseDate(req.PeriodEnd)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "periodEnd must be YYYY-MM-DD")
+		return
+	}
+
+	c := &models.ReviewCycle{Name: req.Name, PeriodStart: start, PeriodEnd: end}
+	if err := h.service.CreateCycle(r.Context(), c); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, toReviewCycleResponse(c))
+}
+
+func (h *ReviewHandler) GetCycle(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/review-cycles/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	c, err := h.service.GetCycle(r.Context(), id)
+	if err != nil {
+		writeReviewError(w, err, "review cycle not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toReviewCycleResponse(c))
+}
+
+// OpenCycle handles POST /review-cycles/{id}/open, creating a review for
+// every current employee.
+func (h *ReviewHandler) OpenCycle(w http.ResponseWriter, r *http.Request) {
+	log.Println("OpenCycle handler called")
+
+	id, err := pathID(r, "/review-cycles/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	c, n, err := h.service.OpenCycle(r.Context(), id)
+	if err != nil {
+		writeReviewError(w, err, "review cycle not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Cycle   ReviewCycleResponse `json:"cycle"`
+		Reviews int                 `json:"reviews"`
+	}{toReviewCycleResponse(c), n})
+}
+
+// CloseCycle handles POST /review-cycles/{id}/close.
+func (h *ReviewHandler) CloseCycle(w http.ResponseWriter, r *http.Request) {
+	log.Println("CloseCycle handler called")
+
+	id, err := pathID(r, "/review-cycles/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	c, err := h.service.CloseCycle(r.Context(), id)
+	if err != nil {
+		writeReviewError(w, err, "review cycle not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toReviewCycleResponse(c))
+}
+
+type ReviewCompletionResponse struct {
+	DepartmentID    int64   `json:"departmentId"`
+	DepartmentName  string  `json:"departmentName"`
+	Total           int     `json:"total"`
+	SelfDone        int     `json:"selfReviewsDone"`
+	ManagerDone     int     `json:"managerReviewsDone"`
+	Calibrated      int     `json:"calibrated"`
+	WithoutReviewer int     `json:"withoutReviewer"`
+	CompletionPct   float64 `json:"completionPct"`
+}
+
+func completionPct(done, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(done*1000/total) / 10
+}
+
+// Dashboard handles GET /review-cycles/{id}/dashboard: per department, how
+// many reviews have reached each stage. A review is complete once
+// calibrated.
+func (h *ReviewHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/review-cycles/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	c, completion, err := h.service.Dashboard(r.Context(), id)
+	if err != nil {
+		writeReviewError(w, err, "review cycle not found")
+		return
+	}
+
+	out := []ReviewCompletionResponse{}
+	total := ReviewCompletionResponse{DepartmentName: "Total"}
+	for _, d := range completion {
+		out = append(out, ReviewCompletionResponse{
+			DepartmentID:    d.DepartmentID,
+			DepartmentName:  d.DepartmentName,
+			Total:           d.Total,
+			SelfDone:        d.SelfDone,
+			ManagerDone:     d.ManagerDone,
+			Calibrated:      d.Calibrated,
+			WithoutReviewer: d.WithoutReviewer,
+			CompletionPct:   completionPct(d.Calibrated, d.Total),
+		})
+		total.Total += d.Total
+		total.SelfDone += d.SelfDone
+		total.ManagerDone += d.ManagerDone
+		total.Calibrated += d.Calibrated
+		total.WithoutReviewer += d.WithoutReviewer
+	}
+	total.CompletionPct = completionPct(total.Calibrated, total.Total)
+
+	writeJSON(w, http.StatusOK, struct {
+		Cycle       ReviewCycleResponse        `json:"cycle"`
+		Departments []ReviewCompletionResponse `json:"departments"`
+		Total       ReviewCompletionResponse   `json:"total"`
+	}{toReviewCycleResponse(c), out, total})
+}
+
+// ListReviews handles GET /review-cycles/{id}/reviews?reviewerId=&departmentId=,
+// e.g. a manager's queue of reviews to write.
+func (h *ReviewHandler) ListReviews(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/review-cycles/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	reviewerID, err := queryInt64(r, "reviewerId")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	departmentID, err := queryInt64(r, "departmentId")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	reviews, err := h.service.ListReviews(r.Context(), id, reviewerID, departmentID)
+	if err != nil {
+		writeReviewError(w, err, "review cycle not found")
+		return
+	}
+	out := []PerformanceReviewResponse{}
+	for _, pr := range reviews {
+		out = append(out, toPerformanceReviewResponse(pr))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// EmployeeReviews handles GET /employees/{id}/reviews, latest cycle first.
+func (h *ReviewHandler) EmployeeReviews(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	reviews, err := h.service.EmployeeReviews(r.Context(), id)
+	if err != nil {
+		writeReviewError(w, err, "employee not found")
+		return
+	}
+	out := []PerformanceReviewResponse{}
+	for _, pr := range reviews {
+		out = append(out, toPerformanceReviewResponse(pr))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// GetReview handles GET /reviews/{id}, including the employee's goals for
+// the cycle and their weighted progress.
+func (h *ReviewHandler) GetReview(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/reviews/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	pr, err := h.service.GetReview(r.Context(), id)
+	if err != nil {
+		writeReviewError(w, err, "review not found")
+		return
+	}
+	goals, err := h.service.ListGoals(r.Context(), pr.EmployeeID, &pr.CycleID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp := toPerformanceReviewResponse(pr)
+	progress := services.GoalProgress(goals)
+	resp.GoalProgress = &progress
+	resp.Goals = []ReviewGoalResponse{}
+	for _, g := range goals {
+		resp.Goals = append(resp.Goals, toReviewGoalResponse(g))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+type reviewSubmission struct {
+	ReviewerID int64   `json:"reviewerId"`
+	Rating     int     `json:"rating"`
+	Comment    *string `json:"comment"`
+}
+
+// SubmitReview handles POST /reviews/{id}/self, /reviews/{id}/manager and
+// /reviews/{id}/calibrate, each with {"rating", "comment"}. The manager
+// review also names the reviewerId submitting it.
+func (h *ReviewHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	log.Println("SubmitReview handler called")
+
+	id, err := pathID(r, "/reviews/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req reviewSubmission
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	var pr *models.PerformanceReview
+	switch pathSegment(r, "/reviews/", 1) {
+	case "self":
+		pr, err = h.service.SubmitSelf(r.Context(), id, req.Rating, req.Comment)
+	case "manager":
+		pr, err = h.service.SubmitManager(r.Context(), id, req.ReviewerID, req.Rating, req.Comment)
+	case "calibrate":
+		pr, err = h.service.Calibrate(r.Context(), id, req.Rating, req.Comment)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		writeReviewError(w, err, "review not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toPerformanceReviewResponse(pr))
+}
+
+// SetReviewer handles PUT /reviews/{id}/reviewer with {"reviewerId"}.
+func (h *ReviewHandler) SetReviewer(w http.ResponseWriter, r *http.Request) {
+	log.Println("SetReviewer handler called")
+
+	id, err := pathID(r, "/reviews/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		ReviewerID int64 `json:"reviewerId"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	pr, err := h.service.SetReviewer(r.Context(), id, req.ReviewerID)
+	if err != nil {
+		writeReviewError(w, err, "review not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toPerformanceReviewResponse(pr))
+}
+
+// goalRequest is the body of both POST /employees/{id}/goals and
+// PUT /goals/{id}; cycleId is only read on create.
+type goalRequest struct {
+	CycleID     int64   `json:"cycleId"`
+	Title       string  `json:"title"`
+	Description *string `json:"description"`
+	Weight      int     `json:"weight"`
+	Progress    int     `json:"progress"`
+	DueDate     *string `json:"dueDate"`
+}
+
+func (req goalRequest) toGoal() (*models.ReviewGoal, error) {
+	due, err := parseOptionalDate(req.DueDate, "dueDate")
+	if err != nil {
+		return nil, err
+	}
+	return &models.ReviewGoal{
+		CycleID:     req.CycleID,
+		Title:       req.Title,
+		Description: req.Description,
+		Weight:      req.Weight,
+		Progress:    req.Progress,
+		DueDate:     due,
+	}, nil
+}
+
+// ListGoals handles GET /employees/{id}/goals?cycleId=.
+func (h *ReviewHandler) ListGoals(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	cycleID, err := queryInt64(r, "cycleId")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	goals, err := h.service.ListGoals(r.Context(), id, cycleID)
+	if err != nil {
+		writeReviewError(w, err, "employee not found")
+		return
+	}
+	out := []ReviewGoalResponse{}
+	for _, g := range goals {
+		out = append(out, toReviewGoalResponse(g))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *ReviewHandler) CreateGoal(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreateGoal handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req goalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	g, err := req.toGoal()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	g.EmployeeID = id
+
+	if err := h.service.CreateGoal(r.Context(), g); err != nil {
+		writeReviewError(w, err, "employee not found")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toReviewGoalResponse(g))
+}
+
+// UpdateGoal handles PUT /goals/{id}, including progress updates.
+func (h *ReviewHandler) UpdateGoal(w http.ResponseWriter, r *http.Request) {
+	log.Println("UpdateGoal handler called")
+
+	id, err := pathID(r, "/goals/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req goalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	g, err := req.toGoal()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	g.ID = id
+
+	if err := h.service.UpdateGoal(r.Context(), g); err != nil {
+		writeReviewError(w, err, "goal not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toReviewGoalResponse(g))
+}
+
+func (h *ReviewHandler) DeleteGoal(w http.ResponseWriter, r *http.Request) {
+	log.Println("DeleteGoal handler called")
+
+	id, err := pathID(r, "/goals/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.DeleteGoal(r.Context(), id); err != nil {
+		writeReviewError(w, err, "goal not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type RosterHandler struct {
+	service *services.RosterService
+}
+
+func NewRosterHandler(service *services.RosterService) *RosterHandler {
+	return &RosterHandler{
+		service: service,
+	}
+}
+
+type RosterConflictResponse struct {
+	Kind         string `json:"kind"`
+	EmployeeID   int64  `json:"employeeId"`
+	Date         string `json:"date"`
+	AssignmentID int64  `json:"assignmentId,omitempty"`
+	OtherID      int64  `json:"otherAssignmentId,omitempty"`
+	Message      string `json:"message"`
+}
+
+func toRosterConflictResponses(conflicts []models.RosterConflict) []RosterConflictResponse {
+	out := []RosterConflictResponse{}
+	for _, c := range conflicts {
+		resp := RosterConflictResponse{
+			Kind:       c.Kind,
+			EmployeeID: c.EmployeeID,
+			Date:       c.Date.Format(dateLayout),
+			Message:    c.Message,
+		}
+		if c.Assignment != nil {
+			resp.AssignmentID = c.Assignment.ID
+		}
+		if c.Other != nil {
+			resp.OtherID = c.Other.ID
+		}
+		out = append(out, resp)
+	}
+	return out
+}
+
+type rosterShiftResponse struct {
+	AssignmentID int64   `json:"assignmentId"`
+	ShiftID      int64   `json:"shiftId"`
+	Shift        string  `json:"shift"`
+	StartTime    string  `json:"startTime"`
+	EndTime      string  `json:"endTime"`
+	Note         *string `json:"note"`
+}
+
+// GetRoster handles GET /departments/{id}/roster?week=YYYY-MM-DD. Any date
+// selects the Monday-to-Sunday week containing it; the default is this week.
+func (h *RosterHandler) GetRoster(w http.ResponseWriter, r *http.Request) {
+	deptID, err := pathID(r, "/departments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	day := time.Now()
+	if v := r.URL.Query().Get("week"); v != "" {
+		if day, err = parseDate(v); err != nil {
+			writeError(w, http.StatusBadRequest, "week must be YYYY-MM-DD")
+			return
+		}
+	}
+
+	week, err := h.service.Week(r.Context(), deptID, day)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "department not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	dates := make([]string, 7)
+	for i := range dates {
+		dates[i] = week.WeekStart.AddDate(0, 0, i).Format(dateLayout)
+	}
+
+	byEmployee := map[int64]map[string][]rosterShiftResponse{}
+	for _, a := range week.Assignments {
+		sh := week.Shifts[a.ShiftID]
+		if byEmployee[a.EmployeeID] == nil {
+			byEmployee[a.EmployeeID] = map[string][]rosterShiftResponse{}
+		}
+		date := a.WorkDate.Format(dateLayout)
+		byEmployee[a.EmployeeID][date] = append(byEmployee[a.EmployeeID][date], rosterShiftResponse{
+			AssignmentID: a.ID,
+			ShiftID:      a.ShiftID,
+			Shift:        sh.Name,
+			StartTime:    sh.StartTime,
+			EndTime:      sh.EndTime,
+			Note:         a.Note,
+		})
+	}
+
+	type respRow struct {
+		EmployeeID int64                            `json:"employeeId"`
+		Name       string                           `json:"name"`
+		Days       map[string][]rosterShiftResponse `json:"days"`
+	}
+	rows := []respRow{}
+	for _, e := range week.Employees {
+		days := byEmployee[e.ID]
+		if days == nil {
+			days = map[string][]rosterShiftResponse{}
+		}
+		rows = append(rows, respRow{EmployeeID: e.ID, Name: e.Name, Days: days})
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		DepartmentID int64                    `json:"departmentId"`
+		WeekStart    string                   `json:"weekStart"`
+		Dates        []string                 `json:"dates"`
+		Employees    []respRow                `json:"employees"`
+		Conflicts    []RosterConflictResponse `json:"conflicts"`
+	}{deptID, dates[0], dates, rows, toRosterConflictResponses(week.Conflicts)})
+}
+
+// AssignShifts handles POST /departments/{id}/roster with
+// {"assignments": [{"employeeId", "shiftId", "date", "note"}], "allowConflicts"}.
+// Conflicting assignments are refused with 409 unless allowConflicts is set.
+func (h *RosterHandler) AssignShifts(w http.ResponseWriter, r *http.Request) {
+	log.Println("AssignShifts handler called")
+
+	deptID, err := pathID(r, "/departments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req struct {
+		Assignments []struct {
+			EmployeeID int64   `json:"employeeId"`
+			ShiftID    int64   `json:"shiftId"`
+			Date       string  `json:"date"`
+			Note       *string `json:"note"`
+		} `json:"assignments"`
+		AllowConflicts bool `json:"allowConflicts"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	assignments := make([]*models.RosterAssignment, 0, len(req.Assignments))
+	for _, a := range req.Assignments {
+		date, err := parseDate(a.Date)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "date must be YYYY-MM-DD")
+			return
+		}
+		assignments = append(assignments, &models.RosterAssignment{
+			EmployeeID: a.EmployeeID,
+			ShiftID:    a.ShiftID,
+			WorkDate:   date,
+			Note:       a.Note,
+		})
+	}
+
+	conflicts, err := h.service.Assign(r.Context(), deptID, assignments, req.AllowConflicts)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrRosterConflict):
+			writeJSON(w, http.StatusConflict, struct {
+				Error     string                   `json:"error"`
+				Conflicts []RosterConflictResponse `json:"conflicts"`
+			}{err.Error(), toRosterConflictResponses(conflicts)})
+		case errors.Is(err, sql.ErrNoRows):
+			writeError(w, http.StatusNotFound, "department not found")
+		default:
+			writeError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	type respAssignment struct {
+		ID         int64   `json:"id"`
+		EmployeeID int64   `json:"employeeId"`
+		ShiftID    int64   `json:"shiftId"`
+		Date       string  `json:"date"`
+		Note       *string `json:"note"`
+	}
+	created := []respAssignment{}
+	for _, a := range assignments {
+		created = append(created, respAssignment{a.ID, a.EmployeeID, a.ShiftID, a.WorkDate.Format(dateLayout), a.Note})
+	}
+	writeJSON(w, http.StatusCreated, struct {
+		Assignments []respAssignment         `json:"assignments"`
+		Conflicts   []RosterConflictResponse `json:"conflicts"`
+	}{created, toRosterConflictResponses(conflicts)})
+}
+
+// DeleteAssignment handles DELETE /roster-assignments/{id}.
+func (h *RosterHandler) DeleteAssignment(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/roster-assignments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "assignment not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type SalaryBandHandler struct {
+	service *services.SalaryBandService
+}
+
+func NewSalaryBandHandler(service *services.SalaryBandService) *SalaryBandHandler {
+	return &SalaryBandHandler{
+		service: service,
+	}
+}
+
+type SalaryBandResponse struct {
+	ID           int64          `json:"id"`
+	PositionID   int64          `json:"positionId"`
+	DepartmentID *int64         `json:"departmentId"`
+	Min          models.Decimal `json:"min"`
+	Mid          models.Decimal `json:"mid"`
+	Max          models.Decimal `json:"max"`
+	Currency     string         `json:"currency"`
+	CreatedAt    string         `json:"createdAt"`
+	UpdatedAt    string         `json:"updatedAt"`
+}
+
+func toSalaryBandResponse(b *models.SalaryBand) SalaryBandResponse {
+	return SalaryBandResponse{
+		ID:           b.ID,
+		PositionID:   b.PositionID,
+		DepartmentID: b.DepartmentID,
+		Min:          b.Min,
+		Mid:          b.Mid,
+		Max:          b.Max,
+		Currency:     b.Currency,
+		CreatedAt:    b.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    b.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (h *SalaryBandHandler) ListBands(w http.ResponseWriter, r *http.Request) {
+	var positionID *int64
+	if p := r.URL.Query().Get("positionId"); p != "" {
+		v, err := strconv.ParseInt(p, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid positionId")
+			return
+		}
+		positionID = &v
+	}
+
+	bands, err := h.service.List(r.Context(), positionID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	out := []SalaryBandResponse{}
+	for _, b := range bands {
+		out = append(out, toSalaryBandResponse(b))
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Policy string               `json:"policy"`
+		Bands  []SalaryBandResponse `json:"bands"`
+	}{Policy: h.service.Policy(), Bands: out})
+}
+
+// SaveBand handles POST /salary-bands; posting an existing position and
+// department pair replaces its range.
+func (h *SalaryBandHandler) SaveBand(w http.ResponseWriter, r *http.Request) {
+	log.Println("SaveBand handler called")
+
+	var req struct {
+		PositionID   int64          `json:"positionId"`
+		DepartmentID *int64         `json:"departmentId"`
+		Min          models.Decimal `json:"min"`
+		Mid          models.Decimal `json:"mid"`
+		Max          models.Decimal `json:"max"`
+		Currency     string         `json:"currency"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	b := &models.SalaryBand{
+		PositionID:   req.PositionID,
+		DepartmentID: req.DepartmentID,
+		Min:          req.Min,
+		Mid:          req.Mid,
+		Max:          req.Max,
+		Currency:     req.Currency,
+	}
+	if err := h.service.Save(r.Context(), b); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, toSalaryBandResponse(b))
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"app/internal/models"
+)
+
+type SalaryStatisticsResponse struct {
+	Key        string          `json:"key"`
+	Label      string          `json:"label"`
+	Employees  *int64          `json:"employees"`
+	Suppressed bool            `json:"suppressed"`
+	Min        *models.Decimal `json:"min,omitempty"`
+	Max        *models.Decimal `json:"max,omitempty"`
+	Mean       *models.Decimal `json:"mean,omitempty"`
+	Median     *models.Decimal `json:"median,omitempty"`
+	P10        *models.Decimal `json:"p10,omitempty"`
+	P25        *models.Decimal `json:"p25,omitempty"`
+	P75        *models.Decimal `json:"p75,omitempty"`
+	P90        *models.Decimal `json:"p90,omitempty"`
+	StdDev     *models.Decimal `json:"stdDev,omitempty"`
+}
+
+// toSalaryStatisticsResponse leaves the size of suppressed groups out, as it
+// would help infer their figures.
+func toSalaryStatisticsResponse(st *models.SalaryStatistics) SalaryStatisticsResponse {
+	var employees *int64
+	if !st.Suppressed {
+		employees = &st.Employees
+	}
+	return SalaryStatisticsResponse{
+		Key:        st.Key,
+		Label:      st.Label,
+		Employees:  employees,
+		Suppressed: st.Suppressed,
+		Min:        st.Min,
+		Max:        st.Max,
+		Mean:       st.Mean,
+		Median:     st.Median,
+		P10:        st.P10,
+		P25:        st.P25,
+		P75:        st.P75,
+		P90:        st.P90,
+		StdDev:     st.StdDev,
+	}
+}
+
+// salaryStatsParams reads the ?currency=, ?departmentId= and ?minGroupSize=
+// parameters shared by the salary statistics reports.
+func salaryStatsParams(w http.ResponseWriter, r *http.Request) (string, *int64, int, bool) {
+	currency, err := parseCurrencyParam(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return "", nil, 0, false
+	}
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+	deptID, err := queryInt64(r, "departmentId")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return "", nil, 0, false
+	}
+	minGroupSize := 0
+	if v := r.URL.Query().Get("minGroupSize"); v != "" {
+		if minGroupSize, err = strconv.Atoi(v); err != nil || minGroupSize <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid minGroupSize")
+			return "", nil, 0, false
+		}
+	}
+	return currency, deptID, minGroupSize, true
+}
+
+// SalaryStatistics handles GET /reports/salary-statistics?groupBy=position.
+// Groups below the privacy threshold report neither figures nor size.
+func (h *ReportHandler) SalaryStatistics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	currency, deptID, minGroupSize, ok := salaryStatsParams(w, r)
+	if !ok {
+		return
+	}
+	groupBy := r.URL.Query().Get("groupBy")
+	if groupBy == "" {
+		groupBy = "department"
+	}
+
+	stats, err := h.service.SalaryStatistics(r.Context(), groupBy, currency, deptID, minGroupSize)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	out := []SalaryStatisticsResponse{}
+	for _, st := range stats {
+		out = append(out, toSalaryStatisticsResponse(st))
+	}
+	writeJSON(w, http.StatusOK, struct {
+		GroupBy  string                     `json:"groupBy"`
+		Currency string                     `json:"currency"`
+		Groups   []SalaryStatisticsResponse `json:"groups"`
+	}{groupBy, currency, out})
+}
+
+// PayGap handles GET /reports/pay-gap?attribute=age&reference=35-44. Without
+// a reference each group is compared with all employees in scope.
+func (h *ReportHandler) PayGap(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	currency, deptID, minGroupSize, ok := salaryStatsParams(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	attribute := q.Get("attribute")
+	if attribute == "" {
+		writeError(w, http.StatusBadRequest, "attribute is required")
+		return
+	}
+
+	gap, err := h.service.PayGap(r.Context(), attribute, q.Get("reference"), currency, deptID, minGroupSize)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	type respGroup struct {
+		SalaryStatisticsResponse
+		MeanGap     *models.Decimal `json:"meanGapPercent"`
+		MedianGap   *models.Decimal `json:"medianGapPercent"`
+		IsReference bool            `json:"isReference"`
+	}
+	out := []respGroup{}
+	for _, g := range gap.Groups {
+		out = append(out, respGroup{
+			SalaryStatisticsResponse: toSalaryStatisticsResponse(g.Stats),
+			MeanGap:                  g.MeanGap,
+			MedianGap:                g.MedianGap,
+			IsReference:              g.IsReference,
+		})
+	}
+	var reference *SalaryStatisticsResponse
+	if gap.Reference != nil {
+		ref := toSalaryStatisticsResponse(gap.Reference)
+		reference = &ref
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Attribute string                    `json:"attribute"`
+		Currency  string                    `json:"currency"`
+		Reference *SalaryStatisticsResponse `json:"reference"`
+		Groups    []respGroup               `json:"groups"`
+	}{gap.Attribute, gap.Currency, reference, out})
+}
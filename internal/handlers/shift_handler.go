@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type ShiftHandler struct {
+	service *services.ShiftService
+}
+
+func NewShiftHandler(service *services.ShiftService) *ShiftHandler {
+	return &ShiftHandler{
+		service: service,
+	}
+}
+
+type ShiftResponse struct {
+	ID               int64  `json:"id"`
+	Name             string `json:"name"`
+	StartTime        string `json:"startTime"`
+	EndTime          string `json:"endTime"`
+	BreakMinutes     int    `json:"breakMinutes"`
+	GraceMinutes     int    `json:"graceMinutes"`
+	ScheduledMinutes int    `json:"scheduledMinutes"`
+	CreatedAt        string `json:"createdAt"`
+	UpdatedAt        string `json:"updatedAt"`
+}
+
+func toShiftResponse(s *models.Shift) ShiftResponse {
+	return ShiftResponse{
+		ID:               s.ID,
+		Name:             s.Name,
+		StartTime:        s.StartTime,
+		EndTime:          s.EndTime,
+		BreakMinutes:     s.BreakMinutes,
+		GraceMinutes:     s.GraceMinutes,
+		ScheduledMinutes: s.ScheduledMinutes(),
+		CreatedAt:        s.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        s.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type shiftRequest struct {
+	Name         string `json:"name"`
+	StartTime    string `json:"startTime"`
+	EndTime      string `json:"endTime"`
+	BreakMinutes int    `json:"breakMinutes"`
+	GraceMinutes int    `json:"graceMinutes"`
+}
+
+func (h *ShiftHandler) ListShifts(w http.ResponseWriter, r *http.Request) {
+	shifts, err := h.service.List(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	out := []ShiftResponse{}
+	for _, s := range shifts {
+		out = append(out, toShiftResponse(s))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// CreateShift handles POST /shifts. A shift ending at or before its start,
+// e.g. 22:00-06:00, runs overnight.
+func (h *ShiftHandler) CreateShift(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreateShift handler called")
+
+	var req shiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	s := &models.Shift{
+		Name:         req.Name,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		BreakMinutes: req.BreakMinutes,
+		GraceMinutes: req.GraceMinutes,
+	}
+	if err := h.service.Create(r.Context(), s); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, toShiftResponse(s))
+}
+
+func (h *ShiftHandler) UpdateShift(w http.ResponseWriter, r *http.Request) {
+	log.Println("UpdateShift handler called")
+
+	id, err := pathID(r, "/shifts/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "shift not found")
+		return
+	}
+
+	req := shiftRequest{Name: s.Name, StartTime: s.StartTime, EndTime: s.EndTime, BreakMinutes: s.BreakMinutes, GraceMinutes: s.GraceMinutes}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	s.Name = req.Name
+	s.StartTime = req.StartTime
+	s.EndTime = req.EndTime
+	s.BreakMinutes = req.BreakMinutes
+	s.GraceMinutes = req.GraceMinutes
+
+	if err := h.service.Update(r.Context(), s); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, toShiftResponse(s))
+}
+
+// SetDepartmentShift handles PUT /departments/{id}/shift with {"shiftId": ...};
+// null removes the default shift.
+func (h *ShiftHandler) SetDepartmentShift(w http.ResponseWriter, r *http.Request) {
+	log.Println("SetDepartmentShift handler called")
+
+	id, err := pathID(r, "/departments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid department id")
+		return
+	}
+
+	var req struct {
+		ShiftID *int64 `json:"shiftId"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.service.SetDepartmentDefault(r.Context(), id, req.ShiftID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "department not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"departmentId": id, "shiftId": req.ShiftID})
+}
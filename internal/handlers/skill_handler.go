@@ -0,0 +1,290 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type SkillHandler struct {
+	service *services.SkillService
+}
+
+func NewSkillHandler(service *services.SkillService) *SkillHandler {
+	return &SkillHandler{
+		service: service,
+	}
+}
+
+type SkillResponse struct {
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	Category  *string `json:"category"`
+	CreatedAt string  `json:"createdAt"`
+}
+
+func toSkillResponse(s *models.Skill) SkillResponse {
+	return SkillResponse{
+		ID:        s.ID,
+		Name:      s.Name,
+		Category:  s.Category,
+		CreatedAt: s.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+type SkillEndorsementResponse struct {
+	EndorserID   int64   `json:"endorserId"`
+	EndorserName string  `json:"endorserName"`
+	Comment      *string `json:"comment"`
+	CreatedAt    string  `json:"createdAt"`
+}
+
+func toSkillEndorsementResponse(e *models.SkillEndorsement) SkillEndorsementResponse {
+	return SkillEndorsementResponse{
+		EndorserID:   e.EndorserID,
+		EndorserName: e.EndorserName,
+		Comment:      e.Comment,
+		CreatedAt:    e.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+type EmployeeSkillResponse struct {
+	SkillID      int64                      `json:"skillId"`
+	Skill        string                     `json:"skill"`
+	Category     *string                    `json:"category"`
+	Level        int                        `json:"level"`
+	Endorsements int                        `json:"endorsements"`
+	EndorsedBy   []SkillEndorsementResponse `json:"endorsedBy,omitempty"`
+	UpdatedAt    string                     `json:"updatedAt"`
+}
+
+func toEmployeeSkillResponse(es *models.EmployeeSkill) EmployeeSkillResponse {
+	return EmployeeSkillResponse{
+		SkillID:      es.SkillID,
+		Skill:        es.SkillName,
+		Category:     es.Category,
+		Level:        es.Level,
+		Endorsements: es.Endorsements,
+		UpdatedAt:    es.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func writeSkillError(w http.ResponseWriter, err error, notFound string) {
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, notFound)
+		return
+	}
+	writeError(w, http.StatusBadRequest, err.Error())
+}
+
+// ListSkills handles GET /skills?keyword=.
+func (h *SkillHandler) ListSkills(w http.ResponseWriter, r *http.Request) {
+	skills, err := h.service.List(r.Context(), r.URL.Query().Get("keyword"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	out := []SkillResponse{}
+	for _, s := range skills {
+		out = append(out, toSkillResponse(s))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// CreateSkill handles POST /skills with {"name", "category"}.
+func (h *SkillHandler) CreateSkill(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreateSkill handler called")
+
+	var req struct {
+		Name     string  `json:"name"`
+		Category *string `json:"category"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	s := &models.Skill{Name: req.Name, Category: req.Category}
+	if err := h.service.Create(r.Context(), s); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, toSkillResponse(s))
+}
+
+// EmployeeSkills handles GET /employees/{id}/skills, strongest first, with
+// who endorsed each skill.
+func (h *SkillHandler) EmployeeSkills(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	skills, endorsements, err := h.service.EmployeeSkills(r.Context(), id)
+	if err != nil {
+		writeSkillError(w, err, "employee not found")
+		return
+	}
+	bySkill := map[int64][]SkillEndorsementResponse{}
+	for _, e := range endorsements {
+		bySkill[e.SkillID] = append(bySkill[e.SkillID], toSkillEndorsementResponse(e))
+	}
+	out := []EmployeeSkillResponse{}
+	for _, es := range skills {
+		resp := toEmployeeSkillResponse(es)
+		resp.EndorsedBy = bySkill[es.SkillID]
+		out = append(out, resp)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// SetEmployeeSkill handles POST /employees/{id}/skills with {"skillId" or
+// "skill", "level"}, adding the skill or changing its level.
+func (h *SkillHandler) SetEmployeeSkill(w http.ResponseWriter, r *http.Request) {
+	log.Println("SetEmployeeSkill handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		SkillID int64  `json:"skillId"`
+		Skill   string `json:"skill"`
+		Level   int    `json:"level"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	es, err := h.service.SetLevel(r.Context(), id, req.SkillID, req.Skill, req.Level)
+	if err != nil {
+		writeSkillError(w, err, "employee not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toEmployeeSkillResponse(es))
+}
+
+// skillPathID parses the skill id in /employees/{id}/skills/{skillId}.
+func skillPathID(r *http.Request) (int64, error) {
+	skillID, err := strconv.ParseInt(pathSegment(r, "/employees/", 2), 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid skill id")
+	}
+	return skillID, nil
+}
+
+// RemoveEmployeeSkill handles DELETE /employees/{id}/skills/{skillId}.
+func (h *SkillHandler) RemoveEmployeeSkill(w http.ResponseWriter, r *http.Request) {
+	log.Println("RemoveEmployeeSkill handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	skillID, err := skillPathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.RemoveSkill(r.Context(), id, skillID); err != nil {
+		writeSkillError(w, err, "employee skill not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Endorse handles POST /employees/{id}/skills/{skillId}/endorse with
+// {"endorserId", "comment"}.
+func (h *SkillHandler) Endorse(w http.ResponseWriter, r *http.Request) {
+	log.Println("Endorse handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	skillID, err := skillPathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		EndorserID int64   `json:"endorserId"`
+		Comment    *string `json:"comment"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.EndorserID == 0 {
+		writeError(w, http.StatusBadRequest, "endorserId is required")
+		return
+	}
+
+	e, err := h.service.Endorse(r.Context(), id, skillID, req.EndorserID, req.Comment)
+	if err != nil {
+		writeSkillError(w, err, "employee not found")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toSkillEndorsementResponse(e))
+}
+
+// SearchEmployees handles GET /employees/search?skill=Go:3&skill=PostgreSQL:2
+// with the departmentId, keyword, limit and offset of the employee list.
+// Each employee comes with all their skills.
+func (h *SkillHandler) SearchEmployees(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	q := r.URL.Query()
+	limit := 10
+	offset := 0
+	if l := q.Get("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			limit = v
+		}
+	}
+	if o := q.Get("offset"); o != "" {
+		if v, err := strconv.Atoi(o); err == nil && v >= 0 {
+			offset = v
+		}
+	}
+	filter, err := parseEmployeeFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	employees, total, skills, err := h.service.Search(r.Context(), limit, offset, filter)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	type match struct {
+		EmployeeResponse
+		Skills []EmployeeSkillResponse `json:"skills"`
+	}
+	out := []match{}
+	for _, e := range employees {
+		m := match{EmployeeResponse: toEmployeeResponse(e), Skills: []EmployeeSkillResponse{}}
+		for _, es := range skills[e.ID] {
+			m.Skills = append(m.Skills, toEmployeeSkillResponse(es))
+		}
+		out = append(out, m)
+	}
+	writeJSON(w, http.StatusOK, struct {
+		TotalCount int64   `json:"totalCount"`
+		Employees  []match `json:"employees"`
+	}{total, out})
+}
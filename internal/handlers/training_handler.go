@@ -0,0 +1,581 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"app/internal/models"
+	"app/internal/services"
+)
+
+type TrainingHandler struct {
+	service *services.TrainingService
+}
+
+func NewTrainingHandler(service *services.TrainingService) *TrainingHandler {
+	return &TrainingHandler{
+		service: service,
+	}
+}
+
+type CertificationTypeResponse struct {
+	ID             int64  `json:"id"`
+	Name           string `json:"name"`
+	ValidityMonths *int   `json:"validityMonths"`
+	CreatedAt      string `json:"createdAt"`
+}
+
+func toCertificationTypeResponse(c *models.CertificationType) CertificationTypeResponse {
+	return CertificationTypeResponse{
+		ID:             c.ID,
+		Name:           c.Name,
+		ValidityMonths: c.ValidityMonths,
+		CreatedAt:      c.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+type TrainingCourseResponse struct {
+	ID                  int64           `json:"id"`
+	Code                string          `json:"code"`
+	Title               string          `json:"title"`
+	Provider            *string         `json:"provider"`
+	DurationHours       *models.Decimal `json:"durationHours"`
+	CertificationTypeID *int64          `json:"certificationTypeId"`
+	CreatedAt           string          `json:"createdAt"`
+	UpdatedAt           string          `json:"updatedAt"`
+}
+
+func toTrainingCourseResponse(c *models.TrainingCourse) TrainingCourseResponse {
+	return TrainingCourseResponse{
+		ID:                  c.ID,
+		Code:                c.Code,
+		Title:               c.Title,
+		Provider:            c.Provider,
+		DurationHours:       c.DurationHours,
+		CertificationTypeID: c.CertificationTypeID,
+		CreatedAt:           c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:           c.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type CourseEnrolmentResponse struct {
+	ID           int64           `json:"id"`
+	CourseID     int64           `json:"courseId"`
+	CourseCode   string          `json:"courseCode"`
+	CourseTitle  string          `json:"courseTitle"`
+	EmployeeID   int64           `json:"employeeId"`
+	EmployeeName string          `json:"employeeName"`
+	Status       string          `json:"status"`
+	EnrolledOn   string          `json:"enrolledOn"`
+	CompletedOn  *string         `json:"completedOn"`
+	Score        *models.Decimal `json:"score"`
+}
+
+func toCourseEnrolmentResponse(en *models.CourseEnrolment) CourseEnrolmentResponse {
+	return CourseEnrolmentResponse{
+		ID:           en.ID,
+		CourseID:     en.CourseID,
+		CourseCode:   en.CourseCode,
+		CourseTitle:  en.CourseTitle,
+		EmployeeID:   en.EmployeeID,
+		EmployeeName: en.EmployeeName,
+		Status:       en.Status,
+		EnrolledOn:   en.EnrolledOn.Format(dateLayout),
+		CompletedOn:  formatDatePtr(en.CompletedOn),
+		Score:        en.Score,
+	}
+}
+
+type EmployeeCertificationResponse struct {
+	ID                  int64   `json:"id"`
+	EmployeeID          int64   `json:"employeeId"`
+	CertificationTypeID int64   `json:"certificationTypeId"`
+	CertificationName   string  `json:"certificationName"`
+	IssuedOn            string  `json:"issuedOn"`
+	ExpiresOn           *string `json:"expiresOn"`
+	CertificateNumber   *string `json:"certificateNumber"`
+	DocumentRef         *string `json:"documentRef"`
+	EnrolmentID         *int64  `json:"enrolmentId"`
+	Status              string  `json:"status"`
+}
+
+func toEmployeeCertificationResponse(c *models.EmployeeCertification, today time.Time, days int) EmployeeCertificationResponse {
+	return EmployeeCertificationResponse{
+		ID:                  c.ID,
+		EmployeeID:          c.EmployeeID,
+		CertificationTypeID: c.CertificationTypeID,
+		CertificationName:   c.CertificationName,
+		IssuedOn:            c.IssuedOn.Format(dateLayout),
+		ExpiresOn:           formatDatePtr(c.ExpiresOn),
+		CertificateNumber:   c.CertificateNumber,
+		DocumentRef:         c.DocumentRef,
+		EnrolmentID:         c.EnrolmentID,
+		Status:              services.ComplianceStatus(&c.IssuedOn, c.ExpiresOn, today, days),
+	}
+}
+
+func writeTrainingError(w http.ResponseWriter, err error, notFound string) {
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, notFound)
+		return
+	}
+	writeError(w, http.StatusBadRequest, err.Error())
+}
+
+// noticeDays reads ?days=, falling back to CERTIFICATION_EXPIRY_NOTICE_DAYS.
+func (h *TrainingHandler) noticeDays(r *http.Request) (int, error) {
+	v := r.URL.Query().Get("days")
+	if v == "" {
+		return h.service.NoticeDays(), nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, errors.New("days must be a positive number")
+	}
+	return n, nil
+}
+
+func (h *TrainingHandler) ListCertificationTypes(w http.ResponseWriter, r *http.Request) {
+	types, err := h.service.ListCertificationTypes(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	out := []CertificationTypeResponse{}
+	for _, c := range types {
+		out = append(out, toCertificationTypeResponse(c))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// CreateCertificationType handles POST /certifications. Leave
+// validityMonths null for certifications that do not expire.
+func (h *TrainingHandler) CreateCertificationType(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreateCertificationType handler called")
+
+	var req struct {
+		Name           string `json:"name"`
+		ValidityMonths *int   `json:"validityMonths"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	c := &models.CertificationType{Name: req.Name, ValidityMonths: req.ValidityMonths}
+	if err := h.service.CreateCertificationType(r.Context(), c); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, toCertificationTypeResponse(c))
+}
+
+// RequiredCertifications handles GET /departments/{id}/required-certifications.
+func (h *TrainingHandler) RequiredCertifications(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/departments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	types, err := h.service.RequiredCertifications(r.Context(), id)
+	if err != nil {
+		writeTrainingError(w, err, "department not found")
+		return
+	}
+	out := []CertificationTypeResponse{}
+	for _, c := range types {
+		out = append(out, toCertificationTypeResponse(c))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// SetRequiredCertifications handles PUT /departments/{id}/required-certifications
+// with {"certificationTypeIds": [...]}, replacing the whole list.
+func (h *TrainingHandler) SetRequiredCertifications(w http.ResponseWriter, r *http.Request) {
+	log.Println("SetRequiredCertifications handler called")
+
+	id, err := pathID(r, "/departments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		CertificationTypeIDs []int64 `json:"certificationTypeIds"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	types, err := h.service.SetRequiredCertifications(r.Context(), id, req.CertificationTypeIDs)
+	if err != nil {
+		writeTrainingError(w, err, "department not found")
+		return
+	}
+	out := []CertificationTypeResponse{}
+	for _, c := range types {
+		out = append(out, toCertificationTypeResponse(c))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *TrainingHandler) ListCourses(w http.ResponseWriter, r *http.Request) {
+	courses, err := h.service.ListCourses(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	out := []TrainingCourseResponse{}
+	for _, c := range courses {
+		out = append(out, toTrainingCourseResponse(c))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// CreateCourse handles POST /courses. Completing a course with a
+// certificationTypeId issues that certification.
+func (h *TrainingHandler) CreateCourse(w http.ResponseWriter, r *http.Request) {
+	log.Println("CreateCourse handler called")
+
+	var req struct {
+		Code                string          `json:"code"`
+		Title               string          `json:"title"`
+		Provider            *string         `json:"provider"`
+		DurationHours       *models.Decimal `json:"durationHours"`
+		CertificationTypeID *int64          `json:"certificationTypeId"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	c := &models.TrainingCourse{
+		Code:                req.Code,
+		Title:               req.Title,
+		Provider:            req.Provider,
+		DurationHours:       req.DurationHours,
+		CertificationTypeID: req.CertificationTypeID,
+	}
+	if err := h.service.CreateCourse(r.Context(), c); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, toTrainingCourseResponse(c))
+}
+
+func (h *TrainingHandler) GetCourse(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/courses/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	c, err := h.service.GetCourse(r.Context(), id)
+	if err != nil {
+		writeTrainingError(w, err, "course not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toTrainingCourseResponse(c))
+}
+
+// ListEnrolments handles GET /courses/{id}/enrolments.
+func (h *TrainingHandler) ListEnrolments(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/courses/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	enrolments, err := h.service.ListEnrolments(r.Context(), id)
+	if err != nil {
+		writeTrainingError(w, err, "course not found")
+		return
+	}
+	out := []CourseEnrolmentResponse{}
+	for _, en := range enrolments {
+		out = append(out, toCourseEnrolmentResponse(en))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// Enrol handles POST /courses/{id}/enrolments with {"employeeId",
+// "enrolledOn"}; enrolledOn defaults to today.
+func (h *TrainingHandler) Enrol(w http.ResponseWriter, r *http.Request) {
+	log.Println("Enrol handler called")
+
+	id, err := pathID(r, "/courses/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		EmployeeID int64   `json:"employeeId"`
+		EnrolledOn *string `json:"enrolledOn"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	enrolledOn := time.Now()
+	if d, err := parseOptionalDate(req.EnrolledOn, "enrolledOn"); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if d != nil {
+		enrolledOn = *d
+	}
+
+	en, err := h.service.Enrol(r.Context(), id, req.EmployeeID, enrolledOn)
+	if err != nil {
+		writeTrainingError(w, err, "course not found")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toCourseEnrolmentResponse(en))
+}
+
+// CompleteEnrolment handles POST /enrolments/{id}/complete with
+// {"completedOn", "passed", "score"}. Passing a course that grants a
+// certification answers with the issued certificate too.
+func (h *TrainingHandler) CompleteEnrolment(w http.ResponseWriter, r *http.Request) {
+	log.Println("CompleteEnrolment handler called")
+
+	id, err := pathID(r, "/enrolments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		CompletedOn *string         `json:"completedOn"`
+		Passed      *bool           `json:"passed"`
+		Score       *models.Decimal `json:"score"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	completedOn := time.Now()
+	if d, err := parseOptionalDate(req.CompletedOn, "completedOn"); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if d != nil {
+		completedOn = *d
+	}
+	passed := req.Passed == nil || *req.Passed
+
+	en, cert, err := h.service.Complete(r.Context(), id, completedOn, passed, req.Score)
+	if err != nil {
+		writeTrainingError(w, err, "enrolment not found")
+		return
+	}
+	resp := struct {
+		Enrolment     CourseEnrolmentResponse        `json:"enrolment"`
+		Certification *EmployeeCertificationResponse `json:"certification"`
+	}{Enrolment: toCourseEnrolmentResponse(en)}
+	if cert != nil {
+		c := toEmployeeCertificationResponse(cert, time.Now(), h.service.NoticeDays())
+		resp.Certification = &c
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// CancelEnrolment handles POST /enrolments/{id}/cancel.
+func (h *TrainingHandler) CancelEnrolment(w http.ResponseWriter, r *http.Request) {
+	log.Println("CancelEnrolment handler called")
+
+	id, err := pathID(r, "/enrolments/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	en, err := h.service.Cancel(r.Context(), id)
+	if err != nil {
+		writeTrainingError(w, err, "enrolment not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, toCourseEnrolmentResponse(en))
+}
+
+// EmployeeTraining handles GET /employees/{id}/training, the employee's
+// course enrolments, latest first.
+func (h *TrainingHandler) EmployeeTraining(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	enrolments, err := h.service.EmployeeEnrolments(r.Context(), id)
+	if err != nil {
+		writeTrainingError(w, err, "employee not found")
+		return
+	}
+	out := []CourseEnrolmentResponse{}
+	for _, en := range enrolments {
+		out = append(out, toCourseEnrolmentResponse(en))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// EmployeeCertifications handles GET /employees/{id}/certifications?days=,
+// every certificate the employee holds with its status today.
+func (h *TrainingHandler) EmployeeCertifications(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	days, err := h.noticeDays(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	certs, err := h.service.EmployeeCertifications(r.Context(), id)
+	if err != nil {
+		writeTrainingError(w, err, "employee not found")
+		return
+	}
+	today := time.Now()
+	out := []EmployeeCertificationResponse{}
+	for _, c := range certs {
+		out = append(out, toEmployeeCertificationResponse(c, today, days))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// AddCertification handles POST /employees/{id}/certifications for
+// certificates obtained outside the company's courses.
+func (h *TrainingHandler) AddCertification(w http.ResponseWriter, r *http.Request) {
+	log.Println("AddCertification handler called")
+
+	id, err := pathID(r, "/employees/")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req struct {
+		CertificationTypeID int64   `json:"certificationTypeId"`
+		IssuedOn            string  `json:"issuedOn"`
+		ExpiresOn           *string `json:"expiresOn"`
+		CertificateNumber   *string `json:"certificateNumber"`
+		DocumentRef         *string `json:"documentRef"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	issued, err := parseDate(req.IssuedOn)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "issuedOn must be YYYY-MM-DD")
+		return
+	}
+	expires, err := parseOptionalDate(req.ExpiresOn, "expiresOn")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c := &models.EmployeeCertification{
+		EmployeeID:          id,
+		CertificationTypeID: req.CertificationTypeID,
+		IssuedOn:            issued,
+		ExpiresOn:           expires,
+		CertificateNumber:   req.CertificateNumber,
+		DocumentRef:         req.DocumentRef,
+	}
+	if err := h.service.AddCertification(r.Context(), c); err != nil {
+		writeTrainingError(w, err, "employee not found")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toEmployeeCertificationResponse(c, time.Now(), h.service.NoticeDays()))
+}
+
+type ComplianceItemResponse struct {
+	EmployeeID          int64   `json:"employeeId"`
+	EmployeeName        string  `json:"employeeName"`
+	CertificationTypeID int64   `json:"certificationTypeId"`
+	CertificationName   string  `json:"certificationName"`
+	IssuedOn            *string `json:"issuedOn"`
+	ExpiresOn           *string `json:"expiresOn"`
+	Status              string  `json:"status"`
+}
+
+type DepartmentComplianceResponse struct {
+	DepartmentID   int64                    `json:"departmentId"`
+	DepartmentName string                   `json:"departmentName"`
+	Compliant      bool                     `json:"compliant"`
+	Valid          int                      `json:"valid"`
+	Expiring       int                      `json:"expiring"`
+	Expired        int                      `json:"expired"`
+	Missing        int                      `json:"missing"`
+	Items          []ComplianceItemResponse `json:"items"`
+}
+
+// CertificationCompliance handles GET
+// /reports/certification-compliance?departmentId=&days=&all=true. Each
+// department with required certifications is listed with its expired,
+// missing and soon-to-expire certificates; all=true includes the valid
+// ones too. A department is compliant when nothing is expired or missing.
+func (h *TrainingHandler) CertificationCompliance(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	departmentID, err := queryInt64(r, "departmentId")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	days, err := h.noticeDays(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	all := r.URL.Query().Get("all") == "true"
+
+	items, err := h.service.Compliance(r.Context(), departmentID, time.Now(), days)
+	if err != nil {
+		writeTrainingError(w, err, "department not found")
+		return
+	}
+
+	out := []*DepartmentComplianceResponse{}
+	var cur *DepartmentComplianceResponse
+	for _, c := range items {
+		if cur == nil || cur.DepartmentID != c.DepartmentID {
+			cur = &DepartmentComplianceResponse{
+				DepartmentID:   c.DepartmentID,
+				DepartmentName: c.DepartmentName,
+				Items:          []ComplianceItemResponse{},
+			}
+			out = append(out, cur)
+		}
+		switch c.Status {
+		case models.ComplianceValid:
+			cur.Valid++
+		case models.ComplianceExpiring:
+			cur.Expiring++
+		case models.ComplianceExpired:
+			cur.Expired++
+		case models.ComplianceMissing:
+			cur.Missing++
+		}
+		if c.Status == models.ComplianceValid && !all {
+			continue
+		}
+		cur.Items = append(cur.Items, ComplianceItemResponse{
+			EmployeeID:          c.EmployeeID,
+			EmployeeName:        c.EmployeeName,
+			CertificationTypeID: c.CertificationTypeID,
+			CertificationName:   c.CertificationName,
+			IssuedOn:            formatDatePtr(c.IssuedOn),
+			ExpiresOn:           formatDatePtr(c.ExpiresOn),
+			Status:              c.Status,
+		})
+	}
+	for _, d := range out {
+		d.Compliant = d.Expired == 0 && d.Missing == 0
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		Days        int                             `json:"days"`
+		Departments []*DepartmentComplianceResponse `json:"departments"`
+	}{days, out})
+}
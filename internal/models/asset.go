@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+const (
+	AssetKindLaptop  = "laptop"
+	AssetKindPhone   = "phone"
+	AssetKindBadge   = "badge"
+	AssetKindMonitor = "monitor"
+	AssetKindOther   = "other"
+)
+
+const (
+	AssetStatusAvailable = "available"
+	AssetStatusAssigned  = "assigned"
+	AssetStatusRetired   = "retired"
+	AssetStatusLost      = "lost"
+)
+
+func IsValidAssetKind(kind string) bool {
+	switch kind {
+	case AssetKindLaptop, AssetKindPhone, AssetKindBadge, AssetKindMonitor, AssetKindOther:
+		return true
+	}
+	return false
+}
+
+func IsValidAssetStatus(status string) bool {
+	switch status {
+	case AssetStatusAvailable, AssetStatusAssigned, AssetStatusRetired, AssetStatusLost:
+		return true
+	}
+	return false
+}
+
+// Asset is a piece of company equipment. HolderID and HolderName are filled
+// from the open assignment while the asset is assigned.
+type Asset struct {
+	ID           int64
+	AssetTag     string
+	Kind         string
+	Name         string
+	SerialNumber *string
+	Status       string
+	PurchaseDate *time.Time
+	Note         *string
+	HolderID     *int64
+	HolderName   *string
+	AssignedOn   *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// AssetAssignment is one hand-over of an asset to an employee; ReturnedOn
+// is nil while they still hold it. The asset and employee fields are
+// joined in for listings.
+type AssetAssignment struct {
+	ID           int64
+	AssetID      int64
+	EmployeeID   int64
+	AssignedOn   time.Time
+	ReturnedOn   *time.Time
+	ConditionOut *string
+	ConditionIn  *string
+	Note         *string
+	CreatedAt    time.Time
+
+	AssetTag     string
+	AssetKind    string
+	AssetName    string
+	SerialNumber *string
+	EmployeeName string
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+const (
+	AttendanceSourceClock  = "clock"
+	AttendanceSourceManual = "manual"
+)
+
+// AttendanceRecord is one worked interval. WorkDate is the local date the
+// interval started on; ClockOut is nil while the employee is clocked in.
+type AttendanceRecord struct {
+	ID         int64
+	EmployeeID int64
+	WorkDate   time.Time
+	ClockIn    time.Time
+	ClockOut   *time.Time
+	Source     string
+	ShiftID    *int64
+	Note       *string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// AttendanceDay sums an employee's records for one work date and compares
+// them with the scheduled shift, if any.
+type AttendanceDay struct {
+	Date          time.Time
+	Shift         *Shift
+	Records       []*AttendanceRecord
+	WorkedMinutes int
+	LateMinutes   int
+	EarlyMinutes  int
+	Open          bool
+}
+
+type AttendanceWeek struct {
+	WeekStart     time.Time
+	DaysWorked    int
+	WorkedMinutes int
+	LateDays      int
+	EarlyDays     int
+}
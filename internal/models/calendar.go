@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+const (
+	CalendarDayHoliday    = "holiday"
+	CalendarDayOff        = "day_off"
+	CalendarDayWorkingDay = "working_day"
+)
+
+func IsValidCalendarDayKind(kind string) bool {
+	switch kind {
+	case CalendarDayHoliday, CalendarDayOff, CalendarDayWorkingDay:
+		return true
+	}
+	return false
+}
+
+// WorkCalendar describes the working week of a country or office. Days not
+// in WeekendDays are working days unless a CalendarDay says otherwise.
+type WorkCalendar struct {
+	ID          int64
+	Name        string
+	Country     *string
+	WeekendDays []time.Weekday
+	IsDefault   bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type CalendarDay struct {
+	ID         int64
+	CalendarID int64
+	Date       time.Time
+	Kind       string
+	Name       string
+}
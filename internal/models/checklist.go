@@ -0,0 +1,97 @@
+package models
+
+import "time"
+
+const (
+	ChecklistKindOnboarding  = "onboarding"
+	ChecklistKindOffboarding = "offboarding"
+)
+
+const (
+	ChecklistAssigneeEmployee       = "employee"
+	ChecklistAssigneeManager        = "manager"
+	ChecklistAssigneeDepartmentHead = "department_head"
+)
+
+func IsValidChecklistKind(kind string) bool {
+	return kind == ChecklistKindOnboarding || kind == ChecklistKindOffboarding
+}
+
+func IsValidChecklistAssigneeRole(role string) bool {
+	switch role {
+	case ChecklistAssigneeEmployee, ChecklistAssigneeManager, ChecklistAssigneeDepartmentHead:
+		return true
+	}
+	return false
+}
+
+// ChecklistTemplate is the list of tasks copied into a checklist when an
+// employee joins or leaves. DepartmentID is nil for the company-wide
+// fallback.
+type ChecklistTemplate struct {
+	ID           int64
+	DepartmentID *int64
+	Kind         string
+	Name         string
+	Tasks        []*ChecklistTemplateTask
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ChecklistTemplateTask is assigned to AssigneeID when set, otherwise to
+// whoever AssigneeRole resolves to for the employee. It is due
+// DueOffsetDays after the hire date or the last working day.
+type ChecklistTemplateTask struct {
+	ID            int64
+	TemplateID    int64
+	Position      int
+	Title         string
+	AssigneeRole  *string
+	AssigneeID    *int64
+	DueOffsetDays int
+}
+
+// Checklist is one employee's onboarding or offboarding. AnchorDate is the
+// hire date or the last working day the due dates count from.
+type Checklist struct {
+	ID          int64
+	EmployeeID  int64
+	TemplateID  *int64
+	Kind        string
+	AnchorDate  time.Time
+	Tasks       []*ChecklistTask
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+type ChecklistTask struct {
+	ID          int64
+	ChecklistID int64
+	Position    int
+	Title       string
+	AssigneeID  *int64
+	DueDate     time.Time
+	CompletedAt *time.Time
+	CompletedBy *int64
+	Note        *string
+}
+
+// ChecklistSummary is an open checklist with its progress, for the list of
+// who is mid-onboarding or offboarding.
+type ChecklistSummary struct {
+	Checklist
+	EmployeeName string
+	DepartmentID int64
+	TotalTasks   int
+	DoneTasks    int
+	OverdueTasks int
+}
+
+// OverdueTask is an open checklist task past its due date, with the
+// employee the checklist is for.
+type OverdueTask struct {
+	ChecklistTask
+	Kind         string
+	EmployeeID   int64
+	EmployeeName string
+}
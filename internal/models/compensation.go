@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+const (
+	CompensationReasonHire         = "hire"
+	CompensationReasonPromotion    = "promotion"
+	CompensationReasonAnnualReview = "annual_review"
+	CompensationReasonCorrection   = "correction"
+)
+
+const DefaultCurrency = "VND"
+
+// Compensation is one effective-dated salary entry. The employee's current
+// salary is the latest entry whose EffectiveDate is not in the future.
+type Compensation struct {
+	ID            int64
+	EmployeeID    int64
+	EffectiveDate time.Time
+	Amount        Decimal
+	Currency      string
+	Reason        string
+	ApprovedBy    *int64
+	Note          *string
+	CreatedAt     time.Time
+}
+
+func IsValidCompensationReason(reason string) bool {
+	switch reason {
+	case CompensationReasonHire, CompensationReasonPromotion, CompensationReasonAnnualReview, CompensationReasonCorrection:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+const (
+	ContractTypeFixedTerm  = "fixed_term"
+	ContractTypeIndefinite = "indefinite"
+	ContractTypeInternship = "internship"
+)
+
+func IsValidContractType(t string) bool {
+	switch t {
+	case ContractTypeFixedTerm, ContractTypeIndefinite, ContractTypeInternship:
+		return true
+	}
+	return false
+}
+
+// Contract is one employment contract. EndDate is nil only for indefinite
+// contracts; DocumentRef points at the signed copy in the document store.
+type Contract struct {
+	ID             int64
+	EmployeeID     int64
+	ContractNumber *string
+	Type           string
+	StartDate      time.Time
+	EndDate        *time.Time
+	SignedDate     *time.Time
+	DocumentRef    *string
+	Note           *string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// ContractExpiry is a contract ending soon that has not been followed by
+// another one, with the employee it belongs to.
+type ContractExpiry struct {
+	Contract
+	EmployeeName string
+	DepartmentID int64
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// currencyMinorUnits lists the currencies salaries may be paid in, with the
+// number of fraction digits used when an amount is shown in that currency.
+var currencyMinorUnits = map[string]int{
+	"VND": 0,
+	"JPY": 0,
+	"USD": 2,
+	"EUR": 2,
+	"SGD": 2,
+}
+
+func IsSupportedCurrency(code string) bool {
+	_, ok := currencyMinorUnits[code]
+	return ok
+}
+
+// CurrencyMinorUnits returns the display precision for code, 2 if unknown.
+func CurrencyMinorUnits(code string) int {
+	if n, ok := currencyMinorUnits[code]; ok {
+		return n
+	}
+	return 2
+}
+
+// FitsCurrency reports whether amount has no more fraction digits than
+// code's minor units, so it can be stored and paid out exactly.
+func FitsCurrency(amount Decimal, code string) bool {
+	return amount.Round(CurrencyMinorUnits(code)).Cmp(amount) == 0
+}
+
+// ExchangeRate states that one unit of BaseCurrency buys Rate units of
+// QuoteCurrency from EffectiveDate onwards.
+type ExchangeRate struct {
+	ID            int64
+	BaseCurrency  string
+	QuoteCurrency string
+	Rate          Decimal
+	EffectiveDate time.Time
+	CreatedAt     time.Time
+}
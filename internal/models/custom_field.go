@@ -0,0 +1,83 @@
+package models
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	CustomFieldString = "string"
+	CustomFieldNumber = "number"
+	CustomFieldDate   = "date"
+	CustomFieldEnum   = "enum"
+)
+
+func IsValidCustomFieldType(t string) bool {
+	switch t {
+	case CustomFieldString, CustomFieldNumber, CustomFieldDate, CustomFieldEnum:
+		return true
+	}
+	return false
+}
+
+// CustomFieldDefinition is an admin-defined employee attribute. Key names
+// the value in Employee.CustomFields; Options lists the allowed values of
+// an enum.
+type CustomFieldDefinition struct {
+	ID        int64
+	Key       string
+	Label     string
+	Type      string
+	Options   []string
+	Required  bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CustomFieldValues holds an employee's custom field values by key, stored
+// as a JSONB object. Numbers are json.Number so they round-trip exactly;
+// dates are "YYYY-MM-DD" strings.
+type CustomFieldValues map[string]interface{}
+
+func (v CustomFieldValues) Value() (driver.Value, error) {
+	if v == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(map[string]interface{}(v))
+}
+
+func (v *CustomFieldValues) Scan(src interface{}) error {
+	var data []byte
+	switch s := src.(type) {
+	case []byte:
+		data = s
+	case string:
+		data = []byte(s)
+	case nil:
+		*v = CustomFieldValues{}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into CustomFieldValues", src)
+	}
+	*v = CustomFieldValues{}
+	return v.UnmarshalJSON(data)
+}
+
+// UnmarshalJSON decodes numbers as json.Number, so a value sent in a
+// request body keeps every digit.
+func (v *CustomFieldValues) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	out := map[string]interface{}{}
+	if err := dec.Decode(&out); err != nil {
+		return err
+	}
+	*v = out
+	return nil
+}
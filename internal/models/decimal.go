@@ -0,0 +1,186 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact base-10 number used for money and exchange rates, so
+// amounts survive the trip between NUMERIC columns and JSON without float
+// rounding. The zero value is 0.
+type Decimal struct {
+	rat *big.Rat
+}
+
+var errInvalidDecimal = errors.New("invalid decimal")
+
+func NewDecimalFromInt(v int64) Decimal {
+	return Decimal{rat: new(big.Rat).SetInt64(v)}
+}
+
+// ParseDecimal accepts plain decimal notation such as "-12", "12.5" or ".75".
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	digits := strings.TrimLeft(s, "+-")
+	if digits == "" || len(s)-len(digits) > 1 || strings.Count(digits, ".") > 1 || digits == "." {
+		return Decimal{}, errInvalidDecimal
+	}
+	for _, ch := range digits {
+		if (ch < '0' || ch > '9') && ch != '.' {
+			return Decimal{}, errInvalidDecimal
+		}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Decimal{}, errInvalidDecimal
+	}
+	return Decimal{rat: r}, nil
+}
+
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (d Decimal) r() *big.Rat {
+	if d.rat == nil {
+		return new(big.Rat)
+	}
+	return d.rat
+}
+
+func (d Decimal) Add(o Decimal) Decimal {
+	return Decimal{rat: new(big.Rat).Add(d.r(), o.r())}
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	return Decimal{rat: new(big.Rat).Sub(d.r(), o.r())}
+}
+
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{rat: new(big.Rat).Mul(d.r(), o.r())}
+}
+
+// Div returns d / o. Dividing by zero is an error rather than a panic since
+// divisors usually come from stored data (rates, working days).
+func (d Decimal) Div(o Decimal) (Decimal, error) {
+	if o.IsZero() {
+		return Decimal{}, errors.New("division by zero")
+	}
+	return Decimal{rat: new(big.Rat).Quo(d.r(), o.r())}, nil
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{rat: new(big.Rat).Neg(d.r())}
+}
+
+func (d Decimal) Cmp(o Decimal) int {
+	return d.r().Cmp(o.r())
+}
+
+func (d Decimal) Sign() int {
+	return d.r().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Round rounds half away from zero to the given number of fraction digits.
+func (d Decimal) Round(places int) Decimal {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil)
+	scaled := new(big.Rat).Mul(d.r(), new(big.Rat).SetInt(scale))
+
+	num := new(big.Int).Abs(scaled.Num())
+	q, rem := new(big.Int).QuoRem(num, scaled.Denom(), new(big.Int))
+	if new(big.Int).Mul(rem, big.NewInt(2)).Cmp(scaled.Denom()) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if scaled.Sign() < 0 {
+		q.Neg(q)
+	}
+	return Decimal{rat: new(big.Rat).SetFrac(q, scale)}
+}
+
+// StringFixed formats d rounded to exactly places fraction digits.
+func (d Decimal) StringFixed(places int) string {
+	return d.Round(places).r().FloatString(places)
+}
+
+// String prints the shortest exact representation, falling back to 18
+// fraction digits for values such as 1/3 that have no finite expansion.
+func (d Decimal) String() string {
+	r := d.r()
+	if r.IsInt() {
+		return r.Num().String()
+	}
+
+	// A fraction terminates in base 10 only when its denominator is 2^a*5^b,
+	// and then it needs max(a, b) fraction digits.
+	denom := new(big.Int).Set(r.Denom())
+	places := 0
+	for _, f := range []*big.Int{big.NewInt(2), big.NewInt(5)} {
+		n := 0
+		for new(big.Int).Mod(denom, f).Sign() == 0 {
+			denom.Quo(denom, f)
+			n++
+		}
+		places = max(places, n)
+	}
+	if denom.Cmp(big.NewInt(1)) != 0 {
+		return strings.TrimRight(strings.TrimRight(r.FloatString(18), "0"), ".")
+	}
+	return r.FloatString(places)
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON accepts both JSON numbers and numeric strings.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return fmt.Errorf("invalid decimal %s", string(data))
+	}
+	*d = v
+	return nil
+}
+
+func (d *Decimal) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	case int64:
+		*d = NewDecimalFromInt(v)
+		return nil
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Errorf("cannot scan %T into Decimal", src)
+	}
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
@@ -3,8 +3,32 @@ package models
 import "time"
 
 type Department struct {
-	ID        int64
-	Name      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID             int64
+	Name           string
+	HeadEmployeeID *int64
+	CalendarID     *int64
+	DefaultShiftID *int64
+	Plans          []*DepartmentPlan // only loaded where needed, newest year first
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// DepartmentPlan is the headcount a department may have at the end of a
+// fiscal year and its annual salary budget in Currency.
+type DepartmentPlan struct {
+	DepartmentID     int64
+	FiscalYear       int
+	PlannedHeadcount int
+	SalaryBudget     Decimal
+	Currency         string
+	Note             *string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// FiscalYearPeriod returns the first and last day of fiscal year year,
+// which starts on the first of startMonth in that calendar year.
+func FiscalYearPeriod(year int, startMonth time.Month) (time.Time, time.Time) {
+	start := time.Date(year, startMonth, 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(1, 0, -1)
 }
@@ -7,9 +7,35 @@ type Employee struct {
 	Name         string
 	Email        *string
 	DepartmentID int64
-	Age          *int
-	Position     *string
-	Salary      *float64
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ManagerID    *int64
+	DateOfBirth  *time.Time
+	// DateOfBirthEstimated marks a date of birth estimated from the age
+	// recorded before dates of birth were kept, until HR enters the real one.
+	DateOfBirthEstimated bool
+	Position             *string
+	PositionID           *int64
+	Salary               *Decimal
+	SalaryCurrency       *string
+	Status               string
+	HireDate             time.Time
+	ProbationEndDate     *time.Time
+	TerminationDate      *time.Time // last day of employment, nil while employed
+	TerminationReason    *string
+	CustomFields         CustomFieldValues
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// AgeOn is the employee's age in whole years on the given date, or nil when
+// the date of birth is unknown.
+func (e *Employee) AgeOn(t time.Time) *int {
+	if e.DateOfBirth == nil {
+		return nil
+	}
+	dob := *e.DateOfBirth
+	age := t.Year() - dob.Year()
+	if t.Month() < dob.Month() || (t.Month() == dob.Month() && t.Day() < dob.Day()) {
+		age--
+	}
+	return &age
 }
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+const (
+	EmploymentStatusCandidate  = "candidate"
+	EmploymentStatusProbation  = "probation"
+	EmploymentStatusActive     = "active"
+	EmploymentStatusOnLeave    = "on_leave"
+	EmploymentStatusTerminated = "terminated"
+)
+
+const (
+	EmploymentEventHire         = "hire"
+	EmploymentEventStatusChange = "status_change"
+	EmploymentEventTransfer     = "transfer"
+	EmploymentEventTermination  = "termination"
+	EmploymentEventRehire       = "rehire"
+	// EmploymentEventPositionChange records a new position, or none, taking
+	// effect on its date; it is always applied at once.
+	EmploymentEventPositionChange = "position_change"
+)
+
+// employmentTransitions lists the statuses each status may move to.
+var employmentTransitions = map[string][]string{
+	EmploymentStatusCandidate:  {EmploymentStatusProbation, EmploymentStatusActive},
+	EmploymentStatusProbation:  {EmploymentStatusActive, EmploymentStatusTerminated},
+	EmploymentStatusActive:     {EmploymentStatusOnLeave, EmploymentStatusTerminated},
+	EmploymentStatusOnLeave:    {EmploymentStatusActive, EmploymentStatusTerminated},
+	EmploymentStatusTerminated: {EmploymentStatusProbation, EmploymentStatusActive},
+}
+
+func IsValidEmploymentStatus(status string) bool {
+	_, ok := employmentTransitions[status]
+	return ok
+}
+
+func CanTransitionEmployment(from, to string) bool {
+	for _, s := range employmentTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// EmploymentEvent is one step of an employee's history. Events effective in
+// the future have a nil AppliedAt until they take effect.
+type EmploymentEvent struct {
+	ID               int64
+	EmployeeID       int64
+	Kind             string
+	EffectiveDate    time.Time
+	FromStatus       *string
+	ToStatus         *string
+	FromDepartmentID *int64
+	ToDepartmentID   *int64
+	ToManagerID      *int64
+	ToPositionID     *int64 // set by hires, rehires and position changes
+	Reason           *string
+	AppliedAt        *time.Time
+	CreatedAt        time.Time
+}
@@ -0,0 +1,69 @@
+package models
+
+import "time"
+
+const (
+	LeaveStatusPending   = "pending"
+	LeaveStatusApproved  = "approved"
+	LeaveStatusRejected  = "rejected"
+	LeaveStatusCancelled = "cancelled"
+)
+
+// LeaveType is a kind of leave. Types that do not require a balance (e.g.
+// unpaid leave) can be requested without an entitlement.
+type LeaveType struct {
+	ID              int64
+	Code            string
+	Name            string
+	Paid            bool
+	RequiresBalance bool
+	Policies        []LeaveAccrualPolicy
+	CreatedAt       time.Time
+}
+
+// LeaveAccrualPolicy grants DaysPerYear once an employee has completed
+// MinYearsOfService years.
+type LeaveAccrualPolicy struct {
+	MinYearsOfService int
+	DaysPerYear       Decimal
+}
+
+// EntitlementFor returns the yearly days for someone with the given years
+// of service, i.e. the policy with the highest threshold reached.
+func (t *LeaveType) EntitlementFor(years int) Decimal {
+	best := -1
+	var days Decimal
+	for _, p := range t.Policies {
+		if p.MinYearsOfService <= years && p.MinYearsOfService > best {
+			best = p.MinYearsOfService
+			days = p.DaysPerYear
+		}
+	}
+	return days
+}
+
+type LeaveRequest struct {
+	ID           int64
+	EmployeeID   int64
+	LeaveTypeID  int64
+	StartDate    time.Time
+	EndDate      time.Time
+	Days         Decimal
+	Status       string
+	Reason       *string
+	ApproverID   *int64
+	DecidedAt    *time.Time
+	DecisionNote *string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// LeaveBalance is one leave type's position for a calendar year.
+type LeaveBalance struct {
+	LeaveType   LeaveType
+	Year        int
+	Entitlement Decimal
+	Used        Decimal
+	Pending     Decimal
+	Available   Decimal
+}
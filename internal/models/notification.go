@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+const (
+	NotificationContractExpiring  = "contract_expiring"
+	NotificationAssetsOutstanding = "assets_outstanding"
+)
+
+// Notification is a message raised for HR by a background check. DedupeKey,
+// when set, is unique so a check that runs daily raises it only once.
+type Notification struct {
+	ID         int64
+	Kind       string
+	EmployeeID *int64
+	Message    string
+	DedupeKey  *string
+	ReadAt     *time.Time
+	CreatedAt  time.Time
+}
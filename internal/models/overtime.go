@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+const (
+	OvertimeDayWeekday = "weekday"
+	OvertimeDayWeekend = "weekend"
+	OvertimeDayHoliday = "holiday"
+)
+
+const (
+	OvertimeStatusPending  = "pending"
+	OvertimeStatusApproved = "approved"
+	OvertimeStatusRejected = "rejected"
+)
+
+func IsValidOvertimeDayKind(kind string) bool {
+	switch kind {
+	case OvertimeDayWeekday, OvertimeDayWeekend, OvertimeDayHoliday:
+		return true
+	}
+	return false
+}
+
+// OvertimeItem is the overtime an employee worked on one day, paid at Rate
+// times their hourly wage once approved. Minutes is what remains payable
+// after the monthly cap; CappedMinutes is what the cap cut off.
+type OvertimeItem struct {
+	ID            int64
+	EmployeeID    int64
+	Period        string
+	WorkDate      time.Time
+	DayKind       string
+	WorkedMinutes int
+	Minutes       int
+	CappedMinutes int
+	Rate          Decimal
+	Status        string
+	ApproverID    *int64
+	DecidedAt     *time.Time
+	DecisionNote  *string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+const (
+	PayslipLineEarning      = "earning"
+	PayslipLineContribution = "contribution"
+	PayslipLineTax          = "tax"
+	PayslipLineDeduction    = "deduction"
+)
+
+// PayrollRun is the finalized payroll of one month. Runs and their payslips
+// are never updated; the database rejects changes.
+type PayrollRun struct {
+	ID            int64
+	Period        string
+	Currency      string
+	EmployeeCount int
+	TotalGross    Decimal
+	TotalNet      Decimal
+	CreatedAt     time.Time
+}
+
+// Payslip keeps a snapshot of the employee's name and department so the
+// record stays accurate after later transfers or renames.
+type Payslip struct {
+	ID              int64
+	PayrollRunID    int64
+	EmployeeID      int64
+	EmployeeName    string
+	DepartmentID    int64
+	Period          string
+	Currency        string
+	BaseSalary      Decimal
+	WorkingDays     int
+	PaidDays        int
+	Gross           Decimal
+	SocialInsurance Decimal
+	TaxableIncome   Decimal
+	IncomeTax       Decimal
+	Deductions      Decimal
+	Net             Decimal
+	Lines           []PayslipLine
+	CreatedAt       time.Time
+}
+
+type PayslipLine struct {
+	Kind   string
+	Code   string
+	Label  string
+	Amount Decimal
+}
+
+// PayslipTotals sums payslip amounts, e.g. year to date.
+type PayslipTotals struct {
+	Gross           Decimal
+	SocialInsurance Decimal
+	IncomeTax       Decimal
+	Deductions      Decimal
+	Net             Decimal
+}
@@ -0,0 +1,105 @@
+package models
+
+import "time"
+
+const (
+	AddressKindPermanent = "permanent"
+	AddressKindTemporary = "temporary"
+	AddressKindMailing   = "mailing"
+)
+
+func IsValidAddressKind(k string) bool {
+	switch k {
+	case AddressKindPermanent, AddressKindTemporary, AddressKindMailing:
+		return true
+	}
+	return false
+}
+
+const (
+	PhoneKindMobile = "mobile"
+	PhoneKindHome   = "home"
+	PhoneKindWork   = "work"
+)
+
+func IsValidPhoneKind(k string) bool {
+	switch k {
+	case PhoneKindMobile, PhoneKindHome, PhoneKindWork:
+		return true
+	}
+	return false
+}
+
+const (
+	DependentChild  = "child"
+	DependentSpouse = "spouse"
+	DependentParent = "parent"
+	DependentOther  = "other"
+)
+
+func IsValidDependentRelationship(r string) bool {
+	switch r {
+	case DependentChild, DependentSpouse, DependentParent, DependentOther:
+		return true
+	}
+	return false
+}
+
+// EmployeeAddress is a postal address of an employee. Country is an ISO
+// 3166-1 alpha-2 code; at most one address per employee is primary.
+type EmployeeAddress struct {
+	ID         int64
+	EmployeeID int64
+	Kind       string
+	Line1      string
+	Line2      *string
+	City       string
+	Region     *string
+	PostalCode *string
+	Country    string
+	IsPrimary  bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// EmployeePhone is a phone number stored without spaces or punctuation,
+// e.g. +84901234567. At most one number per employee is primary.
+type EmployeePhone struct {
+	ID         int64
+	EmployeeID int64
+	Kind       string
+	Number     string
+	IsPrimary  bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// EmergencyContact is someone to call about an employee; lower Priority is
+// called first.
+type EmergencyContact struct {
+	ID           int64
+	EmployeeID   int64
+	Name         string
+	Relationship string
+	Phone        string
+	Email        *string
+	Priority     int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Dependent is a family member of an employee. Dependents registered for
+// tax relief count towards the payroll dependent relief in every month
+// overlapping [ReliefFrom, ReliefTo]; ReliefTo is nil while it lasts.
+type Dependent struct {
+	ID           int64
+	EmployeeID   int64
+	Name         string
+	Relationship string
+	DateOfBirth  *time.Time
+	TaxID        *string
+	ReliefFrom   *time.Time
+	ReliefTo     *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
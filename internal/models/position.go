@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// Position is a job title in the catalog. Employees reference it by ID; the
+// old free-text spellings live on as aliases that resolve to it.
+type Position struct {
+	ID        int64
+	Code      string
+	Title     string
+	Level     *int
+	JobFamily *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
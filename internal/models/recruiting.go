@@ -0,0 +1,128 @@
+package models
+
+import "time"
+
+const (
+	RequisitionStatusOpen      = "open"
+	RequisitionStatusOnHold    = "on_hold"
+	RequisitionStatusFilled    = "filled"
+	RequisitionStatusCancelled = "cancelled"
+)
+
+const (
+	CandidateStatusActive    = "active"
+	CandidateStatusHired     = "hired"
+	CandidateStatusRejected  = "rejected"
+	CandidateStatusWithdrawn = "withdrawn"
+)
+
+const (
+	RecommendationStrongYes = "strong_yes"
+	RecommendationYes       = "yes"
+	RecommendationNo        = "no"
+	RecommendationStrongNo  = "strong_no"
+)
+
+func IsValidRequisitionStatus(status string) bool {
+	switch status {
+	case RequisitionStatusOpen, RequisitionStatusOnHold, RequisitionStatusFilled, RequisitionStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func IsValidRecommendation(r string) bool {
+	switch r {
+	case RecommendationStrongYes, RecommendationYes, RecommendationNo, RecommendationStrongNo:
+		return true
+	}
+	return false
+}
+
+// PipelineStage is a step in the hiring process. Stages are ordered by
+// Position; offers are accepted from the last one.
+type PipelineStage struct {
+	ID        int64
+	Name      string
+	Position  int
+	CreatedAt time.Time
+}
+
+// JobRequisition is approval to hire Openings people into a department.
+// Hired counts the candidates who accepted an offer.
+type JobRequisition struct {
+	ID              int64
+	DepartmentID    int64
+	PositionID      *int64
+	Title           string
+	Openings        int
+	Status          string
+	HiringManagerID *int64
+	SalaryMin       *Decimal
+	SalaryMax       *Decimal
+	SalaryCurrency  *string
+	Description     *string
+	OpenedOn        time.Time
+	ClosedOn        *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+
+	Hired int
+}
+
+// Candidate applies for a requisition and moves through the pipeline
+// stages. The offer fields carry over to the employee record when the
+// offer is accepted.
+type Candidate struct {
+	ID                int64
+	RequisitionID     int64
+	Name              string
+	Email             *string
+	Phone             *string
+	Source            *string
+	StageID           int64
+	Status            string
+	OfferedSalary     *Decimal
+	OfferedCurrency   *string
+	ExpectedStartDate *time.Time
+	EmployeeID        *int64
+	Note              *string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+
+	StageName string
+}
+
+type CandidateStageChange struct {
+	ID          int64
+	CandidateID int64
+	FromStageID *int64
+	ToStageID   int64
+	ToStageName string
+	Note        *string
+	ChangedAt   time.Time
+}
+
+type InterviewFeedback struct {
+	ID              int64
+	CandidateID     int64
+	StageID         int64
+	StageName       string
+	InterviewerID   int64
+	InterviewerName string
+	Rating          int
+	Recommendation  string
+	Comment         *string
+	InterviewedOn   time.Time
+	CreatedAt       time.Time
+}
+
+// DepartmentHeadcount is a department's current staff by employment
+// status next to the hiring it still has open.
+type DepartmentHeadcount struct {
+	DepartmentID     int64
+	ByStatus         map[string]int
+	Employed         int
+	OpenRequisitions []*JobRequisition
+	OpenSlots        int
+}
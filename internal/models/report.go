@@ -0,0 +1,102 @@
+package models
+
+import "time"
+
+// SalaryTotal is the sum of current salaries of one department in one
+// currency, as aggregated by the database.
+type SalaryTotal struct {
+	DepartmentID   int64
+	DepartmentName string
+	Currency       string
+	Employees      int64
+	Total          Decimal
+}
+
+// PlanMember is an employee as the headcount plan report sees them: their
+// salary today and at the end of the fiscal year, and any transfer or
+// termination scheduled before then.
+type PlanMember struct {
+	EmployeeID          int64
+	DepartmentID        int64
+	HireDate            time.Time
+	TerminationDate     *time.Time
+	Salary              *Decimal
+	SalaryCurrency      *string
+	EndSalary           *Decimal
+	EndSalaryCurrency   *string
+	TransferTo          *int64
+	TransferEffectiveOn *time.Time
+}
+
+// HeadcountBudget compares a department's plan for a fiscal year with its
+// actual headcount and annualised salary cost on AsOf, and with the
+// forecast for the end of the year. Variances are actual or forecast minus
+// plan, nil without a plan.
+type HeadcountBudget struct {
+	DepartmentID   int64
+	DepartmentName string
+	FiscalYear     int
+	PeriodStart    time.Time
+	PeriodEnd      time.Time
+	AsOf           time.Time
+	Plan           *DepartmentPlan
+	Currency       string
+
+	ActualHeadcount    int
+	ActualSalaryCost   Decimal
+	ScheduledHires     int
+	ScheduledLeavers   int
+	TransfersIn        int
+	TransfersOut       int
+	ForecastHeadcount  int
+	ForecastSalaryCost Decimal
+
+	HeadcountVariance         *int
+	SalaryVariance            *Decimal
+	ForecastHeadcountVariance *int
+	ForecastSalaryVariance    *Decimal
+}
+
+// MonthlyHeadcount is one department's staff movement in one calendar
+// month. Opening counts those employed before the month started, Closing
+// those still employed after it ended, so Closing = Opening + Hires -
+// Leavers.
+type MonthlyHeadcount struct {
+	Month          time.Time
+	DepartmentID   int64
+	DepartmentName string
+	Opening        int64
+	Hires          int64
+	Leavers        int64
+	Closing        int64
+}
+
+// DistributionBucket counts a department's employees in one band of a
+// distribution such as tenure or age. Total sums the measured values (days
+// of tenure, years of age) so averages can be derived.
+type DistributionBucket struct {
+	DepartmentID   int64
+	DepartmentName string
+	Bucket         string
+	Employees      int64
+	Total          int64
+}
+
+// SalaryStatistics describes the current salaries of one group of
+// employees, converted to a single currency. Groups smaller than the
+// privacy threshold are Suppressed and carry only their size.
+type SalaryStatistics struct {
+	Key        string
+	Label      string
+	Employees  int64
+	Suppressed bool
+	Min        *Decimal
+	Max        *Decimal
+	Mean       *Decimal
+	Median     *Decimal
+	P10        *Decimal
+	P25        *Decimal
+	P75        *Decimal
+	P90        *Decimal
+	StdDev     *Decimal
+}
@@ -0,0 +1,96 @@
+package models
+
+import "time"
+
+const (
+	ReviewCycleDraft  = "draft"
+	ReviewCycleOpen   = "open"
+	ReviewCycleClosed = "closed"
+)
+
+const (
+	MinReviewRating = 1
+	MaxReviewRating = 5
+)
+
+// Stages a review moves through, derived from which parts are submitted.
+const (
+	ReviewStagePending    = "pending"
+	ReviewStageSelf       = "self_review_done"
+	ReviewStageManager    = "manager_review_done"
+	ReviewStageCalibrated = "calibrated"
+)
+
+type ReviewCycle struct {
+	ID          int64
+	Name        string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Status      string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// PerformanceReview is one employee's review in a cycle: their self-review,
+// the review by ReviewerID and the calibrated final rating. The employee
+// and reviewer names are joined in for listings.
+type PerformanceReview struct {
+	ID                 int64
+	CycleID            int64
+	EmployeeID         int64
+	ReviewerID         *int64
+	SelfRating         *int
+	SelfComment        *string
+	SelfSubmittedAt    *time.Time
+	ManagerRating      *int
+	ManagerComment     *string
+	ManagerSubmittedAt *time.Time
+	CalibratedRating   *int
+	CalibrationNote    *string
+	CalibratedAt       *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+
+	EmployeeName string
+	DepartmentID int64
+	ReviewerName *string
+}
+
+func (r *PerformanceReview) Stage() string {
+	switch {
+	case r.CalibratedAt != nil:
+		return ReviewStageCalibrated
+	case r.ManagerSubmittedAt != nil:
+		return ReviewStageManager
+	case r.SelfSubmittedAt != nil:
+		return ReviewStageSelf
+	}
+	return ReviewStagePending
+}
+
+// ReviewGoal is a goal an employee works towards during a cycle. Weight is
+// its share of the employee's overall progress.
+type ReviewGoal struct {
+	ID          int64
+	CycleID     int64
+	EmployeeID  int64
+	Title       string
+	Description *string
+	Weight      int
+	Progress    int
+	DueDate     *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ReviewCompletion counts a department's reviews in a cycle by how far
+// they have got.
+type ReviewCompletion struct {
+	DepartmentID    int64
+	DepartmentName  string
+	Total           int
+	SelfDone        int
+	ManagerDone     int
+	Calibrated      int
+	WithoutReviewer int
+}
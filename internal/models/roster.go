@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type RosterAssignment struct {
+	ID         int64
+	EmployeeID int64
+	ShiftID    int64
+	WorkDate   time.Time
+	Note       *string
+	CreatedAt  time.Time
+}
+
+const (
+	RosterConflictDoubleBooking = "double_booking"
+	RosterConflictRestPeriod    = "rest_period"
+	RosterConflictOnLeave       = "on_leave"
+)
+
+// RosterConflict flags an assignment that cannot be worked as planned.
+// Other is the assignment it clashes with, for double bookings and rest
+// period violations.
+type RosterConflict struct {
+	Kind       string
+	EmployeeID int64
+	Date       time.Time
+	Assignment *RosterAssignment
+	Other      *RosterAssignment
+	Message    string
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+const (
+	BandStatusBelow  = "below"
+	BandStatusWithin = "within"
+	BandStatusAbove  = "above"
+)
+
+// SalaryBand is the min/mid/max range for a position. DepartmentID nil means
+// the band applies company-wide unless a department-specific band exists.
+type SalaryBand struct {
+	ID           int64
+	PositionID   int64
+	DepartmentID *int64
+	Min          Decimal
+	Mid          Decimal
+	Max          Decimal
+	Currency     string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Place classifies salary (already in the band's currency) against the band
+// and returns its compa-ratio, salary / midpoint, rounded to 4 places.
+func (b *SalaryBand) Place(salary Decimal) (status string, compaRatio Decimal) {
+	status = BandStatusWithin
+	if salary.Cmp(b.Min) < 0 {
+		status = BandStatusBelow
+	} else if salary.Cmp(b.Max) > 0 {
+		status = BandStatusAbove
+	}
+	if ratio, err := salary.Div(b.Mid); err == nil {
+		compaRatio = ratio.Round(4)
+	}
+	return status, compaRatio
+}
+
+// BandPlacement is one employee measured against the band that applies to
+// their position and department.
+type BandPlacement struct {
+	EmployeeID     int64
+	EmployeeName   string
+	DepartmentID   int64
+	PositionID     int64
+	PositionTitle  string
+	Salary         Decimal
+	SalaryCurrency string
+	Band           SalaryBand
+	// BandSalary is Salary converted to the band's currency.
+	BandSalary Decimal
+	Status     string
+	CompaRatio Decimal
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+const shiftClockLayout = "15:04"
+
+// Shift is a scheduled working time. StartTime and EndTime are "HH:MM" in
+// the company time zone; a shift ending at or before its start runs past
+// midnight.
+type Shift struct {
+	ID           int64
+	Name         string
+	StartTime    string
+	EndTime      string
+	BreakMinutes int
+	GraceMinutes int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ParseShiftClock parses an "HH:MM" time of day into minutes after midnight.
+func ParseShiftClock(s string) (int, error) {
+	t, err := time.Parse(shiftClockLayout, s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Bounds returns the start and end of the shift worked on date in loc.
+func (s *Shift) Bounds(date time.Time, loc *time.Location) (start, end time.Time) {
+	startMin, _ := ParseShiftClock(s.StartTime)
+	endMin, _ := ParseShiftClock(s.EndTime)
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	start = day.Add(time.Duration(startMin) * time.Minute)
+	end = day.Add(time.Duration(endMin) * time.Minute)
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start, end
+}
+
+// ScheduledMinutes is the paid length of the shift, net of its break.
+func (s *Shift) ScheduledMinutes() int {
+	start, end := s.Bounds(time.Date(2000, 1, 3, 0, 0, 0, 0, time.UTC), time.UTC)
+	return int(end.Sub(start).Minutes()) - s.BreakMinutes
+}
@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// Skill proficiency levels run from MinSkillLevel (basic) to MaxSkillLevel
+// (expert).
+const (
+	MinSkillLevel = 1
+	MaxSkillLevel = 5
+)
+
+type Skill struct {
+	ID        int64
+	Name      string
+	Category  *string
+	CreatedAt time.Time
+}
+
+// EmployeeSkill is an employee's level in one skill, with the skill's name
+// and how many colleagues endorsed it.
+type EmployeeSkill struct {
+	EmployeeID   int64
+	SkillID      int64
+	SkillName    string
+	Category     *string
+	Level        int
+	Endorsements int
+	UpdatedAt    time.Time
+}
+
+type SkillEndorsement struct {
+	EmployeeID   int64
+	SkillID      int64
+	EndorserID   int64
+	EndorserName string
+	Comment      *string
+	CreatedAt    time.Time
+}
+
+// SkillRequirement matches employees with the named skill at MinLevel or
+// above.
+type SkillRequirement struct {
+	Skill    string
+	MinLevel int
+}
+
+// EmployeeFilter narrows employee listings. Every set field must match.
+// With AsOf the listing shows the staff employed on that day, with the
+// department, position, status and salary they had then. CustomFields
+// matches custom field values by key, compared as text.
+type EmployeeFilter struct {
+	DepartmentID *int64
+	Keyword      *string
+	Skills       []SkillRequirement
+	AsOf         *time.Time
+	CustomFields map[string]string
+}
@@ -0,0 +1,84 @@
+package models
+
+import "time"
+
+// CertificationType is a certification employees can hold, valid for
+// ValidityMonths from issue, or indefinitely when nil.
+type CertificationType struct {
+	ID             int64
+	Name           string
+	ValidityMonths *int
+	CreatedAt      time.Time
+}
+
+// TrainingCourse issues the certification CertificationTypeID, when set, to
+// everyone who completes it.
+type TrainingCourse struct {
+	ID                  int64
+	Code                string
+	Title               string
+	Provider            *string
+	DurationHours       *Decimal
+	CertificationTypeID *int64
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+const (
+	EnrolmentStatusEnrolled  = "enrolled"
+	EnrolmentStatusCompleted = "completed"
+	EnrolmentStatusFailed    = "failed"
+	EnrolmentStatusCancelled = "cancelled"
+)
+
+// CourseEnrolment is an employee taking a course. The course and employee
+// names are joined in for listings.
+type CourseEnrolment struct {
+	ID          int64
+	CourseID    int64
+	EmployeeID  int64
+	Status      string
+	EnrolledOn  time.Time
+	CompletedOn *time.Time
+	Score       *Decimal
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	CourseCode   string
+	CourseTitle  string
+	EmployeeName string
+}
+
+type EmployeeCertification struct {
+	ID                  int64
+	EmployeeID          int64
+	CertificationTypeID int64
+	CertificationName   string
+	IssuedOn            time.Time
+	ExpiresOn           *time.Time
+	CertificateNumber   *string
+	DocumentRef         *string
+	EnrolmentID         *int64
+	CreatedAt           time.Time
+}
+
+const (
+	ComplianceValid    = "valid"
+	ComplianceExpiring = "expiring"
+	ComplianceExpired  = "expired"
+	ComplianceMissing  = "missing"
+)
+
+// CertificationCompliance is one employee against one certification their
+// department requires, with the latest certificate they hold, if any.
+type CertificationCompliance struct {
+	DepartmentID        int64
+	DepartmentName      string
+	EmployeeID          int64
+	EmployeeName        string
+	CertificationTypeID int64
+	CertificationName   string
+	IssuedOn            *time.Time
+	ExpiresOn           *time.Time
+	Status              string
+}
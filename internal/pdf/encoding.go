@@ -0,0 +1,74 @@
+package pdf
+
+// The standard fonts only cover WinAnsi (roughly Latin-1), so Vietnamese
+// letters are written without their diacritics and anything else outside
+// Latin-1 becomes '?'.
+var vietnameseFolds = map[string]string{
+	"a": "àáạảãâầấậẩẫăằắặẳẵ",
+	"A": "ÀÁẠẢÃÂẦẤẬẨẪĂẰẮẶẲẴ",
+	"e": "èéẹẻẽêềếệểễ",
+	"E": "ÈÉẸẺẼÊỀẾỆỂỄ",
+	"i": "ìíịỉĩ",
+	"I": "ÌÍỊỈĨ",
+	"o": "òóọỏõôồốộổỗơờớợởỡ",
+	"O": "ÒÓỌỎÕÔỒỐỘỔỖƠỜỚỢỞỠ",
+	"u": "ùúụủũưừứựửữ",
+	"U": "ÙÚỤỦŨƯỪỨỰỬỮ",
+	"y": "ỳýỵỷỹ",
+	"Y": "ỲÝỴỶỸ",
+	"d": "đ",
+	"D": "Đ",
+}
+
+var foldTable = func() map[rune]byte {
+	t := map[rune]byte{}
+	for base, variants := range vietnameseFolds {
+		for _, r := range variants {
+			t[r] = base[0]
+		}
+	}
+	return t
+}()
+
+func encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			out = append(out, ' ')
+		case r < 0x80:
+			out = append(out, byte(r))
+		case foldTable[r] != 0:
+			out = append(out, foldTable[r])
+		case r >= 0xA0 && r <= 0xFF:
+			out = append(out, byte(r))
+		default:
+			out = append(out, '?')
+		}
+	}
+	return out
+}
+
+// helveticaWidths are the Helvetica advance widths of ASCII 32..126 in
+// 1/1000 em. Helvetica-Bold is close enough for aligning amounts.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// TextWidth returns the width of s in points at the given font size.
+func TextWidth(s string, size float64) float64 {
+	total := 0
+	for _, c := range encode(s) {
+		if c >= 32 && c <= 126 {
+			total += helveticaWidths[c-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
@@ -0,0 +1,208 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"app/internal/models"
+)
+
+type assetPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewAssetRepository(db *sql.DB) AssetRepository {
+	return &assetPostgresRepository{db: db}
+}
+
+type AssetRepository interface {
+	Create(ctx context.Context, a *models.Asset) error
+	FindByID(ctx context.Context, id int64) (*models.Asset, error)
+	Update(ctx context.Context, a *models.Asset) error
+	List(ctx context.Context, kind, status, keyword string) ([]*models.Asset, error)
+
+	Assign(ctx context.Context, as *models.AssetAssignment) error
+	Return(ctx context.Context, as *models.AssetAssignment, status string) error
+	FindOpenAssignment(ctx context.Context, assetID int64) (*models.AssetAssignment, error)
+	ListAssignmentsByAsset(ctx context.Context, assetID int64) ([]*models.AssetAssignment, error)
+	ListAssignmentsByEmployee(ctx context.Context, employeeID int64, openOnly bool) ([]*models.AssetAssignment, error)
+}
+
+const assetSelect = `
+	SELECT a.id, a.asset_tag, a.kind, a.name, a.serial_number, a.status, a.purchase_date, a.note,
+		aa.employee_id, e.name, aa.assigned_on, a.created_at, a.updated_at
+	FROM assets a
+	LEFT JOIN asset_assignments aa ON aa.asset_id = a.id AND aa.returned_on IS NULL
+	LEFT JOIN employees e ON e.id = aa.employee_id
+`
+
+func scanAsset(row rowScanner) (*models.Asset, error) {
+	var a models.Asset
+	if err := row.Scan(
+		&a.ID, &a.AssetTag, &a.Kind, &a.Name, &a.SerialNumber, &a.Status, &a.PurchaseDate, &a.Note,
+		&a.HolderID, &a.HolderName, &a.AssignedOn, &a.CreatedAt, &a.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *assetPostgresRepository) Create(ctx context.Context, a *models.Asset) error {
+	query := `
+		INSERT INTO assets (asset_tag, kind, name, serial_number, status, purchase_date, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		a.AssetTag, a.Kind, a.Name, a.SerialNumber, a.Status, a.PurchaseDate, a.Note,
+	).Scan(&a.ID, &a.CreatedAt, &a.UpdatedAt)
+}
+
+func (r *assetPostgresRepository) FindByID(ctx context.Context, id int64) (*models.Asset, error) {
+	return scanAsset(conn(ctx, r.db).QueryRowContext(ctx, assetSelect+` WHERE a.id = $1`, id))
+}
+
+func (r *assetPostgresRepository) Update(ctx context.Context, a *models.Asset) error {
+	query := `
+		UPDATE assets
+		SET asset_tag = $1, kind = $2, name = $3, serial_number = $4, status = $5,
+			purchase_date = $6, note = $7, updated_at = now()
+		WHERE id = $8
+		RETURNING updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		a.AssetTag, a.Kind, a.Name, a.SerialNumber, a.Status, a.PurchaseDate, a.Note, a.ID,
+	).Scan(&a.UpdatedAt)
+}
+
+// List returns the assets by tag, filtered by kind and status when given and
+// by keyword on tag, name or serial number.
+func (r *assetPostgresRepository) List(ctx context.Context, kind, status, keyword string) ([]*models.Asset, error) {
+	query := assetSelect + `
+		WHERE ($1 = '' OR a.kind = $1)
+		  AND ($2 = '' OR a.status = $2)
+		  AND ($3 = '' OR a.asset_tag ILIKE '%' || $3 || '%' OR a.name ILIKE '%' || $3 || '%' OR a.serial_number ILIKE '%' || $3 || '%')
+		ORDER BY a.asset_tag
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, kind, status, keyword)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.Asset
+	for rows.Next() {
+		a, err := scanAsset(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, a)
+	}
+	return res, rows.Err()
+}
+
+// Assign records the hand-over and marks the asset assigned in one
+// transaction. It returns sql.ErrNoRows if the asset is no longer
+// available.
+func (r *assetPostgresRepository) Assign(ctx context.Context, as *models.AssetAssignment) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	if err := tx.QueryRowContext(ctx,
+		`UPDATE assets SET status = 'assigned', updated_at = now() WHERE id = $1 AND status = 'available' RETURNING id`,
+		as.AssetID,
+	).Scan(&id); err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO asset_assignments (asset_id, employee_id, assigned_on, condition_out, note)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	if err := tx.QueryRowContext(ctx, query,
+		as.AssetID, as.EmployeeID, as.AssignedOn, as.ConditionOut, as.Note,
+	).Scan(&as.ID, &as.CreatedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Return closes the open assignment and sets the asset's status, available
+// again or retired or lost, in one transaction. It returns sql.ErrNoRows if
+// the assignment was already closed.
+func (r *assetPostgresRepository) Return(ctx context.Context, as *models.AssetAssignment, status string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	if err := tx.QueryRowContext(ctx, `
+		UPDATE asset_assignments SET returned_on = $1, condition_in = $2, note = $3
+		WHERE id = $4 AND returned_on IS NULL
+		RETURNING id
+	`, as.ReturnedOn, as.ConditionIn, as.Note, as.ID).Scan(&id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE assets SET status = $1, updated_at = now() WHERE id = $2`, status, as.AssetID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+const assetAssignmentSelect = `
+	SELECT aa.id, aa.asset_id, aa.employee_id, aa.assigned_on, aa.returned_on, aa.condition_out,
+		aa.condition_in, aa.note, aa.created_at, a.asset_tag, a.kind, a.name, a.serial_number, e.name
+	FROM asset_assignments aa
+	JOIN assets a ON a.id = aa.asset_id
+	JOIN employees e ON e.id = aa.employee_id
+`
+
+func scanAssetAssignment(row rowScanner) (*models.AssetAssignment, error) {
+	var as models.AssetAssignment
+	if err := row.Scan(
+		&as.ID, &as.AssetID, &as.EmployeeID, &as.AssignedOn, &as.ReturnedOn, &as.ConditionOut,
+		&as.ConditionIn, &as.Note, &as.CreatedAt, &as.AssetTag, &as.AssetKind, &as.AssetName, &as.SerialNumber, &as.EmployeeName,
+	); err != nil {
+		return nil, err
+	}
+	return &as, nil
+}
+
+func (r *assetPostgresRepository) queryAssignments(ctx context.Context, query string, args ...interface{}) ([]*models.AssetAssignment, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.AssetAssignment
+	for rows.Next() {
+		as, err := scanAssetAssignment(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, as)
+	}
+	return res, rows.Err()
+}
+
+func (r *assetPostgresRepository) FindOpenAssignment(ctx context.Context, assetID int64) (*models.AssetAssignment, error) {
+	return scanAssetAssignment(conn(ctx, r.db).QueryRowContext(ctx, assetAssignmentSelect+` WHERE aa.asset_id = $1 AND aa.returned_on IS NULL`, assetID))
+}
+
+func (r *assetPostgresRepository) ListAssignmentsByAsset(ctx context.Context, assetID int64) ([]*models.AssetAssignment, error) {
+	return r.queryAssignments(ctx, assetAssignmentSelect+` WHERE aa.asset_id = $1 ORDER BY aa.assigned_on, aa.id`, assetID)
+}
+
+func (r *assetPostgresRepository) ListAssignmentsByEmployee(ctx context.Context, employeeID int64, openOnly bool) ([]*models.AssetAssignment, error) {
+	return r.queryAssignments(ctx, assetAssignmentSelect+`
+		WHERE aa.employee_id = $1 AND ($2 = false OR aa.returned_on IS NULL)
+		ORDER BY aa.assigned_on, aa.id
+	`, employeeID, openOnly)
+}
@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	"app/internal/models"
+)
+
+// ErrAlreadyClockedIn is returned by Create when the employee already has
+// an open record, e.g. when two clock-ins race past the service check.
+var ErrAlreadyClockedIn = errors.New("already clocked in")
+
+type attendancePostgresRepository struct {
+	db *sql.DB
+}
+
+func NewAttendanceRepository(db *sql.DB) AttendanceRepository {
+	return &attendancePostgresRepository{db: db}
+}
+
+type AttendanceRepository interface {
+	Create(ctx context.Context, a *models.AttendanceRecord) error
+	FindByID(ctx context.Context, id int64) (*models.AttendanceRecord, error)
+	FindOpen(ctx context.Context, employeeID int64) (*models.AttendanceRecord, error)
+	Close(ctx context.Context, a *models.AttendanceRecord) error
+	Delete(ctx context.Context, id int64) error
+	ListByEmployee(ctx context.Context, employeeID int64, from, to time.Time) ([]*models.AttendanceRecord, error)
+	ListByDepartment(ctx context.Context, departmentID int64, from, to time.Time) ([]*models.AttendanceRecord, error)
+	CountOverlapping(ctx context.Context, employeeID int64, clockIn, clockOut time.Time) (int, error)
+}
+
+const attendanceColumns = `a.id, a.employee_id, a.work_date, a.clock_in, a.clock_out, a.source, a.shift_id, a.note, a.created_at, a.updated_at`
+
+func scanAttendance(row rowScanner) (*models.AttendanceRecord, error) {
+	var a models.AttendanceRecord
+	if err := row.Scan(&a.ID, &a.EmployeeID, &a.WorkDate, &a.ClockIn, &a.ClockOut, &a.Source, &a.ShiftID, &a.Note, &a.CreatedAt, &a.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *attendancePostgresRepository) queryAttendance(ctx context.Context, query string, args ...interface{}) ([]*models.AttendanceRecord, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.AttendanceRecord
+	for rows.Next() {
+		a, err := scanAttendance(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, a)
+	}
+	return res, rows.Err()
+}
+
+func (r *attendancePostgresRepository) Create(ctx context.Context, a *models.AttendanceRecord) error {
+	query := `
+		INSERT INTO attendance_records (employee_id, work_date, clock_in, clock_out, source, shift_id, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`
+	err := conn(ctx, r.db).QueryRowContext(ctx, query,
+		a.EmployeeID, a.WorkDate, a.ClockIn, a.ClockOut, a.Source, a.ShiftID, a.Note,
+	).Scan(&a.ID, &a.CreatedAt, &a.UpdatedAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "uq_attendance_open" {
+		return ErrAlreadyClockedIn
+	}
+	return err
+}
+
+func (r *attendancePostgresRepository) FindByID(ctx context.Context, id int64) (*models.AttendanceRecord, error) {
+	return scanAttendance(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+attendanceColumns+` FROM attendance_records a WHERE a.id = $1`, id))
+}
+
+func (r *attendancePostgresRepository) FindOpen(ctx context.Context, employeeID int64) (*models.AttendanceRecord, error) {
+	query := `SELECT ` + attendanceColumns + ` FROM attendance_records a WHERE a.employee_id = $1 AND a.clock_out IS NULL`
+	return scanAttendance(conn(ctx, r.db).QueryRowContext(ctx, query, employeeID))
+}
+
+// Close sets the clock-out of a record that is still open.
+func (r *attendancePostgresRepository) Close(ctx context.Context, a *models.AttendanceRecord) error {
+	query := `
+		UPDATE attendance_records
+		SET clock_out = $1, updated_at = now()
+		WHERE id = $2 AND clock_out IS NULL
+		RETURNING updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query, a.ClockOut, a.ID).Scan(&a.UpdatedAt)
+}
+
+func (r *attendancePostgresRepository) Delete(ctx context.Context, id int64) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM attendance_records WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *attendancePostgresRepository) ListByEmployee(ctx context.Context, employeeID int64, from, to time.Time) ([]*models.AttendanceRecord, error) {
+	query := `
+		SELECT ` + attendanceColumns + `
+		FROM attendance_records a
+		WHERE a.employee_id = $1 AND a.work_date BETWEEN $2 AND $3
+		ORDER BY a.clock_in
+	`
+	return r.queryAttendance(ctx, query, employeeID, from, to)
+}
+
+func (r *attendancePostgresRepository) ListByDepartment(ctx context.Context, departmentID int64, from, to time.Time) ([]*models.AttendanceRecord, error) {
+	query := `
+		SELECT ` + attendanceColumns + `
+		FROM attendance_records a
+		JOIN employees e ON e.id = a.employee_id
+		WHERE e.department_id = $1 AND a.work_date BETWEEN $2 AND $3
+		ORDER BY a.employee_id, a.clock_in
+	`
+	return r.queryAttendance(ctx, query, departmentID, from, to)
+}
+
+// CountOverlapping counts the employee's records sharing time with
+// [clockIn, clockOut); an open record counts as running until now.
+func (r *attendancePostgresRepository) CountOverlapping(ctx context.Context, employeeID int64, clockIn, clockOut time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM attendance_records
+		WHERE employee_id = $1
+		  AND clock_in < $3
+		  AND COALESCE(clock_out, now()) > $2
+	`
+	var n int
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, employeeID, clockIn, clockOut).Scan(&n)
+	return n, err
+}
@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"app/internal/models"
+)
+
+type calendarPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewCalendarRepository(db *sql.DB) CalendarRepository {
+	return &calendarPostgresRepository{db: db}
+}
+
+type CalendarRepository interface {
+	Create(ctx context.Context, c *models.WorkCalendar) error
+	FindByID(ctx context.Context, id int64) (*models.WorkCalendar, error)
+	FindDefault(ctx context.Context) (*models.WorkCalendar, error)
+	List(ctx context.Context) ([]*models.WorkCalendar, error)
+	Update(ctx context.Context, c *models.WorkCalendar) error
+	UpsertDays(ctx context.Context, days []*models.CalendarDay) error
+	DeleteDay(ctx context.Context, calendarID int64, date time.Time) error
+	ListDays(ctx context.Context, calendarID int64, from, to time.Time) ([]*models.CalendarDay, error)
+}
+
+const workCalendarColumns = `id, name, country, weekend_days, is_default, created_at, updated_at`
+
+func scanWorkCalendar(row rowScanner) (*models.WorkCalendar, error) {
+	var c models.WorkCalendar
+	var weekend []int64
+	if err := row.Scan(&c.ID, &c.Name, &c.Country, pq.Array(&weekend), &c.IsDefault, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, err
+	}
+	for _, d := range weekend {
+		c.WeekendDays = append(c.WeekendDays, time.Weekday(d))
+	}
+	return &c, nil
+}
+
+func weekendArray(days []time.Weekday) interface{} {
+	out := make([]int64, 0, len(days))
+	for _, d := range days {
+		out = append(out, int64(d))
+	}
+	return pq.Array(out)
+}
+
+// Create inserts the calendar. Making it the default unsets the previous
+// default in the same transaction.
+func (r *calendarPostgresRepository) Create(ctx context.Context, c *models.WorkCalendar) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if c.IsDefault {
+		if _, err := tx.ExecContext(ctx, `UPDATE work_calendars SET is_default = false, updated_at = now() WHERE is_default`); err != nil {
+			return err
+		}
+	}
+	query := `
+		INSERT INTO work_calendars (name, country, weekend_days, is_default)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`
+	if err := tx.QueryRowContext(ctx, query, c.Name, c.Country, weekendArray(c.WeekendDays), c.IsDefault).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *calendarPostgresRepository) FindByID(ctx context.Context, id int64) (*models.WorkCalendar, error) {
+	return scanWorkCalendar(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+workCalendarColumns+` FROM work_calendars WHERE id = $1`, id))
+}
+
+func (r *calendarPostgresRepository) FindDefault(ctx context.Context) (*models.WorkCalendar, error) {
+	return scanWorkCalendar(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+workCalendarColumns+` FROM work_calendars WHERE is_default`))
+}
+
+func (r *calendarPostgresRepository) List(ctx context.Context) ([]*models.WorkCalendar, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT `+workCalendarColumns+` FROM work_calendars ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.WorkCalendar
+	for rows.Next() {
+		c, err := scanWorkCalendar(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, c)
+	}
+	return res, rows.Err()
+}
+
+func (r *calendarPostgresRepository) Update(ctx context.Context, c *models.WorkCalendar) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if c.IsDefault {
+		if _, err := tx.ExecContext(ctx, `UPDATE work_calendars SET is_default = false, updated_at = now() WHERE is_default AND id <> $1`, c.ID); err != nil {
+			return err
+		}
+	}
+	query := `
+		UPDATE work_calendars
+		SET name = $1, country = $2, weekend_days = $3, is_default = $4, updated_at = now()
+		WHERE id = $5
+		RETURNING updated_at
+	`
+	if err := tx.QueryRowContext(ctx, query, c.Name, c.Country, weekendArray(c.WeekendDays), c.IsDefault, c.ID).Scan(&c.UpdatedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpsertDays writes the days in one transaction, replacing any existing
+// entry for the same calendar and date.
+func (r *calendarPostgresRepository) UpsertDays(ctx context.Context, days []*models.CalendarDay) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO calendar_days (calendar_id, date, kind, name)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (calendar_id, date)
+		DO UPDATE SET kind = EXCLUDED.kind, name = EXCLUDED.name
+		RETURNING id
+	`
+	for _, d := range days {
+		if err := tx.QueryRowContext(ctx, query, d.CalendarID, d.Date, d.Kind, d.Name).Scan(&d.ID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *calendarPostgresRepository) DeleteDay(ctx context.Context, calendarID int64, date time.Time) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM calendar_days WHERE calendar_id = $1 AND date = $2`, calendarID, date)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *calendarPostgresRepository) ListDays(ctx context.Context, calendarID int64, from, to time.Time) ([]*models.CalendarDay, error) {
+	query := `
+		SELECT id, calendar_id, date, kind, name
+		FROM calendar_days
+		WHERE calendar_id = $1 AND date BETWEEN $2 AND $3
+		ORDER BY date
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, calendarID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.CalendarDay
+	for rows.Next() {
+		var d models.CalendarDay
+		if err := rows.Scan(&d.ID, &d.CalendarID, &d.Date, &d.Kind, &d.Name); err != nil {
+			return nil, err
+		}
+		res = append(res, &d)
+	}
+	return res, rows.Err()
+}
@@ -0,0 +1,395 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"app/internal/models"
+)
+
+type checklistPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewChecklistRepository(db *sql.DB) ChecklistRepository {
+	return &checklistPostgresRepository{db: db}
+}
+
+type ChecklistRepository interface {
+	CreateTemplate(ctx context.Context, t *models.ChecklistTemplate) error
+	UpdateTemplate(ctx context.Context, t *models.ChecklistTemplate) error
+	DeleteTemplate(ctx context.Context, id int64) error
+	FindTemplateByID(ctx context.Context, id int64) (*models.ChecklistTemplate, error)
+	FindTemplateFor(ctx context.Context, departmentID int64, kind string) (*models.ChecklistTemplate, error)
+	ListTemplates(ctx context.Context, departmentID *int64, kind string) ([]*models.ChecklistTemplate, error)
+
+	Create(ctx context.Context, c *models.Checklist) error
+	ListByEmployee(ctx context.Context, employeeID int64) ([]*models.Checklist, error)
+	ListOpen(ctx context.Context, kind string, departmentID *int64, today time.Time) ([]*models.ChecklistSummary, error)
+	FindTask(ctx context.Context, id int64) (*models.ChecklistTask, error)
+	CompleteTask(ctx context.Context, t *models.ChecklistTask) error
+	ListOverdue(ctx context.Context, today time.Time, assigneeID, departmentID *int64) ([]*models.OverdueTask, error)
+}
+
+const checklistTemplateColumns = `id, department_id, kind, name, created_at, updated_at`
+
+func scanChecklistTemplate(row rowScanner) (*models.ChecklistTemplate, error) {
+	var t models.ChecklistTemplate
+	if err := row.Scan(&t.ID, &t.DepartmentID, &t.Kind, &t.Name, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func insertTemplateTasks(ctx context.Context, tx querier, t *models.ChecklistTemplate) error {
+	query := `
+		INSERT INTO checklist_template_tasks (template_id, position, title, assignee_role, assignee_id, due_offset_days)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+	for i, task := range t.Tasks {
+		task.TemplateID = t.ID
+		task.Position = i + 1
+		if err := tx.QueryRowContext(ctx, query,
+			task.TemplateID, task.Position, task.Title, task.AssigneeRole, task.AssigneeID, task.DueOffsetDays,
+		).Scan(&task.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateTemplate writes the template and its tasks in one transaction; the
+// tasks are numbered in slice order.
+func (r *checklistPostgresRepository) CreateTemplate(ctx context.Context, t *models.ChecklistTemplate) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO checklist_templates (department_id, kind, name)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`
+	if err := tx.QueryRowContext(ctx, query, t.DepartmentID, t.Kind, t.Name).Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return err
+	}
+	if err := insertTemplateTasks(ctx, tx, t); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateTemplate renames the template and replaces its tasks. Checklists
+// already created from it keep their own copy of the tasks.
+func (r *checklistPostgresRepository) UpdateTemplate(ctx context.Context, t *models.ChecklistTemplate) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE checklist_templates SET name = $1, updated_at = now() WHERE id = $2 RETURNING updated_at`
+	if err := tx.QueryRowContext(ctx, query, t.Name, t.ID).Scan(&t.UpdatedAt); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM checklist_template_tasks WHERE template_id = $1`, t.ID); err != nil {
+		return err
+	}
+	if err := insertTemplateTasks(ctx, tx, t); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *checklistPostgresRepository) DeleteTemplate(ctx context.Context, id int64) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM checklist_templates WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *checklistPostgresRepository) loadTemplateTasks(ctx context.Context, t *models.ChecklistTemplate) error {
+	query := `
+		SELECT id, template_id, position, title, assignee_role, assignee_id, due_offset_days
+		FROM checklist_template_tasks
+		WHERE template_id = $1
+		ORDER BY position
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, t.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	t.Tasks = nil
+	for rows.Next() {
+		var task models.ChecklistTemplateTask
+		if err := rows.Scan(&task.ID, &task.TemplateID, &task.Position, &task.Title, &task.AssigneeRole, &task.AssigneeID, &task.DueOffsetDays); err != nil {
+			return err
+		}
+		t.Tasks = append(t.Tasks, &task)
+	}
+	return rows.Err()
+}
+
+func (r *checklistPostgresRepository) FindTemplateByID(ctx context.Context, id int64) (*models.ChecklistTemplate, error) {
+	t, err := scanChecklistTemplate(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+checklistTemplateColumns+` FROM checklist_templates WHERE id = $1`, id))
+	if err != nil {
+		return nil, err
+	}
+	return t, r.loadTemplateTasks(ctx, t)
+}
+
+// FindTemplateFor returns the department's template of kind, falling back
+// to the company-wide one. It returns sql.ErrNoRows when neither exists.
+func (r *checklistPostgresRepository) FindTemplateFor(ctx context.Context, departmentID int64, kind string) (*models.ChecklistTemplate, error) {
+	query := `
+		SELECT ` + checklistTemplateColumns + `
+		FROM checklist_templates
+		WHERE kind = $2 AND (department_id = $1 OR department_id IS NULL)
+		ORDER BY department_id NULLS LAST
+		LIMIT 1
+	`
+	t, err := scanChecklistTemplate(conn(ctx, r.db).QueryRowContext(ctx, query, departmentID, kind))
+	if err != nil {
+		return nil, err
+	}
+	return t, r.loadTemplateTasks(ctx, t)
+}
+
+func (r *checklistPostgresRepository) ListTemplates(ctx context.Context, departmentID *int64, kind string) ([]*models.ChecklistTemplate, error) {
+	query := `
+		SELECT ` + checklistTemplateColumns + `
+		FROM checklist_templates
+		WHERE ($1::BIGINT IS NULL OR department_id = $1) AND ($2 = '' OR kind = $2)
+		ORDER BY department_id NULLS FIRST, kind
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, departmentID, kind)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.ChecklistTemplate
+	for rows.Next() {
+		t, err := scanChecklistTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, t := range res {
+		if err := r.loadTemplateTasks(ctx, t); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// Create writes the checklist and its tasks in one transaction.
+func (r *checklistPostgresRepository) Create(ctx context.Context, c *models.Checklist) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO checklists (employee_id, template_id, kind, anchor_date)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	if err := tx.QueryRowContext(ctx, query, c.EmployeeID, c.TemplateID, c.Kind, c.AnchorDate).Scan(&c.ID, &c.CreatedAt); err != nil {
+		return err
+	}
+	taskQuery := `
+		INSERT INTO checklist_tasks (checklist_id, position, title, assignee_id, due_date)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+	for _, t := range c.Tasks {
+		t.ChecklistID = c.ID
+		if err := tx.QueryRowContext(ctx, taskQuery, t.ChecklistID, t.Position, t.Title, t.AssigneeID, t.DueDate).Scan(&t.ID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+const checklistTaskColumns = `t.id, t.checklist_id, t.position, t.title, t.assignee_id, t.due_date, t.completed_at, t.completed_by, t.note`
+
+func scanChecklistTask(row rowScanner, extra ...interface{}) (*models.ChecklistTask, error) {
+	var t models.ChecklistTask
+	dest := []interface{}{&t.ID, &t.ChecklistID, &t.Position, &t.Title, &t.AssigneeID, &t.DueDate, &t.CompletedAt, &t.CompletedBy, &t.Note}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *checklistPostgresRepository) ListByEmployee(ctx context.Context, employeeID int64) ([]*models.Checklist, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT id, employee_id, template_id, kind, anchor_date, created_at, completed_at
+		FROM checklists
+		WHERE employee_id = $1
+		ORDER BY anchor_date, id
+	`, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.Checklist
+	byID := map[int64]*models.Checklist{}
+	for rows.Next() {
+		var c models.Checklist
+		if err := rows.Scan(&c.ID, &c.EmployeeID, &c.TemplateID, &c.Kind, &c.AnchorDate, &c.CreatedAt, &c.CompletedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, &c)
+		byID[c.ID] = &c
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	taskRows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+checklistTaskColumns+`
+		FROM checklist_tasks t
+		JOIN checklists c ON c.id = t.checklist_id
+		WHERE c.employee_id = $1
+		ORDER BY t.checklist_id, t.position
+	`, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer taskRows.Close()
+
+	for taskRows.Next() {
+		t, err := scanChecklistTask(taskRows)
+		if err != nil {
+			return nil, err
+		}
+		if c := byID[t.ChecklistID]; c != nil {
+			c.Tasks = append(c.Tasks, t)
+		}
+	}
+	return res, taskRows.Err()
+}
+
+// ListOpen returns the checklists with tasks left, with how many are done
+// and how many are past due on today, oldest anchor date first.
+func (r *checklistPostgresRepository) ListOpen(ctx context.Context, kind string, departmentID *int64, today time.Time) ([]*models.ChecklistSummary, error) {
+	query := `
+		SELECT c.id, c.employee_id, c.template_id, c.kind, c.anchor_date, c.created_at, c.completed_at,
+			e.name, e.department_id,
+			COUNT(t.id),
+			COUNT(t.completed_at),
+			COUNT(t.id) FILTER (WHERE t.completed_at IS NULL AND t.due_date < $3)
+		FROM checklists c
+		JOIN employees e ON e.id = c.employee_id
+		LEFT JOIN checklist_tasks t ON t.checklist_id = c.id
+		WHERE c.completed_at IS NULL
+		  AND ($1 = '' OR c.kind = $1)
+		  AND ($2::BIGINT IS NULL OR e.department_id = $2)
+		GROUP BY c.id, e.name, e.department_id
+		ORDER BY c.anchor_date, c.id
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, kind, departmentID, today)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.ChecklistSummary
+	for rows.Next() {
+		var s models.ChecklistSummary
+		if err := rows.Scan(
+			&s.ID, &s.EmployeeID, &s.TemplateID, &s.Kind, &s.AnchorDate, &s.CreatedAt, &s.CompletedAt,
+			&s.EmployeeName, &s.DepartmentID,
+			&s.TotalTasks, &s.DoneTasks, &s.OverdueTasks,
+		); err != nil {
+			return nil, err
+		}
+		res = append(res, &s)
+	}
+	return res, rows.Err()
+}
+
+func (r *checklistPostgresRepository) FindTask(ctx context.Context, id int64) (*models.ChecklistTask, error) {
+	return scanChecklistTask(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+checklistTaskColumns+` FROM checklist_tasks t WHERE t.id = $1`, id))
+}
+
+// CompleteTask marks the task done and, when it was the last open one, the
+// checklist too. It returns sql.ErrNoRows if the task was already done.
+func (r *checklistPostgresRepository) CompleteTask(ctx context.Context, t *models.ChecklistTask) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE checklist_tasks SET completed_at = now(), completed_by = $1, note = $2
+		WHERE id = $3 AND completed_at IS NULL
+		RETURNING completed_at
+	`
+	if err := tx.QueryRowContext(ctx, query, t.CompletedBy, t.Note, t.ID).Scan(&t.CompletedAt); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE checklists SET completed_at = now()
+		WHERE id = $1 AND completed_at IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM checklist_tasks WHERE checklist_id = $1 AND completed_at IS NULL)
+	`, t.ChecklistID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListOverdue returns the open tasks due before today, optionally only
+// those of one assignee or of employees in one department, oldest first.
+func (r *checklistPostgresRepository) ListOverdue(ctx context.Context, today time.Time, assigneeID, departmentID *int64) ([]*models.OverdueTask, error) {
+	query := `
+		SELECT ` + checklistTaskColumns + `, c.kind, c.employee_id, e.name
+		FROM checklist_tasks t
+		JOIN checklists c ON c.id = t.checklist_id
+		JOIN employees e ON e.id = c.employee_id
+		WHERE t.completed_at IS NULL AND t.due_date < $1
+		  AND ($2::BIGINT IS NULL OR t.assignee_id = $2)
+		  AND ($3::BIGINT IS NULL OR e.department_id = $3)
+		ORDER BY t.due_date, t.id
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, today, assigneeID, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.OverdueTask
+	for rows.Next() {
+		var o models.OverdueTask
+		t, err := scanChecklistTask(rows, &o.Kind, &o.EmployeeID, &o.EmployeeName)
+		if err != nil {
+			return nil, err
+		}
+		o.ChecklistTask = *t
+		res = append(res, &o)
+	}
+	return res, rows.Err()
+}
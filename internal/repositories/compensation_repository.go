@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"app/internal/models"
+)
+
+type compensationPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewCompensationRepository(db *sql.DB) CompensationRepository {
+	return &compensationPostgresRepository{db: db}
+}
+
+type CompensationRepository interface {
+	Create(ctx context.Context, c *models.Compensation) error
+	ListByEmployee(ctx context.Context, employeeID int64) ([]*models.Compensation, error)
+	FindEffective(ctx context.Context, employeeID int64, on time.Time) (*models.Compensation, error)
+}
+
+func (r *compensationPostgresRepository) Create(ctx context.Context, c *models.Compensation) error {
+	query := `
+		INSERT INTO employee_compensations (employee_id, effective_date, amount, currency, reason, approved_by, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	return conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		c.EmployeeID,
+		c.EffectiveDate,
+		c.Amount,
+		c.Currency,
+		c.Reason,
+		c.ApprovedBy,
+		c.Note,
+	).Scan(&c.ID, &c.CreatedAt)
+}
+
+// ListByEmployee returns every entry, scheduled ones included, newest effective date first.
+func (r *compensationPostgresRepository) ListByEmployee(ctx context.Context, employeeID int64) ([]*models.Compensation, error) {
+	query := `
+		SELECT id, employee_id, effective_date, amount, currency, reason, approved_by, note, created_at
+		FROM employee_compensations
+		WHERE employee_id = $1
+		ORDER BY effective_date DESC, id DESC
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.Compensation
+	for rows.Next() {
+		var c models.Compensation
+		if err := rows.Scan(&c.ID, &c.EmployeeID, &c.EffectiveDate, &c.Amount, &c.Currency, &c.Reason, &c.ApprovedBy, &c.Note, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// FindEffective returns the entry in effect on the given day.
+func (r *compensationPostgresRepository) FindEffective(ctx context.Context, employeeID int64, on time.Time) (*models.Compensation, error) {
+	query := `
+		SELECT id, employee_id, effective_date, amount, currency, reason, approved_by, note, created_at
+		FROM employee_compensations
+		WHERE employee_id = $1 AND effective_date <= $2
+		ORDER BY effective_date DESC, id DESC
+		LIMIT 1
+	`
+	var c models.Compensation
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, employeeID, on).Scan(&c.ID, &c.EmployeeID, &c.EffectiveDate, &c.Amount, &c.Currency, &c.Reason, &c.ApprovedBy, &c.Note, &c.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"app/internal/models"
+)
+
+type contractPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewContractRepository(db *sql.DB) ContractRepository {
+	return &contractPostgresRepository{db: db}
+}
+
+type ContractRepository interface {
+	Create(ctx context.Context, c *models.Contract) error
+	FindByID(ctx context.Context, id int64) (*models.Contract, error)
+	Update(ctx context.Context, c *models.Contract) error
+	Delete(ctx context.Context, id int64) error
+	ListByEmployee(ctx context.Context, employeeID int64) ([]*models.Contract, error)
+	ListExpiring(ctx context.Context, from, to time.Time) ([]*models.ContractExpiry, error)
+}
+
+const contractColumns = `
+	c.id, c.employee_id, c.contract_number, c.contract_type, c.start_date, c.end_date,
+	c.signed_date, c.document_ref, c.note, c.created_at, c.updated_at
+`
+
+func scanContract(row rowScanner, extra ...interface{}) (*models.Contract, error) {
+	var c models.Contract
+	dest := []interface{}{
+		&c.ID, &c.EmployeeID, &c.ContractNumber, &c.Type, &c.StartDate, &c.EndDate,
+		&c.SignedDate, &c.DocumentRef, &c.Note, &c.CreatedAt, &c.UpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *contractPostgresRepository) Create(ctx context.Context, c *models.Contract) error {
+	query := `
+		INSERT INTO employment_contracts (
+			employee_id, contract_number, contract_type, start_date, end_date, signed_date, document_ref, note
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		c.EmployeeID, c.ContractNumber, c.Type, c.StartDate, c.EndDate, c.SignedDate, c.DocumentRef, c.Note,
+	).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+}
+
+func (r *contractPostgresRepository) FindByID(ctx context.Context, id int64) (*models.Contract, error) {
+	query := `SELECT ` + contractColumns + ` FROM employment_contracts c WHERE c.id = $1`
+	return scanContract(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+}
+
+func (r *contractPostgresRepository) Update(ctx context.Context, c *models.Contract) error {
+	query := `
+		UPDATE employment_contracts
+		SET contract_number = $1, contract_type = $2, start_date = $3, end_date = $4,
+			signed_date = $5, document_ref = $6, note = $7, updated_at = now()
+		WHERE id = $8
+		RETURNING updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		c.ContractNumber, c.Type, c.StartDate, c.EndDate, c.SignedDate, c.DocumentRef, c.Note, c.ID,
+	).Scan(&c.UpdatedAt)
+}
+
+func (r *contractPostgresRepository) Delete(ctx context.Context, id int64) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM employment_contracts WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *contractPostgresRepository) ListByEmployee(ctx context.Context, employeeID int64) ([]*models.Contract, error) {
+	query := `SELECT ` + contractColumns + ` FROM employment_contracts c WHERE c.employee_id = $1 ORDER BY c.start_date, c.id`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.Contract
+	for rows.Next() {
+		c, err := scanContract(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, c)
+	}
+	return res, rows.Err()
+}
+
+// ListExpiring returns the contracts ending between from and to, soonest
+// first. Contracts already followed by a later one, and those of employees
+// who have left, are skipped.
+func (r *contractPostgresRepository) ListExpiring(ctx context.Context, from, to time.Time) ([]*models.ContractExpiry, error) {
+	query := `
+		SELECT ` + contractColumns + `, e.name, e.department_id
+		FROM employment_contracts c
+		JOIN employees e ON e.id = c.employee_id
+		WHERE c.end_date BETWEEN $1 AND $2
+		  AND e.status <> 'terminated'
+		  AND NOT EXISTS (
+			SELECT 1 FROM employment_contracts n
+			WHERE n.employee_id = c.employee_id AND n.start_date > c.start_date
+		  )
+		ORDER BY c.end_date, e.name
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.ContractExpiry
+	for rows.Next() {
+		var x models.ContractExpiry
+		c, err := scanContract(rows, &x.EmployeeName, &x.DepartmentID)
+		if err != nil {
+			return nil, err
+		}
+		x.Contract = *c
+		res = append(res, &x)
+	}
+	return res, rows.Err()
+}
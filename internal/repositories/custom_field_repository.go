@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+
+	"app/internal/models"
+)
+
+type customFieldPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewCustomFieldRepository(db *sql.DB) CustomFieldRepository {
+	return &customFieldPostgresRepository{db: db}
+}
+
+type CustomFieldRepository interface {
+	Create(ctx context.Context, d *models.CustomFieldDefinition) error
+	FindByID(ctx context.Context, id int64) (*models.CustomFieldDefinition, error)
+	List(ctx context.Context) ([]*models.CustomFieldDefinition, error)
+	Update(ctx context.Context, d *models.CustomFieldDefinition) error
+	Delete(ctx context.Context, id int64) error
+}
+
+const customFieldColumns = `id, field_key, label, type, options, required, created_at, updated_at`
+
+func scanCustomField(row rowScanner) (*models.CustomFieldDefinition, error) {
+	var d models.CustomFieldDefinition
+	if err := row.Scan(&d.ID, &d.Key, &d.Label, &d.Type, pq.Array(&d.Options), &d.Required, &d.CreatedAt, &d.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *customFieldPostgresRepository) Create(ctx context.Context, d *models.CustomFieldDefinition) error {
+	query := `
+		INSERT INTO custom_field_definitions (field_key, label, type, options, required)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query, d.Key, d.Label, d.Type, pq.Array(d.Options), d.Required).
+		Scan(&d.ID, &d.CreatedAt, &d.UpdatedAt)
+}
+
+func (r *customFieldPostgresRepository) FindByID(ctx context.Context, id int64) (*models.CustomFieldDefinition, error) {
+	return scanCustomField(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+customFieldColumns+` FROM custom_field_definitions WHERE id = $1`, id))
+}
+
+func (r *customFieldPostgresRepository) List(ctx context.Context) ([]*models.CustomFieldDefinition, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT `+customFieldColumns+` FROM custom_field_definitions ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.CustomFieldDefinition
+	for rows.Next() {
+		d, err := scanCustomField(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, d)
+	}
+	return res, rows.Err()
+}
+
+// Update changes the label, options and required flag; the key and type
+// are fixed once values may have been stored under them.
+func (r *customFieldPostgresRepository) Update(ctx context.Context, d *models.CustomFieldDefinition) error {
+	query := `
+		UPDATE custom_field_definitions
+		SET label = $1, options = $2, required = $3, updated_at = now()
+		WHERE id = $4
+		RETURNING updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query, d.Label, pq.Array(d.Options), d.Required, d.ID).Scan(&d.UpdatedAt)
+}
+
+// Delete removes the definition and its values from every employee.
+func (r *customFieldPostgresRepository) Delete(ctx context.Context, id int64) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var key string
+	if err := tx.QueryRowContext(ctx, `DELETE FROM custom_field_definitions WHERE id = $1 RETURNING field_key`, id).Scan(&key); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE employees SET custom_fields = custom_fields - $1::text WHERE custom_fields ? $1::text`, key); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
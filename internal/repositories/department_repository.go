@@ -20,6 +20,12 @@ type DepartmentRepository interface {
 	Create(ctx context.Context, d *models.Department) error
 	FindByID(ctx context.Context, id int64) (*models.Department, error)
 	FindAll(ctx context.Context, limit, offset int) ([]*models.Department, int64, error)
+	SetHead(ctx context.Context, id int64, employeeID *int64) error
+	SetCalendar(ctx context.Context, id int64, calendarID *int64) error
+	SetDefaultShift(ctx context.Context, id int64, shiftID *int64) error
+	ListPlans(ctx context.Context, id int64) ([]*models.DepartmentPlan, error)
+	SavePlan(ctx context.Context, p *models.DepartmentPlan) error
+	DeletePlan(ctx context.Context, id int64, fiscalYear int) error
 }
 
 func (r *departmentPostgresRepository) Create(ctx context.Context, d *models.Department) error {
@@ -28,18 +34,18 @@ func (r *departmentPostgresRepository) Create(ctx context.Context, d *models.Dep
 		VALUES ($1)
 		RETURNING id
 	`
-	return r.db.QueryRowContext(ctx, query, d.Name).Scan(&d.ID)
+	return conn(ctx, r.db).QueryRowContext(ctx, query, d.Name).Scan(&d.ID)
 }
 
 func (r *departmentPostgresRepository) FindByID(ctx context.Context, id int64) (*models.Department, error) {
 	query := `
-		SELECT id, name
+		SELECT id, name, head_employee_id, calendar_id, default_shift_id
 		FROM departments
 		WHERE id = $1
 	`
 
 	var d models.Department
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&d.ID, &d.Name)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(&d.ID, &d.Name, &d.HeadEmployeeID, &d.CalendarID, &d.DefaultShiftID)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, err
 	}
@@ -49,12 +55,12 @@ func (r *departmentPostgresRepository) FindByID(ctx context.Context, id int64) (
 
 func (r *departmentPostgresRepository) FindAll(ctx context.Context, limit, offset int) ([]*models.Department, int64, error) {
 	var total int64
-	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM departments`).Scan(&total); err != nil {
+	if err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM departments`).Scan(&total); err != nil {
 		return nil, 0, err
 	}
 
-	query := `SELECT id, name, created_at, updated_at FROM departments ORDER BY id LIMIT $1 OFFSET $2`
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	query := `SELECT id, name, head_employee_id, calendar_id, default_shift_id, created_at, updated_at FROM departments ORDER BY id LIMIT $1 OFFSET $2`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -63,7 +69,7 @@ func (r *departmentPostgresRepository) FindAll(ctx context.Context, limit, offse
 	var departments []*models.Department
 	for rows.Next() {
 		var d models.Department
-		if err := rows.Scan(&d.ID, &d.Name, &d.CreatedAt, &d.UpdatedAt); err != nil {
+		if err := rows.Scan(&d.ID, &d.Name, &d.HeadEmployeeID, &d.CalendarID, &d.DefaultShiftID, &d.CreatedAt, &d.UpdatedAt); err != nil {
 			return nil, 0, err
 		}
 		departments = append(departments, &d)
@@ -74,3 +80,88 @@ func (r *departmentPostgresRepository) FindAll(ctx context.Context, limit, offse
 
 	return departments, total, nil
 }
+
+func (r *departmentPostgresRepository) SetHead(ctx context.Context, id int64, employeeID *int64) error {
+	return r.setColumn(ctx, id, "head_employee_id", employeeID)
+}
+
+func (r *departmentPostgresRepository) SetCalendar(ctx context.Context, id int64, calendarID *int64) error {
+	return r.setColumn(ctx, id, "calendar_id", calendarID)
+}
+
+func (r *departmentPostgresRepository) SetDefaultShift(ctx context.Context, id int64, shiftID *int64) error {
+	return r.setColumn(ctx, id, "default_shift_id", shiftID)
+}
+
+// setColumn updates one nullable reference column; column is never user input.
+func (r *departmentPostgresRepository) setColumn(ctx context.Context, id int64, column string, value *int64) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE departments SET `+column+` = $1, updated_at = now() WHERE id = $2`, value, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *departmentPostgresRepository) ListPlans(ctx context.Context, id int64) ([]*models.DepartmentPlan, error) {
+	query := `
+		SELECT department_id, fiscal_year, planned_headcount, salary_budget, currency, note, created_at, updated_at
+		FROM department_plans
+		WHERE department_id = $1
+		ORDER BY fiscal_year DESC
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var plans []*models.DepartmentPlan
+	for rows.Next() {
+		var p models.DepartmentPlan
+		if err := rows.Scan(&p.DepartmentID, &p.FiscalYear, &p.PlannedHeadcount, &p.SalaryBudget, &p.Currency, &p.Note, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		plans = append(plans, &p)
+	}
+	return plans, rows.Err()
+}
+
+// SavePlan creates or replaces the department's plan for the fiscal year.
+func (r *departmentPostgresRepository) SavePlan(ctx context.Context, p *models.DepartmentPlan) error {
+	query := `
+		INSERT INTO department_plans (department_id, fiscal_year, planned_headcount, salary_budget, currency, note)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (department_id, fiscal_year) DO UPDATE SET
+			planned_headcount = EXCLUDED.planned_headcount,
+			salary_budget = EXCLUDED.salary_budget,
+			currency = EXCLUDED.currency,
+			note = EXCLUDED.note,
+			updated_at = now()
+		RETURNING created_at, updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		p.DepartmentID, p.FiscalYear, p.PlannedHeadcount, p.SalaryBudget, p.Currency, p.Note,
+	).Scan(&p.CreatedAt, &p.UpdatedAt)
+}
+
+func (r *departmentPostgresRepository) DeletePlan(ctx context.Context, id int64, fiscalYear int) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM department_plans WHERE department_id = $1 AND fiscal_year = $2`, id, fiscalYear)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -3,12 +3,21 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/lib/pq"
 
 	"app/internal/models"
 )
 
+// ErrEmployeeReferenced is returned by Delete when records that must be
+// kept, such as payslips, still point at the employee.
+var ErrEmployeeReferenced = errors.New("employee is referenced by records that must be kept")
+
 type employeePostgresRepository struct {
 	db *sql.DB
 }
@@ -21,79 +30,179 @@ type EmployeeRepository interface {
 	Create(ctx context.Context, e *models.Employee) error
 	FindByID(ctx context.Context, id int64) (*models.Employee, error)
 	FindByDepartmentID(ctx context.Context, departmentID int64) ([]*models.Employee, error)
-	List(ctx context.Context, limit, offset int, departmentID *int64, keyword *string) ([]*models.Employee, int64, error)
+	List(ctx context.Context, limit, offset int, filter models.EmployeeFilter) ([]*models.Employee, int64, error)
+	ListEmployedBetween(ctx context.Context, from, to time.Time) ([]*models.Employee, error)
 	Update(ctx context.Context, e *models.Employee) error
 	Delete(ctx context.Context, id int64) error
+	HasPayslips(ctx context.Context, id int64) (bool, error)
 }
 
-func (r *employeePostgresRepository) Create(ctx context.Context, e *models.Employee) error {
-	query := `
-		INSERT INTO employees (name, email, department_id)
-		VALUES ($1, $2, $3)
-		RETURNING id
-	`
-	return r.db.QueryRowContext(
-		ctx,
-		query,
-		e.Name,
-		e.Email,
-		e.DepartmentID,
-	).Scan(&e.ID)
-}
+// currentCompensationJoin exposes the salary currently in effect for
+// employees aliased as e, i.e. the latest compensation entry whose effective
+// date has passed, as comp.amount and comp.currency.
+const currentCompensationJoin = `
+	LEFT JOIN LATERAL (
+		SELECT c.amount, c.currency
+		FROM employee_compensations c
+		WHERE c.employee_id = e.id AND c.effective_date <= CURRENT_DATE
+		ORDER BY c.effective_date DESC, c.id DESC
+		LIMIT 1
+	) comp ON true
+`
 
-func (r *employeePostgresRepository) FindByID(ctx context.Context, id int64) (*models.Employee, error) {
-	query := `
+const employeeSelect = `
+	SELECT
+		e.id,
+		e.name,
+		e.email,
+		e.department_id,
+		e.manager_id,
+		e.date_of_birth,
+		e.date_of_birth_estimated,
+		pos.title,
+		e.position_id,
+		comp.amount,
+		comp.currency,
+		e.status,
+		e.hire_date,
+		e.probation_end_date,
+		e.termination_date,
+		e.termination_reason,
+		e.custom_fields,
+		e.created_at,
+		e.updated_at
+	FROM employees e
+	LEFT JOIN positions pos ON pos.id = e.position_id
+` + currentCompensationJoin
+
+// employeeFromAsOf reconstructs employees as they stood on the date in $1:
+// status, hire date, department and position come from the latest applied
+// employment events by then, and the salary from the compensation history.
+// Employees without such events keep their current values. Rows carry the
+// historical status as hist.status, so callers filter on who was employed.
+var employeeFromAsOf = `
+	FROM employees e
+	CROSS JOIN LATERAL (
 		SELECT
-			id,
-			name,
-			email,
-			department_id,
-			age,
-			position,
-			salary,
-			created_at,
-			updated_at
-		FROM employees
-		WHERE id = $1
-	`
+			(SELECT ev.to_status FROM employment_events ev
+			 WHERE ev.employee_id = e.id AND ev.applied_at IS NOT NULL AND ev.to_status IS NOT NULL AND ev.effective_date <= $1::date
+			 ORDER BY ev.effective_date DESC, ev.id DESC LIMIT 1) AS status,
+			(SELECT ev.effective_date FROM employment_events ev
+			 WHERE ev.employee_id = e.id AND ev.applied_at IS NOT NULL AND ev.kind IN ('hire', 'rehire') AND ev.effective_date <= $1::date
+			 ORDER BY ev.effective_date DESC, ev.id DESC LIMIT 1) AS hire_date,
+			(SELECT ev.id FROM employment_events ev
+			 WHERE ev.employee_id = e.id AND ev.applied_at IS NOT NULL AND ev.kind IN ('hire', 'rehire', 'position_change') AND ev.effective_date <= $1::date
+			 ORDER BY ev.effective_date DESC, ev.id DESC LIMIT 1) AS position_event_id
+	) hist
+	LEFT JOIN employment_events pe ON pe.id = hist.position_event_id
+	LEFT JOIN positions pos ON pos.id = CASE WHEN pe.id IS NULL THEN e.position_id ELSE pe.to_position_id END
+` + departmentOn("$1::date") + `
+	LEFT JOIN LATERAL (
+		SELECT c.amount, c.currency
+		FROM employee_compensations c
+		WHERE c.employee_id = e.id AND c.effective_date <= $1::date
+		ORDER BY c.effective_date DESC, c.id DESC
+		LIMIT 1
+	) comp ON true
+`
 
+// employeeSelectAsOf selects the columns scanEmployee expects from
+// employeeFromAsOf.
+var employeeSelectAsOf = `
+	SELECT
+		e.id,
+		e.name,
+		e.email,
+		hd.department_id,
+		e.manager_id,
+		e.date_of_birth,
+		e.date_of_birth_estimated,
+		pos.title,
+		pos.id,
+		comp.amount,
+		comp.currency,
+		hist.status,
+		COALESCE(hist.hire_date, e.hire_date),
+		e.probation_end_date,
+		e.termination_date,
+		e.termination_reason,
+		e.custom_fields,
+		e.created_at,
+		e.updated_at
+` + employeeFromAsOf
+
+// employedAsOf keeps the rows of employeeFromAsOf employed on $1. The
+// termination date check covers employees terminated before the
+// employment history was recorded.
+const employedAsOf = `hist.status IS NOT NULL AND hist.status NOT IN ('candidate', 'terminated') AND (e.termination_date IS NULL OR e.termination_date >= $1::date)`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEmployee(row rowScanner) (*models.Employee, error) {
 	var e models.Employee
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	if err := row.Scan(
 		&e.ID,
 		&e.Name,
 		&e.Email,
 		&e.DepartmentID,
-		&e.Age,
+		&e.ManagerID,
+		&e.DateOfBirth,
+		&e.DateOfBirthEstimated,
 		&e.Position,
+		&e.PositionID,
 		&e.Salary,
+		&e.SalaryCurrency,
+		&e.Status,
+		&e.HireDate,
+		&e.ProbationEndDate,
+		&e.TerminationDate,
+		&e.TerminationReason,
+		&e.CustomFields,
 		&e.CreatedAt,
 		&e.UpdatedAt,
-	)
-
-	if err != nil {
+	); err != nil {
 		return nil, err
 	}
-
 	return &e, nil
 }
 
-func (r *employeePostgresRepository) FindByDepartmentID(ctx context.Context, departmentID int64) ([]*models.Employee, error) {
+func (r *employeePostgresRepository) Create(ctx context.Context, e *models.Employee) error {
 	query := `
-		SELECT
-			id,
-			name,
-			email,
-			department_id,
-			age,
-			position,
-			salary,
-			created_at,
-			updated_at
-		FROM employees
-		WHERE department_id = $1
+		INSERT INTO employees (name, email, department_id, manager_id, date_of_birth, position_id, status, hire_date, probation_end_date, custom_fields)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		e.Name,
+		e.Email,
+		e.DepartmentID,
+		e.ManagerID,
+		e.DateOfBirth,
+		e.PositionID,
+		e.Status,
+		e.HireDate,
+		e.ProbationEndDate,
+		e.CustomFields,
+	).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
+}
+
+func (r *employeePostgresRepository) FindByID(ctx context.Context, id int64) (*models.Employee, error) {
+	query := employeeSelect + `
+		WHERE e.id = $1
+	`
+	return scanEmployee(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+}
+
+func (r *employeePostgresRepository) FindByDepartmentID(ctx context.Context, departmentID int64) ([]*models.Employee, error) {
+	query := employeeSelect + `
+		WHERE e.department_id = $1
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, departmentID)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, departmentID)
 	if err != nil {
 		return nil, err
 	}
@@ -101,25 +210,51 @@ func (r *employeePostgresRepository) FindByDepartmentID(ctx context.Context, dep
 
 	var employees []*models.Employee
 	for rows.Next() {
-		var e models.Employee
-		if err := rows.Scan(&e.ID, &e.Name, &e.Email, &e.DepartmentID); err != nil {
+		e, err := scanEmployee(rows)
+		if err != nil {
 			return nil, err
 		}
-		employees = append(employees, &e)
+		employees = append(employees, e)
 	}
-	return employees, nil
+	return employees, rows.Err()
 }
 
-func (r *employeePostgresRepository) List(ctx context.Context, limit, offset int, departmentID *int64, keyword *string) ([]*models.Employee, int64, error) {
+func (r *employeePostgresRepository) List(ctx context.Context, limit, offset int, filter models.EmployeeFilter) ([]*models.Employee, int64, error) {
 	whereParts := []string{}
 	args := []interface{}{}
-	if departmentID != nil {
-		whereParts = append(whereParts, "department_id = $"+strconv.Itoa(len(args)+1))
-		args = append(args, *departmentID)
+	selectQuery := employeeSelect
+	countFrom := "FROM employees e LEFT JOIN positions pos ON pos.id = e.position_id "
+	departmentColumn := "e.department_id"
+	if filter.AsOf != nil {
+		selectQuery = employeeSelectAsOf
+		countFrom = employeeFromAsOf
+		departmentColumn = "hd.department_id"
+		whereParts = append(whereParts, employedAsOf)
+		args = append(args, *filter.AsOf)
 	}
-	if keyword != nil && *keyword != "" {
-		whereParts = append(whereParts, "(name ILIKE $"+strconv.Itoa(len(args)+1)+" OR position ILIKE $"+strconv.Itoa(len(args)+1)+")")
-		args = append(args, "%"+*keyword+"%")
+	if filter.DepartmentID != nil {
+		whereParts = append(whereParts, departmentColumn+" = $"+strconv.Itoa(len(args)+1))
+		args = append(args, *filter.DepartmentID)
+	}
+	if filter.Keyword != nil && *filter.Keyword != "" {
+		// Position matches go through the catalog, so searching "engineer"
+		// finds everyone whose position title or code contains it.
+		whereParts = append(whereParts, "(e.name ILIKE $"+strconv.Itoa(len(args)+1)+" OR pos.title ILIKE $"+strconv.Itoa(len(args)+1)+" OR pos.code ILIKE $"+strconv.Itoa(len(args)+1)+")")
+		args = append(args, "%"+*filter.Keyword+"%")
+	}
+	for _, req := range filter.Skills {
+		whereParts = append(whereParts, "EXISTS (SELECT 1 FROM employee_skills es JOIN skills s ON s.id = es.skill_id"+
+			" WHERE es.employee_id = e.id AND LOWER(s.name) = LOWER($"+strconv.Itoa(len(args)+1)+") AND es.level >= $"+strconv.Itoa(len(args)+2)+")")
+		args = append(args, req.Skill, req.MinLevel)
+	}
+	keys := make([]string, 0, len(filter.CustomFields))
+	for k := range filter.CustomFields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		whereParts = append(whereParts, "e.custom_fields ->> $"+strconv.Itoa(len(args)+1)+" = $"+strconv.Itoa(len(args)+2))
+		args = append(args, k, filter.CustomFields[k])
 	}
 
 	where := ""
@@ -128,17 +263,17 @@ func (r *employeePostgresRepository) List(ctx context.Context, limit, offset int
 	}
 
 	var total int64
-	countQuery := "SELECT COUNT(*) FROM employees " + where
-	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+	countQuery := "SELECT COUNT(*) " + countFrom + where
+	if err := conn(ctx, r.db).QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
 		return nil, 0, err
 	}
 
 	argPos := len(args) + 1
 	args = append(args, limit, offset)
-	query := "SELECT id, name, email, department_id, age, position, salary, created_at, updated_at FROM employees " + where +
-		" ORDER BY id DESC LIMIT $" + strconv.Itoa(argPos) + " OFFSET $" + strconv.Itoa(argPos+1)
+	query := selectQuery + " " + where +
+		" ORDER BY e.id DESC LIMIT $" + strconv.Itoa(argPos) + " OFFSET $" + strconv.Itoa(argPos+1)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -146,17 +281,11 @@ func (r *employeePostgresRepository) List(ctx context.Context, limit, offset int
 
 	var res []*models.Employee
 	for rows.Next() {
-		var e models.Employee
-		var email sql.NullString
-		if err := rows.Scan(&e.ID, &e.Name, &email, &e.DepartmentID, &e.Age, &e.Position, &e.Salary, &e.CreatedAt, &e.UpdatedAt); err != nil {
+		e, err := scanEmployee(rows)
+		if err != nil {
 			return nil, 0, err
 		}
-		if email.Valid {
-			e.Email = &email.String
-		} else {
-			e.Email = nil
-		}
-		res = append(res, &e)
+		res = append(res, e)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, 0, err
@@ -165,15 +294,44 @@ func (r *employeePostgresRepository) List(ctx context.Context, limit, offset int
 	return res, total, nil
 }
 
+// ListEmployedBetween returns everyone employed on at least one day of
+// [from, to], i.e. hired by to and not terminated before from. Candidates
+// have not been hired yet.
+func (r *employeePostgresRepository) ListEmployedBetween(ctx context.Context, from, to time.Time) ([]*models.Employee, error) {
+	query := employeeSelect + `
+		WHERE e.status <> 'candidate'
+		  AND e.hire_date <= $2 AND (e.termination_date IS NULL OR e.termination_date >= $1)
+		ORDER BY e.id
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.Employee
+	for rows.Next() {
+		e, err := scanEmployee(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+	return res, rows.Err()
+}
+
+// Update writes the employee's own columns. Salary and the position title
+// are not stored here; they come from the compensation history and the
+// position catalog.
 func (r *employeePostgresRepository) Update(ctx context.Context, e *models.Employee) error {
 	var email sql.NullString
 	if e.Email != nil {
 		email = sql.NullString{String: *e.Email, Valid: true}
 	}
 
-	query := `UPDATE employees SET name = $1, email = $2, department_id = $3, age = $4, position = $5, salary = $6, updated_at = now() WHERE id = $7 RETURNING updated_at`
+	query := `UPDATE employees SET name = $1, email = $2, department_id = $3, manager_id = $4, date_of_birth = $5, date_of_birth_estimated = $6, position_id = $7, status = $8, hire_date = $9, probation_end_date = $10, termination_date = $11, termination_reason = $12, custom_fields = $13, updated_at = now() WHERE id = $14 RETURNING updated_at`
 	var updatedAt sql.NullTime
-	if err := r.db.QueryRowContext(ctx, query, e.Name, email, e.DepartmentID, e.Age, e.Position, e.Salary, e.ID).Scan(&updatedAt); err != nil {
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, e.Name, email, e.DepartmentID, e.ManagerID, e.DateOfBirth, e.DateOfBirthEstimated, e.PositionID, e.Status, e.HireDate, e.ProbationEndDate, e.TerminationDate, e.TerminationReason, e.CustomFields, e.ID).Scan(&updatedAt); err != nil {
 		return err
 	}
 	if updatedAt.Valid {
@@ -183,7 +341,11 @@ func (r *employeePostgresRepository) Update(ctx context.Context, e *models.Emplo
 }
 
 func (r *employeePostgresRepository) Delete(ctx context.Context, id int64) error {
-	res, err := r.db.ExecContext(ctx, `DELETE FROM employees WHERE id = $1`, id)
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM employees WHERE id = $1`, id)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		return ErrEmployeeReferenced
+	}
 	if err != nil {
 		return err
 	}
@@ -195,4 +357,10 @@ func (r *employeePostgresRepository) Delete(ctx context.Context, id int64) error
 		return sql.ErrNoRows
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+func (r *employeePostgresRepository) HasPayslips(ctx context.Context, id int64) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM payslips WHERE employee_id = $1)`, id).Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"app/internal/models"
+)
+
+type employmentEventPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewEmploymentEventRepository(db *sql.DB) EmploymentEventRepository {
+	return &employmentEventPostgresRepository{db: db}
+}
+
+type EmploymentEventRepository interface {
+	Create(ctx context.Context, ev *models.EmploymentEvent) error
+	ListByEmployee(ctx context.Context, employeeID int64) ([]*models.EmploymentEvent, error)
+	ListDue(ctx context.Context, on time.Time) ([]*models.EmploymentEvent, error)
+	FindPending(ctx context.Context, employeeID int64, kind string) (*models.EmploymentEvent, error)
+	MarkApplied(ctx context.Context, ev *models.EmploymentEvent) error
+	DeletePending(ctx context.Context, employeeID int64, kind string) error
+}
+
+const employmentEventColumns = `
+	id, employee_id, kind, effective_date, from_status, to_status, from_department_id,
+	to_department_id, to_manager_id, to_position_id, reason, applied_at, created_at
+`
+
+func scanEmploymentEvent(row rowScanner) (*models.EmploymentEvent, error) {
+	var ev models.EmploymentEvent
+	if err := row.Scan(
+		&ev.ID, &ev.EmployeeID, &ev.Kind, &ev.EffectiveDate, &ev.FromStatus, &ev.ToStatus, &ev.FromDepartmentID,
+		&ev.ToDepartmentID, &ev.ToManagerID, &ev.ToPositionID, &ev.Reason, &ev.AppliedAt, &ev.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &ev, nil
+}
+
+func (r *employmentEventPostgresRepository) query(ctx context.Context, query string, args ...interface{}) ([]*models.EmploymentEvent, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.EmploymentEvent
+	for rows.Next() {
+		ev, err := scanEmploymentEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, ev)
+	}
+	return res, rows.Err()
+}
+
+func (r *employmentEventPostgresRepository) Create(ctx context.Context, ev *models.EmploymentEvent) error {
+	query := `
+		INSERT INTO employment_events (
+			employee_id, kind, effective_date, from_status, to_status, from_department_id,
+			to_department_id, to_manager_id, to_position_id, reason, applied_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		ev.EmployeeID, ev.Kind, ev.EffectiveDate, ev.FromStatus, ev.ToStatus, ev.FromDepartmentID,
+		ev.ToDepartmentID, ev.ToManagerID, ev.ToPositionID, ev.Reason, ev.AppliedAt,
+	).Scan(&ev.ID, &ev.CreatedAt)
+}
+
+func (r *employmentEventPostgresRepository) ListByEmployee(ctx context.Context, employeeID int64) ([]*models.EmploymentEvent, error) {
+	query := `SELECT ` + employmentEventColumns + ` FROM employment_events WHERE employee_id = $1 ORDER BY effective_date, id`
+	return r.query(ctx, query, employeeID)
+}
+
+// ListDue returns the pending events effective on or before on, oldest
+// first.
+func (r *employmentEventPostgresRepository) ListDue(ctx context.Context, on time.Time) ([]*models.EmploymentEvent, error) {
+	query := `
+		SELECT ` + employmentEventColumns + `
+		FROM employment_events
+		WHERE applied_at IS NULL AND effective_date <= $1
+		ORDER BY effective_date, id
+	`
+	return r.query(ctx, query, on)
+}
+
+func (r *employmentEventPostgresRepository) FindPending(ctx context.Context, employeeID int64, kind string) (*models.EmploymentEvent, error) {
+	query := `
+		SELECT ` + employmentEventColumns + `
+		FROM employment_events
+		WHERE employee_id = $1 AND kind = $2 AND applied_at IS NULL
+		ORDER BY effective_date
+		LIMIT 1
+	`
+	return scanEmploymentEvent(conn(ctx, r.db).QueryRowContext(ctx, query, employeeID, kind))
+}
+
+// MarkApplied stamps the event as applied now. It returns sql.ErrNoRows if
+// it was already applied, so a concurrent run cannot apply it twice.
+func (r *employmentEventPostgresRepository) MarkApplied(ctx context.Context, ev *models.EmploymentEvent) error {
+	query := `UPDATE employment_events SET applied_at = now() WHERE id = $1 AND applied_at IS NULL RETURNING applied_at`
+	return conn(ctx, r.db).QueryRowContext(ctx, query, ev.ID).Scan(&ev.AppliedAt)
+}
+
+func (r *employmentEventPostgresRepository) DeletePending(ctx context.Context, employeeID int64, kind string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM employment_events WHERE employee_id = $1 AND kind = $2 AND applied_at IS NULL`, employeeID, kind)
+	return err
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"app/internal/models"
+)
+
+type exchangeRatePostgresRepository struct {
+	db *sql.DB
+}
+
+func NewExchangeRateRepository(db *sql.DB) ExchangeRateRepository {
+	return &exchangeRatePostgresRepository{db: db}
+}
+
+type ExchangeRateRepository interface {
+	Upsert(ctx context.Context, rates []*models.ExchangeRate) error
+	FindEffective(ctx context.Context, base, quote string, on time.Time) (*models.ExchangeRate, error)
+	List(ctx context.Context, limit, offset int, base, quote *string) ([]*models.ExchangeRate, int64, error)
+}
+
+// Upsert stores all rates in one transaction so a partially valid import
+// never leaves half a file behind. Re-importing a pair/date replaces the rate.
+func (r *exchangeRatePostgresRepository) Upsert(ctx context.Context, rates []*models.ExchangeRate) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO exchange_rates (base_currency, quote_currency, rate, effective_date)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (base_currency, quote_currency, effective_date)
+		DO UPDATE SET rate = EXCLUDED.rate
+		RETURNING id, created_at
+	`
+	for _, rate := range rates {
+		if err := tx.QueryRowContext(ctx, query, rate.BaseCurrency, rate.QuoteCurrency, rate.Rate, rate.EffectiveDate).Scan(&rate.ID, &rate.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *exchangeRatePostgresRepository) FindEffective(ctx context.Context, base, quote string, on time.Time) (*models.ExchangeRate, error) {
+	query := `
+		SELECT id, base_currency, quote_currency, rate, effective_date, created_at
+		FROM exchange_rates
+		WHERE base_currency = $1 AND quote_currency = $2 AND effective_date <= $3
+		ORDER BY effective_date DESC
+		LIMIT 1
+	`
+	var rate models.ExchangeRate
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, base, quote, on).Scan(
+		&rate.ID,
+		&rate.BaseCurrency,
+		&rate.QuoteCurrency,
+		&rate.Rate,
+		&rate.EffectiveDate,
+		&rate.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (r *exchangeRatePostgresRepository) List(ctx context.Context, limit, offset int, base, quote *string) ([]*models.ExchangeRate, int64, error) {
+	whereParts := []string{}
+	args := []interface{}{}
+	if base != nil {
+		whereParts = append(whereParts, "base_currency = $"+strconv.Itoa(len(args)+1))
+		args = append(args, *base)
+	}
+	if quote != nil {
+		whereParts = append(whereParts, "quote_currency = $"+strconv.Itoa(len(args)+1))
+		args = append(args, *quote)
+	}
+
+	where := ""
+	if len(whereParts) > 0 {
+		where = "WHERE " + strings.Join(whereParts, " AND ")
+	}
+
+	var total int64
+	if err := conn(ctx, r.db).QueryRowContext(ctx, "SELECT COUNT(*) FROM exchange_rates "+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	argPos := len(args) + 1
+	args = append(args, limit, offset)
+	query := "SELECT id, base_currency, quote_currency, rate, effective_date, created_at FROM exchange_rates " + where +
+		" ORDER BY effective_date DESC, base_currency, quote_currency LIMIT $" + strconv.Itoa(argPos) + " OFFSET $" + strconv.Itoa(argPos+1)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var res []*models.ExchangeRate
+	for rows.Next() {
+		var rate models.ExchangeRate
+		if err := rows.Scan(&rate.ID, &rate.BaseCurrency, &rate.QuoteCurrency, &rate.Rate, &rate.EffectiveDate, &rate.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		res = append(res, &rate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return res, total, nil
+}
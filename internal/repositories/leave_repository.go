@@ -0,0 +1,247 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"app/internal/models"
+)
+
+type leavePostgresRepository struct {
+	db *sql.DB
+}
+
+func NewLeaveRepository(db *sql.DB) LeaveRepository {
+	return &leavePostgresRepository{db: db}
+}
+
+type LeaveRepository interface {
+	ListTypes(ctx context.Context) ([]*models.LeaveType, error)
+	FindType(ctx context.Context, id int64) (*models.LeaveType, error)
+	FindTypeByCode(ctx context.Context, code string) (*models.LeaveType, error)
+	CreateRequest(ctx context.Context, l *models.LeaveRequest) error
+	FindRequest(ctx context.Context, id int64) (*models.LeaveRequest, error)
+	ListRequestsByEmployee(ctx context.Context, employeeID int64, status *string) ([]*models.LeaveRequest, error)
+	ListPendingForApprover(ctx context.Context, approverID int64) ([]*models.LeaveRequest, error)
+	FindOverlapping(ctx context.Context, employeeID int64, from, to time.Time) ([]*models.LeaveRequest, error)
+	ListApprovedByDepartment(ctx context.Context, departmentID int64, from, to time.Time) ([]*models.LeaveRequest, error)
+	SumDays(ctx context.Context, employeeID, leaveTypeID int64, year int) (used, pending models.Decimal, err error)
+	UpdateStatus(ctx context.Context, l *models.LeaveRequest, fromStatus string) error
+}
+
+func (r *leavePostgresRepository) ListTypes(ctx context.Context) ([]*models.LeaveType, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT id, code, name, paid, requires_balance, created_at FROM leave_types ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var types []*models.LeaveType
+	byID := map[int64]*models.LeaveType{}
+	for rows.Next() {
+		var t models.LeaveType
+		if err := rows.Scan(&t.ID, &t.Code, &t.Name, &t.Paid, &t.RequiresBalance, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		types = append(types, &t)
+		byID[t.ID] = &t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	policyRows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT leave_type_id, min_years_of_service, days_per_year
+		FROM leave_accrual_policies
+		ORDER BY leave_type_id, min_years_of_service
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer policyRows.Close()
+
+	for policyRows.Next() {
+		var typeID int64
+		var p models.LeaveAccrualPolicy
+		if err := policyRows.Scan(&typeID, &p.MinYearsOfService, &p.DaysPerYear); err != nil {
+			return nil, err
+		}
+		if t, ok := byID[typeID]; ok {
+			t.Policies = append(t.Policies, p)
+		}
+	}
+	return types, policyRows.Err()
+}
+
+func (r *leavePostgresRepository) findType(ctx context.Context, where string, arg interface{}) (*models.LeaveType, error) {
+	var t models.LeaveType
+	query := `SELECT id, code, name, paid, requires_balance, created_at FROM leave_types WHERE ` + where
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, arg).Scan(&t.ID, &t.Code, &t.Name, &t.Paid, &t.RequiresBalance, &t.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT min_years_of_service, days_per_year
+		FROM leave_accrual_policies
+		WHERE leave_type_id = $1
+		ORDER BY min_years_of_service
+	`, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p models.LeaveAccrualPolicy
+		if err := rows.Scan(&p.MinYearsOfService, &p.DaysPerYear); err != nil {
+			return nil, err
+		}
+		t.Policies = append(t.Policies, p)
+	}
+	return &t, rows.Err()
+}
+
+func (r *leavePostgresRepository) FindType(ctx context.Context, id int64) (*models.LeaveType, error) {
+	return r.findType(ctx, "id = $1", id)
+}
+
+func (r *leavePostgresRepository) FindTypeByCode(ctx context.Context, code string) (*models.LeaveType, error) {
+	return r.findType(ctx, "UPPER(code) = UPPER($1)", code)
+}
+
+func (r *leavePostgresRepository) CreateRequest(ctx context.Context, l *models.LeaveRequest) error {
+	query := `
+		INSERT INTO leave_requests (employee_id, leave_type_id, start_date, end_date, days, status, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		l.EmployeeID, l.LeaveTypeID, l.StartDate, l.EndDate, l.Days, l.Status, l.Reason,
+	).Scan(&l.ID, &l.CreatedAt, &l.UpdatedAt)
+}
+
+const leaveRequestColumns = `
+	l.id, l.employee_id, l.leave_type_id, l.start_date, l.end_date, l.days, l.status,
+	l.reason, l.approver_id, l.decided_at, l.decision_note, l.created_at, l.updated_at
+`
+
+func scanLeaveRequest(row rowScanner) (*models.LeaveRequest, error) {
+	var l models.LeaveRequest
+	if err := row.Scan(
+		&l.ID, &l.EmployeeID, &l.LeaveTypeID, &l.StartDate, &l.EndDate, &l.Days, &l.Status,
+		&l.Reason, &l.ApproverID, &l.DecidedAt, &l.DecisionNote, &l.CreatedAt, &l.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (r *leavePostgresRepository) queryRequests(ctx context.Context, query string, args ...interface{}) ([]*models.LeaveRequest, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.LeaveRequest
+	for rows.Next() {
+		l, err := scanLeaveRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, l)
+	}
+	return res, rows.Err()
+}
+
+func (r *leavePostgresRepository) FindRequest(ctx context.Context, id int64) (*models.LeaveRequest, error) {
+	query := `SELECT ` + leaveRequestColumns + ` FROM leave_requests l WHERE l.id = $1`
+	return scanLeaveRequest(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+}
+
+func (r *leavePostgresRepository) ListRequestsByEmployee(ctx context.Context, employeeID int64, status *string) ([]*models.LeaveRequest, error) {
+	query := `
+		SELECT ` + leaveRequestColumns + `
+		FROM leave_requests l
+		WHERE l.employee_id = $1 AND ($2::text IS NULL OR l.status = $2)
+		ORDER BY l.start_date DESC, l.id DESC
+	`
+	return r.queryRequests(ctx, query, employeeID, status)
+}
+
+// ListPendingForApprover returns the pending requests approverID may decide:
+// those of their direct reports and, for department heads, of everyone in
+// the department.
+func (r *leavePostgresRepository) ListPendingForApprover(ctx context.Context, approverID int64) ([]*models.LeaveRequest, error) {
+	query := `
+		SELECT ` + leaveRequestColumns + `
+		FROM leave_requests l
+		JOIN employees e ON e.id = l.employee_id
+		JOIN departments d ON d.id = e.department_id
+		WHERE l.status = 'pending'
+		  AND l.employee_id <> $1
+		  AND (e.manager_id = $1 OR d.head_employee_id = $1)
+		ORDER BY l.start_date, l.id
+	`
+	return r.queryRequests(ctx, query, approverID)
+}
+
+// FindOverlapping returns the pending or approved requests of the employee
+// that share at least one day with [from, to].
+func (r *leavePostgresRepository) FindOverlapping(ctx context.Context, employeeID int64, from, to time.Time) ([]*models.LeaveRequest, error) {
+	query := `
+		SELECT ` + leaveRequestColumns + `
+		FROM leave_requests l
+		WHERE l.employee_id = $1
+		  AND l.status IN ('pending', 'approved')
+		  AND l.start_date <= $3 AND l.end_date >= $2
+		ORDER BY l.start_date
+	`
+	return r.queryRequests(ctx, query, employeeID, from, to)
+}
+
+// ListApprovedByDepartment returns the approved leave of department members
+// overlapping [from, to].
+func (r *leavePostgresRepository) ListApprovedByDepartment(ctx context.Context, departmentID int64, from, to time.Time) ([]*models.LeaveRequest, error) {
+	query := `
+		SELECT ` + leaveRequestColumns + `
+		FROM leave_requests l
+		JOIN employees e ON e.id = l.employee_id
+		WHERE e.department_id = $1
+		  AND l.status = 'approved'
+		  AND l.start_date <= $3 AND l.end_date >= $2
+		ORDER BY l.employee_id, l.start_date
+	`
+	return r.queryRequests(ctx, query, departmentID, from, to)
+}
+
+// SumDays totals the approved and pending days of one leave type taken in
+// year, attributed by start date.
+func (r *leavePostgresRepository) SumDays(ctx context.Context, employeeID, leaveTypeID int64, year int) (used, pending models.Decimal, err error) {
+	query := `
+		SELECT
+			COALESCE(SUM(days) FILTER (WHERE status = 'approved'), 0),
+			COALESCE(SUM(days) FILTER (WHERE status = 'pending'), 0)
+		FROM leave_requests
+		WHERE employee_id = $1 AND leave_type_id = $2
+		  AND EXTRACT(YEAR FROM start_date) = $3
+	`
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, employeeID, leaveTypeID, year).Scan(&used, &pending)
+	return used, pending, err
+}
+
+// UpdateStatus moves the request from fromStatus to l.Status, recording the
+// decision. It returns sql.ErrNoRows when the request is no longer in
+// fromStatus, so concurrent decisions cannot both succeed.
+func (r *leavePostgresRepository) UpdateStatus(ctx context.Context, l *models.LeaveRequest, fromStatus string) error {
+	query := `
+		UPDATE leave_requests
+		SET status = $1, approver_id = $2, decided_at = $3, decision_note = $4, updated_at = now()
+		WHERE id = $5 AND status = $6
+		RETURNING updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		l.Status, l.ApproverID, l.DecidedAt, l.DecisionNote, l.ID, fromStatus,
+	).Scan(&l.UpdatedAt)
+}
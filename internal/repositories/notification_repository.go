@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"app/internal/models"
+)
+
+type notificationPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) NotificationRepository {
+	return &notificationPostgresRepository{db: db}
+}
+
+type NotificationRepository interface {
+	Create(ctx context.Context, n *models.Notification) (bool, error)
+	List(ctx context.Context, unreadOnly bool, limit, offset int) ([]*models.Notification, error)
+	MarkRead(ctx context.Context, id int64) (*models.Notification, error)
+}
+
+const notificationColumns = `id, kind, employee_id, message, dedupe_key, read_at, created_at`
+
+func scanNotification(row rowScanner) (*models.Notification, error) {
+	var n models.Notification
+	if err := row.Scan(&n.ID, &n.Kind, &n.EmployeeID, &n.Message, &n.DedupeKey, &n.ReadAt, &n.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// Create stores n unless a notification with the same dedupe key exists. It
+// reports whether n was stored.
+func (r *notificationPostgresRepository) Create(ctx context.Context, n *models.Notification) (bool, error) {
+	query := `
+		INSERT INTO notifications (kind, employee_id, message, dedupe_key)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (dedupe_key) DO NOTHING
+		RETURNING id, created_at
+	`
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, n.Kind, n.EmployeeID, n.Message, n.DedupeKey).Scan(&n.ID, &n.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *notificationPostgresRepository) List(ctx context.Context, unreadOnly bool, limit, offset int) ([]*models.Notification, error) {
+	query := `
+		SELECT ` + notificationColumns + `
+		FROM notifications
+		WHERE ($1 = false OR read_at IS NULL)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.Notification
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, n)
+	}
+	return res, rows.Err()
+}
+
+// MarkRead stamps the notification as read, keeping the first read time if
+// it was read before.
+func (r *notificationPostgresRepository) MarkRead(ctx context.Context, id int64) (*models.Notification, error) {
+	query := `
+		UPDATE notifications SET read_at = COALESCE(read_at, now())
+		WHERE id = $1
+		RETURNING ` + notificationColumns
+	return scanNotification(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+}
@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"app/internal/models"
+)
+
+type overtimePostgresRepository struct {
+	db *sql.DB
+}
+
+func NewOvertimeRepository(db *sql.DB) OvertimeRepository {
+	return &overtimePostgresRepository{db: db}
+}
+
+type OvertimeRepository interface {
+	ReplacePending(ctx context.Context, employeeID int64, period string, items []*models.OvertimeItem) error
+	FindByID(ctx context.Context, id int64) (*models.OvertimeItem, error)
+	ListByEmployee(ctx context.Context, employeeID int64, period string) ([]*models.OvertimeItem, error)
+	ListPendingForApprover(ctx context.Context, approverID int64) ([]*models.OvertimeItem, error)
+	ListApprovedByPeriod(ctx context.Context, period string) ([]*models.OvertimeItem, error)
+	UpdateStatus(ctx context.Context, o *models.OvertimeItem, fromStatus string) error
+}
+
+const overtimeColumns = `
+	o.id, o.employee_id, o.period, o.work_date, o.day_kind, o.worked_minutes, o.minutes,
+	o.capped_minutes, o.rate, o.status, o.approver_id, o.decided_at, o.decision_note,
+	o.created_at, o.updated_at
+`
+
+func scanOvertimeItem(row rowScanner) (*models.OvertimeItem, error) {
+	var o models.OvertimeItem
+	if err := row.Scan(
+		&o.ID, &o.EmployeeID, &o.Period, &o.WorkDate, &o.DayKind, &o.WorkedMinutes, &o.Minutes,
+		&o.CappedMinutes, &o.Rate, &o.Status, &o.ApproverID, &o.DecidedAt, &o.DecisionNote,
+		&o.CreatedAt, &o.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (r *overtimePostgresRepository) query(ctx context.Context, query string, args ...interface{}) ([]*models.OvertimeItem, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.OvertimeItem
+	for rows.Next() {
+		o, err := scanOvertimeItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, o)
+	}
+	return res, rows.Err()
+}
+
+// ReplacePending swaps the employee's pending items of period for items in
+// one transaction. Days that were already approved or rejected keep their
+// decided item.
+func (r *overtimePostgresRepository) ReplacePending(ctx context.Context, employeeID int64, period string, items []*models.OvertimeItem) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM overtime_items WHERE employee_id = $1 AND period = $2 AND status = 'pending'`, employeeID, period); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO overtime_items (
+			employee_id, period, work_date, day_kind, worked_minutes, minutes, capped_minutes, rate, status
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (employee_id, work_date) DO NOTHING
+	`
+	for _, o := range items {
+		if _, err := tx.ExecContext(ctx, query,
+			o.EmployeeID, o.Period, o.WorkDate, o.DayKind, o.WorkedMinutes, o.Minutes, o.CappedMinutes, o.Rate, o.Status,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *overtimePostgresRepository) FindByID(ctx context.Context, id int64) (*models.OvertimeItem, error) {
+	return scanOvertimeItem(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+overtimeColumns+` FROM overtime_items o WHERE o.id = $1`, id))
+}
+
+func (r *overtimePostgresRepository) ListByEmployee(ctx context.Context, employeeID int64, period string) ([]*models.OvertimeItem, error) {
+	query := `
+		SELECT ` + overtimeColumns + `
+		FROM overtime_items o
+		WHERE o.employee_id = $1 AND o.period = $2
+		ORDER BY o.work_date
+	`
+	return r.query(ctx, query, employeeID, period)
+}
+
+// ListPendingForApprover returns the pending items approverID may decide,
+// on the same terms as leave requests.
+func (r *overtimePostgresRepository) ListPendingForApprover(ctx context.Context, approverID int64) ([]*models.OvertimeItem, error) {
+	query := `
+		SELECT ` + overtimeColumns + `
+		FROM overtime_items o
+		JOIN employees e ON e.id = o.employee_id
+		JOIN departments d ON d.id = e.department_id
+		WHERE o.status = 'pending'
+		  AND o.minutes > 0
+		  AND o.employee_id <> $1
+		  AND (e.manager_id = $1 OR d.head_employee_id = $1)
+		ORDER BY o.work_date, o.employee_id
+	`
+	return r.query(ctx, query, approverID)
+}
+
+func (r *overtimePostgresRepository) ListApprovedByPeriod(ctx context.Context, period string) ([]*models.OvertimeItem, error) {
+	query := `
+		SELECT ` + overtimeColumns + `
+		FROM overtime_items o
+		WHERE o.period = $1 AND o.status = 'approved' AND o.minutes > 0
+		ORDER BY o.employee_id, o.work_date
+	`
+	return r.query(ctx, query, period)
+}
+
+// UpdateStatus records a decision. It returns sql.ErrNoRows when the item
+// is no longer in fromStatus.
+func (r *overtimePostgresRepository) UpdateStatus(ctx context.Context, o *models.OvertimeItem, fromStatus string) error {
+	query := `
+		UPDATE overtime_items
+		SET status = $1, approver_id = $2, decided_at = $3, decision_note = $4, updated_at = now()
+		WHERE id = $5 AND status = $6
+		RETURNING updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		o.Status, o.ApproverID, o.DecidedAt, o.DecisionNote, o.ID, fromStatus,
+	).Scan(&o.UpdatedAt)
+}
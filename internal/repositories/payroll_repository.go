@@ -0,0 +1,237 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"app/internal/models"
+)
+
+type payrollPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPayrollRepository(db *sql.DB) PayrollRepository {
+	return &payrollPostgresRepository{db: db}
+}
+
+type PayrollRepository interface {
+	CreateRun(ctx context.Context, run *models.PayrollRun, payslips []*models.Payslip) error
+	FindRunByID(ctx context.Context, id int64) (*models.PayrollRun, error)
+	FindRunByPeriod(ctx context.Context, period string) (*models.PayrollRun, error)
+	ListRuns(ctx context.Context, limit, offset int) ([]*models.PayrollRun, int64, error)
+	ListPayslipsByRun(ctx context.Context, runID int64) ([]*models.Payslip, error)
+	ListPayslipsByEmployee(ctx context.Context, employeeID int64) ([]*models.Payslip, error)
+	ListPayslipsByDepartment(ctx context.Context, departmentID int64, period string) ([]*models.Payslip, error)
+	FindPayslip(ctx context.Context, employeeID int64, period string) (*models.Payslip, error)
+	YearToDate(ctx context.Context, employeeID int64, period string) (*models.PayslipTotals, error)
+}
+
+// CreateRun writes the run, its payslips and their lines in one transaction.
+func (r *payrollPostgresRepository) CreateRun(ctx context.Context, run *models.PayrollRun, payslips []*models.Payslip) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	runQuery := `
+		INSERT INTO payroll_runs (period, currency, employee_count, total_gross, total_net)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	if err := tx.QueryRowContext(ctx, runQuery, run.Period, run.Currency, run.EmployeeCount, run.TotalGross, run.TotalNet).Scan(&run.ID, &run.CreatedAt); err != nil {
+		return err
+	}
+
+	slipQuery := `
+		INSERT INTO payslips (
+			payroll_run_id, employee_id, employee_name, department_id, period, currency,
+			base_salary, working_days, paid_days, gross, social_insurance, taxable_income,
+			income_tax, deductions, net
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING id, created_at
+	`
+	lineQuery := `
+		INSERT INTO payslip_lines (payslip_id, kind, code, label, amount)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	for _, p := range payslips {
+		p.PayrollRunID = run.ID
+		if err := tx.QueryRowContext(ctx, slipQuery,
+			p.PayrollRunID, p.EmployeeID, p.EmployeeName, p.DepartmentID, p.Period, p.Currency,
+			p.BaseSalary, p.WorkingDays, p.PaidDays, p.Gross, p.SocialInsurance, p.TaxableIncome,
+			p.IncomeTax, p.Deductions, p.Net,
+		).Scan(&p.ID, &p.CreatedAt); err != nil {
+			return err
+		}
+		for _, l := range p.Lines {
+			if _, err := tx.ExecContext(ctx, lineQuery, p.ID, l.Kind, l.Code, l.Label, l.Amount); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+const payrollRunColumns = `id, period, currency, employee_count, total_gross, total_net, created_at`
+
+func scanPayrollRun(row rowScanner) (*models.PayrollRun, error) {
+	var run models.PayrollRun
+	if err := row.Scan(&run.ID, &run.Period, &run.Currency, &run.EmployeeCount, &run.TotalGross, &run.TotalNet, &run.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *payrollPostgresRepository) FindRunByID(ctx context.Context, id int64) (*models.PayrollRun, error) {
+	return scanPayrollRun(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+payrollRunColumns+` FROM payroll_runs WHERE id = $1`, id))
+}
+
+func (r *payrollPostgresRepository) FindRunByPeriod(ctx context.Context, period string) (*models.PayrollRun, error) {
+	return scanPayrollRun(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+payrollRunColumns+` FROM payroll_runs WHERE period = $1`, period))
+}
+
+func (r *payrollPostgresRepository) ListRuns(ctx context.Context, limit, offset int) ([]*models.PayrollRun, int64, error) {
+	var total int64
+	if err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM payroll_runs`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT `+payrollRunColumns+` FROM payroll_runs ORDER BY period DESC LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var runs []*models.PayrollRun
+	for rows.Next() {
+		run, err := scanPayrollRun(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return runs, total, nil
+}
+
+const payslipColumns = `
+	p.id, p.payroll_run_id, p.employee_id, p.employee_name, p.department_id, p.period, p.currency,
+	p.base_salary, p.working_days, p.paid_days, p.gross, p.social_insurance, p.taxable_income,
+	p.income_tax, p.deductions, p.net, p.created_at
+`
+
+func scanPayslip(row rowScanner) (*models.Payslip, error) {
+	var p models.Payslip
+	if err := row.Scan(
+		&p.ID, &p.PayrollRunID, &p.EmployeeID, &p.EmployeeName, &p.DepartmentID, &p.Period, &p.Currency,
+		&p.BaseSalary, &p.WorkingDays, &p.PaidDays, &p.Gross, &p.SocialInsurance, &p.TaxableIncome,
+		&p.IncomeTax, &p.Deductions, &p.Net, &p.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// queryPayslips runs a payslip query and attaches each payslip's lines.
+func (r *payrollPostgresRepository) queryPayslips(ctx context.Context, query string, args ...interface{}) ([]*models.Payslip, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payslips []*models.Payslip
+	byID := map[int64]*models.Payslip{}
+	for rows.Next() {
+		p, err := scanPayslip(rows)
+		if err != nil {
+			return nil, err
+		}
+		payslips = append(payslips, p)
+		byID[p.ID] = p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(payslips) == 0 {
+		return payslips, nil
+	}
+
+	lineQuery := `
+		SELECT l.payslip_id, l.kind, l.code, l.label, l.amount
+		FROM payslip_lines l
+		JOIN (` + query + `) p ON p.id = l.payslip_id
+		ORDER BY l.payslip_id, l.id
+	`
+	lineRows, err := conn(ctx, r.db).QueryContext(ctx, lineQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer lineRows.Close()
+
+	for lineRows.Next() {
+		var payslipID int64
+		var l models.PayslipLine
+		if err := lineRows.Scan(&payslipID, &l.Kind, &l.Code, &l.Label, &l.Amount); err != nil {
+			return nil, err
+		}
+		if p, ok := byID[payslipID]; ok {
+			p.Lines = append(p.Lines, l)
+		}
+	}
+	return payslips, lineRows.Err()
+}
+
+func (r *payrollPostgresRepository) ListPayslipsByRun(ctx context.Context, runID int64) ([]*models.Payslip, error) {
+	query := `SELECT ` + payslipColumns + ` FROM payslips p WHERE p.payroll_run_id = $1 ORDER BY p.department_id, p.employee_id`
+	return r.queryPayslips(ctx, query, runID)
+}
+
+func (r *payrollPostgresRepository) ListPayslipsByEmployee(ctx context.Context, employeeID int64) ([]*models.Payslip, error) {
+	query := `SELECT ` + payslipColumns + ` FROM payslips p WHERE p.employee_id = $1 ORDER BY p.period DESC`
+	return r.queryPayslips(ctx, query, employeeID)
+}
+
+// ListPayslipsByDepartment uses the department recorded on the payslip, i.e.
+// where the employee was when the payroll ran.
+func (r *payrollPostgresRepository) ListPayslipsByDepartment(ctx context.Context, departmentID int64, period string) ([]*models.Payslip, error) {
+	query := `SELECT ` + payslipColumns + ` FROM payslips p WHERE p.department_id = $1 AND p.period = $2 ORDER BY p.employee_id`
+	return r.queryPayslips(ctx, query, departmentID, period)
+}
+
+func (r *payrollPostgresRepository) FindPayslip(ctx context.Context, employeeID int64, period string) (*models.Payslip, error) {
+	query := `SELECT ` + payslipColumns + ` FROM payslips p WHERE p.employee_id = $1 AND p.period = $2`
+	payslips, err := r.queryPayslips(ctx, query, employeeID, period)
+	if err != nil {
+		return nil, err
+	}
+	if len(payslips) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return payslips[0], nil
+}
+
+// YearToDate sums the employee's payslips from January up to and including
+// period. Periods are YYYY-MM, so they compare correctly as text.
+func (r *payrollPostgresRepository) YearToDate(ctx context.Context, employeeID int64, period string) (*models.PayslipTotals, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(gross), 0),
+			COALESCE(SUM(social_insurance), 0),
+			COALESCE(SUM(income_tax), 0),
+			COALESCE(SUM(deductions), 0),
+			COALESCE(SUM(net), 0)
+		FROM payslips
+		WHERE employee_id = $1 AND period >= left($2, 4) || '-01' AND period <= $2
+	`
+	var t models.PayslipTotals
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, employeeID, period).Scan(&t.Gross, &t.SocialInsurance, &t.IncomeTax, &t.Deductions, &t.Net); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,369 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	"app/internal/models"
+)
+
+// ErrDetailConflict is returned when a write breaks a constraint the
+// service checks could not see, e.g. two requests adding the same phone
+// number at once.
+var ErrDetailConflict = errors.New("record conflicts with the employee's other records")
+
+type personalDetailsPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPersonalDetailsRepository(db *sql.DB) PersonalDetailsRepository {
+	return &personalDetailsPostgresRepository{db: db}
+}
+
+// PersonalDetailsRepository stores an employee's addresses, phone numbers,
+// emergency contacts and dependents. Updates and deletes match on both the
+// record and the employee id and return sql.ErrNoRows when either differs.
+type PersonalDetailsRepository interface {
+	ListAddresses(ctx context.Context, employeeID int64) ([]*models.EmployeeAddress, error)
+	CreateAddress(ctx context.Context, a *models.EmployeeAddress) error
+	UpdateAddress(ctx context.Context, a *models.EmployeeAddress) error
+	DeleteAddress(ctx context.Context, employeeID, id int64) error
+
+	ListPhones(ctx context.Context, employeeID int64) ([]*models.EmployeePhone, error)
+	CreatePhone(ctx context.Context, p *models.EmployeePhone) error
+	UpdatePhone(ctx context.Context, p *models.EmployeePhone) error
+	DeletePhone(ctx context.Context, employeeID, id int64) error
+
+	ListEmergencyContacts(ctx context.Context, employeeID int64) ([]*models.EmergencyContact, error)
+	CreateEmergencyContact(ctx context.Context, c *models.EmergencyContact) error
+	UpdateEmergencyContact(ctx context.Context, c *models.EmergencyContact) error
+	DeleteEmergencyContact(ctx context.Context, employeeID, id int64) error
+
+	ListDependents(ctx context.Context, employeeID int64) ([]*models.Dependent, error)
+	CreateDependent(ctx context.Context, d *models.Dependent) error
+	UpdateDependent(ctx context.Context, d *models.Dependent) error
+	DeleteDependent(ctx context.Context, employeeID, id int64) error
+	// TaxDependentCounts counts, per employee, the dependents registered for
+	// tax relief on any day between from and to.
+	TaxDependentCounts(ctx context.Context, from, to time.Time) (map[int64]int, error)
+}
+
+// clearPrimary unsets the primary flag on the employee's other rows of
+// table before id becomes primary; table is never user input.
+func clearPrimary(ctx context.Context, tx querier, table string, employeeID, id int64) error {
+	_, err := tx.ExecContext(ctx, `UPDATE `+table+` SET is_primary = false, updated_at = now() WHERE employee_id = $1 AND id <> $2 AND is_primary`, employeeID, id)
+	return err
+}
+
+// detailConflict maps integrity constraint violations to ErrDetailConflict.
+func detailConflict(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code.Class() == "23" {
+		return ErrDetailConflict
+	}
+	return err
+}
+
+// deleteDetail removes the employee's row id from table; table is never
+// user input.
+func (r *personalDetailsPostgresRepository) deleteDetail(ctx context.Context, table string, employeeID, id int64) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM `+table+` WHERE id = $1 AND employee_id = $2`, id, employeeID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *personalDetailsPostgresRepository) ListAddresses(ctx context.Context, employeeID int64) ([]*models.EmployeeAddress, error) {
+	query := `
+		SELECT id, employee_id, kind, line1, line2, city, region, postal_code, country, is_primary, created_at, updated_at
+		FROM employee_addresses
+		WHERE employee_id = $1
+		ORDER BY is_primary DESC, id
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.EmployeeAddress
+	for rows.Next() {
+		var a models.EmployeeAddress
+		if err := rows.Scan(&a.ID, &a.EmployeeID, &a.Kind, &a.Line1, &a.Line2, &a.City, &a.Region, &a.PostalCode, &a.Country, &a.IsPrimary, &a.CreatedAt, &a.UpdatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, &a)
+	}
+	return res, rows.Err()
+}
+
+func (r *personalDetailsPostgresRepository) CreateAddress(ctx context.Context, a *models.EmployeeAddress) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if a.IsPrimary {
+		if err := clearPrimary(ctx, tx, "employee_addresses", a.EmployeeID, 0); err != nil {
+			return err
+		}
+	}
+	query := `
+		INSERT INTO employee_addresses (employee_id, kind, line1, line2, city, region, postal_code, country, is_primary)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at, updated_at
+	`
+	if err := tx.QueryRowContext(ctx, query,
+		a.EmployeeID, a.Kind, a.Line1, a.Line2, a.City, a.Region, a.PostalCode, a.Country, a.IsPrimary,
+	).Scan(&a.ID, &a.CreatedAt, &a.UpdatedAt); err != nil {
+		return detailConflict(err)
+	}
+	return tx.Commit()
+}
+
+func (r *personalDetailsPostgresRepository) UpdateAddress(ctx context.Context, a *models.EmployeeAddress) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if a.IsPrimary {
+		if err := clearPrimary(ctx, tx, "employee_addresses", a.EmployeeID, a.ID); err != nil {
+			return err
+		}
+	}
+	query := `
+		UPDATE employee_addresses
+		SET kind = $1, line1 = $2, line2 = $3, city = $4, region = $5, postal_code = $6,
+			country = $7, is_primary = $8, updated_at = now()
+		WHERE id = $9 AND employee_id = $10
+		RETURNING created_at, updated_at
+	`
+	if err := tx.QueryRowContext(ctx, query,
+		a.Kind, a.Line1, a.Line2, a.City, a.Region, a.PostalCode, a.Country, a.IsPrimary, a.ID, a.EmployeeID,
+	).Scan(&a.CreatedAt, &a.UpdatedAt); err != nil {
+		return detailConflict(err)
+	}
+	return tx.Commit()
+}
+
+func (r *personalDetailsPostgresRepository) DeleteAddress(ctx context.Context, employeeID, id int64) error {
+	return r.deleteDetail(ctx, "employee_addresses", employeeID, id)
+}
+
+func (r *personalDetailsPostgresRepository) ListPhones(ctx context.Context, employeeID int64) ([]*models.EmployeePhone, error) {
+	query := `
+		SELECT id, employee_id, kind, number, is_primary, created_at, updated_at
+		FROM employee_phones
+		WHERE employee_id = $1
+		ORDER BY is_primary DESC, id
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.EmployeePhone
+	for rows.Next() {
+		var p models.EmployeePhone
+		if err := rows.Scan(&p.ID, &p.EmployeeID, &p.Kind, &p.Number, &p.IsPrimary, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, &p)
+	}
+	return res, rows.Err()
+}
+
+func (r *personalDetailsPostgresRepository) CreatePhone(ctx context.Context, p *models.EmployeePhone) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if p.IsPrimary {
+		if err := clearPrimary(ctx, tx, "employee_phones", p.EmployeeID, 0); err != nil {
+			return err
+		}
+	}
+	query := `
+		INSERT INTO employee_phones (employee_id, kind, number, is_primary)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`
+	if err := tx.QueryRowContext(ctx, query, p.EmployeeID, p.Kind, p.Number, p.IsPrimary).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		return detailConflict(err)
+	}
+	return tx.Commit()
+}
+
+func (r *personalDetailsPostgresRepository) UpdatePhone(ctx context.Context, p *models.EmployeePhone) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if p.IsPrimary {
+		if err := clearPrimary(ctx, tx, "employee_phones", p.EmployeeID, p.ID); err != nil {
+			return err
+		}
+	}
+	query := `
+		UPDATE employee_phones
+		SET kind = $1, number = $2, is_primary = $3, updated_at = now()
+		WHERE id = $4 AND employee_id = $5
+		RETURNING created_at, updated_at
+	`
+	if err := tx.QueryRowContext(ctx, query, p.Kind, p.Number, p.IsPrimary, p.ID, p.EmployeeID).Scan(&p.CreatedAt, &p.UpdatedAt); err != nil {
+		return detailConflict(err)
+	}
+	return tx.Commit()
+}
+
+func (r *personalDetailsPostgresRepository) DeletePhone(ctx context.Context, employeeID, id int64) error {
+	return r.deleteDetail(ctx, "employee_phones", employeeID, id)
+}
+
+func (r *personalDetailsPostgresRepository) ListEmergencyContacts(ctx context.Context, employeeID int64) ([]*models.EmergencyContact, error) {
+	query := `
+		SELECT id, employee_id, name, relationship, phone, email, priority, created_at, updated_at
+		FROM emergency_contacts
+		WHERE employee_id = $1
+		ORDER BY priority, id
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.EmergencyContact
+	for rows.Next() {
+		var c models.EmergencyContact
+		if err := rows.Scan(&c.ID, &c.EmployeeID, &c.Name, &c.Relationship, &c.Phone, &c.Email, &c.Priority, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, &c)
+	}
+	return res, rows.Err()
+}
+
+func (r *personalDetailsPostgresRepository) CreateEmergencyContact(ctx context.Context, c *models.EmergencyContact) error {
+	query := `
+		INSERT INTO emergency_contacts (employee_id, name, relationship, phone, email, priority)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`
+	return detailConflict(conn(ctx, r.db).QueryRowContext(ctx, query,
+		c.EmployeeID, c.Name, c.Relationship, c.Phone, c.Email, c.Priority,
+	).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt))
+}
+
+func (r *personalDetailsPostgresRepository) UpdateEmergencyContact(ctx context.Context, c *models.EmergencyContact) error {
+	query := `
+		UPDATE emergency_contacts
+		SET name = $1, relationship = $2, phone = $3, email = $4, priority = $5, updated_at = now()
+		WHERE id = $6 AND employee_id = $7
+		RETURNING created_at, updated_at
+	`
+	return detailConflict(conn(ctx, r.db).QueryRowContext(ctx, query,
+		c.Name, c.Relationship, c.Phone, c.Email, c.Priority, c.ID, c.EmployeeID,
+	).Scan(&c.CreatedAt, &c.UpdatedAt))
+}
+
+func (r *personalDetailsPostgresRepository) DeleteEmergencyContact(ctx context.Context, employeeID, id int64) error {
+	return r.deleteDetail(ctx, "emergency_contacts", employeeID, id)
+}
+
+func (r *personalDetailsPostgresRepository) ListDependents(ctx context.Context, employeeID int64) ([]*models.Dependent, error) {
+	query := `
+		SELECT id, employee_id, name, relationship, date_of_birth, tax_id, relief_from, relief_to, created_at, updated_at
+		FROM employee_dependents
+		WHERE employee_id = $1
+		ORDER BY id
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.Dependent
+	for rows.Next() {
+		var d models.Dependent
+		if err := rows.Scan(&d.ID, &d.EmployeeID, &d.Name, &d.Relationship, &d.DateOfBirth, &d.TaxID, &d.ReliefFrom, &d.ReliefTo, &d.CreatedAt, &d.UpdatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, &d)
+	}
+	return res, rows.Err()
+}
+
+func (r *personalDetailsPostgresRepository) CreateDependent(ctx context.Context, d *models.Dependent) error {
+	query := `
+		INSERT INTO employee_dependents (employee_id, name, relationship, date_of_birth, tax_id, relief_from, relief_to)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`
+	return detailConflict(conn(ctx, r.db).QueryRowContext(ctx, query,
+		d.EmployeeID, d.Name, d.Relationship, d.DateOfBirth, d.TaxID, d.ReliefFrom, d.ReliefTo,
+	).Scan(&d.ID, &d.CreatedAt, &d.UpdatedAt))
+}
+
+func (r *personalDetailsPostgresRepository) UpdateDependent(ctx context.Context, d *models.Dependent) error {
+	query := `
+		UPDATE employee_dependents
+		SET name = $1, relationship = $2, date_of_birth = $3, tax_id = $4, relief_from = $5,
+			relief_to = $6, updated_at = now()
+		WHERE id = $7 AND employee_id = $8
+		RETURNING created_at, updated_at
+	`
+	return detailConflict(conn(ctx, r.db).QueryRowContext(ctx, query,
+		d.Name, d.Relationship, d.DateOfBirth, d.TaxID, d.ReliefFrom, d.ReliefTo, d.ID, d.EmployeeID,
+	).Scan(&d.CreatedAt, &d.UpdatedAt))
+}
+
+func (r *personalDetailsPostgresRepository) DeleteDependent(ctx context.Context, employeeID, id int64) error {
+	return r.deleteDetail(ctx, "employee_dependents", employeeID, id)
+}
+
+func (r *personalDetailsPostgresRepository) TaxDependentCounts(ctx context.Context, from, to time.Time) (map[int64]int, error) {
+	query := `
+		SELECT employee_id, COUNT(*)
+		FROM employee_dependents
+		WHERE relief_from <= $2 AND (relief_to IS NULL OR relief_to >= $1)
+		GROUP BY employee_id
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[int64]int{}
+	for rows.Next() {
+		var id int64
+		var n int
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, err
+		}
+		counts[id] = n
+	}
+	return counts, rows.Err()
+}
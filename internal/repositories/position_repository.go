@@ -0,0 +1,191 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"app/internal/models"
+)
+
+type positionPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPositionRepository(db *sql.DB) PositionRepository {
+	return &positionPostgresRepository{db: db}
+}
+
+type PositionRepository interface {
+	Create(ctx context.Context, p *models.Position) error
+	FindByID(ctx context.Context, id int64) (*models.Position, error)
+	FindAll(ctx context.Context, limit, offset int, keyword, jobFamily *string) ([]*models.Position, int64, error)
+	Resolve(ctx context.Context, name string) (*models.Position, error)
+	Update(ctx context.Context, p *models.Position) error
+	Delete(ctx context.Context, id int64) error
+	CountEmployees(ctx context.Context, id int64) (int64, error)
+	Merge(ctx context.Context, targetID int64, sourceIDs []int64) error
+}
+
+const positionColumns = `p.id, p.code, p.title, p.level, p.job_family, p.created_at, p.updated_at`
+
+func scanPosition(row rowScanner) (*models.Position, error) {
+	var p models.Position
+	if err := row.Scan(&p.ID, &p.Code, &p.Title, &p.Level, &p.JobFamily, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *positionPostgresRepository) Create(ctx context.Context, p *models.Position) error {
+	query := `
+		INSERT INTO positions (code, title, level, job_family)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query, p.Code, p.Title, p.Level, p.JobFamily).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+}
+
+func (r *positionPostgresRepository) FindByID(ctx context.Context, id int64) (*models.Position, error) {
+	query := `SELECT ` + positionColumns + ` FROM positions p WHERE p.id = $1`
+	return scanPosition(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+}
+
+func (r *positionPostgresRepository) FindAll(ctx context.Context, limit, offset int, keyword, jobFamily *string) ([]*models.Position, int64, error) {
+	whereParts := []string{}
+	args := []interface{}{}
+	if keyword != nil && *keyword != "" {
+		whereParts = append(whereParts, "(p.code ILIKE $"+strconv.Itoa(len(args)+1)+" OR p.title ILIKE $"+strconv.Itoa(len(args)+1)+")")
+		args = append(args, "%"+*keyword+"%")
+	}
+	if jobFamily != nil && *jobFamily != "" {
+		whereParts = append(whereParts, "p.job_family ILIKE $"+strconv.Itoa(len(args)+1))
+		args = append(args, *jobFamily)
+	}
+
+	where := ""
+	if len(whereParts) > 0 {
+		where = "WHERE " + strings.Join(whereParts, " AND ")
+	}
+
+	var total int64
+	if err := conn(ctx, r.db).QueryRowContext(ctx, "SELECT COUNT(*) FROM positions p "+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	argPos := len(args) + 1
+	args = append(args, limit, offset)
+	query := "SELECT " + positionColumns + " FROM positions p " + where +
+		" ORDER BY p.code LIMIT $" + strconv.Itoa(argPos) + " OFFSET $" + strconv.Itoa(argPos+1)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var positions []*models.Position
+	for rows.Next() {
+		p, err := scanPosition(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		positions = append(positions, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return positions, total, nil
+}
+
+// Resolve finds the position a free-text name refers to, matching a known
+// alias, the code or the title, all case-insensitively.
+func (r *positionPostgresRepository) Resolve(ctx context.Context, name string) (*models.Position, error) {
+	query := `
+		SELECT ` + positionColumns + `
+		FROM positions p
+		LEFT JOIN position_aliases a ON a.position_id = p.id AND a.alias = lower(btrim($1))
+		WHERE a.alias IS NOT NULL
+		   OR upper(p.code) = upper(btrim($1))
+		   OR lower(p.title) = lower(btrim($1))
+		ORDER BY (a.alias IS NOT NULL) DESC, p.id
+		LIMIT 1
+	`
+	return scanPosition(conn(ctx, r.db).QueryRowContext(ctx, query, name))
+}
+
+func (r *positionPostgresRepository) Update(ctx context.Context, p *models.Position) error {
+	query := `
+		UPDATE positions
+		SET code = $1, title = $2, level = $3, job_family = $4, updated_at = now()
+		WHERE id = $5
+		RETURNING updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query, p.Code, p.Title, p.Level, p.JobFamily, p.ID).Scan(&p.UpdatedAt)
+}
+
+func (r *positionPostgresRepository) Delete(ctx context.Context, id int64) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM positions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// CountEmployees counts the employees who hold the position now or held it
+// according to their employment history.
+func (r *positionPostgresRepository) CountEmployees(ctx context.Context, id int64) (int64, error) {
+	query := `
+		SELECT COUNT(*) FROM (
+			SELECT id FROM employees WHERE position_id = $1
+			UNION
+			SELECT employee_id FROM employment_events WHERE to_position_id = $1
+		) holders
+	`
+	var n int64
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(&n)
+	return n, err
+}
+
+// Merge folds the source positions into the target: employees, their
+// employment history and aliases are repointed, the sources' codes and titles become aliases of the target,
+// bands the target already defines win, and the sources are deleted.
+func (r *positionPostgresRepository) Merge(ctx context.Context, targetID int64, sourceIDs []int64) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, sourceID := range sourceIDs {
+		stmts := []string{
+			`UPDATE employees SET position_id = $1 WHERE position_id = $2`,
+			`UPDATE employment_events SET to_position_id = $1 WHERE to_position_id = $2`,
+			`INSERT INTO position_aliases (alias, position_id)
+			 SELECT lower(btrim(v)), $1 FROM positions, unnest(ARRAY[code, title]) AS v WHERE id = $2
+			 ON CONFLICT (alias) DO UPDATE SET position_id = EXCLUDED.position_id`,
+			`UPDATE position_aliases SET position_id = $1 WHERE position_id = $2`,
+			`DELETE FROM salary_bands s
+			 WHERE s.position_id = $2
+			   AND EXISTS (
+			     SELECT 1 FROM salary_bands t
+			     WHERE t.position_id = $1 AND COALESCE(t.department_id, 0) = COALESCE(s.department_id, 0)
+			   )`,
+			`UPDATE salary_bands SET position_id = $1 WHERE position_id = $2`,
+			`DELETE FROM positions WHERE id = $2`,
+		}
+		for _, stmt := range stmts {
+			if _, err := tx.ExecContext(ctx, stmt, targetID, sourceID); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,435 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"app/internal/models"
+)
+
+type recruitingPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewRecruitingRepository(db *sql.DB) RecruitingRepository {
+	return &recruitingPostgresRepository{db: db}
+}
+
+type RecruitingRepository interface {
+	ListStages(ctx context.Context) ([]*models.PipelineStage, error)
+	FindStage(ctx context.Context, id int64) (*models.PipelineStage, error)
+	CreateStage(ctx context.Context, s *models.PipelineStage) error
+	UpdateStage(ctx context.Context, s *models.PipelineStage) error
+	DeleteStage(ctx context.Context, id int64) error
+	StageInUse(ctx context.Context, id int64) (bool, error)
+
+	CreateRequisition(ctx context.Context, req *models.JobRequisition) error
+	FindRequisition(ctx context.Context, id int64) (*models.JobRequisition, error)
+	UpdateRequisition(ctx context.Context, req *models.JobRequisition) error
+	ListRequisitions(ctx context.Context, departmentID *int64, status string) ([]*models.JobRequisition, error)
+
+	CreateCandidate(ctx context.Context, c *models.Candidate) error
+	FindCandidate(ctx context.Context, id int64) (*models.Candidate, error)
+	UpdateCandidate(ctx context.Context, c *models.Candidate) error
+	ListCandidates(ctx context.Context, requisitionID int64, stageID *int64, status string) ([]*models.Candidate, error)
+	MoveCandidate(ctx context.Context, c *models.Candidate, fromStageID int64, note *string) error
+	// ClaimCandidate marks an active candidate hired while their
+	// requisition is open with an opening left, before the employee is
+	// created. It returns sql.ErrNoRows when the candidate or opening is
+	// already taken.
+	ClaimCandidate(ctx context.Context, c *models.Candidate) error
+	HireCandidate(ctx context.Context, c *models.Candidate, employeeID int64) error
+	ListStageChanges(ctx context.Context, candidateID int64) ([]*models.CandidateStageChange, error)
+
+	CreateFeedback(ctx context.Context, f *models.InterviewFeedback) error
+	ListFeedback(ctx context.Context, candidateID int64) ([]*models.InterviewFeedback, error)
+}
+
+func scanPipelineStage(row rowScanner) (*models.PipelineStage, error) {
+	var s models.PipelineStage
+	if err := row.Scan(&s.ID, &s.Name, &s.Position, &s.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *recruitingPostgresRepository) ListStages(ctx context.Context) ([]*models.PipelineStage, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT id, name, position, created_at FROM pipeline_stages ORDER BY position`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.PipelineStage
+	for rows.Next() {
+		s, err := scanPipelineStage(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	return res, rows.Err()
+}
+
+func (r *recruitingPostgresRepository) FindStage(ctx context.Context, id int64) (*models.PipelineStage, error) {
+	return scanPipelineStage(conn(ctx, r.db).QueryRowContext(ctx, `SELECT id, name, position, created_at FROM pipeline_stages WHERE id = $1`, id))
+}
+
+func (r *recruitingPostgresRepository) CreateStage(ctx context.Context, s *models.PipelineStage) error {
+	return conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO pipeline_stages (name, position) VALUES ($1, $2) RETURNING id, created_at`,
+		s.Name, s.Position,
+	).Scan(&s.ID, &s.CreatedAt)
+}
+
+func (r *recruitingPostgresRepository) UpdateStage(ctx context.Context, s *models.PipelineStage) error {
+	return conn(ctx, r.db).QueryRowContext(ctx,
+		`UPDATE pipeline_stages SET name = $1, position = $2 WHERE id = $3 RETURNING created_at`,
+		s.Name, s.Position, s.ID,
+	).Scan(&s.CreatedAt)
+}
+
+func (r *recruitingPostgresRepository) DeleteStage(ctx context.Context, id int64) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM pipeline_stages WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// StageInUse reports whether any candidate, past stage change or feedback
+// refers to the stage.
+func (r *recruitingPostgresRepository) StageInUse(ctx context.Context, id int64) (bool, error) {
+	var used bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM candidates WHERE stage_id = $1)
+			OR EXISTS (SELECT 1 FROM candidate_stage_changes WHERE from_stage_id = $1 OR to_stage_id = $1)
+			OR EXISTS (SELECT 1 FROM interview_feedback WHERE stage_id = $1)
+	`, id).Scan(&used)
+	return used, err
+}
+
+const requisitionSelect = `
+	SELECT jr.id, jr.department_id, jr.position_id, jr.title, jr.openings, jr.status, jr.hiring_manager_id,
+		jr.salary_min, jr.salary_max, jr.salary_currency, jr.description, jr.opened_on, jr.closed_on,
+		jr.created_at, jr.updated_at,
+		(SELECT COUNT(*) FROM candidates c WHERE c.requisition_id = jr.id AND c.status = 'hired')
+	FROM job_requisitions jr
+`
+
+func scanRequisition(row rowScanner) (*models.JobRequisition, error) {
+	var jr models.JobRequisition
+	if err := row.Scan(
+		&jr.ID, &jr.DepartmentID, &jr.PositionID, &jr.Title, &jr.Openings, &jr.Status, &jr.HiringManagerID,
+		&jr.SalaryMin, &jr.SalaryMax, &jr.SalaryCurrency, &jr.Description, &jr.OpenedOn, &jr.ClosedOn,
+		&jr.CreatedAt, &jr.UpdatedAt, &jr.Hired,
+	); err != nil {
+		return nil, err
+	}
+	return &jr, nil
+}
+
+func (r *recruitingPostgresRepository) CreateRequisition(ctx context.Context, jr *models.JobRequisition) error {
+	query := `
+		INSERT INTO job_requisitions (
+			department_id, position_id, title, openings, status, hiring_manager_id,
+			salary_min, salary_max, salary_currency, description, opened_on
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at, updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		jr.DepartmentID, jr.PositionID, jr.Title, jr.Openings, jr.Status, jr.HiringManagerID,
+		jr.SalaryMin, jr.SalaryMax, jr.SalaryCurrency, jr.Description, jr.OpenedOn,
+	).Scan(&jr.ID, &jr.CreatedAt, &jr.UpdatedAt)
+}
+
+func (r *recruitingPostgresRepository) FindRequisition(ctx context.Context, id int64) (*models.JobRequisition, error) {
+	return scanRequisition(conn(ctx, r.db).QueryRowContext(ctx, requisitionSelect+` WHERE jr.id = $1`, id))
+}
+
+func (r *recruitingPostgresRepository) UpdateRequisition(ctx context.Context, jr *models.JobRequisition) error {
+	query := `
+		UPDATE job_requisitions SET
+			position_id = $1, title = $2, openings = $3, status = $4, hiring_manager_id = $5,
+			salary_min = $6, salary_max = $7, salary_currency = $8, description = $9, closed_on = $10,
+			updated_at = now()
+		WHERE id = $11
+		RETURNING updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		jr.PositionID, jr.Title, jr.Openings, jr.Status, jr.HiringManagerID,
+		jr.SalaryMin, jr.SalaryMax, jr.SalaryCurrency, jr.Description, jr.ClosedOn,
+		jr.ID,
+	).Scan(&jr.UpdatedAt)
+}
+
+func (r *recruitingPostgresRepository) ListRequisitions(ctx context.Context, departmentID *int64, status string) ([]*models.JobRequisition, error) {
+	query := requisitionSelect + `
+		WHERE ($1::BIGINT IS NULL OR jr.department_id = $1)
+			AND ($2 = '' OR jr.status = $2)
+		ORDER BY jr.opened_on DESC, jr.id DESC
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, departmentID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.JobRequisition
+	for rows.Next() {
+		jr, err := scanRequisition(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, jr)
+	}
+	return res, rows.Err()
+}
+
+const candidateSelect = `
+	SELECT c.id, c.requisition_id, c.name, c.email, c.phone, c.source, c.stage_id, c.status,
+		c.offered_salary, c.offered_currency, c.expected_start_date, c.employee_id, c.note,
+		c.created_at, c.updated_at, s.name
+	FROM candidates c
+	JOIN pipeline_stages s ON s.id = c.stage_id
+`
+
+func scanCandidate(row rowScanner) (*models.Candidate, error) {
+	var c models.Candidate
+	if err := row.Scan(
+		&c.ID, &c.RequisitionID, &c.Name, &c.Email, &c.Phone, &c.Source, &c.StageID, &c.Status,
+		&c.OfferedSalary, &c.OfferedCurrency, &c.ExpectedStartDate, &c.EmployeeID, &c.Note,
+		&c.CreatedAt, &c.UpdatedAt, &c.StageName,
+	); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// CreateCandidate adds the candidate and records their entry into the
+// first stage.
+func (r *recruitingPostgresRepository) CreateCandidate(ctx context.Context, c *models.Candidate) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO candidates (
+			requisition_id, name, email, phone, source, stage_id, status,
+			offered_salary, offered_currency, expected_start_date, note
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at, updated_at
+	`
+	if err := tx.QueryRowContext(ctx, query,
+		c.RequisitionID, c.Name, c.Email, c.Phone, c.Source, c.StageID, c.Status,
+		c.OfferedSalary, c.OfferedCurrency, c.ExpectedStartDate, c.Note,
+	).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO candidate_stage_changes (candidate_id, to_stage_id) VALUES ($1, $2)`,
+		c.ID, c.StageID,
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *recruitingPostgresRepository) FindCandidate(ctx context.Context, id int64) (*models.Candidate, error) {
+	return scanCandidate(conn(ctx, r.db).QueryRowContext(ctx, candidateSelect+` WHERE c.id = $1`, id))
+}
+
+// UpdateCandidate saves the contact details, offer and status; the stage
+// only changes through MoveCandidate.
+func (r *recruitingPostgresRepository) UpdateCandidate(ctx context.Context, c *models.Candidate) error {
+	query := `
+		UPDATE candidates SET
+			name = $1, email = $2, phone = $3, source = $4, status = $5,
+			offered_salary = $6, offered_currency = $7, expected_start_date = $8, note = $9,
+			updated_at = now()
+		WHERE id = $10
+		RETURNING updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		c.Name, c.Email, c.Phone, c.Source, c.Status,
+		c.OfferedSalary, c.OfferedCurrency, c.ExpectedStartDate, c.Note,
+		c.ID,
+	).Scan(&c.UpdatedAt)
+}
+
+func (r *recruitingPostgresRepository) ListCandidates(ctx context.Context, requisitionID int64, stageID *int64, status string) ([]*models.Candidate, error) {
+	query := candidateSelect + `
+		WHERE c.requisition_id = $1
+			AND ($2::BIGINT IS NULL OR c.stage_id = $2)
+			AND ($3 = '' OR c.status = $3)
+		ORDER BY s.position DESC, c.created_at
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, requisitionID, stageID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.Candidate
+	for rows.Next() {
+		c, err := scanCandidate(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, c)
+	}
+	return res, rows.Err()
+}
+
+// MoveCandidate puts the candidate in c.StageID and records the change.
+func (r *recruitingPostgresRepository) MoveCandidate(ctx context.Context, c *models.Candidate, fromStageID int64, note *string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRowContext(ctx,
+		`UPDATE candidates SET stage_id = $1, updated_at = now() WHERE id = $2 RETURNING updated_at`,
+		c.StageID, c.ID,
+	).Scan(&c.UpdatedAt); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO candidate_stage_changes (candidate_id, from_stage_id, to_stage_id, note) VALUES ($1, $2, $3, $4)`,
+		c.ID, fromStageID, c.StageID, note,
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *recruitingPostgresRepository) ClaimCandidate(ctx context.Context, c *models.Candidate) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Locking the requisition serialises concurrent offers against its
+	// openings.
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM job_requisitions WHERE id = $1 FOR UPDATE`, c.RequisitionID); err != nil {
+		return err
+	}
+	if err := tx.QueryRowContext(ctx, `
+		UPDATE candidates c SET status = 'hired', updated_at = now()
+		WHERE c.id = $1 AND c.status = 'active' AND EXISTS (
+			SELECT 1 FROM job_requisitions jr
+			WHERE jr.id = c.requisition_id AND jr.status = 'open'
+				AND (SELECT COUNT(*) FROM candidates h WHERE h.requisition_id = jr.id AND h.status = 'hired') < jr.openings
+		)
+		RETURNING status, updated_at
+	`, c.ID).Scan(&c.Status, &c.UpdatedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// HireCandidate links the claimed candidate to their new employee record
+// and marks the requisition filled once every opening is taken.
+func (r *recruitingPostgresRepository) HireCandidate(ctx context.Context, c *models.Candidate, employeeID int64) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRowContext(ctx,
+		`UPDATE candidates SET employee_id = $1, updated_at = now() WHERE id = $2 RETURNING status, updated_at`,
+		employeeID, c.ID,
+	).Scan(&c.Status, &c.UpdatedAt); err != nil {
+		return err
+	}
+	c.EmployeeID = &employeeID
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE job_requisitions jr SET status = 'filled', closed_on = CURRENT_DATE, updated_at = now()
+		WHERE jr.id = $1 AND jr.status = 'open'
+			AND (SELECT COUNT(*) FROM candidates c WHERE c.requisition_id = jr.id AND c.status = 'hired') >= jr.openings
+	`, c.RequisitionID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *recruitingPostgresRepository) ListStageChanges(ctx context.Context, candidateID int64) ([]*models.CandidateStageChange, error) {
+	query := `
+		SELECT ch.id, ch.candidate_id, ch.from_stage_id, ch.to_stage_id, s.name, ch.note, ch.changed_at
+		FROM candidate_stage_changes ch
+		JOIN pipeline_stages s ON s.id = ch.to_stage_id
+		WHERE ch.candidate_id = $1
+		ORDER BY ch.changed_at, ch.id
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.CandidateStageChange
+	for rows.Next() {
+		var ch models.CandidateStageChange
+		if err := rows.Scan(&ch.ID, &ch.CandidateID, &ch.FromStageID, &ch.ToStageID, &ch.ToStageName, &ch.Note, &ch.ChangedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, &ch)
+	}
+	return res, rows.Err()
+}
+
+func (r *recruitingPostgresRepository) CreateFeedback(ctx context.Context, f *models.InterviewFeedback) error {
+	query := `
+		INSERT INTO interview_feedback (candidate_id, stage_id, interviewer_id, rating, recommendation, comment, interviewed_on)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		f.CandidateID, f.StageID, f.InterviewerID, f.Rating, f.Recommendation, f.Comment, f.InterviewedOn,
+	).Scan(&f.ID, &f.CreatedAt)
+}
+
+func (r *recruitingPostgresRepository) ListFeedback(ctx context.Context, candidateID int64) ([]*models.InterviewFeedback, error) {
+	query := `
+		SELECT f.id, f.candidate_id, f.stage_id, s.name, f.interviewer_id, e.name,
+			f.rating, f.recommendation, f.comment, f.interviewed_on, f.created_at
+		FROM interview_feedback f
+		JOIN pipeline_stages s ON s.id = f.stage_id
+		JOIN employees e ON e.id = f.interviewer_id
+		WHERE f.candidate_id = $1
+		ORDER BY f.interviewed_on, f.id
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.InterviewFeedback
+	for rows.Next() {
+		var f models.InterviewFeedback
+		if err := rows.Scan(
+			&f.ID, &f.CandidateID, &f.StageID, &f.StageName, &f.InterviewerID, &f.InterviewerName,
+			&f.Rating, &f.Recommendation, &f.Comment, &f.InterviewedOn, &f.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		res = append(res, &f)
+	}
+	return res, rows.Err()
+}
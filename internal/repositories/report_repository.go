@@ -0,0 +1,496 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"app/internal/models"
+
+	"github.com/lib/pq"
+)
+
+type reportPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewReportRepository(db *sql.DB) ReportRepository {
+	return &reportPostgresRepository{db: db}
+}
+
+type ReportRepository interface {
+	SalaryTotalsByDepartment(ctx context.Context) ([]*models.SalaryTotal, error)
+	BandPlacements(ctx context.Context, departmentID *int64) ([]*models.BandPlacement, error)
+	DepartmentPlans(ctx context.Context, fiscalYear int, departmentID *int64) ([]*models.Department, error)
+	PlanMembers(ctx context.Context, asOf, end time.Time) ([]*models.PlanMember, error)
+	MonthlyHeadcount(ctx context.Context, from, to time.Time, departmentID *int64) ([]*models.MonthlyHeadcount, error)
+	TenureDistribution(ctx context.Context, asOf time.Time, departmentID *int64) ([]*models.DistributionBucket, error)
+	AgeDistribution(ctx context.Context, asOf time.Time, departmentID *int64) ([]*models.DistributionBucket, error)
+	SalaryCurrencies(ctx context.Context, departmentID *int64) ([]string, error)
+	SalaryStatistics(ctx context.Context, attribute string, rates map[string]models.Decimal, departmentID *int64, minGroupSize int) ([]*models.SalaryStatistics, error)
+}
+
+// SalaryTotalsByDepartment sums current salaries per department and
+// currency; converting the per-currency sums is left to the caller.
+func (r *reportPostgresRepository) SalaryTotalsByDepartment(ctx context.Context) ([]*models.SalaryTotal, error) {
+	query := `
+		SELECT d.id, d.name, comp.currency, COUNT(*), SUM(comp.amount)
+		FROM employees e
+		JOIN departments d ON d.id = e.department_id
+	` + currentCompensationJoin + `
+		WHERE comp.amount IS NOT NULL
+		GROUP BY d.id, d.name, comp.currency
+		ORDER BY d.id, comp.currency
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.SalaryTotal
+	for rows.Next() {
+		var t models.SalaryTotal
+		if err := rows.Scan(&t.DepartmentID, &t.DepartmentName, &t.Currency, &t.Employees, &t.Total); err != nil {
+			return nil, err
+		}
+		res = append(res, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// BandPlacements pairs every employee that has a position and a salary with
+// the band that applies to them. Status and compa-ratio are left to the
+// service since the salary may first need converting to the band currency.
+func (r *reportPostgresRepository) BandPlacements(ctx context.Context, departmentID *int64) ([]*models.BandPlacement, error) {
+	query := `
+		SELECT
+			e.id, e.name, e.department_id, p.id, p.title, comp.amount, comp.currency,
+			band.id, band.position_id, band.department_id, band.min_amount, band.mid_amount, band.max_amount, band.currency
+		FROM employees e
+		JOIN positions p ON p.id = e.position_id
+	` + currentCompensationJoin + `
+		JOIN LATERAL (
+			SELECT b.*
+			FROM salary_bands b
+			WHERE b.position_id = e.position_id AND (b.department_id = e.department_id OR b.department_id IS NULL)
+			ORDER BY b.department_id NULLS LAST
+			LIMIT 1
+		) band ON true
+		WHERE comp.amount IS NOT NULL AND ($1::BIGINT IS NULL OR e.department_id = $1)
+		ORDER BY e.department_id, e.id
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.BandPlacement
+	for rows.Next() {
+		var p models.BandPlacement
+		if err := rows.Scan(
+			&p.EmployeeID, &p.EmployeeName, &p.DepartmentID, &p.PositionID, &p.PositionTitle, &p.Salary, &p.SalaryCurrency,
+			&p.Band.ID, &p.Band.PositionID, &p.Band.DepartmentID, &p.Band.Min, &p.Band.Mid, &p.Band.Max, &p.Band.Currency,
+		); err != nil {
+			return nil, err
+		}
+		res = append(res, &p)
+	}
+	return res, rows.Err()
+}
+
+// DepartmentPlans lists departments, or just departmentID, each with its
+// plan for the fiscal year in Plans when it has one.
+func (r *reportPostgresRepository) DepartmentPlans(ctx context.Context, fiscalYear int, departmentID *int64) ([]*models.Department, error) {
+	query := `
+		SELECT d.id, d.name, p.fiscal_year, p.planned_headcount, p.salary_budget, p.currency, p.note, p.created_at, p.updated_at
+		FROM departments d
+		LEFT JOIN department_plans p ON p.department_id = d.id AND p.fiscal_year = $1
+		WHERE ($2::BIGINT IS NULL OR d.id = $2)
+		ORDER BY d.id
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, fiscalYear, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.Department
+	for rows.Next() {
+		var (
+			d         models.Department
+			year      sql.NullInt64
+			headcount sql.NullInt64
+			budget    *models.Decimal
+			currency  sql.NullString
+			note      *string
+			createdAt sql.NullTime
+			updatedAt sql.NullTime
+		)
+		if err := rows.Scan(&d.ID, &d.Name, &year, &headcount, &budget, &currency, &note, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+		if year.Valid {
+			d.Plans = []*models.DepartmentPlan{{
+				DepartmentID:     d.ID,
+				FiscalYear:       int(year.Int64),
+				PlannedHeadcount: int(headcount.Int64),
+				SalaryBudget:     *budget,
+				Currency:         currency.String,
+				Note:             note,
+				CreatedAt:        createdAt.Time,
+				UpdatedAt:        updatedAt.Time,
+			}}
+		}
+		res = append(res, &d)
+	}
+	return res, rows.Err()
+}
+
+// PlanMembers lists everyone employed at some point between asOf and end,
+// with their department and salary on asOf, their salary on end and the
+// transfer scheduled before end, if any. Both the filter and the department
+// are point in time, so a past year is not skewed by later terminations and
+// transfers.
+func (r *reportPostgresRepository) PlanMembers(ctx context.Context, asOf, end time.Time) ([]*models.PlanMember, error) {
+	query := `
+		SELECT e.id, hd.department_id, e.hire_date, e.termination_date,
+			now_comp.amount, now_comp.currency, end_comp.amount, end_comp.currency,
+			tr.to_department_id, tr.effective_date
+		FROM employees e
+	` + departmentOn("$1::date") + `
+		LEFT JOIN LATERAL (
+			SELECT c.amount, c.currency
+			FROM employee_compensations c
+			WHERE c.employee_id = e.id AND c.effective_date <= $1
+			ORDER BY c.effective_date DESC, c.id DESC
+			LIMIT 1
+		) now_comp ON true
+		LEFT JOIN LATERAL (
+			SELECT c.amount, c.currency
+			FROM employee_compensations c
+			WHERE c.employee_id = e.id AND c.effective_date <= $2
+			ORDER BY c.effective_date DESC, c.id DESC
+			LIMIT 1
+		) end_comp ON true
+		LEFT JOIN LATERAL (
+			SELECT ev.to_department_id, ev.effective_date
+			FROM employment_events ev
+			WHERE ev.employee_id = e.id AND ev.kind = 'transfer' AND ev.applied_at IS NULL AND ev.effective_date <= $2
+			ORDER BY ev.effective_date DESC
+			LIMIT 1
+		) tr ON true
+		WHERE e.status <> 'candidate'
+		  AND e.hire_date <= $2::date AND (e.termination_date IS NULL OR e.termination_date >= $1::date)
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, asOf, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.PlanMember
+	for rows.Next() {
+		var m models.PlanMember
+		if err := rows.Scan(
+			&m.EmployeeID, &m.DepartmentID, &m.HireDate, &m.TerminationDate,
+			&m.Salary, &m.SalaryCurrency, &m.EndSalary, &m.EndSalaryCurrency,
+			&m.TransferTo, &m.TransferEffectiveOn,
+		); err != nil {
+			return nil, err
+		}
+		res = append(res, &m)
+	}
+	return res, rows.Err()
+}
+
+// departmentOn resolves, as hd.department_id, the department employee e
+// belonged to on date: the target of the latest applied hire, rehire or
+// transfer by then, or the current department for history predating the
+// employment events. date is an SQL expression, never user input.
+func departmentOn(date string) string {
+	return `
+		CROSS JOIN LATERAL (
+			SELECT COALESCE((
+				SELECT ev.to_department_id
+				FROM employment_events ev
+				WHERE ev.employee_id = e.id AND ev.applied_at IS NOT NULL
+				  AND ev.to_department_id IS NOT NULL AND ev.effective_date <= ` + date + `
+				ORDER BY ev.effective_date DESC, ev.id DESC
+				LIMIT 1
+			), e.department_id) AS department_id
+		) hd
+	`
+}
+
+// MonthlyHeadcount counts hires, leavers and staff per department for each
+// calendar month from the month of from to the month of to. Employment
+// spells come from the hire, rehire and termination events rather than the
+// employee row, which a rehire overwrites, so earlier spells still count;
+// a termination event takes effect the day after the last day worked.
+// Employees are attributed to the department they were in at the end of
+// the month, or on their last day if they left during it. Months without
+// staff are included with zeros.
+func (r *reportPostgresRepository) MonthlyHeadcount(ctx context.Context, from, to time.Time, departmentID *int64) ([]*models.MonthlyHeadcount, error) {
+	query := `
+		WITH months AS (
+			SELECT gs::date AS month_start, (gs + interval '1 month' - interval '1 day')::date AS month_end
+			FROM generate_series(date_trunc('month', $1::date), date_trunc('month', $2::date), interval '1 month') gs
+		), spells AS (
+			SELECT h.employee_id, h.effective_date AS hire_date, (
+				SELECT MIN(t.effective_date) - 1
+				FROM employment_events t
+				WHERE t.employee_id = h.employee_id AND t.kind = 'termination' AND t.effective_date > h.effective_date
+			) AS termination_date
+			FROM employment_events h
+			WHERE h.kind IN ('hire', 'rehire')
+		), facts AS (
+			SELECT m.month_start, hd.department_id,
+				sp.hire_date < m.month_start AS opening,
+				sp.hire_date >= m.month_start AS hired,
+				sp.termination_date IS NOT NULL AND sp.termination_date <= m.month_end AS left_company
+			FROM months m
+			JOIN spells sp ON sp.hire_date <= m.month_end
+				AND (sp.termination_date IS NULL OR sp.termination_date >= m.month_start)
+			JOIN employees e ON e.id = sp.employee_id
+	` + departmentOn("LEAST(m.month_end, COALESCE(sp.termination_date, m.month_end))") + `
+		)
+		SELECT m.month_start, d.id, d.name,
+			COUNT(*) FILTER (WHERE f.opening),
+			COUNT(*) FILTER (WHERE f.hired),
+			COUNT(*) FILTER (WHERE f.left_company),
+			COUNT(*) FILTER (WHERE NOT f.left_company)
+		FROM months m
+		CROSS JOIN departments d
+		LEFT JOIN facts f ON f.month_start = m.month_start AND f.department_id = d.id
+		WHERE ($3::BIGINT IS NULL OR d.id = $3)
+		GROUP BY m.month_start, d.id, d.name
+		ORDER BY m.month_start, d.id
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, from, to, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.MonthlyHeadcount
+	for rows.Next() {
+		var h models.MonthlyHeadcount
+		if err := rows.Scan(&h.Month, &h.DepartmentID, &h.DepartmentName, &h.Opening, &h.Hires, &h.Leavers, &h.Closing); err != nil {
+			return nil, err
+		}
+		res = append(res, &h)
+	}
+	return res, rows.Err()
+}
+
+// TenureDistribution buckets everyone employed on asOf by years since their
+// hire date. Hire dates of employees predating the lifecycle data were
+// backfilled from created_at.
+func (r *reportPostgresRepository) TenureDistribution(ctx context.Context, asOf time.Time, departmentID *int64) ([]*models.DistributionBucket, error) {
+	query := `
+		SELECT d.id, d.name,
+			CASE
+				WHEN t.years < 1 THEN '<1'
+				WHEN t.years < 3 THEN '1-3'
+				WHEN t.years < 5 THEN '3-5'
+				WHEN t.years < 10 THEN '5-10'
+				ELSE '10+'
+			END AS bucket,
+			COUNT(*), SUM(t.days)
+		FROM employees e
+	` + departmentOn("$1::date") + `
+		JOIN departments d ON d.id = hd.department_id
+		CROSS JOIN LATERAL (
+			SELECT date_part('year', age($1::date, e.hire_date)) AS years, ($1::date - e.hire_date) AS days
+		) t
+		WHERE e.status <> 'candidate'
+		  AND e.hire_date <= $1::date AND (e.termination_date IS NULL OR e.termination_date >= $1::date)
+		  AND ($2::BIGINT IS NULL OR d.id = $2)
+		GROUP BY d.id, d.name, bucket
+		ORDER BY d.id
+	`
+	return r.distribution(ctx, query, asOf, departmentID)
+}
+
+// AgeDistribution buckets everyone employed on asOf by their age on that
+// date. Employees without a recorded date of birth fall in the "unknown"
+// bucket.
+func (r *reportPostgresRepository) AgeDistribution(ctx context.Context, asOf time.Time, departmentID *int64) ([]*models.DistributionBucket, error) {
+	query := `
+		SELECT d.id, d.name,
+			CASE
+				WHEN a.years IS NULL THEN 'unknown'
+				WHEN a.years < 25 THEN '<25'
+				WHEN a.years < 35 THEN '25-34'
+				WHEN a.years < 45 THEN '35-44'
+				WHEN a.years < 55 THEN '45-54'
+				ELSE '55+'
+			END AS bucket,
+			COUNT(*), COALESCE(SUM(a.years), 0)
+		FROM employees e
+	` + departmentOn("$1::date") + `
+		JOIN departments d ON d.id = hd.department_id
+		CROSS JOIN LATERAL (
+			SELECT date_part('year', age($1::date, e.date_of_birth))::int AS years
+		) a
+		WHERE e.status <> 'candidate'
+		  AND e.hire_date <= $1::date AND (e.termination_date IS NULL OR e.termination_date >= $1::date)
+		  AND ($2::BIGINT IS NULL OR d.id = $2)
+		GROUP BY d.id, d.name, bucket
+		ORDER BY d.id
+	`
+	return r.distribution(ctx, query, asOf, departmentID)
+}
+
+func (r *reportPostgresRepository) distribution(ctx context.Context, query string, args ...interface{}) ([]*models.DistributionBucket, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.DistributionBucket
+	for rows.Next() {
+		var b models.DistributionBucket
+		if err := rows.Scan(&b.DepartmentID, &b.DepartmentName, &b.Bucket, &b.Employees, &b.Total); err != nil {
+			return nil, err
+		}
+		res = append(res, &b)
+	}
+	return res, rows.Err()
+}
+
+// salaryGroups maps the attributes salary statistics can be grouped by to
+// the SQL for the group key and label; an empty label reuses the key. "all"
+// puts everyone in one group. Age and tenure are taken on CURRENT_DATE,
+// like the salaries from currentCompensationJoin they are grouped with.
+var salaryGroups = map[string][2]string{
+	"all":        {`'all'`, `'All employees'`},
+	"department": {`d.id::text`, `d.name`},
+	"position":   {`COALESCE(pos.id::text, 'none')`, `COALESCE(pos.title, 'No position')`},
+	"status":     {`e.status`, `e.status`},
+	"age": {`
+		CASE
+			WHEN e.date_of_birth IS NULL THEN 'unknown'
+			WHEN date_part('year', age(CURRENT_DATE, e.date_of_birth)) < 25 THEN '<25'
+			WHEN date_part('year', age(CURRENT_DATE, e.date_of_birth)) < 35 THEN '25-34'
+			WHEN date_part('year', age(CURRENT_DATE, e.date_of_birth)) < 45 THEN '35-44'
+			WHEN date_part('year', age(CURRENT_DATE, e.date_of_birth)) < 55 THEN '45-54'
+			ELSE '55+'
+		END`, ``},
+	"tenure": {`
+		CASE
+			WHEN date_part('year', age(CURRENT_DATE, e.hire_date)) < 1 THEN '<1'
+			WHEN date_part('year', age(CURRENT_DATE, e.hire_date)) < 3 THEN '1-3'
+			WHEN date_part('year', age(CURRENT_DATE, e.hire_date)) < 5 THEN '3-5'
+			WHEN date_part('year', age(CURRENT_DATE, e.hire_date)) < 10 THEN '5-10'
+			ELSE '10+'
+		END`, ``},
+}
+
+// salaryStatsFrom selects the current salaries of employed staff converted
+// with the currency -> rate pairs in $1 and $2, optionally limited to the
+// department in $3.
+const salaryStatsFrom = `
+	FROM employees e
+	JOIN departments d ON d.id = e.department_id
+	LEFT JOIN positions pos ON pos.id = e.position_id
+` + currentCompensationJoin + `
+	JOIN unnest($1::text[], $2::numeric[]) AS r(currency, rate) ON r.currency = comp.currency
+	WHERE e.status NOT IN ('candidate', 'terminated')
+	  AND ($3::BIGINT IS NULL OR e.department_id = $3)
+`
+
+// SalaryCurrencies lists the currencies of the current salaries of employed
+// staff, so the caller can look up the rates SalaryStatistics needs.
+func (r *reportPostgresRepository) SalaryCurrencies(ctx context.Context, departmentID *int64) ([]string, error) {
+	query := `
+		SELECT DISTINCT comp.currency
+		FROM employees e
+	` + currentCompensationJoin + `
+		WHERE e.status NOT IN ('candidate', 'terminated') AND comp.currency IS NOT NULL
+		  AND ($1::BIGINT IS NULL OR e.department_id = $1)
+		ORDER BY comp.currency
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []string
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return nil, err
+		}
+		res = append(res, c)
+	}
+	return res, rows.Err()
+}
+
+// SalaryStatistics aggregates current salaries, converted with rates (one
+// per currency, as units of the target currency), per group of attribute.
+// Groups with fewer than minGroupSize employees get no figures beyond
+// their size, so individual salaries cannot be inferred; callers must not
+// publish that size either.
+func (r *reportPostgresRepository) SalaryStatistics(ctx context.Context, attribute string, rates map[string]models.Decimal, departmentID *int64, minGroupSize int) ([]*models.SalaryStatistics, error) {
+	group, ok := salaryGroups[attribute]
+	if !ok {
+		return nil, errors.New("unknown attribute " + attribute)
+	}
+	label := group[1]
+	if label == "" {
+		label = group[0]
+	}
+
+	var currencies, values []string
+	for c, rate := range rates {
+		currencies = append(currencies, c)
+		values = append(values, rate.String())
+	}
+
+	query := `
+		WITH salaries AS (
+			SELECT ` + group[0] + ` AS group_key, ` + label + ` AS group_label, comp.amount * r.rate AS amount
+	` + salaryStatsFrom + `
+		)
+		SELECT group_key, MIN(group_label), COUNT(*), COUNT(*) < $4,
+			CASE WHEN COUNT(*) >= $4 THEN MIN(amount) END,
+			CASE WHEN COUNT(*) >= $4 THEN MAX(amount) END,
+			CASE WHEN COUNT(*) >= $4 THEN AVG(amount) END,
+			CASE WHEN COUNT(*) >= $4 THEN (percentile_cont(0.5) WITHIN GROUP (ORDER BY amount))::numeric END,
+			CASE WHEN COUNT(*) >= $4 THEN (percentile_cont(0.1) WITHIN GROUP (ORDER BY amount))::numeric END,
+			CASE WHEN COUNT(*) >= $4 THEN (percentile_cont(0.25) WITHIN GROUP (ORDER BY amount))::numeric END,
+			CASE WHEN COUNT(*) >= $4 THEN (percentile_cont(0.75) WITHIN GROUP (ORDER BY amount))::numeric END,
+			CASE WHEN COUNT(*) >= $4 THEN (percentile_cont(0.9) WITHIN GROUP (ORDER BY amount))::numeric END,
+			CASE WHEN COUNT(*) >= $4 THEN STDDEV_POP(amount) END
+		FROM salaries
+		GROUP BY group_key
+		ORDER BY MIN(group_label)
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, pq.Array(currencies), pq.Array(values), departmentID, minGroupSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.SalaryStatistics
+	for rows.Next() {
+		var st models.SalaryStatistics
+		if err := rows.Scan(
+			&st.Key, &st.Label, &st.Employees, &st.Suppressed,
+			&st.Min, &st.Max, &st.Mean, &st.Median, &st.P10, &st.P25, &st.P75, &st.P90, &st.StdDev,
+		); err != nil {
+			return nil, err
+		}
+		res = append(res, &st)
+	}
+	return res, rows.Err()
+}
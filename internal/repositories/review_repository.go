@@ -0,0 +1,311 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"app/internal/models"
+)
+
+type reviewPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewReviewRepository(db *sql.DB) ReviewRepository {
+	return &reviewPostgresRepository{db: db}
+}
+
+type ReviewRepository interface {
+	CreateCycle(ctx context.Context, c *models.ReviewCycle) error
+	FindCycle(ctx context.Context, id int64) (*models.ReviewCycle, error)
+	ListCycles(ctx context.Context) ([]*models.ReviewCycle, error)
+	OpenCycle(ctx context.Context, c *models.ReviewCycle) (int, error)
+	CloseCycle(ctx context.Context, c *models.ReviewCycle) error
+
+	FindReview(ctx context.Context, id int64) (*models.PerformanceReview, error)
+	ListReviews(ctx context.Context, cycleID int64, reviewerID, departmentID *int64) ([]*models.PerformanceReview, error)
+	ListReviewsByEmployee(ctx context.Context, employeeID int64) ([]*models.PerformanceReview, error)
+	UpdateReview(ctx context.Context, r *models.PerformanceReview) error
+	Completion(ctx context.Context, cycleID int64) ([]*models.ReviewCompletion, error)
+
+	CreateGoal(ctx context.Context, g *models.ReviewGoal) error
+	FindGoal(ctx context.Context, id int64) (*models.ReviewGoal, error)
+	UpdateGoal(ctx context.Context, g *models.ReviewGoal) error
+	DeleteGoal(ctx context.Context, id int64) error
+	ListGoals(ctx context.Context, employeeID int64, cycleID *int64) ([]*models.ReviewGoal, error)
+}
+
+const reviewCycleColumns = `id, name, period_start, period_end, status, created_at, updated_at`
+
+func scanReviewCycle(row rowScanner) (*models.ReviewCycle, error) {
+	var c models.ReviewCycle
+	if err := row.Scan(&c.ID, &c.Name, &c.PeriodStart, &c.PeriodEnd, &c.Status, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *reviewPostgresRepository) CreateCycle(ctx context.Context, c *models.ReviewCycle) error {
+	query := `
+		INSERT INTO review_cycles (name, period_start, period_end, status)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query, c.Name, c.PeriodStart, c.PeriodEnd, c.Status).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+}
+
+func (r *reviewPostgresRepository) FindCycle(ctx context.Context, id int64) (*models.ReviewCycle, error) {
+	return scanReviewCycle(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+reviewCycleColumns+` FROM review_cycles WHERE id = $1`, id))
+}
+
+func (r *reviewPostgresRepository) ListCycles(ctx context.Context) ([]*models.ReviewCycle, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT `+reviewCycleColumns+` FROM review_cycles ORDER BY period_start DESC, id DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.ReviewCycle
+	for rows.Next() {
+		c, err := scanReviewCycle(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, c)
+	}
+	return res, rows.Err()
+}
+
+// OpenCycle opens a draft cycle and creates a review for every current
+// employee, reviewed by their manager or, failing that, by the head of
+// their department unless they head it themselves. It returns how many
+// reviews were created, or sql.ErrNoRows if the cycle is not a draft.
+func (r *reviewPostgresRepository) OpenCycle(ctx context.Context, c *models.ReviewCycle) (int, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRowContext(ctx,
+		`UPDATE review_cycles SET status = 'open', updated_at = now() WHERE id = $1 AND status = 'draft' RETURNING status, updated_at`,
+		c.ID,
+	).Scan(&c.Status, &c.UpdatedAt); err != nil {
+		return 0, err
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO performance_reviews (cycle_id, employee_id, reviewer_id)
+		SELECT $1, e.id, COALESCE(e.manager_id, NULLIF(d.head_employee_id, e.id))
+		FROM employees e
+		JOIN departments d ON d.id = e.department_id
+		WHERE e.status NOT IN ('candidate', 'terminated')
+		ON CONFLICT (cycle_id, employee_id) DO NOTHING
+	`, c.ID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(n), tx.Commit()
+}
+
+// CloseCycle returns sql.ErrNoRows if the cycle is not open.
+func (r *reviewPostgresRepository) CloseCycle(ctx context.Context, c *models.ReviewCycle) error {
+	return conn(ctx, r.db).QueryRowContext(ctx,
+		`UPDATE review_cycles SET status = 'closed', updated_at = now() WHERE id = $1 AND status = 'open' RETURNING status, updated_at`,
+		c.ID,
+	).Scan(&c.Status, &c.UpdatedAt)
+}
+
+const reviewSelect = `
+	SELECT pr.id, pr.cycle_id, pr.employee_id, pr.reviewer_id,
+		pr.self_rating, pr.self_comment, pr.self_submitted_at,
+		pr.manager_rating, pr.manager_comment, pr.manager_submitted_at,
+		pr.calibrated_rating, pr.calibration_note, pr.calibrated_at,
+		pr.created_at, pr.updated_at, e.name, e.department_id, rv.name
+	FROM performance_reviews pr
+	JOIN employees e ON e.id = pr.employee_id
+	LEFT JOIN employees rv ON rv.id = pr.reviewer_id
+`
+
+func scanReview(row rowScanner) (*models.PerformanceReview, error) {
+	var pr models.PerformanceReview
+	if err := row.Scan(
+		&pr.ID, &pr.CycleID, &pr.EmployeeID, &pr.ReviewerID,
+		&pr.SelfRating, &pr.SelfComment, &pr.SelfSubmittedAt,
+		&pr.ManagerRating, &pr.ManagerComment, &pr.ManagerSubmittedAt,
+		&pr.CalibratedRating, &pr.CalibrationNote, &pr.CalibratedAt,
+		&pr.CreatedAt, &pr.UpdatedAt, &pr.EmployeeName, &pr.DepartmentID, &pr.ReviewerName,
+	); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+func (r *reviewPostgresRepository) queryReviews(ctx context.Context, query string, args ...interface{}) ([]*models.PerformanceReview, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.PerformanceReview
+	for rows.Next() {
+		pr, err := scanReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, pr)
+	}
+	return res, rows.Err()
+}
+
+func (r *reviewPostgresRepository) FindReview(ctx context.Context, id int64) (*models.PerformanceReview, error) {
+	return scanReview(conn(ctx, r.db).QueryRowContext(ctx, reviewSelect+` WHERE pr.id = $1`, id))
+}
+
+func (r *reviewPostgresRepository) ListReviews(ctx context.Context, cycleID int64, reviewerID, departmentID *int64) ([]*models.PerformanceReview, error) {
+	return r.queryReviews(ctx, reviewSelect+`
+		WHERE pr.cycle_id = $1
+			AND ($2::BIGINT IS NULL OR pr.reviewer_id = $2)
+			AND ($3::BIGINT IS NULL OR e.department_id = $3)
+		ORDER BY e.name
+	`, cycleID, reviewerID, departmentID)
+}
+
+func (r *reviewPostgresRepository) ListReviewsByEmployee(ctx context.Context, employeeID int64) ([]*models.PerformanceReview, error) {
+	return r.queryReviews(ctx, reviewSelect+`
+		JOIN review_cycles c ON c.id = pr.cycle_id
+		WHERE pr.employee_id = $1
+		ORDER BY c.period_start DESC
+	`, employeeID)
+}
+
+func (r *reviewPostgresRepository) UpdateReview(ctx context.Context, pr *models.PerformanceReview) error {
+	query := `
+		UPDATE performance_reviews SET
+			reviewer_id = $1,
+			self_rating = $2, self_comment = $3, self_submitted_at = $4,
+			manager_rating = $5, manager_comment = $6, manager_submitted_at = $7,
+			calibrated_rating = $8, calibration_note = $9, calibrated_at = $10,
+			updated_at = now()
+		WHERE id = $11
+		RETURNING updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		pr.ReviewerID,
+		pr.SelfRating, pr.SelfComment, pr.SelfSubmittedAt,
+		pr.ManagerRating, pr.ManagerComment, pr.ManagerSubmittedAt,
+		pr.CalibratedRating, pr.CalibrationNote, pr.CalibratedAt,
+		pr.ID,
+	).Scan(&pr.UpdatedAt)
+}
+
+// Completion groups the cycle's reviews by the employee's current
+// department.
+func (r *reviewPostgresRepository) Completion(ctx context.Context, cycleID int64) ([]*models.ReviewCompletion, error) {
+	query := `
+		SELECT d.id, d.name,
+			COUNT(*),
+			COUNT(pr.self_submitted_at),
+			COUNT(pr.manager_submitted_at),
+			COUNT(pr.calibrated_at),
+			COUNT(*) FILTER (WHERE pr.reviewer_id IS NULL)
+		FROM performance_reviews pr
+		JOIN employees e ON e.id = pr.employee_id
+		JOIN departments d ON d.id = e.department_id
+		WHERE pr.cycle_id = $1
+		GROUP BY d.id, d.name
+		ORDER BY d.name
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, cycleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.ReviewCompletion
+	for rows.Next() {
+		var c models.ReviewCompletion
+		if err := rows.Scan(&c.DepartmentID, &c.DepartmentName, &c.Total, &c.SelfDone, &c.ManagerDone, &c.Calibrated, &c.WithoutReviewer); err != nil {
+			return nil, err
+		}
+		res = append(res, &c)
+	}
+	return res, rows.Err()
+}
+
+const reviewGoalColumns = `id, cycle_id, employee_id, title, description, weight, progress, due_date, created_at, updated_at`
+
+func scanReviewGoal(row rowScanner) (*models.ReviewGoal, error) {
+	var g models.ReviewGoal
+	if err := row.Scan(&g.ID, &g.CycleID, &g.EmployeeID, &g.Title, &g.Description, &g.Weight, &g.Progress, &g.DueDate, &g.CreatedAt, &g.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (r *reviewPostgresRepository) CreateGoal(ctx context.Context, g *models.ReviewGoal) error {
+	query := `
+		INSERT INTO review_goals (cycle_id, employee_id, title, description, weight, progress, due_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		g.CycleID, g.EmployeeID, g.Title, g.Description, g.Weight, g.Progress, g.DueDate,
+	).Scan(&g.ID, &g.CreatedAt, &g.UpdatedAt)
+}
+
+func (r *reviewPostgresRepository) FindGoal(ctx context.Context, id int64) (*models.ReviewGoal, error) {
+	return scanReviewGoal(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+reviewGoalColumns+` FROM review_goals WHERE id = $1`, id))
+}
+
+func (r *reviewPostgresRepository) UpdateGoal(ctx context.Context, g *models.ReviewGoal) error {
+	query := `
+		UPDATE review_goals SET title = $1, description = $2, weight = $3, progress = $4, due_date = $5, updated_at = now()
+		WHERE id = $6
+		RETURNING updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query, g.Title, g.Description, g.Weight, g.Progress, g.DueDate, g.ID).Scan(&g.UpdatedAt)
+}
+
+func (r *reviewPostgresRepository) DeleteGoal(ctx context.Context, id int64) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM review_goals WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *reviewPostgresRepository) ListGoals(ctx context.Context, employeeID int64, cycleID *int64) ([]*models.ReviewGoal, error) {
+	query := `
+		SELECT ` + reviewGoalColumns + ` FROM review_goals
+		WHERE employee_id = $1 AND ($2::BIGINT IS NULL OR cycle_id = $2)
+		ORDER BY cycle_id DESC, id
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, employeeID, cycleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.ReviewGoal
+	for rows.Next() {
+		g, err := scanReviewGoal(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, g)
+	}
+	return res, rows.Err()
+}
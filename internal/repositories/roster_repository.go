@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"app/internal/models"
+)
+
+type rosterPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewRosterRepository(db *sql.DB) RosterRepository {
+	return &rosterPostgresRepository{db: db}
+}
+
+type RosterRepository interface {
+	CreateBatch(ctx context.Context, assignments []*models.RosterAssignment) error
+	FindByID(ctx context.Context, id int64) (*models.RosterAssignment, error)
+	ListByDepartment(ctx context.Context, departmentID int64, from, to time.Time) ([]*models.RosterAssignment, error)
+	FindForEmployeeOn(ctx context.Context, employeeID int64, date time.Time) (*models.RosterAssignment, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+const rosterColumns = `r.id, r.employee_id, r.shift_id, r.work_date, r.note, r.created_at`
+
+func scanRosterAssignment(row rowScanner) (*models.RosterAssignment, error) {
+	var a models.RosterAssignment
+	if err := row.Scan(&a.ID, &a.EmployeeID, &a.ShiftID, &a.WorkDate, &a.Note, &a.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// CreateBatch inserts the assignments in one transaction.
+func (r *rosterPostgresRepository) CreateBatch(ctx context.Context, assignments []*models.RosterAssignment) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO roster_assignments (employee_id, shift_id, work_date, note)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	for _, a := range assignments {
+		if err := tx.QueryRowContext(ctx, query, a.EmployeeID, a.ShiftID, a.WorkDate, a.Note).Scan(&a.ID, &a.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *rosterPostgresRepository) FindByID(ctx context.Context, id int64) (*models.RosterAssignment, error) {
+	return scanRosterAssignment(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+rosterColumns+` FROM roster_assignments r WHERE r.id = $1`, id))
+}
+
+func (r *rosterPostgresRepository) ListByDepartment(ctx context.Context, departmentID int64, from, to time.Time) ([]*models.RosterAssignment, error) {
+	query := `
+		SELECT ` + rosterColumns + `
+		FROM roster_assignments r
+		JOIN employees e ON e.id = r.employee_id
+		JOIN shifts s ON s.id = r.shift_id
+		WHERE e.department_id = $1 AND r.work_date BETWEEN $2 AND $3
+		ORDER BY r.work_date, s.start_time, r.employee_id
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, departmentID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.RosterAssignment
+	for rows.Next() {
+		a, err := scanRosterAssignment(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, a)
+	}
+	return res, rows.Err()
+}
+
+// FindForEmployeeOn returns the employee's earliest rostered shift on date.
+func (r *rosterPostgresRepository) FindForEmployeeOn(ctx context.Context, employeeID int64, date time.Time) (*models.RosterAssignment, error) {
+	query := `
+		SELECT ` + rosterColumns + `
+		FROM roster_assignments r
+		JOIN shifts s ON s.id = r.shift_id
+		WHERE r.employee_id = $1 AND r.work_date = $2
+		ORDER BY s.start_time
+		LIMIT 1
+	`
+	return scanRosterAssignment(conn(ctx, r.db).QueryRowContext(ctx, query, employeeID, date))
+}
+
+func (r *rosterPostgresRepository) Delete(ctx context.Context, id int64) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM roster_assignments WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"app/internal/models"
+)
+
+type salaryBandPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewSalaryBandRepository(db *sql.DB) SalaryBandRepository {
+	return &salaryBandPostgresRepository{db: db}
+}
+
+type SalaryBandRepository interface {
+	Upsert(ctx context.Context, b *models.SalaryBand) error
+	FindApplicable(ctx context.Context, positionID, departmentID int64) (*models.SalaryBand, error)
+	List(ctx context.Context, positionID *int64) ([]*models.SalaryBand, error)
+}
+
+const salaryBandColumns = `id, position_id, department_id, min_amount, mid_amount, max_amount, currency, created_at, updated_at`
+
+func scanSalaryBand(row rowScanner) (*models.SalaryBand, error) {
+	var b models.SalaryBand
+	if err := row.Scan(&b.ID, &b.PositionID, &b.DepartmentID, &b.Min, &b.Mid, &b.Max, &b.Currency, &b.CreatedAt, &b.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// Upsert creates the band for (position, department) or replaces its range.
+func (r *salaryBandPostgresRepository) Upsert(ctx context.Context, b *models.SalaryBand) error {
+	query := `
+		INSERT INTO salary_bands (position_id, department_id, min_amount, mid_amount, max_amount, currency)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (position_id, (COALESCE(department_id, 0)))
+		DO UPDATE SET
+			min_amount = EXCLUDED.min_amount,
+			mid_amount = EXCLUDED.mid_amount,
+			max_amount = EXCLUDED.max_amount,
+			currency = EXCLUDED.currency,
+			updated_at = now()
+		RETURNING id, created_at, updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query, b.PositionID, b.DepartmentID, b.Min, b.Mid, b.Max, b.Currency).Scan(&b.ID, &b.CreatedAt, &b.UpdatedAt)
+}
+
+// FindApplicable prefers the department's own band over the company-wide one.
+func (r *salaryBandPostgresRepository) FindApplicable(ctx context.Context, positionID, departmentID int64) (*models.SalaryBand, error) {
+	query := `
+		SELECT ` + salaryBandColumns + `
+		FROM salary_bands
+		WHERE position_id = $1 AND (department_id = $2 OR department_id IS NULL)
+		ORDER BY department_id NULLS LAST
+		LIMIT 1
+	`
+	return scanSalaryBand(conn(ctx, r.db).QueryRowContext(ctx, query, positionID, departmentID))
+}
+
+func (r *salaryBandPostgresRepository) List(ctx context.Context, positionID *int64) ([]*models.SalaryBand, error) {
+	query := `SELECT ` + salaryBandColumns + ` FROM salary_bands WHERE ($1::BIGINT IS NULL OR position_id = $1) ORDER BY position_id, department_id NULLS FIRST`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, positionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.SalaryBand
+	for rows.Next() {
+		b, err := scanSalaryBand(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, b)
+	}
+	return res, rows.Err()
+}
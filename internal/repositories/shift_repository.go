@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"app/internal/models"
+)
+
+type shiftPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewShiftRepository(db *sql.DB) ShiftRepository {
+	return &shiftPostgresRepository{db: db}
+}
+
+type ShiftRepository interface {
+	Create(ctx context.Context, s *models.Shift) error
+	FindByID(ctx context.Context, id int64) (*models.Shift, error)
+	List(ctx context.Context) ([]*models.Shift, error)
+	Update(ctx context.Context, s *models.Shift) error
+}
+
+const shiftColumns = `id, name, to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI'), break_minutes, grace_minutes, created_at, updated_at`
+
+func scanShift(row rowScanner) (*models.Shift, error) {
+	var s models.Shift
+	if err := row.Scan(&s.ID, &s.Name, &s.StartTime, &s.EndTime, &s.BreakMinutes, &s.GraceMinutes, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *shiftPostgresRepository) Create(ctx context.Context, s *models.Shift) error {
+	query := `
+		INSERT INTO shifts (name, start_time, end_time, break_minutes, grace_minutes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query, s.Name, s.StartTime, s.EndTime, s.BreakMinutes, s.GraceMinutes).Scan(&s.ID, &s.CreatedAt, &s.UpdatedAt)
+}
+
+func (r *shiftPostgresRepository) FindByID(ctx context.Context, id int64) (*models.Shift, error) {
+	return scanShift(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+shiftColumns+` FROM shifts WHERE id = $1`, id))
+}
+
+func (r *shiftPostgresRepository) List(ctx context.Context) ([]*models.Shift, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT `+shiftColumns+` FROM shifts ORDER BY start_time, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.Shift
+	for rows.Next() {
+		s, err := scanShift(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	return res, rows.Err()
+}
+
+func (r *shiftPostgresRepository) Update(ctx context.Context, s *models.Shift) error {
+	query := `
+		UPDATE shifts
+		SET name = $1, start_time = $2, end_time = $3, break_minutes = $4, grace_minutes = $5, updated_at = now()
+		WHERE id = $6
+		RETURNING updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query, s.Name, s.StartTime, s.EndTime, s.BreakMinutes, s.GraceMinutes, s.ID).Scan(&s.UpdatedAt)
+}
@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+
+	"app/internal/models"
+)
+
+type skillPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewSkillRepository(db *sql.DB) SkillRepository {
+	return &skillPostgresRepository{db: db}
+}
+
+type SkillRepository interface {
+	Create(ctx context.Context, s *models.Skill) error
+	FindByID(ctx context.Context, id int64) (*models.Skill, error)
+	FindByName(ctx context.Context, name string) (*models.Skill, error)
+	List(ctx context.Context, keyword string) ([]*models.Skill, error)
+
+	SetLevel(ctx context.Context, es *models.EmployeeSkill) error
+	RemoveSkill(ctx context.Context, employeeID, skillID int64) error
+	FindEmployeeSkill(ctx context.Context, employeeID, skillID int64) (*models.EmployeeSkill, error)
+	ListByEmployees(ctx context.Context, employeeIDs []int64) ([]*models.EmployeeSkill, error)
+
+	Endorse(ctx context.Context, e *models.SkillEndorsement) error
+	ListEndorsements(ctx context.Context, employeeID int64) ([]*models.SkillEndorsement, error)
+}
+
+func scanSkill(row rowScanner) (*models.Skill, error) {
+	var s models.Skill
+	if err := row.Scan(&s.ID, &s.Name, &s.Category, &s.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *skillPostgresRepository) Create(ctx context.Context, s *models.Skill) error {
+	query := `INSERT INTO skills (name, category) VALUES ($1, $2) RETURNING id, created_at`
+	return conn(ctx, r.db).QueryRowContext(ctx, query, s.Name, s.Category).Scan(&s.ID, &s.CreatedAt)
+}
+
+func (r *skillPostgresRepository) FindByID(ctx context.Context, id int64) (*models.Skill, error) {
+	return scanSkill(conn(ctx, r.db).QueryRowContext(ctx, `SELECT id, name, category, created_at FROM skills WHERE id = $1`, id))
+}
+
+// FindByName looks the skill up ignoring case, so "golang" and "Golang"
+// are the same skill.
+func (r *skillPostgresRepository) FindByName(ctx context.Context, name string) (*models.Skill, error) {
+	return scanSkill(conn(ctx, r.db).QueryRowContext(ctx, `SELECT id, name, category, created_at FROM skills WHERE LOWER(name) = LOWER($1)`, name))
+}
+
+func (r *skillPostgresRepository) List(ctx context.Context, keyword string) ([]*models.Skill, error) {
+	query := `
+		SELECT id, name, category, created_at
+		FROM skills
+		WHERE ($1 = '' OR name ILIKE '%' || $1 || '%' OR category ILIKE '%' || $1 || '%')
+		ORDER BY category NULLS LAST, name
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, keyword)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.Skill
+	for rows.Next() {
+		s, err := scanSkill(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	return res, rows.Err()
+}
+
+// SetLevel records or changes the employee's level in the skill.
+func (r *skillPostgresRepository) SetLevel(ctx context.Context, es *models.EmployeeSkill) error {
+	query := `
+		INSERT INTO employee_skills (employee_id, skill_id, level)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (employee_id, skill_id) DO UPDATE SET level = EXCLUDED.level, updated_at = now()
+		RETURNING updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query, es.EmployeeID, es.SkillID, es.Level).Scan(&es.UpdatedAt)
+}
+
+func (r *skillPostgresRepository) RemoveSkill(ctx context.Context, employeeID, skillID int64) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM employee_skills WHERE employee_id = $1 AND skill_id = $2`, employeeID, skillID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+const employeeSkillSelect = `
+	SELECT es.employee_id, es.skill_id, s.name, s.category, es.level,
+		(SELECT COUNT(*) FROM skill_endorsements se WHERE se.employee_id = es.employee_id AND se.skill_id = es.skill_id),
+		es.updated_at
+	FROM employee_skills es
+	JOIN skills s ON s.id = es.skill_id
+`
+
+func scanEmployeeSkill(row rowScanner) (*models.EmployeeSkill, error) {
+	var es models.EmployeeSkill
+	if err := row.Scan(&es.EmployeeID, &es.SkillID, &es.SkillName, &es.Category, &es.Level, &es.Endorsements, &es.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &es, nil
+}
+
+func (r *skillPostgresRepository) FindEmployeeSkill(ctx context.Context, employeeID, skillID int64) (*models.EmployeeSkill, error) {
+	return scanEmployeeSkill(conn(ctx, r.db).QueryRowContext(ctx, employeeSkillSelect+` WHERE es.employee_id = $1 AND es.skill_id = $2`, employeeID, skillID))
+}
+
+// ListByEmployees returns the skills of all the given employees, strongest
+// first within each employee.
+func (r *skillPostgresRepository) ListByEmployees(ctx context.Context, employeeIDs []int64) ([]*models.EmployeeSkill, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, employeeSkillSelect+`
+		WHERE es.employee_id = ANY($1)
+		ORDER BY es.employee_id, es.level DESC, s.name
+	`, pq.Array(employeeIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.EmployeeSkill
+	for rows.Next() {
+		es, err := scanEmployeeSkill(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, es)
+	}
+	return res, rows.Err()
+}
+
+func (r *skillPostgresRepository) Endorse(ctx context.Context, e *models.SkillEndorsement) error {
+	query := `
+		INSERT INTO skill_endorsements (employee_id, skill_id, endorser_id, comment)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query, e.EmployeeID, e.SkillID, e.EndorserID, e.Comment).Scan(&e.CreatedAt)
+}
+
+func (r *skillPostgresRepository) ListEndorsements(ctx context.Context, employeeID int64) ([]*models.SkillEndorsement, error) {
+	query := `
+		SELECT se.employee_id, se.skill_id, se.endorser_id, e.name, se.comment, se.created_at
+		FROM skill_endorsements se
+		JOIN employees e ON e.id = se.endorser_id
+		WHERE se.employee_id = $1
+		ORDER BY se.skill_id, se.created_at
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.SkillEndorsement
+	for rows.Next() {
+		var e models.SkillEndorsement
+		if err := rows.Scan(&e.EmployeeID, &e.SkillID, &e.EndorserID, &e.EndorserName, &e.Comment, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, &e)
+	}
+	return res, rows.Err()
+}
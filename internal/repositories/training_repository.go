@@ -0,0 +1,324 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"app/internal/models"
+)
+
+type trainingPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewTrainingRepository(db *sql.DB) TrainingRepository {
+	return &trainingPostgresRepository{db: db}
+}
+
+type TrainingRepository interface {
+	CreateCertificationType(ctx context.Context, c *models.CertificationType) error
+	FindCertificationType(ctx context.Context, id int64) (*models.CertificationType, error)
+	ListCertificationTypes(ctx context.Context) ([]*models.CertificationType, error)
+	ListRequired(ctx context.Context, departmentID int64) ([]*models.CertificationType, error)
+	SetRequired(ctx context.Context, departmentID int64, certificationTypeIDs []int64) error
+
+	CreateCourse(ctx context.Context, c *models.TrainingCourse) error
+	FindCourse(ctx context.Context, id int64) (*models.TrainingCourse, error)
+	ListCourses(ctx context.Context) ([]*models.TrainingCourse, error)
+
+	CreateEnrolment(ctx context.Context, en *models.CourseEnrolment) error
+	FindEnrolment(ctx context.Context, id int64) (*models.CourseEnrolment, error)
+	ListEnrolmentsByCourse(ctx context.Context, courseID int64) ([]*models.CourseEnrolment, error)
+	ListEnrolmentsByEmployee(ctx context.Context, employeeID int64) ([]*models.CourseEnrolment, error)
+	FinishEnrolment(ctx context.Context, en *models.CourseEnrolment, cert *models.EmployeeCertification) error
+
+	CreateCertification(ctx context.Context, c *models.EmployeeCertification) error
+	ListCertificationsByEmployee(ctx context.Context, employeeID int64) ([]*models.EmployeeCertification, error)
+	Compliance(ctx context.Context, departmentID *int64, today time.Time) ([]*models.CertificationCompliance, error)
+}
+
+func scanCertificationType(row rowScanner) (*models.CertificationType, error) {
+	var c models.CertificationType
+	if err := row.Scan(&c.ID, &c.Name, &c.ValidityMonths, &c.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *trainingPostgresRepository) queryCertificationTypes(ctx context.Context, query string, args ...interface{}) ([]*models.CertificationType, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.CertificationType
+	for rows.Next() {
+		c, err := scanCertificationType(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, c)
+	}
+	return res, rows.Err()
+}
+
+func (r *trainingPostgresRepository) CreateCertificationType(ctx context.Context, c *models.CertificationType) error {
+	query := `INSERT INTO certification_types (name, validity_months) VALUES ($1, $2) RETURNING id, created_at`
+	return conn(ctx, r.db).QueryRowContext(ctx, query, c.Name, c.ValidityMonths).Scan(&c.ID, &c.CreatedAt)
+}
+
+func (r *trainingPostgresRepository) FindCertificationType(ctx context.Context, id int64) (*models.CertificationType, error) {
+	return scanCertificationType(conn(ctx, r.db).QueryRowContext(ctx, `SELECT id, name, validity_months, created_at FROM certification_types WHERE id = $1`, id))
+}
+
+func (r *trainingPostgresRepository) ListCertificationTypes(ctx context.Context) ([]*models.CertificationType, error) {
+	return r.queryCertificationTypes(ctx, `SELECT id, name, validity_months, created_at FROM certification_types ORDER BY name`)
+}
+
+func (r *trainingPostgresRepository) ListRequired(ctx context.Context, departmentID int64) ([]*models.CertificationType, error) {
+	return r.queryCertificationTypes(ctx, `
+		SELECT ct.id, ct.name, ct.validity_months, ct.created_at
+		FROM department_required_certifications rc
+		JOIN certification_types ct ON ct.id = rc.certification_type_id
+		WHERE rc.department_id = $1
+		ORDER BY ct.name
+	`, departmentID)
+}
+
+// SetRequired replaces the department's required certifications in one
+// transaction.
+func (r *trainingPostgresRepository) SetRequired(ctx context.Context, departmentID int64, certificationTypeIDs []int64) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM department_required_certifications WHERE department_id = $1`, departmentID); err != nil {
+		return err
+	}
+	for _, id := range certificationTypeIDs {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO department_required_certifications (department_id, certification_type_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+			departmentID, id,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+const trainingCourseColumns = `id, code, title, provider, duration_hours, certification_type_id, created_at, updated_at`
+
+func scanTrainingCourse(row rowScanner) (*models.TrainingCourse, error) {
+	var c models.TrainingCourse
+	if err := row.Scan(&c.ID, &c.Code, &c.Title, &c.Provider, &c.DurationHours, &c.CertificationTypeID, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *trainingPostgresRepository) CreateCourse(ctx context.Context, c *models.TrainingCourse) error {
+	query := `
+		INSERT INTO training_courses (code, title, provider, duration_hours, certification_type_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		c.Code, c.Title, c.Provider, c.DurationHours, c.CertificationTypeID,
+	).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+}
+
+func (r *trainingPostgresRepository) FindCourse(ctx context.Context, id int64) (*models.TrainingCourse, error) {
+	return scanTrainingCourse(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+trainingCourseColumns+` FROM training_courses WHERE id = $1`, id))
+}
+
+func (r *trainingPostgresRepository) ListCourses(ctx context.Context) ([]*models.TrainingCourse, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT `+trainingCourseColumns+` FROM training_courses ORDER BY code`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.TrainingCourse
+	for rows.Next() {
+		c, err := scanTrainingCourse(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, c)
+	}
+	return res, rows.Err()
+}
+
+const enrolmentSelect = `
+	SELECT en.id, en.course_id, en.employee_id, en.status, en.enrolled_on, en.completed_on, en.score,
+		en.created_at, en.updated_at, c.code, c.title, e.name
+	FROM course_enrolments en
+	JOIN training_courses c ON c.id = en.course_id
+	JOIN employees e ON e.id = en.employee_id
+`
+
+func scanEnrolment(row rowScanner) (*models.CourseEnrolment, error) {
+	var en models.CourseEnrolment
+	if err := row.Scan(
+		&en.ID, &en.CourseID, &en.EmployeeID, &en.Status, &en.EnrolledOn, &en.CompletedOn, &en.Score,
+		&en.CreatedAt, &en.UpdatedAt, &en.CourseCode, &en.CourseTitle, &en.EmployeeName,
+	); err != nil {
+		return nil, err
+	}
+	return &en, nil
+}
+
+func (r *trainingPostgresRepository) queryEnrolments(ctx context.Context, query string, args ...interface{}) ([]*models.CourseEnrolment, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.CourseEnrolment
+	for rows.Next() {
+		en, err := scanEnrolment(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, en)
+	}
+	return res, rows.Err()
+}
+
+func (r *trainingPostgresRepository) CreateEnrolment(ctx context.Context, en *models.CourseEnrolment) error {
+	query := `
+		INSERT INTO course_enrolments (course_id, employee_id, status, enrolled_on)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`
+	return conn(ctx, r.db).QueryRowContext(ctx, query, en.CourseID, en.EmployeeID, en.Status, en.EnrolledOn).Scan(&en.ID, &en.CreatedAt, &en.UpdatedAt)
+}
+
+func (r *trainingPostgresRepository) FindEnrolment(ctx context.Context, id int64) (*models.CourseEnrolment, error) {
+	return scanEnrolment(conn(ctx, r.db).QueryRowContext(ctx, enrolmentSelect+` WHERE en.id = $1`, id))
+}
+
+func (r *trainingPostgresRepository) ListEnrolmentsByCourse(ctx context.Context, courseID int64) ([]*models.CourseEnrolment, error) {
+	return r.queryEnrolments(ctx, enrolmentSelect+` WHERE en.course_id = $1 ORDER BY en.enrolled_on, e.name`, courseID)
+}
+
+func (r *trainingPostgresRepository) ListEnrolmentsByEmployee(ctx context.Context, employeeID int64) ([]*models.CourseEnrolment, error) {
+	return r.queryEnrolments(ctx, enrolmentSelect+` WHERE en.employee_id = $1 ORDER BY en.enrolled_on DESC, en.id DESC`, employeeID)
+}
+
+// FinishEnrolment closes an open enrolment with its final status and, when
+// cert is given, issues the certification in the same transaction. It
+// returns sql.ErrNoRows if the enrolment is no longer open.
+func (r *trainingPostgresRepository) FinishEnrolment(ctx context.Context, en *models.CourseEnrolment, cert *models.EmployeeCertification) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE course_enrolments SET status = $1, completed_on = $2, score = $3, updated_at = now()
+		WHERE id = $4 AND status = 'enrolled'
+		RETURNING updated_at
+	`
+	if err := tx.QueryRowContext(ctx, query, en.Status, en.CompletedOn, en.Score, en.ID).Scan(&en.UpdatedAt); err != nil {
+		return err
+	}
+	if cert != nil {
+		if err := tx.QueryRowContext(ctx, insertCertificationQuery,
+			cert.EmployeeID, cert.CertificationTypeID, cert.IssuedOn, cert.ExpiresOn, cert.CertificateNumber, cert.DocumentRef, cert.EnrolmentID,
+		).Scan(&cert.ID, &cert.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+const insertCertificationQuery = `
+	INSERT INTO employee_certifications (
+		employee_id, certification_type_id, issued_on, expires_on, certificate_number, document_ref, enrolment_id
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING id, created_at
+`
+
+func (r *trainingPostgresRepository) CreateCertification(ctx context.Context, c *models.EmployeeCertification) error {
+	return conn(ctx, r.db).QueryRowContext(ctx, insertCertificationQuery,
+		c.EmployeeID, c.CertificationTypeID, c.IssuedOn, c.ExpiresOn, c.CertificateNumber, c.DocumentRef, c.EnrolmentID,
+	).Scan(&c.ID, &c.CreatedAt)
+}
+
+func (r *trainingPostgresRepository) ListCertificationsByEmployee(ctx context.Context, employeeID int64) ([]*models.EmployeeCertification, error) {
+	query := `
+		SELECT ec.id, ec.employee_id, ec.certification_type_id, ct.name, ec.issued_on, ec.expires_on,
+			ec.certificate_number, ec.document_ref, ec.enrolment_id, ec.created_at
+		FROM employee_certifications ec
+		JOIN certification_types ct ON ct.id = ec.certification_type_id
+		WHERE ec.employee_id = $1
+		ORDER BY ct.name, ec.issued_on DESC
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.EmployeeCertification
+	for rows.Next() {
+		var c models.EmployeeCertification
+		if err := rows.Scan(
+			&c.ID, &c.EmployeeID, &c.CertificationTypeID, &c.CertificationName, &c.IssuedOn, &c.ExpiresOn,
+			&c.CertificateNumber, &c.DocumentRef, &c.EnrolmentID, &c.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		res = append(res, &c)
+	}
+	return res, rows.Err()
+}
+
+// Compliance pairs every current member of a department with each
+// certification the department requires and the longest-running
+// certificate they hold of it, issued by today. Status is left for the
+// caller to decide.
+func (r *trainingPostgresRepository) Compliance(ctx context.Context, departmentID *int64, today time.Time) ([]*models.CertificationCompliance, error) {
+	query := `
+		SELECT d.id, d.name, e.id, e.name, ct.id, ct.name, c.issued_on, c.expires_on
+		FROM department_required_certifications rc
+		JOIN departments d ON d.id = rc.department_id
+		JOIN certification_types ct ON ct.id = rc.certification_type_id
+		JOIN employees e ON e.department_id = rc.department_id AND e.status NOT IN ('candidate', 'terminated')
+		LEFT JOIN LATERAL (
+			SELECT ec.issued_on, ec.expires_on
+			FROM employee_certifications ec
+			WHERE ec.employee_id = e.id AND ec.certification_type_id = ct.id AND ec.issued_on <= $2
+			ORDER BY ec.expires_on DESC NULLS FIRST
+			LIMIT 1
+		) c ON true
+		WHERE ($1::BIGINT IS NULL OR d.id = $1)
+		ORDER BY d.name, e.name, ct.name
+	`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, departmentID, today)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*models.CertificationCompliance
+	for rows.Next() {
+		var c models.CertificationCompliance
+		if err := rows.Scan(
+			&c.DepartmentID, &c.DepartmentName, &c.EmployeeID, &c.EmployeeName,
+			&c.CertificationTypeID, &c.CertificationName, &c.IssuedOn, &c.ExpiresOn,
+		); err != nil {
+			return nil, err
+		}
+		res = append(res, &c)
+	}
+	return res, rows.Err()
+}
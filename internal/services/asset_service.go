@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+// Policies for terminating an employee who still holds assets, chosen with
+// ASSET_TERMINATION_POLICY.
+const (
+	AssetPolicyWarn  = "warn"
+	AssetPolicyBlock = "block"
+)
+
+type AssetService struct {
+	repo          repositories.AssetRepository
+	employeeRepo  repositories.EmployeeRepository
+	notifications *NotificationService
+	policy        string
+}
+
+func NewAssetService(repo repositories.AssetRepository, employeeRepo repositories.EmployeeRepository, notifications *NotificationService, policy string) *AssetService {
+	if policy != AssetPolicyBlock {
+		policy = AssetPolicyWarn
+	}
+	return &AssetService{
+		repo:          repo,
+		employeeRepo:  employeeRepo,
+		notifications: notifications,
+		policy:        policy,
+	}
+}
+
+func (s *AssetService) Policy() string {
+	return s.policy
+}
+
+func (s *AssetService) List(ctx context.Context, kind, status, keyword string) ([]*models.Asset, error) {
+	return s.repo.List(ctx, kind, status, keyword)
+}
+
+func (s *AssetService) GetByID(ctx context.Context, id int64) (*models.Asset, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *AssetService) validate(a *models.Asset) error {
+	a.AssetTag = strings.TrimSpace(a.AssetTag)
+	a.Name = strings.TrimSpace(a.Name)
+	if a.AssetTag == "" {
+		return errors.New("assetTag is required")
+	}
+	if a.Name == "" {
+		return errors.New("name is required")
+	}
+	if !models.IsValidAssetKind(a.Kind) {
+		return errors.New("kind must be laptop, phone, badge, monitor or other")
+	}
+	if a.SerialNumber != nil && strings.TrimSpace(*a.SerialNumber) == "" {
+		a.SerialNumber = nil
+	}
+	return nil
+}
+
+// Create adds an asset to the inventory, available unless it is recorded
+// as retired or lost. Assets become assigned only through Assign.
+func (s *AssetService) Create(ctx context.Context, a *models.Asset) error {
+	if a.Status == "" {
+		a.Status = models.AssetStatusAvailable
+	}
+	if a.Status == models.AssetStatusAssigned || !models.IsValidAssetStatus(a.Status) {
+		return errors.New("status must be available, retired or lost; use /assets/{id}/assign to hand it out")
+	}
+	if err := s.validate(a); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, a)
+}
+
+// Update edits the asset's details. The status can move between available,
+// retired and lost while nobody holds the asset.
+func (s *AssetService) Update(ctx context.Context, a *models.Asset) error {
+	current, err := s.repo.FindByID(ctx, a.ID)
+	if err != nil {
+		return err
+	}
+	if a.Status == "" {
+		a.Status = current.Status
+	}
+	if a.Status != current.Status {
+		if current.Status == models.AssetStatusAssigned {
+			return errors.New("the asset is assigned; return it first")
+		}
+		if a.Status == models.AssetStatusAssigned || !models.IsValidAssetStatus(a.Status) {
+			return errors.New("status must be available, retired or lost")
+		}
+	}
+	if err := s.validate(a); err != nil {
+		return err
+	}
+	if err := s.repo.Update(ctx, a); err != nil {
+		return err
+	}
+	a.HolderID, a.HolderName, a.AssignedOn = current.HolderID, current.HolderName, current.AssignedOn
+	a.CreatedAt = current.CreatedAt
+	return nil
+}
+
+// Assign hands the asset to an employee on the given day.
+func (s *AssetService) Assign(ctx context.Context, assetID, employeeID int64, on time.Time, condition, note *string) (*models.AssetAssignment, error) {
+	a, err := s.repo.FindByID(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+	e, err := s.employeeRepo.FindByID(ctx, employeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+	if e.Status == models.EmploymentStatusTerminated {
+		return nil, errors.New("cannot assign assets to a former employee")
+	}
+	if a.Status != models.AssetStatusAvailable {
+		return nil, fmt.Errorf("asset %s is %s", a.AssetTag, a.Status)
+	}
+
+	as := &models.AssetAssignment{
+		AssetID:      a.ID,
+		EmployeeID:   e.ID,
+		AssignedOn:   truncateToDate(on),
+		ConditionOut: condition,
+		Note:         note,
+		AssetTag:     a.AssetTag,
+		AssetKind:    a.Kind,
+		AssetName:    a.Name,
+		SerialNumber: a.SerialNumber,
+		EmployeeName: e.Name,
+	}
+	if err := s.repo.Assign(ctx, as); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("asset %s is no longer available", a.AssetTag)
+		}
+		return nil, err
+	}
+	return as, nil
+}
+
+// Return takes the asset back from its holder. status is what the asset
+// becomes: available by default, or retired when broken, or lost when it
+// was never handed back.
+func (s *AssetService) Return(ctx context.Context, assetID int64, on time.Time, status string, condition, note *string) (*models.AssetAssignment, error) {
+	if status == "" {
+		status = models.AssetStatusAvailable
+	}
+	if status == models.AssetStatusAssigned || !models.IsValidAssetStatus(status) {
+		return nil, errors.New("status must be available, retired or lost")
+	}
+	as, err := s.repo.FindOpenAssignment(ctx, assetID)
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := s.repo.FindByID(ctx, assetID); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("the asset is not assigned")
+	}
+	if err != nil {
+		return nil, err
+	}
+	on = truncateToDate(on)
+	if on.Before(as.AssignedOn) {
+		return nil, errors.New("returnedOn must not be before the assignment")
+	}
+
+	as.ReturnedOn = &on
+	as.ConditionIn = condition
+	if note != nil {
+		as.Note = note
+	}
+	if err := s.repo.Return(ctx, as, status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("the asset has already been returned")
+		}
+		return nil, err
+	}
+	return as, nil
+}
+
+func (s *AssetService) History(ctx context.Context, assetID int64) ([]*models.AssetAssignment, error) {
+	if _, err := s.repo.FindByID(ctx, assetID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAssignmentsByAsset(ctx, assetID)
+}
+
+// ByEmployee lists the assets the employee holds, or every assignment they
+// ever had when all is set.
+func (s *AssetService) ByEmployee(ctx context.Context, employeeID int64, all bool) ([]*models.AssetAssignment, error) {
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAssignmentsByEmployee(ctx, employeeID, !all)
+}
+
+// CheckTermination looks for assets e still holds before they leave. With
+// the block policy it refuses the termination; with warn it returns the
+// warning for the caller to show and to pass to NotifyOutstanding once the
+// termination is saved.
+func (s *AssetService) CheckTermination(ctx context.Context, e *models.Employee) (string, error) {
+	held, err := s.repo.ListAssignmentsByEmployee(ctx, e.ID, true)
+	if err != nil || len(held) == 0 {
+		return "", err
+	}
+	tags := make([]string, 0, len(held))
+	for _, as := range held {
+		tags = append(tags, as.AssetTag+" ("+as.AssetKind+")")
+	}
+	msg := fmt.Sprintf("%s still holds %d asset(s): %s", e.Name, len(held), strings.Join(tags, ", "))
+	if s.policy == AssetPolicyBlock {
+		return "", errors.New(msg + "; return them before terminating")
+	}
+	return msg, nil
+}
+
+// NotifyOutstanding raises a notification to collect the assets named in
+// warning, as returned by CheckTermination, by e's lastDay.
+func (s *AssetService) NotifyOutstanding(ctx context.Context, e *models.Employee, lastDay time.Time, warning string) error {
+	key := fmt.Sprintf("%s:%d:%s", models.NotificationAssetsOutstanding, e.ID, lastDay.Format("2006-01-02"))
+	_, err := s.notifications.Notify(ctx, &models.Notification{
+		Kind:       models.NotificationAssetsOutstanding,
+		EmployeeID: &e.ID,
+		Message:    warning + "; collect them by " + lastDay.Format("2006-01-02"),
+		DedupeKey:  &key,
+	})
+	return err
+}
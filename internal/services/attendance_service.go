@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+var (
+	// ErrAlreadyClockedIn is returned by ClockIn while the employee has an
+	// open attendance record.
+	ErrAlreadyClockedIn = repositories.ErrAlreadyClockedIn
+	// ErrNotClockedIn is returned by ClockOut without an open record.
+	ErrNotClockedIn = errors.New("not clocked in")
+	// ErrNotStarted and ErrNoLongerEmployed are returned when attendance
+	// falls outside the employee's employment.
+	ErrNotStarted       = errors.New("employee has not started yet")
+	ErrNoLongerEmployed = errors.New("employee is no longer employed")
+)
+
+type AttendanceService struct {
+	repo         repositories.AttendanceRepository
+	employeeRepo repositories.EmployeeRepository
+	deptRepo     repositories.DepartmentRepository
+	shiftRepo    repositories.ShiftRepository
+	rosterRepo   repositories.RosterRepository
+	loc          *time.Location
+}
+
+func NewAttendanceService(repo repositories.AttendanceRepository, employeeRepo repositories.EmployeeRepository, deptRepo repositories.DepartmentRepository, shiftRepo repositories.ShiftRepository, rosterRepo repositories.RosterRepository, loc *time.Location) *AttendanceService {
+	return &AttendanceService{
+		repo:         repo,
+		employeeRepo: employeeRepo,
+		deptRepo:     deptRepo,
+		shiftRepo:    shiftRepo,
+		rosterRepo:   rosterRepo,
+		loc:          loc,
+	}
+}
+
+// Location is the time zone shifts and work dates are expressed in.
+func (s *AttendanceService) Location() *time.Location {
+	return s.loc
+}
+
+// Timesheet is an employee's attendance summarised per day and per week.
+type Timesheet struct {
+	Employee *models.Employee
+	Days     []models.AttendanceDay
+	Weeks    []models.AttendanceWeek
+}
+
+// localDate is the calendar date of t in the company time zone, as a UTC
+// midnight like the other DATE values.
+func (s *AttendanceService) localDate(t time.Time) time.Time {
+	l := t.In(s.loc)
+	return time.Date(l.Year(), l.Month(), l.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// scheduledShift is the shift e is expected to work on date: their rostered
+// shift, else the department's default shift, if it has one.
+func (s *AttendanceService) scheduledShift(ctx context.Context, e *models.Employee, date time.Time) (*int64, error) {
+	if a, err := s.rosterRepo.FindForEmployeeOn(ctx, e.ID, date); err == nil {
+		return &a.ShiftID, nil
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	d, err := s.deptRepo.FindByID(ctx, e.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	return d.DefaultShiftID, nil
+}
+
+func (s *AttendanceService) activeEmployee(ctx context.Context, id int64, on time.Time) (*models.Employee, error) {
+	e, err := s.employeeRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if on.Before(e.HireDate) {
+		return nil, ErrNotStarted
+	}
+	if e.TerminationDate != nil && on.After(*e.TerminationDate) {
+		return nil, ErrNoLongerEmployed
+	}
+	return e, nil
+}
+
+func (s *AttendanceService) ClockIn(ctx context.Context, employeeID int64, note *string) (*models.AttendanceRecord, error) {
+	now := time.Now()
+	workDate := s.localDate(now)
+	e, err := s.activeEmployee(ctx, employeeID, workDate)
+	if err != nil {
+		return nil, err
+	}
+	if open, err := s.repo.FindOpen(ctx, employeeID); err == nil {
+		return nil, fmt.Errorf("%w since %s", ErrAlreadyClockedIn, open.ClockIn.In(s.loc).Format(time.RFC3339))
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	shiftID, err := s.scheduledShift(ctx, e, workDate)
+	if err != nil {
+		return nil, err
+	}
+	a := &models.AttendanceRecord{
+		EmployeeID: employeeID,
+		WorkDate:   workDate,
+		ClockIn:    now,
+		Source:     models.AttendanceSourceClock,
+		ShiftID:    shiftID,
+		Note:       note,
+	}
+	if err := s.repo.Create(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *AttendanceService) ClockOut(ctx context.Context, employeeID int64) (*models.AttendanceRecord, error) {
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	a, err := s.repo.FindOpen(ctx, employeeID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotClockedIn
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	a.ClockOut = &now
+	if err := s.repo.Close(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// AddEntry records a manual timesheet entry, e.g. for a forgotten clock-in.
+// It must not overlap the employee's other records.
+func (s *AttendanceService) AddEntry(ctx context.Context, a *models.AttendanceRecord) error {
+	if a.ClockOut == nil || !a.ClockOut.After(a.ClockIn) {
+		return errors.New("clockOut must be after clockIn")
+	}
+	if a.ClockOut.After(time.Now()) {
+		return errors.New("entries cannot be in the future")
+	}
+	if a.ClockOut.Sub(a.ClockIn) > 24*time.Hour {
+		return errors.New("an entry cannot exceed 24 hours")
+	}
+	a.WorkDate = s.localDate(a.ClockIn)
+	e, err := s.activeEmployee(ctx, a.EmployeeID, a.WorkDate)
+	if err != nil {
+		return err
+	}
+
+	n, err := s.repo.CountOverlapping(ctx, a.EmployeeID, a.ClockIn, *a.ClockOut)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return errors.New("entry overlaps an existing attendance record")
+	}
+
+	if a.ShiftID, err = s.scheduledShift(ctx, e, a.WorkDate); err != nil {
+		return err
+	}
+	a.Source = models.AttendanceSourceManual
+	return s.repo.Create(ctx, a)
+}
+
+// DeleteEntry removes a manual entry; clocked records are kept as evidence.
+func (s *AttendanceService) DeleteEntry(ctx context.Context, id int64) error {
+	a, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if a.Source != models.AttendanceSourceManual {
+		return errors.New("only manual entries can be deleted")
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// EmployeeTimesheet summarises the employee's attendance over [from, to].
+func (s *AttendanceService) EmployeeTimesheet(ctx context.Context, employeeID int64, from, to time.Time) (*Timesheet, error) {
+	e, err := s.employeeRepo.FindByID(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.repo.ListByEmployee(ctx, employeeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	shifts, err := s.shiftsOf(ctx, records)
+	if err != nil {
+		return nil, err
+	}
+	days := summarizeAttendance(records, shifts, s.loc, time.Now())
+	return &Timesheet{Employee: e, Days: days, Weeks: summarizeWeeks(days)}, nil
+}
+
+// DepartmentTimesheets returns one timesheet per department member for the
+// month starting at monthStart, in employee id order.
+func (s *AttendanceService) DepartmentTimesheets(ctx context.Context, departmentID int64, monthStart time.Time) ([]*Timesheet, error) {
+	if _, err := s.deptRepo.FindByID(ctx, departmentID); err != nil {
+		return nil, err
+	}
+	monthEnd := monthStart.AddDate(0, 1, -1)
+	employees, err := s.employeeRepo.FindByDepartmentID(ctx, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.repo.ListByDepartment(ctx, departmentID, monthStart, monthEnd)
+	if err != nil {
+		return nil, err
+	}
+	shifts, err := s.shiftsOf(ctx, records)
+	if err != nil {
+		return nil, err
+	}
+
+	byEmployee := map[int64][]*models.AttendanceRecord{}
+	for _, a := range records {
+		byEmployee[a.EmployeeID] = append(byEmployee[a.EmployeeID], a)
+	}
+	sort.Slice(employees, func(i, j int) bool { return employees[i].ID < employees[j].ID })
+
+	now := time.Now()
+	var out []*Timesheet
+	for _, e := range employees {
+		if e.HireDate.After(monthEnd) || (e.TerminationDate != nil && e.TerminationDate.Before(monthStart) && len(byEmployee[e.ID]) == 0) {
+			continue
+		}
+		days := summarizeAttendance(byEmployee[e.ID], shifts, s.loc, now)
+		out = append(out, &Timesheet{Employee: e, Days: days, Weeks: summarizeWeeks(days)})
+	}
+	return out, nil
+}
+
+func (s *AttendanceService) shiftsOf(ctx context.Context, records []*models.AttendanceRecord) (map[int64]*models.Shift, error) {
+	shifts := map[int64]*models.Shift{}
+	for _, a := range records {
+		if a.ShiftID == nil {
+			continue
+		}
+		if _, ok := shifts[*a.ShiftID]; ok {
+			continue
+		}
+		sh, err := s.shiftRepo.FindByID(ctx, *a.ShiftID)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		shifts[sh.ID] = sh
+	}
+	return shifts, nil
+}
+
+// summarizeAttendance groups records (ordered by clock-in) into days. A day
+// with a single record is assumed to include the shift's unpaid break.
+// Lateness is measured from the first clock-in, leaving early from the last
+// clock-out, each beyond the shift's grace period.
+func summarizeAttendance(records []*models.AttendanceRecord, shifts map[int64]*models.Shift, loc *time.Location, now time.Time) []models.AttendanceDay {
+	var days []models.AttendanceDay
+	for _, a := range records {
+		if len(days) == 0 || !days[len(days)-1].Date.Equal(a.WorkDate) {
+			days = append(days, models.AttendanceDay{Date: a.WorkDate})
+		}
+		d := &days[len(days)-1]
+		d.Records = append(d.Records, a)
+		if d.Shift == nil && a.ShiftID != nil {
+			d.Shift = shifts[*a.ShiftID]
+		}
+		out := now
+		if a.ClockOut != nil {
+			out = *a.ClockOut
+		} else {
+			d.Open = true
+		}
+		d.WorkedMinutes += int(out.Sub(a.ClockIn).Minutes())
+	}
+
+	for i := range days {
+		d := &days[i]
+		if d.Shift == nil {
+			continue
+		}
+		if len(d.Records) == 1 && !d.Open {
+			d.WorkedMinutes -= d.Shift.BreakMinutes
+			if d.WorkedMinutes < 0 {
+				d.WorkedMinutes = 0
+			}
+		}
+		start, end := d.Shift.Bounds(d.Date, loc)
+		grace := time.Duration(d.Shift.GraceMinutes) * time.Minute
+		if first := d.Records[0].ClockIn; first.After(start.Add(grace)) {
+			d.LateMinutes = int(first.Sub(start).Minutes())
+		}
+		if last := d.Records[len(d.Records)-1].ClockOut; !d.Open && last != nil && last.Before(end.Add(-grace)) {
+			d.EarlyMinutes = int(end.Sub(*last).Minutes())
+		}
+	}
+	return days
+}
+
+// summarizeWeeks totals days per ISO week (Monday to Sunday).
+func summarizeWeeks(days []models.AttendanceDay) []models.AttendanceWeek {
+	var weeks []models.AttendanceWeek
+	for _, d := range days {
+		weekStart := WeekStart(d.Date)
+		if len(weeks) == 0 || !weeks[len(weeks)-1].WeekStart.Equal(weekStart) {
+			weeks = append(weeks, models.AttendanceWeek{WeekStart: weekStart})
+		}
+		w := &weeks[len(weeks)-1]
+		w.DaysWorked++
+		w.WorkedMinutes += d.WorkedMinutes
+		if d.LateMinutes > 0 {
+			w.LateDays++
+		}
+		if d.EarlyMinutes > 0 {
+			w.EarlyDays++
+		}
+	}
+	return weeks
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+// WorkingCalendar answers working-day questions for one calendar over a
+// date range it was loaded for.
+type WorkingCalendar struct {
+	Calendar *models.WorkCalendar // nil when no calendar is configured
+	Days     []*models.CalendarDay
+
+	weekend    map[time.Weekday]bool
+	exceptions map[string]string
+}
+
+func newWorkingCalendar(cal *models.WorkCalendar, days []*models.CalendarDay) *WorkingCalendar {
+	c := &WorkingCalendar{
+		Calendar:   cal,
+		Days:       days,
+		weekend:    map[time.Weekday]bool{},
+		exceptions: map[string]string{},
+	}
+	if cal == nil {
+		c.weekend[time.Saturday] = true
+		c.weekend[time.Sunday] = true
+	} else {
+		for _, d := range cal.WeekendDays {
+			c.weekend[d] = true
+		}
+	}
+	for _, d := range days {
+		c.exceptions[d.Date.Format("2006-01-02")] = d.Kind
+	}
+	return c
+}
+
+func (c *WorkingCalendar) IsWorkingDay(d time.Time) bool {
+	switch c.exceptions[d.Format("2006-01-02")] {
+	case models.CalendarDayHoliday, models.CalendarDayOff:
+		return false
+	case models.CalendarDayWorkingDay:
+		return true
+	}
+	return !c.weekend[d.Weekday()]
+}
+
+func (c *WorkingCalendar) IsHoliday(d time.Time) bool {
+	return c.exceptions[d.Format("2006-01-02")] == models.CalendarDayHoliday
+}
+
+// WorkingDays counts the working days in [from, to], both inclusive.
+func (c *WorkingCalendar) WorkingDays(from, to time.Time) int {
+	n := 0
+	for d := truncateToDate(from); !d.After(truncateToDate(to)); d = d.AddDate(0, 0, 1) {
+		if c.IsWorkingDay(d) {
+			n++
+		}
+	}
+	return n
+}
+
+type CalendarService struct {
+	repo         repositories.CalendarRepository
+	deptRepo     repositories.DepartmentRepository
+	employeeRepo repositories.EmployeeRepository
+}
+
+func NewCalendarService(repo repositories.CalendarRepository, deptRepo repositories.DepartmentRepository, employeeRepo repositories.EmployeeRepository) *CalendarService {
+	return &CalendarService{
+		repo:         repo,
+		deptRepo:     deptRepo,
+		employeeRepo: employeeRepo,
+	}
+}
+
+func (s *CalendarService) List(ctx context.Context) ([]*models.WorkCalendar, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *CalendarService) GetByID(ctx context.Context, id int64) (*models.WorkCalendar, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *CalendarService) validate(c *models.WorkCalendar) error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	if c.Country != nil {
+		country := strings.ToUpper(strings.TrimSpace(*c.Country))
+		if len(country) != 2 {
+			return errors.New("country must be a 2-letter ISO code")
+		}
+		c.Country = &country
+	}
+	seen := map[time.Weekday]bool{}
+	for _, d := range c.WeekendDays {
+		if d < time.Sunday || d > time.Saturday {
+			return errors.New("weekendDays must be between 0 (Sunday) and 6 (Saturday)")
+		}
+		if seen[d] {
+			return fmt.Errorf("weekend day %d listed twice", d)
+		}
+		seen[d] = true
+	}
+	if len(seen) == 7 {
+		return errors.New("a calendar needs at least one working weekday")
+	}
+	return nil
+}
+
+func (s *CalendarService) Create(ctx context.Context, c *models.WorkCalendar) error {
+	if err := s.validate(c); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, c)
+}
+
+func (s *CalendarService) Update(ctx context.Context, c *models.WorkCalendar) error {
+	if err := s.validate(c); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, c)
+}
+
+// Days lists the holidays and other exceptions of a calendar in year.
+func (s *CalendarService) Days(ctx context.Context, id int64, year int) ([]*models.CalendarDay, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return s.repo.ListDays(ctx, id, from, from.AddDate(1, 0, -1))
+}
+
+func (s *CalendarService) SaveDays(ctx context.Context, id int64, days []*models.CalendarDay) error {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return err
+	}
+	for i, d := range days {
+		d.CalendarID = id
+		d.Date = truncateToDate(d.Date)
+		d.Name = strings.TrimSpace(d.Name)
+		if d.Kind == "" {
+			d.Kind = models.CalendarDayHoliday
+		}
+		if !models.IsValidCalendarDayKind(d.Kind) {
+			return fmt.Errorf("day %d: kind must be holiday, day_off or working_day", i+1)
+		}
+		if d.Name == "" {
+			return fmt.Errorf("day %d: name is required", i+1)
+		}
+	}
+	return s.repo.UpsertDays(ctx, days)
+}
+
+func (s *CalendarService) DeleteDay(ctx context.Context, id int64, date time.Time) error {
+	return s.repo.DeleteDay(ctx, id, truncateToDate(date))
+}
+
+// ImportICal adds every day covered by the events of an iCalendar file as
+// kind (holiday by default). Days already present are overwritten.
+func (s *CalendarService) ImportICal(ctx context.Context, id int64, r io.Reader, kind string) ([]*models.CalendarDay, error) {
+	parsed, err := parseICalDays(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(parsed) == 0 {
+		return nil, errors.New("no events found")
+	}
+
+	days := make([]*models.CalendarDay, 0, len(parsed))
+	for _, p := range parsed {
+		name := p.Summary
+		if name == "" {
+			name = "Imported holiday"
+		}
+		days = append(days, &models.CalendarDay{Date: p.Date, Kind: kind, Name: name})
+	}
+	if err := s.SaveDays(ctx, id, days); err != nil {
+		return nil, err
+	}
+	return days, nil
+}
+
+// SetDepartmentCalendar assigns a calendar to a department, or falls back to
+// the default calendar when calendarID is nil.
+func (s *CalendarService) SetDepartmentCalendar(ctx context.Context, departmentID int64, calendarID *int64) error {
+	if calendarID != nil {
+		if _, err := s.repo.FindByID(ctx, *calendarID); err != nil {
+			return errors.New("calendar not found")
+		}
+	}
+	return s.deptRepo.SetCalendar(ctx, departmentID, calendarID)
+}
+
+// ForDepartment loads the calendar that applies to a department over
+// [from, to]: its own, else the default one, else a plain Monday to Friday
+// week.
+func (s *CalendarService) ForDepartment(ctx context.Context, departmentID int64, from, to time.Time) (*WorkingCalendar, error) {
+	d, err := s.deptRepo.FindByID(ctx, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var cal *models.WorkCalendar
+	if d.CalendarID != nil {
+		cal, err = s.repo.FindByID(ctx, *d.CalendarID)
+	} else {
+		cal, err = s.repo.FindDefault(ctx)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return newWorkingCalendar(nil, nil), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := s.repo.ListDays(ctx, cal.ID, truncateToDate(from), truncateToDate(to))
+	if err != nil {
+		return nil, err
+	}
+	return newWorkingCalendar(cal, days), nil
+}
+
+// ForEmployee loads the calendar of the employee's department.
+func (s *CalendarService) ForEmployee(ctx context.Context, employeeID int64, from, to time.Time) (*WorkingCalendar, error) {
+	e, err := s.employeeRepo.FindByID(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	return s.ForDepartment(ctx, e.DepartmentID, from, to)
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+type ChecklistService struct {
+	repo         repositories.ChecklistRepository
+	employeeRepo repositories.EmployeeRepository
+	deptRepo     repositories.DepartmentRepository
+}
+
+func NewChecklistService(repo repositories.ChecklistRepository, employeeRepo repositories.EmployeeRepository, deptRepo repositories.DepartmentRepository) *ChecklistService {
+	return &ChecklistService{
+		repo:         repo,
+		employeeRepo: employeeRepo,
+		deptRepo:     deptRepo,
+	}
+}
+
+func (s *ChecklistService) ListTemplates(ctx context.Context, departmentID *int64, kind string) ([]*models.ChecklistTemplate, error) {
+	return s.repo.ListTemplates(ctx, departmentID, kind)
+}
+
+func (s *ChecklistService) GetTemplate(ctx context.Context, id int64) (*models.ChecklistTemplate, error) {
+	return s.repo.FindTemplateByID(ctx, id)
+}
+
+func (s *ChecklistService) validateTemplate(ctx context.Context, t *models.ChecklistTemplate) error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(t.Tasks) == 0 {
+		return errors.New("a template needs at least one task")
+	}
+	for i, task := range t.Tasks {
+		task.Title = strings.TrimSpace(task.Title)
+		if task.Title == "" {
+			return fmt.Errorf("task %d: title is required", i+1)
+		}
+		if task.AssigneeRole != nil && !models.IsValidChecklistAssigneeRole(*task.AssigneeRole) {
+			return fmt.Errorf("task %d: assigneeRole must be employee, manager or department_head", i+1)
+		}
+		if task.AssigneeID != nil {
+			if _, err := s.employeeRepo.FindByID(ctx, *task.AssigneeID); err != nil {
+				return fmt.Errorf("task %d: assignee not found", i+1)
+			}
+		}
+	}
+	return nil
+}
+
+// CreateTemplate adds a department's template, or the company-wide one
+// when DepartmentID is nil. There is at most one of each kind per
+// department.
+func (s *ChecklistService) CreateTemplate(ctx context.Context, t *models.ChecklistTemplate) error {
+	if !models.IsValidChecklistKind(t.Kind) {
+		return errors.New("kind must be onboarding or offboarding")
+	}
+	if t.DepartmentID != nil {
+		if _, err := s.deptRepo.FindByID(ctx, *t.DepartmentID); err != nil {
+			return errors.New("department not found")
+		}
+	}
+	if err := s.validateTemplate(ctx, t); err != nil {
+		return err
+	}
+	existing, err := s.repo.ListTemplates(ctx, t.DepartmentID, t.Kind)
+	if err != nil {
+		return err
+	}
+	for _, o := range existing {
+		if (o.DepartmentID == nil) == (t.DepartmentID == nil) {
+			return fmt.Errorf("template %d already covers this department; update it instead", o.ID)
+		}
+	}
+	return s.repo.CreateTemplate(ctx, t)
+}
+
+// UpdateTemplate renames the template and replaces its tasks; department
+// and kind stay as created.
+func (s *ChecklistService) UpdateTemplate(ctx context.Context, t *models.ChecklistTemplate) error {
+	current, err := s.repo.FindTemplateByID(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	t.DepartmentID = current.DepartmentID
+	t.Kind = current.Kind
+	t.CreatedAt = current.CreatedAt
+	if err := s.validateTemplate(ctx, t); err != nil {
+		return err
+	}
+	return s.repo.UpdateTemplate(ctx, t)
+}
+
+func (s *ChecklistService) DeleteTemplate(ctx context.Context, id int64) error {
+	return s.repo.DeleteTemplate(ctx, id)
+}
+
+// assignee resolves who a template task goes to for e: the fixed assignee,
+// or the employee, their manager or their department head.
+func (s *ChecklistService) assignee(ctx context.Context, e *models.Employee, task *models.ChecklistTemplateTask) (*int64, error) {
+	if task.AssigneeID != nil || task.AssigneeRole == nil {
+		return task.AssigneeID, nil
+	}
+	switch *task.AssigneeRole {
+	case models.ChecklistAssigneeEmployee:
+		return &e.ID, nil
+	case models.ChecklistAssigneeManager:
+		return e.ManagerID, nil
+	case models.ChecklistAssigneeDepartmentHead:
+		d, err := s.deptRepo.FindByID(ctx, e.DepartmentID)
+		if err != nil {
+			return nil, err
+		}
+		return d.HeadEmployeeID, nil
+	}
+	return nil, nil
+}
+
+// Start creates e's checklist of kind from their department's template,
+// or the company-wide one, with due dates counted from anchor. It returns
+// nil when no template applies.
+func (s *ChecklistService) Start(ctx context.Context, e *models.Employee, kind string, anchor time.Time) (*models.Checklist, error) {
+	t, err := s.repo.FindTemplateFor(ctx, e.DepartmentID, kind)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	anchor = truncateToDate(anchor)
+	c := &models.Checklist{
+		EmployeeID: e.ID,
+		TemplateID: &t.ID,
+		Kind:       kind,
+		AnchorDate: anchor,
+	}
+	for _, task := range t.Tasks {
+		assigneeID, err := s.assignee(ctx, e, task)
+		if err != nil {
+			return nil, err
+		}
+		c.Tasks = append(c.Tasks, &models.ChecklistTask{
+			Position:   task.Position,
+			Title:      task.Title,
+			AssigneeID: assigneeID,
+			DueDate:    anchor.AddDate(0, 0, task.DueOffsetDays),
+		})
+	}
+	return c, s.repo.Create(ctx, c)
+}
+
+func (s *ChecklistService) ListByEmployee(ctx context.Context, employeeID int64) ([]*models.Checklist, error) {
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByEmployee(ctx, employeeID)
+}
+
+// ListOpen lists the checklists still in progress, e.g. everyone
+// mid-onboarding with kind onboarding.
+func (s *ChecklistService) ListOpen(ctx context.Context, kind string, departmentID *int64, today time.Time) ([]*models.ChecklistSummary, error) {
+	if kind != "" && !models.IsValidChecklistKind(kind) {
+		return nil, errors.New("kind must be onboarding or offboarding")
+	}
+	return s.repo.ListOpen(ctx, kind, departmentID, truncateToDate(today))
+}
+
+func (s *ChecklistService) Overdue(ctx context.Context, today time.Time, assigneeID, departmentID *int64) ([]*models.OverdueTask, error) {
+	return s.repo.ListOverdue(ctx, truncateToDate(today), assigneeID, departmentID)
+}
+
+// CompleteTask ticks off a task; the checklist completes with its last
+// task.
+func (s *ChecklistService) CompleteTask(ctx context.Context, id int64, completedBy *int64, note *string) (*models.ChecklistTask, error) {
+	t, err := s.repo.FindTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t.CompletedAt != nil {
+		return nil, errors.New("task is already completed")
+	}
+	if completedBy != nil {
+		if _, err := s.employeeRepo.FindByID(ctx, *completedBy); err != nil {
+			return nil, errors.New("completedBy employee not found")
+		}
+	}
+	t.CompletedBy = completedBy
+	t.Note = note
+	if err := s.repo.CompleteTask(ctx, t); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("task is already completed")
+		}
+		return nil, err
+	}
+	return t, nil
+}
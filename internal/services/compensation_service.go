@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+type CompensationService struct {
+	repo         repositories.CompensationRepository
+	employeeRepo repositories.EmployeeRepository
+}
+
+func NewCompensationService(repo repositories.CompensationRepository, employeeRepo repositories.EmployeeRepository) *CompensationService {
+	return &CompensationService{
+		repo:         repo,
+		employeeRepo: employeeRepo,
+	}
+}
+
+// CompensationOverview splits an employee's history around today: Current is
+// the entry in effect, Scheduled holds future-dated changes (soonest first).
+type CompensationOverview struct {
+	Current   *models.Compensation
+	History   []*models.Compensation
+	Scheduled []*models.Compensation
+}
+
+func (s *CompensationService) Overview(ctx context.Context, employeeID int64) (*CompensationOverview, error) {
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.repo.ListByEmployee(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := truncateToDate(time.Now())
+	out := &CompensationOverview{}
+	for _, c := range entries {
+		if c.EffectiveDate.After(today) {
+			out.Scheduled = append([]*models.Compensation{c}, out.Scheduled...)
+			continue
+		}
+		if out.Current == nil {
+			out.Current = c
+		}
+		out.History = append(out.History, c)
+	}
+	return out, nil
+}
+
+// Record adds a compensation entry. An effective date in the future schedules
+// the change; it becomes the current salary once that date is reached.
+func (s *CompensationService) Record(ctx context.Context, c *models.Compensation) error {
+	if c.EmployeeID == 0 {
+		return errors.New("employeeId is required")
+	}
+	if _, err := s.employeeRepo.FindByID(ctx, c.EmployeeID); err != nil {
+		return errors.New("employee not found")
+	}
+	if c.EffectiveDate.IsZero() {
+		return errors.New("effectiveDate is required")
+	}
+	if c.Amount.Sign() < 0 {
+		return errors.New("amount must not be negative")
+	}
+	if !models.IsValidCompensationReason(c.Reason) {
+		return errors.New("reason must be one of hire, promotion, annual_review, correction")
+	}
+	c.Currency = strings.ToUpper(strings.TrimSpace(c.Currency))
+	if c.Currency == "" {
+		c.Currency = models.DefaultCurrency
+	}
+	if !models.IsSupportedCurrency(c.Currency) {
+		return errors.New("unsupported currency " + c.Currency)
+	}
+	if !models.FitsCurrency(c.Amount, c.Currency) {
+		return fmt.Errorf("amount must have at most %d decimal places in %s", models.CurrencyMinorUnits(c.Currency), c.Currency)
+	}
+	if c.ApprovedBy != nil {
+		if _, err := s.employeeRepo.FindByID(ctx, *c.ApprovedBy); err != nil {
+			return errors.New("approver not found")
+		}
+	}
+	c.EffectiveDate = truncateToDate(c.EffectiveDate)
+	return s.repo.Create(ctx, c)
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
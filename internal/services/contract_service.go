@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+const (
+	// maxFixedTermMonths is the longest fixed-term contract the Labour Code
+	// allows (Art. 20).
+	maxFixedTermMonths = 36
+	// maxConsecutiveFixedTerm is how many fixed-term contracts may follow
+	// each other; the next one has to be indefinite (Art. 20(2)).
+	maxConsecutiveFixedTerm = 2
+	// DefaultContractNoticeDays is how far ahead expiring contracts are
+	// reported when CONTRACT_EXPIRY_NOTICE_DAYS is not set.
+	DefaultContractNoticeDays = 30
+)
+
+type ContractService struct {
+	repo          repositories.ContractRepository
+	employeeRepo  repositories.EmployeeRepository
+	notifications *NotificationService
+	noticeDays    int
+}
+
+func NewContractService(repo repositories.ContractRepository, employeeRepo repositories.EmployeeRepository, notifications *NotificationService, noticeDays int) *ContractService {
+	if noticeDays <= 0 {
+		noticeDays = DefaultContractNoticeDays
+	}
+	return &ContractService{
+		repo:          repo,
+		employeeRepo:  employeeRepo,
+		notifications: notifications,
+		noticeDays:    noticeDays,
+	}
+}
+
+func (s *ContractService) NoticeDays() int {
+	return s.noticeDays
+}
+
+func (s *ContractService) GetByID(ctx context.Context, id int64) (*models.Contract, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *ContractService) ListByEmployee(ctx context.Context, employeeID int64) ([]*models.Contract, error) {
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByEmployee(ctx, employeeID)
+}
+
+// validate checks c on its own and against the employee's other contracts:
+// they may not overlap, and a third fixed-term contract in a row is refused.
+func (s *ContractService) validate(ctx context.Context, c *models.Contract) error {
+	if !models.IsValidContractType(c.Type) {
+		return errors.New("type must be fixed_term, indefinite or internship")
+	}
+	if c.StartDate.IsZero() {
+		return errors.New("startDate is required")
+	}
+	if c.Type == models.ContractTypeIndefinite {
+		if c.EndDate != nil {
+			return errors.New("an indefinite contract has no endDate")
+		}
+	} else {
+		if c.EndDate == nil {
+			return fmt.Errorf("endDate is required for a %s contract", c.Type)
+		}
+		if c.EndDate.Before(c.StartDate) {
+			return errors.New("endDate must not be before startDate")
+		}
+	}
+	if c.Type == models.ContractTypeFixedTerm && !c.EndDate.Before(c.StartDate.AddDate(0, maxFixedTermMonths, 0)) {
+		return fmt.Errorf("a fixed-term contract may last at most %d months", maxFixedTermMonths)
+	}
+	if c.ContractNumber != nil && strings.TrimSpace(*c.ContractNumber) == "" {
+		c.ContractNumber = nil
+	}
+
+	existing, err := s.repo.ListByEmployee(ctx, c.EmployeeID)
+	if err != nil {
+		return err
+	}
+	fixedTermRun := 0
+	for _, o := range existing {
+		if o.ID == c.ID {
+			continue
+		}
+		if overlaps(o, c) {
+			return fmt.Errorf("overlaps contract %d starting %s", o.ID, o.StartDate.Format("2006-01-02"))
+		}
+		if o.StartDate.Before(c.StartDate) {
+			if o.Type == models.ContractTypeFixedTerm {
+				fixedTermRun++
+			} else {
+				fixedTermRun = 0
+			}
+		}
+	}
+	if c.Type == models.ContractTypeFixedTerm && fixedTermRun >= maxConsecutiveFixedTerm {
+		return fmt.Errorf("the employee has had %d fixed-term contracts in a row; the next one must be indefinite", fixedTermRun)
+	}
+	return nil
+}
+
+// overlaps reports whether two contracts share a day; a nil end date runs
+// indefinitely.
+func overlaps(a, b *models.Contract) bool {
+	if a.EndDate != nil && a.EndDate.Before(b.StartDate) {
+		return false
+	}
+	if b.EndDate != nil && b.EndDate.Before(a.StartDate) {
+		return false
+	}
+	return true
+}
+
+func (s *ContractService) Create(ctx context.Context, c *models.Contract) error {
+	if _, err := s.employeeRepo.FindByID(ctx, c.EmployeeID); err != nil {
+		return err
+	}
+	if err := s.validate(ctx, c); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, c)
+}
+
+// Update edits a contract in place; the employee it belongs to cannot change.
+func (s *ContractService) Update(ctx context.Context, c *models.Contract) error {
+	current, err := s.repo.FindByID(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+	c.EmployeeID = current.EmployeeID
+	c.CreatedAt = current.CreatedAt
+	if err := s.validate(ctx, c); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, c)
+}
+
+func (s *ContractService) Delete(ctx context.Context, id int64) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// Expiring lists the contracts ending within days of today that have not
+// been renewed yet.
+func (s *ContractService) Expiring(ctx context.Context, today time.Time, days int) ([]*models.ContractExpiry, error) {
+	if days <= 0 {
+		days = s.noticeDays
+	}
+	from := truncateToDate(today)
+	return s.repo.ListExpiring(ctx, from, from.AddDate(0, 0, days))
+}
+
+// DaysLeft is the number of days from today until the contract's end date.
+func DaysLeft(c *models.Contract, today time.Time) int {
+	if c.EndDate == nil {
+		return 0
+	}
+	return int(truncateToDate(*c.EndDate).Sub(truncateToDate(today)).Hours() / 24)
+}
+
+// CheckExpiring raises a notification for every contract ending within the
+// notice period, once per contract and end date, and returns how many it
+// raised.
+func (s *ContractService) CheckExpiring(ctx context.Context, today time.Time) (int, error) {
+	expiring, err := s.Expiring(ctx, today, s.noticeDays)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, x := range expiring {
+		employeeID := x.EmployeeID
+		key := fmt.Sprintf("%s:%d:%s", models.NotificationContractExpiring, x.ID, x.EndDate.Format("2006-01-02"))
+		created, err := s.notifications.Notify(ctx, &models.Notification{
+			Kind:       models.NotificationContractExpiring,
+			EmployeeID: &employeeID,
+			Message: fmt.Sprintf("%s contract of %s ends on %s (%d days left); renew it or give notice",
+				strings.ReplaceAll(x.Type, "_", "-"), x.EmployeeName, x.EndDate.Format("2006-01-02"), DaysLeft(&x.Contract, today)),
+			DedupeKey: &key,
+		})
+		if err != nil {
+			return n, err
+		}
+		if created {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// RunExpiryCheck calls CheckExpiring now and then every interval until ctx
+// is cancelled.
+func (s *ContractService) RunExpiryCheck(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := s.CheckExpiring(ctx, time.Now()); err != nil {
+			log.Println("checking expiring contracts:", err)
+		} else if n > 0 {
+			log.Printf("raised %d contract expiry notifications", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+// MaxCustomFieldLength caps string custom field values.
+const MaxCustomFieldLength = 500
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,39}$`)
+
+type CustomFieldService struct {
+	repo repositories.CustomFieldRepository
+}
+
+func NewCustomFieldService(repo repositories.CustomFieldRepository) *CustomFieldService {
+	return &CustomFieldService{
+		repo: repo,
+	}
+}
+
+func (s *CustomFieldService) List(ctx context.Context) ([]*models.CustomFieldDefinition, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *CustomFieldService) Get(ctx context.Context, id int64) (*models.CustomFieldDefinition, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+// Create adds a field definition. Keys are lower_snake_case and unique.
+func (s *CustomFieldService) Create(ctx context.Context, d *models.CustomFieldDefinition) error {
+	d.Key = strings.TrimSpace(d.Key)
+	if !customFieldKeyPattern.MatchString(d.Key) {
+		return errors.New("key must be lower_snake_case, start with a letter and have at most 40 characters")
+	}
+	if !models.IsValidCustomFieldType(d.Type) {
+		return errors.New("type must be string, number, date or enum")
+	}
+	existing, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, e := range existing {
+		if e.Key == d.Key {
+			return fmt.Errorf("custom field %q already exists with id %d", d.Key, e.ID)
+		}
+	}
+	if err := validateDefinition(d); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, d)
+}
+
+// Update changes the label, enum options and required flag of a field.
+// Values stored before an option was removed are kept until next edited.
+func (s *CustomFieldService) Update(ctx context.Context, d *models.CustomFieldDefinition) error {
+	if err := validateDefinition(d); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, d)
+}
+
+// Delete removes the field along with every employee's value for it.
+func (s *CustomFieldService) Delete(ctx context.Context, id int64) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func validateDefinition(d *models.CustomFieldDefinition) error {
+	d.Label = strings.TrimSpace(d.Label)
+	if d.Label == "" {
+		return errors.New("label is required")
+	}
+	if d.Type != models.CustomFieldEnum {
+		if len(d.Options) > 0 {
+			return errors.New("options only apply to enum fields")
+		}
+		d.Options = []string{}
+		return nil
+	}
+	seen := map[string]bool{}
+	var options []string
+	for _, o := range d.Options {
+		o = strings.TrimSpace(o)
+		if o == "" || seen[o] {
+			continue
+		}
+		seen[o] = true
+		options = append(options, o)
+	}
+	if len(options) == 0 {
+		return errors.New("enum fields need at least one option")
+	}
+	d.Options = options
+	return nil
+}
+
+// Normalize checks values against the field definitions and returns them
+// in their stored form: trimmed strings, exact numbers and YYYY-MM-DD
+// dates. Unknown keys are rejected.
+//
+// previous holds the stored values on update and is nil on create. Only
+// values that differ from it are checked, so a definition change does not
+// block unrelated edits of employees it no longer fits. Required fields
+// must be present on create and cannot be removed on update.
+func (s *CustomFieldService) Normalize(ctx context.Context, values, previous models.CustomFieldValues) (models.CustomFieldValues, error) {
+	defs, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byKey := map[string]*models.CustomFieldDefinition{}
+	for _, d := range defs {
+		byKey[d.Key] = d
+	}
+
+	out := models.CustomFieldValues{}
+	for k, v := range values {
+		if old, ok := previous[k]; ok && v != nil && reflect.DeepEqual(old, v) {
+			out[k] = v
+			continue
+		}
+		d, ok := byKey[k]
+		if !ok {
+			return nil, fmt.Errorf("unknown custom field %q; define it via /custom-fields", k)
+		}
+		if v == nil {
+			continue
+		}
+		nv, err := normalizeCustomValue(d, v)
+		if err != nil {
+			return nil, fmt.Errorf("custom field %s: %v", k, err)
+		}
+		out[k] = nv
+	}
+	for _, d := range defs {
+		if _, ok := out[d.Key]; !d.Required || ok {
+			continue
+		}
+		if _, had := previous[d.Key]; previous == nil || had {
+			return nil, fmt.Errorf("custom field %s is required", d.Key)
+		}
+	}
+	return out, nil
+}
+
+// NormalizeFilter checks a listing filter against the field definitions
+// and normalizes each value the way stored values are, so cf.level=2.0
+// finds the employees with level 2. Unknown keys and values the field
+// could never hold come back as a ValidationError.
+func (s *CustomFieldService) NormalizeFilter(ctx context.Context, filter map[string]string) (map[string]string, error) {
+	if len(filter) == 0 {
+		return filter, nil
+	}
+	defs, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byKey := map[string]*models.CustomFieldDefinition{}
+	for _, d := range defs {
+		byKey[d.Key] = d
+	}
+	out := make(map[string]string, len(filter))
+	for k, v := range filter {
+		d, ok := byKey[k]
+		if !ok {
+			return nil, &ValidationError{fmt.Errorf("unknown custom field %q", k)}
+		}
+		nv, err := normalizeCustomValue(d, v)
+		if err != nil {
+			return nil, &ValidationError{fmt.Errorf("custom field %s: %v", k, err)}
+		}
+		out[k] = fmt.Sprint(nv)
+	}
+	return out, nil
+}
+
+func normalizeCustomValue(d *models.CustomFieldDefinition, v interface{}) (interface{}, error) {
+	switch d.Type {
+	case models.CustomFieldNumber:
+		var s string
+		switch n := v.(type) {
+		case json.Number:
+			s = n.String()
+		case string:
+			s = n
+		default:
+			return nil, errors.New("must be a number")
+		}
+		dec, err := models.ParseDecimal(s)
+		if err != nil {
+			return nil, errors.New("must be a number")
+		}
+		return json.Number(dec.String()), nil
+	}
+
+	str, ok := v.(string)
+	if !ok {
+		return nil, errors.New("must be a string")
+	}
+	str = strings.TrimSpace(str)
+	switch d.Type {
+	case models.CustomFieldDate:
+		t, err := time.Parse("2006-01-02", str)
+		if err != nil {
+			return nil, errors.New("must be YYYY-MM-DD")
+		}
+		return t.Format("2006-01-02"), nil
+	case models.CustomFieldEnum:
+		for _, o := range d.Options {
+			if o == str {
+				return str, nil
+			}
+		}
+		return nil, fmt.Errorf("must be one of %s", strings.Join(d.Options, ", "))
+	}
+	if str == "" {
+		return nil, errors.New("must not be empty")
+	}
+	if len(str) > MaxCustomFieldLength {
+		return nil, fmt.Errorf("must be at most %d characters", MaxCustomFieldLength)
+	}
+	return str, nil
+}
@@ -2,18 +2,22 @@ package services
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"app/internal/models"
 	"app/internal/repositories"
 )
 
 type DepartmentService struct {
-	repo repositories.DepartmentRepository
+	repo         repositories.DepartmentRepository
+	employeeRepo repositories.EmployeeRepository
 }
 
-func NewDepartmentService(repo repositories.DepartmentRepository) *DepartmentService {
+func NewDepartmentService(repo repositories.DepartmentRepository, employeeRepo repositories.EmployeeRepository) *DepartmentService {
 	return &DepartmentService{
-		repo: repo,
+		repo:         repo,
+		employeeRepo: employeeRepo,
 	}
 }
 
@@ -24,3 +28,68 @@ func (s *DepartmentService) FindAll(ctx context.Context, limit, offset int) ([]*
 func (s *DepartmentService) Create(ctx context.Context, d *models.Department) error {
 	return s.repo.Create(ctx, d)
 }
+
+func (s *DepartmentService) GetByID(ctx context.Context, id int64) (*models.Department, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+// SetHead appoints a member of the department as its head, or clears the
+// head when employeeID is nil. The head approves requests such as leave for
+// employees without a direct manager.
+func (s *DepartmentService) SetHead(ctx context.Context, id int64, employeeID *int64) error {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return err
+	}
+	if employeeID != nil {
+		e, err := s.employeeRepo.FindByID(ctx, *employeeID)
+		if err != nil {
+			return errors.New("employee not found")
+		}
+		if e.DepartmentID != id {
+			return errors.New("the head must be a member of the department")
+		}
+	}
+	return s.repo.SetHead(ctx, id, employeeID)
+}
+
+// GetWithPlans returns the department with its plans, newest year first.
+func (s *DepartmentService) GetWithPlans(ctx context.Context, id int64) (*models.Department, error) {
+	d, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d.Plans, err = s.repo.ListPlans(ctx, id); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// SavePlan sets the department's headcount and salary budget for a fiscal
+// year, replacing any earlier plan for that year. The budget is annual and
+// in the default currency unless another is given.
+func (s *DepartmentService) SavePlan(ctx context.Context, p *models.DepartmentPlan) error {
+	if _, err := s.repo.FindByID(ctx, p.DepartmentID); err != nil {
+		return err
+	}
+	if p.FiscalYear < 2000 || p.FiscalYear > 2100 {
+		return errors.New("fiscal year must be between 2000 and 2100")
+	}
+	if p.PlannedHeadcount < 0 {
+		return errors.New("planned headcount cannot be negative")
+	}
+	if p.SalaryBudget.Sign() < 0 {
+		return errors.New("salary budget cannot be negative")
+	}
+	p.Currency = strings.ToUpper(strings.TrimSpace(p.Currency))
+	if p.Currency == "" {
+		p.Currency = models.DefaultCurrency
+	}
+	if !models.IsSupportedCurrency(p.Currency) {
+		return errors.New("unsupported currency " + p.Currency)
+	}
+	return s.repo.SavePlan(ctx, p)
+}
+
+func (s *DepartmentService) DeletePlan(ctx context.Context, id int64, fiscalYear int) error {
+	return s.repo.DeletePlan(ctx, id, fiscalYear)
+}
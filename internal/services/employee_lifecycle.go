@@ -0,0 +1,423 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"app/internal/models"
+)
+
+// maxProbationDays is the longest probation the Labour Code allows (art. 25,
+// for enterprise managers; shorter limits apply to other jobs).
+const maxProbationDays = 180
+
+func checkProbation(hireDate time.Time, probationEnd *time.Time) error {
+	if probationEnd == nil {
+		return nil
+	}
+	if !probationEnd.After(hireDate) {
+		return errors.New("probationEndDate must be after hireDate")
+	}
+	if probationEnd.After(hireDate.AddDate(0, 0, maxProbationDays)) {
+		return fmt.Errorf("probation cannot exceed %d days", maxProbationDays)
+	}
+	return nil
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+// transition checks that e may move to status and returns the event
+// recording it.
+func transition(e *models.Employee, kind, status string, effective time.Time, reason *string) (*models.EmploymentEvent, error) {
+	if !models.CanTransitionEmployment(e.Status, status) {
+		return nil, fmt.Errorf("cannot go from %s to %s", e.Status, status)
+	}
+	return &models.EmploymentEvent{
+		EmployeeID:    e.ID,
+		Kind:          kind,
+		EffectiveDate: truncateToDate(effective),
+		FromStatus:    strPtr(e.Status),
+		ToStatus:      strPtr(status),
+		Reason:        reason,
+	}, nil
+}
+
+// record stores ev, applied now unless it takes effect after today.
+func (s *EmployeeService) record(ctx context.Context, ev *models.EmploymentEvent) error {
+	if !ev.EffectiveDate.After(truncateToDate(time.Now())) {
+		now := time.Now()
+		ev.AppliedAt = &now
+	}
+	return s.eventRepo.Create(ctx, ev)
+}
+
+func (s *EmployeeService) History(ctx context.Context, id int64) ([]*models.EmploymentEvent, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.eventRepo.ListByEmployee(ctx, id)
+}
+
+// Hire turns a candidate into an employee starting on hireDate, on
+// probation when probationEnd is given, and starts their onboarding.
+func (s *EmployeeService) Hire(ctx context.Context, id int64, hireDate time.Time, probationEnd *time.Time) (*models.Employee, error) {
+	e, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	status := models.EmploymentStatusActive
+	if probationEnd != nil {
+		status = models.EmploymentStatusProbation
+	}
+	ev, err := transition(e, models.EmploymentEventHire, status, hireDate, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProbation(hireDate, probationEnd); err != nil {
+		return nil, err
+	}
+
+	e.Status = status
+	e.HireDate = truncateToDate(hireDate)
+	e.ProbationEndDate = probationEnd
+	ev.ToDepartmentID = &e.DepartmentID
+	ev.ToPositionID = e.PositionID
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, e); err != nil {
+			return err
+		}
+		now := time.Now()
+		ev.AppliedAt = &now
+		if err := s.eventRepo.Create(ctx, ev); err != nil {
+			return err
+		}
+		_, err := s.checklists.Start(ctx, e, models.ChecklistKindOnboarding, e.HireDate)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// ChangeStatus covers the transitions without an endpoint of their own:
+// passing probation and going on or returning from a leave of absence.
+func (s *EmployeeService) ChangeStatus(ctx context.Context, id int64, status string, reason *string) (*models.Employee, error) {
+	if status != models.EmploymentStatusActive && status != models.EmploymentStatusOnLeave {
+		return nil, errors.New("status must be active or on_leave; use hire, terminate or rehire for other changes")
+	}
+	e, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if e.Status == models.EmploymentStatusCandidate {
+		return nil, errors.New("candidates must be hired first")
+	}
+	ev, err := transition(e, models.EmploymentEventStatusChange, status, time.Now(), reason)
+	if err != nil {
+		return nil, err
+	}
+
+	e.Status = status
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, e); err != nil {
+			return err
+		}
+		return s.record(ctx, ev)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Transfer moves the employee to another department on effective. Past or
+// present dates apply at once; later ones are applied by ApplyDueEvents.
+// managerID, when given, becomes their manager in the new department.
+func (s *EmployeeService) Transfer(ctx context.Context, id, departmentID int64, managerID *int64, effective time.Time, reason *string) (*models.Employee, *models.EmploymentEvent, error) {
+	e, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch e.Status {
+	case models.EmploymentStatusCandidate, models.EmploymentStatusTerminated:
+		return nil, nil, fmt.Errorf("cannot transfer a %s employee", e.Status)
+	}
+	if departmentID == e.DepartmentID {
+		return nil, nil, errors.New("employee is already in this department")
+	}
+	if _, err := s.deptRepo.FindByID(ctx, departmentID); err != nil {
+		return nil, nil, errors.New("department not found")
+	}
+	if managerID != nil {
+		if *managerID == id {
+			return nil, nil, errors.New("an employee cannot be their own manager")
+		}
+		if _, err := s.repo.FindByID(ctx, *managerID); err != nil {
+			return nil, nil, errors.New("manager not found")
+		}
+	}
+	effective = truncateToDate(effective)
+	if effective.Before(e.HireDate) {
+		return nil, nil, errors.New("effectiveDate must not be before hireDate")
+	}
+	if e.TerminationDate != nil && effective.After(*e.TerminationDate) {
+		return nil, nil, errors.New("effectiveDate must not be after terminationDate")
+	}
+	if _, err := s.eventRepo.FindPending(ctx, id, models.EmploymentEventTransfer); err == nil {
+		return nil, nil, errors.New("a transfer is already scheduled for this employee")
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, err
+	}
+
+	ev := &models.EmploymentEvent{
+		EmployeeID:       id,
+		Kind:             models.EmploymentEventTransfer,
+		EffectiveDate:    effective,
+		FromDepartmentID: &e.DepartmentID,
+		ToDepartmentID:   &departmentID,
+		ToManagerID:      managerID,
+		Reason:           reason,
+	}
+	if effective.After(truncateToDate(time.Now())) {
+		return e, ev, s.eventRepo.Create(ctx, ev)
+	}
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.applyTransfer(ctx, e, ev); err != nil {
+			return err
+		}
+		return s.record(ctx, ev)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return e, ev, nil
+}
+
+func (s *EmployeeService) applyTransfer(ctx context.Context, e *models.Employee, ev *models.EmploymentEvent) error {
+	if err := s.releaseHead(ctx, e); err != nil {
+		return err
+	}
+	e.DepartmentID = *ev.ToDepartmentID
+	if ev.ToManagerID != nil {
+		e.ManagerID = ev.ToManagerID
+	}
+	return s.repo.Update(ctx, e)
+}
+
+// releaseHead clears e as head of their current department, since only
+// members may head a department.
+func (s *EmployeeService) releaseHead(ctx context.Context, e *models.Employee) error {
+	d, err := s.deptRepo.FindByID(ctx, e.DepartmentID)
+	if err != nil {
+		return err
+	}
+	if d.HeadEmployeeID != nil && *d.HeadEmployeeID == e.ID {
+		return s.deptRepo.SetHead(ctx, d.ID, nil)
+	}
+	return nil
+}
+
+// Terminate ends the employment after lastDay and starts the offboarding
+// checklist. The employee keeps their status until then; scheduled
+// transfers after lastDay are dropped. Assets still held either block the
+// termination or come back as a warning, depending on the asset policy;
+// the warning is also raised as a notification once the termination is
+// saved.
+func (s *EmployeeService) Terminate(ctx context.Context, id int64, lastDay time.Time, reason string) (*models.Employee, string, error) {
+	if reason == "" {
+		return nil, "", errors.New("reason is required")
+	}
+	e, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	lastDay = truncateToDate(lastDay)
+	if lastDay.Before(e.HireDate) {
+		return nil, "", errors.New("terminationDate must not be before hireDate")
+	}
+	if _, err := s.eventRepo.FindPending(ctx, id, models.EmploymentEventTermination); err == nil {
+		return nil, "", errors.New("a termination is already scheduled for this employee")
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, "", err
+	}
+	ev, err := transition(e, models.EmploymentEventTermination, models.EmploymentStatusTerminated, lastDay.AddDate(0, 0, 1), &reason)
+	if err != nil {
+		return nil, "", err
+	}
+	warning, err := s.assets.CheckTermination(ctx, e)
+	if err != nil {
+		return nil, "", err
+	}
+
+	e.TerminationDate = &lastDay
+	e.TerminationReason = &reason
+	ev.FromDepartmentID = &e.DepartmentID
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if pending, err := s.eventRepo.FindPending(ctx, id, models.EmploymentEventTransfer); err == nil && pending.EffectiveDate.After(lastDay) {
+			if err := s.eventRepo.DeletePending(ctx, id, models.EmploymentEventTransfer); err != nil {
+				return err
+			}
+		} else if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if !ev.EffectiveDate.After(truncateToDate(time.Now())) {
+			if err := s.applyTermination(ctx, e); err != nil {
+				return err
+			}
+		} else if err := s.repo.Update(ctx, e); err != nil {
+			return err
+		}
+		if err := s.record(ctx, ev); err != nil {
+			return err
+		}
+		_, err := s.checklists.Start(ctx, e, models.ChecklistKindOffboarding, lastDay)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	// The termination is saved by now and cannot be undone, so a failed
+	// notification is only logged; the caller still gets the warning.
+	if warning != "" {
+		if err := s.assets.NotifyOutstanding(ctx, e, lastDay, warning); err != nil {
+			log.Printf("notify outstanding assets of employee %d: %v", e.ID, err)
+		}
+	}
+	return e, warning, nil
+}
+
+func (s *EmployeeService) applyTermination(ctx context.Context, e *models.Employee) error {
+	if err := s.releaseHead(ctx, e); err != nil {
+		return err
+	}
+	e.Status = models.EmploymentStatusTerminated
+	return s.repo.Update(ctx, e)
+}
+
+// Rehire starts a new employment for a former employee on hireDate,
+// optionally in another department and on probation.
+func (s *EmployeeService) Rehire(ctx context.Context, id int64, hireDate time.Time, probationEnd *time.Time, departmentID *int64) (*models.Employee, error) {
+	e, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	status := models.EmploymentStatusActive
+	if probationEnd != nil {
+		status = models.EmploymentStatusProbation
+	}
+	ev, err := transition(e, models.EmploymentEventRehire, status, hireDate, nil)
+	if err != nil {
+		return nil, err
+	}
+	hireDate = truncateToDate(hireDate)
+	if e.TerminationDate != nil && !hireDate.After(*e.TerminationDate) {
+		return nil, errors.New("hireDate must be after the previous terminationDate")
+	}
+	if err := checkProbation(hireDate, probationEnd); err != nil {
+		return nil, err
+	}
+	ev.FromDepartmentID = &e.DepartmentID
+	if departmentID != nil {
+		if _, err := s.deptRepo.FindByID(ctx, *departmentID); err != nil {
+			return nil, errors.New("department not found")
+		}
+		e.DepartmentID = *departmentID
+	}
+	ev.ToDepartmentID = &e.DepartmentID
+	ev.ToPositionID = e.PositionID
+
+	e.Status = status
+	e.HireDate = hireDate
+	e.ProbationEndDate = probationEnd
+	e.TerminationDate = nil
+	e.TerminationReason = nil
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, e); err != nil {
+			return err
+		}
+		now := time.Now()
+		ev.AppliedAt = &now
+		if err := s.eventRepo.Create(ctx, ev); err != nil {
+			return err
+		}
+		_, err := s.checklists.Start(ctx, e, models.ChecklistKindOnboarding, e.HireDate)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// ApplyDueEvents applies scheduled transfers and terminations whose date has
+// come, returning how many were applied. An event that fails is logged and
+// left for the next run; the others are still applied, and the failures
+// come back joined.
+func (s *EmployeeService) ApplyDueEvents(ctx context.Context, today time.Time) (int, error) {
+	due, err := s.eventRepo.ListDue(ctx, truncateToDate(today))
+	if err != nil {
+		return 0, err
+	}
+	applied := 0
+	var errs []error
+	for _, ev := range due {
+		if err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+			return s.applyEvent(ctx, ev)
+		}); err != nil {
+			log.Printf("apply employment event %d: %v", ev.ID, err)
+			errs = append(errs, fmt.Errorf("employment event %d: %w", ev.ID, err))
+			continue
+		}
+		applied++
+	}
+	return applied, errors.Join(errs...)
+}
+
+// applyEvent applies a due transfer or termination and marks it applied.
+func (s *EmployeeService) applyEvent(ctx context.Context, ev *models.EmploymentEvent) error {
+	e, err := s.repo.FindByID(ctx, ev.EmployeeID)
+	if err != nil {
+		return err
+	}
+	switch ev.Kind {
+	case models.EmploymentEventTransfer:
+		err = s.applyTransfer(ctx, e, ev)
+	case models.EmploymentEventTermination:
+		err = s.applyTermination(ctx, e)
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.eventRepo.MarkApplied(ctx, ev); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return nil
+}
+
+// RunScheduler calls ApplyDueEvents now and then every interval until ctx
+// is cancelled.
+func (s *EmployeeService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		n, err := s.ApplyDueEvents(ctx, time.Now())
+		if n > 0 {
+			log.Printf("applied %d employment events", n)
+		}
+		if err != nil {
+			log.Println("applying employment events:", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
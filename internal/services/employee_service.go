@@ -3,20 +3,41 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"app/internal/models"
 	"app/internal/repositories"
 )
 
 type EmployeeService struct {
-	repo     repositories.EmployeeRepository
-	deptRepo repositories.DepartmentRepository
+	repo         repositories.EmployeeRepository
+	deptRepo     repositories.DepartmentRepository
+	compRepo     repositories.CompensationRepository
+	positionRepo repositories.PositionRepository
+	eventRepo    repositories.EmploymentEventRepository
+	rates        *ExchangeRateService
+	bands        *SalaryBandService
+	checklists   *ChecklistService
+	assets       *AssetService
+	customFields *CustomFieldService
+	tx           repositories.Transactor
 }
 
-func NewEmployeeService(repo repositories.EmployeeRepository, deptRepo repositories.DepartmentRepository) *EmployeeService {
+func NewEmployeeService(repo repositories.EmployeeRepository, deptRepo repositories.DepartmentRepository, compRepo repositories.CompensationRepository, positionRepo repositories.PositionRepository, eventRepo repositories.EmploymentEventRepository, rates *ExchangeRateService, bands *SalaryBandService, checklists *ChecklistService, assets *AssetService, customFields *CustomFieldService, tx repositories.Transactor) *EmployeeService {
 	return &EmployeeService{
-		repo:     repo,
-		deptRepo: deptRepo,
+		repo:         repo,
+		deptRepo:     deptRepo,
+		compRepo:     compRepo,
+		positionRepo: positionRepo,
+		eventRepo:    eventRepo,
+		rates:        rates,
+		bands:        bands,
+		checklists:   checklists,
+		assets:       assets,
+		customFields: customFields,
+		tx:           tx,
 	}
 }
 
@@ -24,34 +45,336 @@ func (s *EmployeeService) GetByID(ctx context.Context, id int64) (*models.Employ
 	return s.repo.FindByID(ctx, id)
 }
 
+// CustomFieldDefinitions lists the custom fields, e.g. for export columns.
+func (s *EmployeeService) CustomFieldDefinitions(ctx context.Context) ([]*models.CustomFieldDefinition, error) {
+	return s.customFields.List(ctx)
+}
+
 func (s *EmployeeService) GetByDepartmentID(ctx context.Context, departmentID int64) ([]*models.Employee, error) {
 	return s.repo.FindByDepartmentID(ctx, departmentID)
 }
 
-func (s *EmployeeService) List(ctx context.Context, limit, offset int, departmentID *int64, keyword *string) ([]*models.Employee, int64, error) {
-	return s.repo.List(ctx, limit, offset, departmentID, keyword)
+func (s *EmployeeService) List(ctx context.Context, limit, offset int, filter models.EmployeeFilter) ([]*models.Employee, int64, error) {
+	for _, req := range filter.Skills {
+		if req.MinLevel < models.MinSkillLevel || req.MinLevel > models.MaxSkillLevel {
+			return nil, 0, &ValidationError{fmt.Errorf("skill level must be between %d and %d", models.MinSkillLevel, models.MaxSkillLevel)}
+		}
+	}
+	customFields, err := s.customFields.NormalizeFilter(ctx, filter.CustomFields)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.CustomFields = customFields
+	if filter.AsOf != nil {
+		asOf := truncateToDate(*filter.AsOf)
+		if asOf.After(truncateToDate(time.Now())) {
+			return nil, 0, &ValidationError{errors.New("asOf must not be in the future")}
+		}
+		filter.AsOf = &asOf
+	}
+	return s.repo.List(ctx, limit, offset, filter)
 }
 
-func (s *EmployeeService) CreateEmployee(ctx context.Context, e *models.Employee) error {
+// validate checks the fields shared by create and update, including the
+// salary band when SALARY_BAND_POLICY is reject. current is the stored
+// employee on update and nil on create.
+func (s *EmployeeService) validate(ctx context.Context, e, current *models.Employee) error {
 	if e.DepartmentID == 0 {
 		return errors.New("departmentId is required")
 	}
 	if _, err := s.deptRepo.FindByID(ctx, e.DepartmentID); err != nil {
 		return errors.New("department not found")
 	}
-	return s.repo.Create(ctx, e)
+	if e.ManagerID != nil {
+		if e.ID != 0 && *e.ManagerID == e.ID {
+			return errors.New("an employee cannot be their own manager")
+		}
+		if _, err := s.repo.FindByID(ctx, *e.ManagerID); err != nil {
+			return errors.New("manager not found")
+		}
+	}
+	if e.PositionID == nil && e.Position != nil && strings.TrimSpace(*e.Position) != "" {
+		p, err := s.positionRepo.Resolve(ctx, *e.Position)
+		if err != nil {
+			return fmt.Errorf("unknown position %q; add it to the catalog via /positions", *e.Position)
+		}
+		e.PositionID = &p.ID
+	}
+	if e.PositionID != nil {
+		p, err := s.positionRepo.FindByID(ctx, *e.PositionID)
+		if err != nil {
+			return errors.New("position not found")
+		}
+		e.Position = &p.Title
+	}
+	if e.SalaryCurrency != nil && !models.IsSupportedCurrency(*e.SalaryCurrency) {
+		return errors.New("unsupported currency " + *e.SalaryCurrency)
+	}
+	if e.Salary != nil && e.Salary.Sign() < 0 {
+		return errors.New("salary must not be negative")
+	}
+	if e.Salary != nil {
+		currency := e.SalaryCurrency
+		if currency == nil && current != nil {
+			currency = current.SalaryCurrency
+		}
+		if c := derefCurrency(currency); !models.FitsCurrency(*e.Salary, c) {
+			return fmt.Errorf("salary must have at most %d decimal places in %s", models.CurrencyMinorUnits(c), c)
+		}
+	}
+	if e.DateOfBirth != nil {
+		if err := checkDateOfBirth(*e.DateOfBirth, time.Now()); err != nil {
+			return err
+		}
+		if !e.HireDate.IsZero() && !e.DateOfBirth.Before(e.HireDate) {
+			return errors.New("dateOfBirth must be before hireDate")
+		}
+	}
+	if e.TerminationDate != nil && e.TerminationDate.Before(e.HireDate) {
+		return errors.New("terminationDate must not be before hireDate")
+	}
+	var previous models.CustomFieldValues
+	if current != nil {
+		previous = current.CustomFields
+	}
+	values, err := s.customFields.Normalize(ctx, e.CustomFields, previous)
+	if err != nil {
+		return err
+	}
+	e.CustomFields = values
+
+	// Existing out-of-band salaries are left alone until an edit touches
+	// the salary or what selects the band.
+	if s.bands.Policy() == BandPolicyReject && bandFieldsChanged(e, current) {
+		placement, err := s.bands.Check(ctx, e)
+		if err != nil {
+			return err
+		}
+		if msg := bandViolation(placement); msg != "" {
+			return errors.New(msg)
+		}
+	}
+	return nil
+}
+
+// SalaryBandWarning reports an out-of-band salary for e in warn mode, so the
+// handler can surface it without failing the request. It returns "" otherwise.
+func (s *EmployeeService) SalaryBandWarning(ctx context.Context, e *models.Employee) string {
+	if s.bands.Policy() != BandPolicyWarn {
+		return ""
+	}
+	placement, err := s.bands.Check(ctx, e)
+	if err != nil {
+		return ""
+	}
+	return bandViolation(placement)
+}
+
+// CreateEmployee adds a candidate, or an employee on probation or active
+// from HireDate. Status defaults to probation when a probation end date is
+// given and to active otherwise. Everyone but candidates gets their
+// onboarding checklist.
+func (s *EmployeeService) CreateEmployee(ctx context.Context, e *models.Employee) error {
+	if e.HireDate.IsZero() {
+		e.HireDate = truncateToDate(time.Now())
+	}
+	if e.Status == "" {
+		e.Status = models.EmploymentStatusActive
+		if e.ProbationEndDate != nil {
+			e.Status = models.EmploymentStatusProbation
+		}
+	}
+	switch e.Status {
+	case models.EmploymentStatusCandidate, models.EmploymentStatusProbation, models.EmploymentStatusActive:
+	default:
+		return errors.New("status must be candidate, probation or active")
+	}
+	if e.Status == models.EmploymentStatusProbation && e.ProbationEndDate == nil {
+		return errors.New("probationEndDate is required on probation")
+	}
+	if err := checkProbation(e.HireDate, e.ProbationEndDate); err != nil {
+		return err
+	}
+	if err := s.validate(ctx, e, nil); err != nil {
+		return err
+	}
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, e); err != nil {
+			return err
+		}
+		if e.Status != models.EmploymentStatusCandidate {
+			now := time.Now()
+			if err := s.eventRepo.Create(ctx, &models.EmploymentEvent{
+				EmployeeID:     e.ID,
+				Kind:           models.EmploymentEventHire,
+				EffectiveDate:  truncateToDate(e.HireDate),
+				ToStatus:       &e.Status,
+				ToDepartmentID: &e.DepartmentID,
+				ToPositionID:   e.PositionID,
+				AppliedAt:      &now,
+			}); err != nil {
+				return err
+			}
+			if _, err := s.checklists.Start(ctx, e, models.ChecklistKindOnboarding, e.HireDate); err != nil {
+				return err
+			}
+		}
+		if e.Salary == nil {
+			return nil
+		}
+		currency := derefCurrency(e.SalaryCurrency)
+		e.SalaryCurrency = &currency
+		return s.compRepo.Create(ctx, &models.Compensation{
+			EmployeeID:    e.ID,
+			EffectiveDate: truncateToDate(e.HireDate),
+			Amount:        *e.Salary,
+			Currency:      currency,
+			Reason:        models.CompensationReasonHire,
+		})
+	})
 }
 
+// Update edits the employee's details. Department, status and termination
+// only change through the lifecycle operations, which keep the history.
 func (s *EmployeeService) Update(ctx context.Context, e *models.Employee) error {
-	if e.DepartmentID == 0 {
-		return errors.New("departmentId is required")
+	current, err := s.repo.FindByID(ctx, e.ID)
+	if err != nil {
+		return err
 	}
-	if _, err := s.deptRepo.FindByID(ctx, e.DepartmentID); err != nil {
-		return errors.New("department not found")
+	if e.DepartmentID != current.DepartmentID {
+		return errors.New("use /employees/{id}/transfer to change department")
+	}
+	e.Status = current.Status
+	e.TerminationDate = current.TerminationDate
+	e.TerminationReason = current.TerminationReason
+	if err := checkProbation(e.HireDate, e.ProbationEndDate); err != nil {
+		return err
+	}
+	if err := s.validate(ctx, e, current); err != nil {
+		return err
+	}
+
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, e); err != nil {
+			return err
+		}
+
+		// Position changes are kept in the employment history so past states
+		// of the organisation can be reconstructed.
+		if !sameID(e.PositionID, current.PositionID) && e.Status != models.EmploymentStatusCandidate {
+			now := time.Now()
+			if err := s.eventRepo.Create(ctx, &models.EmploymentEvent{
+				EmployeeID:    e.ID,
+				Kind:          models.EmploymentEventPositionChange,
+				EffectiveDate: truncateToDate(now),
+				ToPositionID:  e.PositionID,
+				AppliedAt:     &now,
+			}); err != nil {
+				return err
+			}
+		}
+
+		// A salary edited directly on the employee is kept as a correction
+		// effective today, so the history stays the single source of truth.
+		if e.Salary == nil {
+			return nil
+		}
+		currency := models.DefaultCurrency
+		if current.SalaryCurrency != nil {
+			currency = *current.SalaryCurrency
+		}
+		if e.SalaryCurrency != nil {
+			currency = *e.SalaryCurrency
+		}
+		if current.Salary != nil && current.Salary.Cmp(*e.Salary) == 0 && currency == derefCurrency(current.SalaryCurrency) {
+			return nil
+		}
+		return s.compRepo.Create(ctx, &models.Compensation{
+			EmployeeID:    e.ID,
+			EffectiveDate: truncateToDate(time.Now()),
+			Amount:        *e.Salary,
+			Currency:      currency,
+			Reason:        models.CompensationReasonCorrection,
+		})
+	})
+}
+
+// NormalizeSalaries converts each employee's salary into currency using the
+// rates on the given day, rounded to that currency's minor units. Employees
+// without a salary are left out of the result.
+func (s *EmployeeService) NormalizeSalaries(ctx context.Context, employees []*models.Employee, currency string, on time.Time) (map[int64]models.Decimal, error) {
+	today := truncateToDate(on)
+	out := make(map[int64]models.Decimal, len(employees))
+	for _, e := range employees {
+		if e.Salary == nil {
+			continue
+		}
+		v, err := s.rates.Convert(ctx, *e.Salary, derefCurrency(e.SalaryCurrency), currency, today)
+		if err != nil {
+			return nil, err
+		}
+		out[e.ID] = v.Round(models.CurrencyMinorUnits(currency))
+	}
+	return out, nil
+}
+
+// bandFieldsChanged reports whether e differs from the stored employee in
+// the salary, currency, position or department. It is true on create.
+func bandFieldsChanged(e, current *models.Employee) bool {
+	if current == nil {
+		return true
+	}
+	if (e.Salary == nil) != (current.Salary == nil) || (e.Salary != nil && e.Salary.Cmp(*current.Salary) != 0) {
+		return true
 	}
-	return s.repo.Update(ctx, e)
+	return derefCurrency(e.SalaryCurrency) != derefCurrency(current.SalaryCurrency) ||
+		!sameID(e.PositionID, current.PositionID) || e.DepartmentID != current.DepartmentID
 }
 
+func sameID(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func derefCurrency(v *string) string {
+	if v == nil {
+		return models.DefaultCurrency
+	}
+	return *v
+}
+
+// EmployeeHistoryError is returned when deleting an employee whose records
+// must be kept, e.g. payslips. Such employees are terminated instead.
+type EmployeeHistoryError struct {
+	Records string
+}
+
+func (e *EmployeeHistoryError) Error() string {
+	return "employee has " + e.Records + " that must be kept; terminate them instead of deleting"
+}
+
+// Delete removes an employee entered by mistake. Employees who have been
+// paid or ever held a company asset cannot be deleted.
 func (s *EmployeeService) Delete(ctx context.Context, id int64) error {
-	return s.repo.Delete(ctx, id)
+	paid, err := s.repo.HasPayslips(ctx, id)
+	if err != nil {
+		return err
+	}
+	if paid {
+		return &EmployeeHistoryError{Records: "payslips"}
+	}
+	assignments, err := s.assets.ByEmployee(ctx, id, true)
+	if err != nil {
+		return err
+	}
+	if len(assignments) > 0 {
+		return &EmployeeHistoryError{Records: "asset assignments"}
+	}
+	err = s.repo.Delete(ctx, id)
+	if errors.Is(err, repositories.ErrEmployeeReferenced) {
+		return &EmployeeHistoryError{Records: "records"}
+	}
+	return err
 }
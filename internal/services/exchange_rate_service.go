@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+// pivotCurrency is used for cross rates when a pair has no direct quote,
+// e.g. VND -> JPY is resolved as VND -> USD -> JPY.
+const pivotCurrency = "USD"
+
+type ExchangeRateService struct {
+	repo repositories.ExchangeRateRepository
+}
+
+func NewExchangeRateService(repo repositories.ExchangeRateRepository) *ExchangeRateService {
+	return &ExchangeRateService{
+		repo: repo,
+	}
+}
+
+func (s *ExchangeRateService) List(ctx context.Context, limit, offset int, base, quote *string) ([]*models.ExchangeRate, int64, error) {
+	return s.repo.List(ctx, limit, offset, base, quote)
+}
+
+func (s *ExchangeRateService) Save(ctx context.Context, rate *models.ExchangeRate) error {
+	if err := validateExchangeRate(rate); err != nil {
+		return err
+	}
+	return s.repo.Upsert(ctx, []*models.ExchangeRate{rate})
+}
+
+// ImportCSV reads "base,quote,rate,effectiveDate" rows (header required) and
+// stores them atomically. It returns the number of imported rates.
+func (s *ExchangeRateService) ImportCSV(ctx context.Context, r io.Reader) (int, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return 0, errors.New("csv is empty")
+	}
+	cols := map[string]int{}
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"base", "quote", "rate", "effectivedate"} {
+		if _, ok := cols[name]; !ok {
+			return 0, fmt.Errorf("csv header must contain base, quote, rate, effectiveDate")
+		}
+	}
+
+	var rates []*models.ExchangeRate
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return 0, fmt.Errorf("line %d: %v", line, err)
+		}
+
+		value, err := models.ParseDecimal(record[cols["rate"]])
+		if err != nil {
+			return 0, fmt.Errorf("line %d: invalid rate", line)
+		}
+		effective, err := time.Parse("2006-01-02", strings.TrimSpace(record[cols["effectivedate"]]))
+		if err != nil {
+			return 0, fmt.Errorf("line %d: effectiveDate must be YYYY-MM-DD", line)
+		}
+		rate := &models.ExchangeRate{
+			BaseCurrency:  record[cols["base"]],
+			QuoteCurrency: record[cols["quote"]],
+			Rate:          value,
+			EffectiveDate: effective,
+		}
+		if err := validateExchangeRate(rate); err != nil {
+			return 0, fmt.Errorf("line %d: %v", line, err)
+		}
+		rates = append(rates, rate)
+	}
+
+	if len(rates) == 0 {
+		return 0, errors.New("csv has no rates")
+	}
+	if err := s.repo.Upsert(ctx, rates); err != nil {
+		return 0, err
+	}
+	return len(rates), nil
+}
+
+// Convert returns amount expressed in currency to, using the rates in effect
+// on the given day. The result is exact; callers round for display.
+func (s *ExchangeRateService) Convert(ctx context.Context, amount models.Decimal, from, to string, on time.Time) (models.Decimal, error) {
+	rate, err := s.rate(ctx, from, to, on)
+	if err != nil {
+		return models.Decimal{}, err
+	}
+	return amount.Mul(rate), nil
+}
+
+func (s *ExchangeRateService) rate(ctx context.Context, from, to string, on time.Time) (models.Decimal, error) {
+	if from == to {
+		return models.NewDecimalFromInt(1), nil
+	}
+	if rate, err := s.pairRate(ctx, from, to, on); err == nil {
+		return rate, nil
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return models.Decimal{}, err
+	}
+
+	if from != pivotCurrency && to != pivotCurrency {
+		toPivot, err := s.pairRate(ctx, from, pivotCurrency, on)
+		if err == nil {
+			fromPivot, err := s.pairRate(ctx, pivotCurrency, to, on)
+			if err == nil {
+				return toPivot.Mul(fromPivot), nil
+			}
+		}
+	}
+	return models.Decimal{}, fmt.Errorf("no exchange rate from %s to %s on %s", from, to, on.Format("2006-01-02"))
+}
+
+// pairRate uses whichever of the direct quote and the inverse of the
+// opposite pair took effect last, preferring the direct quote on the same
+// day. It returns sql.ErrNoRows when neither exists.
+func (s *ExchangeRateService) pairRate(ctx context.Context, from, to string, on time.Time) (models.Decimal, error) {
+	direct, err := s.repo.FindEffective(ctx, from, to, on)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return models.Decimal{}, err
+	}
+	inverse, err := s.repo.FindEffective(ctx, to, from, on)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return models.Decimal{}, err
+	}
+
+	switch {
+	case direct == nil && inverse == nil:
+		return models.Decimal{}, sql.ErrNoRows
+	case inverse == nil || (direct != nil && !direct.EffectiveDate.Before(inverse.EffectiveDate)):
+		return direct.Rate, nil
+	}
+	return models.NewDecimalFromInt(1).Div(inverse.Rate)
+}
+
+func validateExchangeRate(rate *models.ExchangeRate) error {
+	rate.BaseCurrency = strings.ToUpper(strings.TrimSpace(rate.BaseCurrency))
+	rate.QuoteCurrency = strings.ToUpper(strings.TrimSpace(rate.QuoteCurrency))
+	if !models.IsSupportedCurrency(rate.BaseCurrency) {
+		return fmt.Errorf("unsupported currency %q", rate.BaseCurrency)
+	}
+	if !models.IsSupportedCurrency(rate.QuoteCurrency) {
+		return fmt.Errorf("unsupported currency %q", rate.QuoteCurrency)
+	}
+	if rate.BaseCurrency == rate.QuoteCurrency {
+		return errors.New("base and quote currency must differ")
+	}
+	if rate.Rate.Sign() <= 0 {
+		return errors.New("rate must be positive")
+	}
+	if rate.EffectiveDate.IsZero() {
+		return errors.New("effectiveDate is required")
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// icalDay is one day covered by a VEVENT.
+type icalDay struct {
+	Date    time.Time
+	Summary string
+}
+
+// parseICalDays reads the VEVENTs of an iCalendar (RFC 5545) file and
+// returns every day they cover. All-day events end the day before DTEND;
+// recurrence rules are not expanded, which matches the holiday feeds
+// published per year.
+func parseICalDays(r io.Reader) ([]icalDay, error) {
+	lines, err := unfoldICalLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		out     []icalDay
+		inEvent bool
+		start   time.Time
+		end     time.Time
+		endSet  bool
+		summary string
+	)
+	for i, line := range lines {
+		name, value := splitICalLine(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			inEvent = true
+			start, end, endSet, summary = time.Time{}, time.Time{}, false, ""
+		case name == "END" && value == "VEVENT":
+			if !inEvent {
+				return nil, fmt.Errorf("line %d: END:VEVENT without BEGIN", i+1)
+			}
+			inEvent = false
+			if start.IsZero() {
+				return nil, fmt.Errorf("line %d: event %q has no DTSTART", i+1, summary)
+			}
+			last := start
+			if endSet && end.After(start) {
+				last = end
+			}
+			for d := start; !d.After(last); d = d.AddDate(0, 0, 1) {
+				out = append(out, icalDay{Date: d, Summary: summary})
+			}
+		case !inEvent:
+			continue
+		case name == "DTSTART":
+			d, _, err := parseICalDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", i+1, err)
+			}
+			start = d
+		case name == "DTEND":
+			d, midnight, err := parseICalDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", i+1, err)
+			}
+			if midnight {
+				d = d.AddDate(0, 0, -1)
+			}
+			end, endSet = d, true
+		case name == "SUMMARY":
+			summary = unescapeICalText(value)
+		}
+	}
+	if inEvent {
+		return nil, errors.New("unterminated VEVENT")
+	}
+	return out, nil
+}
+
+// unfoldICalLines joins continuation lines, which start with a space or tab.
+func unfoldICalLines(r io.Reader) ([]string, error) {
+	var lines []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, sc.Err()
+}
+
+// splitICalLine splits "DTSTART;VALUE=DATE:20260101" into its name and
+// value, dropping the parameters.
+func splitICalLine(line string) (name, value string) {
+	head, value, _ := strings.Cut(line, ":")
+	name, _, _ = strings.Cut(head, ";")
+	return strings.ToUpper(name), value
+}
+
+// parseICalDate accepts DATE and DATE-TIME values and keeps only the date.
+// midnight reports a value at the very start of its day, which as a DTEND
+// excludes that day.
+func parseICalDate(value string) (d time.Time, midnight bool, err error) {
+	if len(value) < 8 {
+		return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+	}
+	d, err = time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+	}
+	midnight = len(value) == 8 || strings.HasPrefix(value[8:], "T000000")
+	return d, midnight, nil
+}
+
+func unescapeICalText(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
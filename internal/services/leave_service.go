@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+// ErrNotLeaveApprover is returned when someone other than the employee's
+// manager or department head tries to decide a leave request.
+var ErrNotLeaveApprover = errors.New("only the employee's manager or department head can decide this request")
+
+type LeaveService struct {
+	repo         repositories.LeaveRepository
+	employeeRepo repositories.EmployeeRepository
+	deptRepo     repositories.DepartmentRepository
+	calendars    *CalendarService
+}
+
+func NewLeaveService(repo repositories.LeaveRepository, employeeRepo repositories.EmployeeRepository, deptRepo repositories.DepartmentRepository, calendars *CalendarService) *LeaveService {
+	return &LeaveService{
+		repo:         repo,
+		employeeRepo: employeeRepo,
+		deptRepo:     deptRepo,
+		calendars:    calendars,
+	}
+}
+
+func (s *LeaveService) ListTypes(ctx context.Context) ([]*models.LeaveType, error) {
+	return s.repo.ListTypes(ctx)
+}
+
+func (s *LeaveService) GetRequest(ctx context.Context, id int64) (*models.LeaveRequest, error) {
+	return s.repo.FindRequest(ctx, id)
+}
+
+func (s *LeaveService) ListByEmployee(ctx context.Context, employeeID int64, status *string) ([]*models.LeaveRequest, error) {
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRequestsByEmployee(ctx, employeeID, status)
+}
+
+func (s *LeaveService) PendingForApprover(ctx context.Context, approverID int64) ([]*models.LeaveRequest, error) {
+	return s.repo.ListPendingForApprover(ctx, approverID)
+}
+
+// leaveType looks a type up by code, falling back to id.
+func (s *LeaveService) leaveType(ctx context.Context, id int64, code string) (*models.LeaveType, error) {
+	var (
+		t   *models.LeaveType
+		err error
+	)
+	if code != "" {
+		t, err = s.repo.FindTypeByCode(ctx, code)
+	} else {
+		t, err = s.repo.FindType(ctx, id)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("unknown leave type")
+	}
+	return t, err
+}
+
+// Submit validates and stores a new pending request. Days are counted as
+// working days of the department's calendar, so weekends and public
+// holidays are not charged; halfDay books half of a single day.
+func (s *LeaveService) Submit(ctx context.Context, l *models.LeaveRequest, typeCode string, halfDay bool) error {
+	e, err := s.employeeRepo.FindByID(ctx, l.EmployeeID)
+	if err != nil {
+		return err
+	}
+	t, err := s.leaveType(ctx, l.LeaveTypeID, typeCode)
+	if err != nil {
+		return err
+	}
+	l.LeaveTypeID = t.ID
+
+	l.StartDate = truncateToDate(l.StartDate)
+	l.EndDate = truncateToDate(l.EndDate)
+	if l.EndDate.Before(l.StartDate) {
+		return errors.New("endDate must not be before startDate")
+	}
+	if l.StartDate.Year() != l.EndDate.Year() {
+		return errors.New("leave spanning the new year must be split into one request per year")
+	}
+	if l.StartDate.Before(e.HireDate) {
+		return errors.New("leave cannot start before the hire date")
+	}
+	if e.TerminationDate != nil && l.EndDate.After(*e.TerminationDate) {
+		return errors.New("leave cannot end after the termination date")
+	}
+	if halfDay && !l.StartDate.Equal(l.EndDate) {
+		return errors.New("halfDay requires startDate and endDate to be the same day")
+	}
+
+	cal, err := s.calendars.ForDepartment(ctx, e.DepartmentID, l.StartDate, l.EndDate)
+	if err != nil {
+		return err
+	}
+	days := cal.WorkingDays(l.StartDate, l.EndDate)
+	if days == 0 {
+		return errors.New("the requested range contains no working days")
+	}
+	l.Days = models.NewDecimalFromInt(int64(days))
+	if halfDay {
+		l.Days = models.MustParseDecimal("0.5")
+	}
+
+	overlaps, err := s.repo.FindOverlapping(ctx, l.EmployeeID, l.StartDate, l.EndDate)
+	if err != nil {
+		return err
+	}
+	if len(overlaps) > 0 {
+		o := overlaps[0]
+		return fmt.Errorf("overlaps %s leave request %d (%s to %s)", o.Status, o.ID,
+			o.StartDate.Format("2006-01-02"), o.EndDate.Format("2006-01-02"))
+	}
+
+	if t.RequiresBalance {
+		b, err := s.balance(ctx, e, t, l.StartDate.Year())
+		if err != nil {
+			return err
+		}
+		if b.Available.Cmp(l.Days) < 0 {
+			return fmt.Errorf("insufficient %s balance: %s days available, %s requested",
+				t.Code, b.Available.String(), l.Days.String())
+		}
+	}
+
+	l.Status = models.LeaveStatusPending
+	return s.repo.CreateRequest(ctx, l)
+}
+
+// Balances returns the employee's position for every leave type in year.
+func (s *LeaveService) Balances(ctx context.Context, employeeID int64, year int) ([]models.LeaveBalance, error) {
+	e, err := s.employeeRepo.FindByID(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	types, err := s.repo.ListTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]models.LeaveBalance, 0, len(types))
+	for _, t := range types {
+		b, err := s.balance(ctx, e, t, year)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *b)
+	}
+	return out, nil
+}
+
+func (s *LeaveService) balance(ctx context.Context, e *models.Employee, t *models.LeaveType, year int) (*models.LeaveBalance, error) {
+	used, pending, err := s.repo.SumDays(ctx, e.ID, t.ID, year)
+	if err != nil {
+		return nil, err
+	}
+	entitlement := leaveEntitlement(t, e.HireDate, e.TerminationDate, year)
+	return &models.LeaveBalance{
+		LeaveType:   *t,
+		Year:        year,
+		Entitlement: entitlement,
+		Used:        used,
+		Pending:     pending,
+		Available:   entitlement.Sub(used).Sub(pending),
+	}, nil
+}
+
+// leaveEntitlement is the yearly allowance for the years of service
+// completed by the end of year, prorated by the months employed when the
+// employee joins or leaves during that year.
+func leaveEntitlement(t *models.LeaveType, hireDate time.Time, terminationDate *time.Time, year int) models.Decimal {
+	yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	yearEnd := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	from, to := yearStart, yearEnd
+	if hireDate.After(from) {
+		from = hireDate
+	}
+	if terminationDate != nil && terminationDate.Before(to) {
+		to = *terminationDate
+	}
+	if to.Before(from) {
+		return models.Decimal{}
+	}
+
+	years := to.Year() - hireDate.Year()
+	if to.YearDay() < hireDate.YearDay() {
+		years--
+	}
+	days := t.EntitlementFor(years)
+
+	months := (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month()) + 1
+	if months >= 12 {
+		return days
+	}
+	prorated, _ := days.Mul(models.NewDecimalFromInt(int64(months))).Div(models.NewDecimalFromInt(12))
+	return prorated.Round(1)
+}
+
+func (s *LeaveService) Approve(ctx context.Context, id, approverID int64, note *string) (*models.LeaveRequest, error) {
+	return s.decide(ctx, id, approverID, models.LeaveStatusApproved, note)
+}
+
+func (s *LeaveService) Reject(ctx context.Context, id, approverID int64, note *string) (*models.LeaveRequest, error) {
+	return s.decide(ctx, id, approverID, models.LeaveStatusRejected, note)
+}
+
+func (s *LeaveService) decide(ctx context.Context, id, approverID int64, status string, note *string) (*models.LeaveRequest, error) {
+	l, err := s.repo.FindRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if l.Status != models.LeaveStatusPending {
+		return nil, fmt.Errorf("leave request is already %s", l.Status)
+	}
+	if err := s.checkApprover(ctx, l.EmployeeID, approverID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	l.Status = status
+	l.ApproverID = &approverID
+	l.DecidedAt = &now
+	l.DecisionNote = note
+	if err := s.repo.UpdateStatus(ctx, l, models.LeaveStatusPending); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("leave request was decided concurrently")
+		}
+		return nil, err
+	}
+	return l, nil
+}
+
+// checkApprover allows the employee's direct manager and the head of their
+// department, but never the employee themselves.
+func (s *LeaveService) checkApprover(ctx context.Context, employeeID, approverID int64) error {
+	ok, err := isApprover(ctx, s.employeeRepo, s.deptRepo, employeeID, approverID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotLeaveApprover
+	}
+	return nil
+}
+
+// isApprover reports whether approverID is the employee's direct manager or
+// the head of their department, and not the employee themselves.
+func isApprover(ctx context.Context, employeeRepo repositories.EmployeeRepository, deptRepo repositories.DepartmentRepository, employeeID, approverID int64) (bool, error) {
+	if employeeID == approverID {
+		return false, nil
+	}
+	e, err := employeeRepo.FindByID(ctx, employeeID)
+	if err != nil {
+		return false, err
+	}
+	if e.ManagerID != nil && *e.ManagerID == approverID {
+		return true, nil
+	}
+	d, err := deptRepo.FindByID(ctx, e.DepartmentID)
+	if err != nil {
+		return false, err
+	}
+	return d.HeadEmployeeID != nil && *d.HeadEmployeeID == approverID, nil
+}
+
+// Cancel withdraws a pending or approved request that has not started yet.
+func (s *LeaveService) Cancel(ctx context.Context, id int64) (*models.LeaveRequest, error) {
+	l, err := s.repo.FindRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if l.Status != models.LeaveStatusPending && l.Status != models.LeaveStatusApproved {
+		return nil, fmt.Errorf("leave request is already %s", l.Status)
+	}
+	if !l.StartDate.After(truncateToDate(time.Now())) {
+		return nil, errors.New("leave that has already started cannot be cancelled")
+	}
+
+	from := l.Status
+	l.Status = models.LeaveStatusCancelled
+	if err := s.repo.UpdateStatus(ctx, l, from); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("leave request was decided concurrently")
+		}
+		return nil, err
+	}
+	return l, nil
+}
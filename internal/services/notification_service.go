@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+type NotificationService struct {
+	repo repositories.NotificationRepository
+}
+
+func NewNotificationService(repo repositories.NotificationRepository) *NotificationService {
+	return &NotificationService{repo: repo}
+}
+
+// Notify stores n and logs it, unless a notification with the same dedupe
+// key was raised before. It reports whether n is new.
+func (s *NotificationService) Notify(ctx context.Context, n *models.Notification) (bool, error) {
+	created, err := s.repo.Create(ctx, n)
+	if err != nil || !created {
+		return false, err
+	}
+	log.Printf("notification %d (%s): %s", n.ID, n.Kind, n.Message)
+	return true, nil
+}
+
+func (s *NotificationService) List(ctx context.Context, unreadOnly bool, limit, offset int) ([]*models.Notification, error) {
+	return s.repo.List(ctx, unreadOnly, limit, offset)
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, id int64) (*models.Notification, error) {
+	return s.repo.MarkRead(ctx, id)
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"app/internal/models"
+)
+
+// OvertimeRule pays the minutes worked on a kind of day beyond
+// ThresholdMinutes at Rate times the hourly wage.
+type OvertimeRule struct {
+	DayKind          string         `json:"dayKind"`
+	ThresholdMinutes int            `json:"thresholdMinutes"`
+	Rate             models.Decimal `json:"rate"`
+}
+
+// OvertimeRules decide how attendance turns into overtime. The hourly wage
+// is the monthly salary divided by the month's working days and
+// StandardDayMinutes. Overtime is counted in whole BlockMinutes, and no
+// more than MonthlyCapMinutes is payable per month (0 = no cap).
+type OvertimeRules struct {
+	StandardDayMinutes int            `json:"standardDayMinutes"`
+	BlockMinutes       int            `json:"blockMinutes"`
+	MonthlyCapMinutes  int            `json:"monthlyCapMinutes"`
+	Rules              []OvertimeRule `json:"rules"`
+}
+
+// DefaultOvertimeRules follows the Labour Code: beyond 8 hours on a working
+// day at 150%, any work on a weekly rest day at 200% and on a public
+// holiday at 300%, at most 40 hours a month.
+func DefaultOvertimeRules() *OvertimeRules {
+	return &OvertimeRules{
+		StandardDayMinutes: 480,
+		BlockMinutes:       15,
+		MonthlyCapMinutes:  40 * 60,
+		Rules: []OvertimeRule{
+			{DayKind: models.OvertimeDayWeekday, ThresholdMinutes: 480, Rate: models.MustParseDecimal("1.5")},
+			{DayKind: models.OvertimeDayWeekend, ThresholdMinutes: 0, Rate: models.MustParseDecimal("2.0")},
+			{DayKind: models.OvertimeDayHoliday, ThresholdMinutes: 0, Rate: models.MustParseDecimal("3.0")},
+		},
+	}
+}
+
+// LoadOvertimeRules reads a JSON rules file, or returns the defaults when
+// path is empty.
+func LoadOvertimeRules(path string) (*OvertimeRules, error) {
+	if path == "" {
+		return DefaultOvertimeRules(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rules OvertimeRules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("overtime rules: %v", err)
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, fmt.Errorf("overtime rules: %v", err)
+	}
+	return &rules, nil
+}
+
+func (o *OvertimeRules) Validate() error {
+	if o.StandardDayMinutes <= 0 {
+		return errors.New("standardDayMinutes must be positive")
+	}
+	if o.BlockMinutes < 0 || o.MonthlyCapMinutes < 0 {
+		return errors.New("blockMinutes and monthlyCapMinutes cannot be negative")
+	}
+	seen := map[string]bool{}
+	for _, r := range o.Rules {
+		if !models.IsValidOvertimeDayKind(r.DayKind) {
+			return fmt.Errorf("unknown dayKind %q", r.DayKind)
+		}
+		if seen[r.DayKind] {
+			return fmt.Errorf("dayKind %q listed twice", r.DayKind)
+		}
+		seen[r.DayKind] = true
+		if r.ThresholdMinutes < 0 {
+			return errors.New("thresholdMinutes cannot be negative")
+		}
+		if r.Rate.Sign() <= 0 {
+			return errors.New("rate must be positive")
+		}
+	}
+	return nil
+}
+
+func (o *OvertimeRules) rule(kind string) (OvertimeRule, bool) {
+	for _, r := range o.Rules {
+		if r.DayKind == kind {
+			return r, true
+		}
+	}
+	return OvertimeRule{}, false
+}
+
+// OvertimeDayKind classifies date on cal: public holidays first, then any
+// other non-working day as a weekend.
+func OvertimeDayKind(cal *WorkingCalendar, date time.Time) string {
+	switch {
+	case cal.IsHoliday(date):
+		return models.OvertimeDayHoliday
+	case !cal.IsWorkingDay(date):
+		return models.OvertimeDayWeekend
+	}
+	return models.OvertimeDayWeekday
+}
+
+// CalculateOvertime applies rules to one employee's attendance days of a
+// single month, without any database access. Days still clocked in are
+// skipped. approved is the month's overtime already approved, which takes
+// up the monthly cap first; the rest of the cap is filled in date order,
+// so the latest days are the ones cut off. Only days with overtime are
+// returned.
+func CalculateOvertime(rules *OvertimeRules, days []models.AttendanceDay, cal *WorkingCalendar, approved int) []*models.OvertimeItem {
+	sorted := append([]models.AttendanceDay(nil), days...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+
+	var items []*models.OvertimeItem
+	payable := approved
+	for _, d := range sorted {
+		if d.Open {
+			continue
+		}
+		kind := OvertimeDayKind(cal, d.Date)
+		rule, ok := rules.rule(kind)
+		if !ok {
+			continue
+		}
+		minutes := d.WorkedMinutes - rule.ThresholdMinutes
+		if rules.BlockMinutes > 0 {
+			minutes -= minutes % rules.BlockMinutes
+		}
+		if minutes <= 0 {
+			continue
+		}
+
+		item := &models.OvertimeItem{
+			WorkDate:      d.Date,
+			Period:        d.Date.Format(periodLayout),
+			DayKind:       kind,
+			WorkedMinutes: d.WorkedMinutes,
+			Minutes:       minutes,
+			Rate:          rule.Rate,
+			Status:        models.OvertimeStatusPending,
+		}
+		if rules.MonthlyCapMinutes > 0 && payable+minutes > rules.MonthlyCapMinutes {
+			item.Minutes = max(rules.MonthlyCapMinutes-payable, 0)
+			item.CappedMinutes = minutes - item.Minutes
+		}
+		payable += item.Minutes
+		items = append(items, item)
+	}
+	return items
+}
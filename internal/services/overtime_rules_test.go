@@ -0,0 +1,123 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"app/internal/models"
+)
+
+func day(s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// testCalendar has Saturday and Sunday weekends, National Day on Wednesday
+// 2026-09-02, a make-up working Saturday on 2026-09-05 and a company day
+// off on Monday 2026-09-07.
+func testCalendar() *WorkingCalendar {
+	return newWorkingCalendar(
+		&models.WorkCalendar{Name: "Test", WeekendDays: []time.Weekday{time.Saturday, time.Sunday}},
+		[]*models.CalendarDay{
+			{Date: day("2026-09-02"), Kind: models.CalendarDayHoliday, Name: "National Day"},
+			{Date: day("2026-09-05"), Kind: models.CalendarDayWorkingDay, Name: "Make-up day"},
+			{Date: day("2026-09-07"), Kind: models.CalendarDayOff, Name: "Company outing"},
+		},
+	)
+}
+
+func TestCalculateOvertime(t *testing.T) {
+	tests := []struct {
+		name    string
+		day     models.AttendanceDay
+		kind    string
+		minutes int
+		rate    string
+	}{
+		{name: "weekday within the threshold", day: models.AttendanceDay{Date: day("2026-09-01"), WorkedMinutes: 480}},
+		{name: "weekday beyond the threshold", day: models.AttendanceDay{Date: day("2026-09-01"), WorkedMinutes: 540}, kind: models.OvertimeDayWeekday, minutes: 60, rate: "1.5"},
+		{name: "weekday rounds down to whole blocks", day: models.AttendanceDay{Date: day("2026-09-01"), WorkedMinutes: 502}, kind: models.OvertimeDayWeekday, minutes: 15, rate: "1.5"},
+		{name: "weekday less than one block", day: models.AttendanceDay{Date: day("2026-09-01"), WorkedMinutes: 494}},
+		{name: "weekend from the first minute", day: models.AttendanceDay{Date: day("2026-09-06"), WorkedMinutes: 300}, kind: models.OvertimeDayWeekend, minutes: 300, rate: "2.0"},
+		{name: "holiday from the first minute", day: models.AttendanceDay{Date: day("2026-09-02"), WorkedMinutes: 480}, kind: models.OvertimeDayHoliday, minutes: 480, rate: "3.0"},
+		{name: "make-up Saturday counts as a weekday", day: models.AttendanceDay{Date: day("2026-09-05"), WorkedMinutes: 540}, kind: models.OvertimeDayWeekday, minutes: 60, rate: "1.5"},
+		{name: "day off counts as a weekend", day: models.AttendanceDay{Date: day("2026-09-07"), WorkedMinutes: 240}, kind: models.OvertimeDayWeekend, minutes: 240, rate: "2.0"},
+		{name: "open day is skipped", day: models.AttendanceDay{Date: day("2026-09-06"), WorkedMinutes: 300, Open: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := CalculateOvertime(DefaultOvertimeRules(), []models.AttendanceDay{tt.day}, testCalendar(), 0)
+			if tt.minutes == 0 {
+				if len(items) != 0 {
+					t.Fatalf("expected no overtime, got %+v", items[0])
+				}
+				return
+			}
+			if len(items) != 1 {
+				t.Fatalf("expected one item, got %d", len(items))
+			}
+			it := items[0]
+			if it.DayKind != tt.kind || it.Minutes != tt.minutes || it.Rate.Cmp(models.MustParseDecimal(tt.rate)) != 0 {
+				t.Errorf("got %s %d min at %s, want %s %d min at %s", it.DayKind, it.Minutes, it.Rate, tt.kind, tt.minutes, tt.rate)
+			}
+			if it.Period != "2026-09" || it.Status != models.OvertimeStatusPending {
+				t.Errorf("got period %s status %s", it.Period, it.Status)
+			}
+		})
+	}
+}
+
+func TestCalculateOvertimeMonthlyCap(t *testing.T) {
+	// Out of order on purpose: the cap is filled in date order.
+	days := []models.AttendanceDay{
+		{Date: day("2026-09-26"), WorkedMinutes: 600},
+		{Date: day("2026-09-06"), WorkedMinutes: 600},
+		{Date: day("2026-09-20"), WorkedMinutes: 600},
+		{Date: day("2026-09-13"), WorkedMinutes: 600},
+		{Date: day("2026-09-19"), WorkedMinutes: 540},
+	}
+	items := CalculateOvertime(DefaultOvertimeRules(), days, testCalendar(), 0)
+
+	want := []struct {
+		date            string
+		minutes, capped int
+	}{
+		{"2026-09-06", 600, 0},
+		{"2026-09-13", 600, 0},
+		{"2026-09-19", 540, 0},
+		{"2026-09-20", 600, 0},
+		{"2026-09-26", 60, 540},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("got %d items, want %d", len(items), len(want))
+	}
+	for i, w := range want {
+		it := items[i]
+		if it.WorkDate.Format("2006-01-02") != w.date || it.Minutes != w.minutes || it.CappedMinutes != w.capped {
+			t.Errorf("item %d: got %s %d min, %d capped; want %s %d min, %d capped",
+				i, it.WorkDate.Format("2006-01-02"), it.Minutes, it.CappedMinutes, w.date, w.minutes, w.capped)
+		}
+	}
+}
+
+func TestCalculateOvertimeApprovedCountsTowardCap(t *testing.T) {
+	days := []models.AttendanceDay{
+		{Date: day("2026-09-13"), WorkedMinutes: 600},
+		{Date: day("2026-09-06"), WorkedMinutes: 600},
+	}
+	items := CalculateOvertime(DefaultOvertimeRules(), days, testCalendar(), 2000)
+
+	want := []struct{ minutes, capped int }{{400, 200}, {0, 600}}
+	if len(items) != len(want) {
+		t.Fatalf("got %d items, want %d", len(items), len(want))
+	}
+	for i, w := range want {
+		if items[i].Minutes != w.minutes || items[i].CappedMinutes != w.capped {
+			t.Errorf("item %d: got %d min, %d capped; want %d min, %d capped", i, items[i].Minutes, items[i].CappedMinutes, w.minutes, w.capped)
+		}
+	}
+}
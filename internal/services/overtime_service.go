@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+// ErrNotOvertimeApprover is returned when someone other than the employee's
+// manager or department head tries to decide an overtime item.
+var ErrNotOvertimeApprover = errors.New("only the employee's manager or department head can decide this overtime")
+
+type OvertimeService struct {
+	repo         repositories.OvertimeRepository
+	employeeRepo repositories.EmployeeRepository
+	deptRepo     repositories.DepartmentRepository
+	payrollRepo  repositories.PayrollRepository
+	attendance   *AttendanceService
+	calendars    *CalendarService
+	rules        *OvertimeRules
+}
+
+func NewOvertimeService(repo repositories.OvertimeRepository, employeeRepo repositories.EmployeeRepository, deptRepo repositories.DepartmentRepository, payrollRepo repositories.PayrollRepository, attendance *AttendanceService, calendars *CalendarService, rules *OvertimeRules) *OvertimeService {
+	return &OvertimeService{
+		repo:         repo,
+		employeeRepo: employeeRepo,
+		deptRepo:     deptRepo,
+		payrollRepo:  payrollRepo,
+		attendance:   attendance,
+		calendars:    calendars,
+		rules:        rules,
+	}
+}
+
+func (s *OvertimeService) Rules() *OvertimeRules {
+	return s.rules
+}
+
+// checkOpen refuses changes to a period whose payroll has been run, since
+// its payslips can no longer change.
+func (s *OvertimeService) checkOpen(ctx context.Context, period string) error {
+	if _, err := s.payrollRepo.FindRunByPeriod(ctx, period); err == nil {
+		return fmt.Errorf("payroll for %s has already been run", period)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return nil
+}
+
+// Calculate recomputes the employee's overtime of period from attendance.
+// Pending items are replaced; days already approved or rejected are kept.
+func (s *OvertimeService) Calculate(ctx context.Context, employeeID int64, period string) ([]*models.OvertimeItem, error) {
+	start, end, err := ParsePeriod(period)
+	if err != nil {
+		return nil, err
+	}
+	if start.After(time.Now()) {
+		return nil, errors.New("cannot calculate overtime for a future period")
+	}
+	if err := s.checkOpen(ctx, period); err != nil {
+		return nil, err
+	}
+	return s.calculate(ctx, employeeID, period, start, end)
+}
+
+func (s *OvertimeService) calculate(ctx context.Context, employeeID int64, period string, start, end time.Time) ([]*models.OvertimeItem, error) {
+	ts, err := s.attendance.EmployeeTimesheet(ctx, employeeID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	cal, err := s.calendars.ForDepartment(ctx, ts.Employee.DepartmentID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decided days keep their item, and approved minutes count toward the
+	// monthly cap before anything recalculated here.
+	existing, err := s.repo.ListByEmployee(ctx, employeeID, period)
+	if err != nil {
+		return nil, err
+	}
+	decided := map[string]bool{}
+	approved := 0
+	for _, o := range existing {
+		if o.Status == models.OvertimeStatusPending {
+			continue
+		}
+		decided[o.WorkDate.Format("2006-01-02")] = true
+		if o.Status == models.OvertimeStatusApproved {
+			approved += o.Minutes
+		}
+	}
+	var days []models.AttendanceDay
+	for _, d := range ts.Days {
+		if !decided[d.Date.Format("2006-01-02")] {
+			days = append(days, d)
+		}
+	}
+
+	items := CalculateOvertime(s.rules, days, cal, approved)
+	for _, o := range items {
+		o.EmployeeID = employeeID
+	}
+	if err := s.repo.ReplacePending(ctx, employeeID, period, items); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByEmployee(ctx, employeeID, period)
+}
+
+// CalculateDepartment runs Calculate for every member of the department
+// employed during period.
+func (s *OvertimeService) CalculateDepartment(ctx context.Context, departmentID int64, period string) ([]*models.OvertimeItem, error) {
+	start, end, err := ParsePeriod(period)
+	if err != nil {
+		return nil, err
+	}
+	if start.After(time.Now()) {
+		return nil, errors.New("cannot calculate overtime for a future period")
+	}
+	if _, err := s.deptRepo.FindByID(ctx, departmentID); err != nil {
+		return nil, err
+	}
+	if err := s.checkOpen(ctx, period); err != nil {
+		return nil, err
+	}
+
+	employees, err := s.employeeRepo.FindByDepartmentID(ctx, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	var all []*models.OvertimeItem
+	for _, e := range employees {
+		if e.HireDate.After(end) || (e.TerminationDate != nil && e.TerminationDate.Before(start)) {
+			continue
+		}
+		items, err := s.calculate(ctx, e.ID, period, start, end)
+		if err != nil {
+			return nil, fmt.Errorf("employee %d: %v", e.ID, err)
+		}
+		all = append(all, items...)
+	}
+	return all, nil
+}
+
+func (s *OvertimeService) ListByEmployee(ctx context.Context, employeeID int64, period string) ([]*models.OvertimeItem, error) {
+	if _, _, err := ParsePeriod(period); err != nil {
+		return nil, err
+	}
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByEmployee(ctx, employeeID, period)
+}
+
+func (s *OvertimeService) GetByID(ctx context.Context, id int64) (*models.OvertimeItem, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *OvertimeService) PendingForApprover(ctx context.Context, approverID int64) ([]*models.OvertimeItem, error) {
+	return s.repo.ListPendingForApprover(ctx, approverID)
+}
+
+func (s *OvertimeService) Approve(ctx context.Context, id, approverID int64, note *string) (*models.OvertimeItem, error) {
+	return s.decide(ctx, id, approverID, models.OvertimeStatusApproved, note)
+}
+
+func (s *OvertimeService) Reject(ctx context.Context, id, approverID int64, note *string) (*models.OvertimeItem, error) {
+	return s.decide(ctx, id, approverID, models.OvertimeStatusRejected, note)
+}
+
+func (s *OvertimeService) decide(ctx context.Context, id, approverID int64, status string, note *string) (*models.OvertimeItem, error) {
+	o, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if o.Status != models.OvertimeStatusPending {
+		return nil, fmt.Errorf("overtime is already %s", o.Status)
+	}
+	if status == models.OvertimeStatusApproved && o.Minutes == 0 {
+		return nil, errors.New("overtime is entirely over the monthly cap")
+	}
+	if err := s.checkOpen(ctx, o.Period); err != nil {
+		return nil, err
+	}
+	ok, err := isApprover(ctx, s.employeeRepo, s.deptRepo, o.EmployeeID, approverID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNotOvertimeApprover
+	}
+
+	now := time.Now()
+	o.Status = status
+	o.ApproverID = &approverID
+	o.DecidedAt = &now
+	o.DecisionNote = note
+	if err := s.repo.UpdateStatus(ctx, o, models.OvertimeStatusPending); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("overtime was decided concurrently")
+		}
+		return nil, err
+	}
+	return o, nil
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"app/internal/models"
+)
+
+// PayItem is a fixed monthly allowance or deduction.
+type PayItem struct {
+	Code    string         `json:"code"`
+	Label   string         `json:"label"`
+	Amount  models.Decimal `json:"amount"`
+	Taxable bool           `json:"taxable"` // allowances only
+	Prorate bool           `json:"prorate"` // scale by paid days like the base salary
+}
+
+// Contribution is an employee-paid social insurance share of the base
+// salary, optionally capped at a maximum contribution base.
+type Contribution struct {
+	Code  string          `json:"code"`
+	Label string          `json:"label"`
+	Rate  models.Decimal  `json:"rate"`
+	Cap   *models.Decimal `json:"cap"`
+}
+
+// TaxBracket taxes the slice of income up to UpTo (nil = no upper bound).
+type TaxBracket struct {
+	UpTo *models.Decimal `json:"upTo"`
+	Rate models.Decimal  `json:"rate"`
+}
+
+// PayrollConfig holds every amount the calculator applies. Amounts are in
+// Currency; salaries paid in other currencies are converted first.
+type PayrollConfig struct {
+	Currency        string         `json:"currency"`
+	Allowances      []PayItem      `json:"allowances"`
+	Deductions      []PayItem      `json:"deductions"`
+	SocialInsurance []Contribution `json:"socialInsurance"`
+	PersonalRelief  models.Decimal `json:"personalRelief"`
+	DependentRelief models.Decimal `json:"dependentRelief"`
+	TaxBrackets     []TaxBracket   `json:"taxBrackets"`
+}
+
+func decimalPtr(s string) *models.Decimal {
+	d := models.MustParseDecimal(s)
+	return &d
+}
+
+// DefaultPayrollConfig follows Vietnamese rules: 10.5% employee social
+// insurance capped at 20x the base wage, family relief and the 7-step
+// personal income tax schedule.
+func DefaultPayrollConfig() *PayrollConfig {
+	return &PayrollConfig{
+		Currency: "VND",
+		Allowances: []PayItem{
+			{Code: "LUNCH", Label: "Lunch allowance", Amount: models.MustParseDecimal("730000"), Taxable: false, Prorate: true},
+		},
+		SocialInsurance: []Contribution{
+			{Code: "SI", Label: "Social insurance", Rate: models.MustParseDecimal("0.08"), Cap: decimalPtr("46800000")},
+			{Code: "HI", Label: "Health insurance", Rate: models.MustParseDecimal("0.015"), Cap: decimalPtr("46800000")},
+			{Code: "UI", Label: "Unemployment insurance", Rate: models.MustParseDecimal("0.01"), Cap: decimalPtr("99200000")},
+		},
+		PersonalRelief:  models.MustParseDecimal("11000000"),
+		DependentRelief: models.MustParseDecimal("4400000"),
+		TaxBrackets: []TaxBracket{
+			{UpTo: decimalPtr("5000000"), Rate: models.MustParseDecimal("0.05")},
+			{UpTo: decimalPtr("10000000"), Rate: models.MustParseDecimal("0.10")},
+			{UpTo: decimalPtr("18000000"), Rate: models.MustParseDecimal("0.15")},
+			{UpTo: decimalPtr("32000000"), Rate: models.MustParseDecimal("0.20")},
+			{UpTo: decimalPtr("52000000"), Rate: models.MustParseDecimal("0.25")},
+			{UpTo: decimalPtr("80000000"), Rate: models.MustParseDecimal("0.30")},
+			{UpTo: nil, Rate: models.MustParseDecimal("0.35")},
+		},
+	}
+}
+
+// LoadPayrollConfig reads a JSON config file, or returns the defaults when
+// path is empty.
+func LoadPayrollConfig(path string) (*PayrollConfig, error) {
+	if path == "" {
+		return DefaultPayrollConfig(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg PayrollConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("payroll config: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("payroll config: %v", err)
+	}
+	return &cfg, nil
+}
+
+func (c *PayrollConfig) Validate() error {
+	if !models.IsSupportedCurrency(c.Currency) {
+		return fmt.Errorf("unsupported currency %q", c.Currency)
+	}
+	if len(c.TaxBrackets) == 0 {
+		return errors.New("taxBrackets is required")
+	}
+	var prev *models.Decimal
+	for i, b := range c.TaxBrackets {
+		last := i == len(c.TaxBrackets)-1
+		if b.UpTo == nil && !last {
+			return errors.New("only the last tax bracket may be unbounded")
+		}
+		if b.UpTo != nil && prev != nil && b.UpTo.Cmp(*prev) <= 0 {
+			return errors.New("tax brackets must be in ascending order")
+		}
+		prev = b.UpTo
+	}
+	if c.TaxBrackets[len(c.TaxBrackets)-1].UpTo != nil {
+		return errors.New("the last tax bracket must be unbounded")
+	}
+	return nil
+}
+
+// OvertimePay is approved overtime at one rate, e.g. 1.5 for 150%.
+type OvertimePay struct {
+	Rate    models.Decimal
+	Minutes int
+}
+
+// PayInput is everything the calculator needs about one employee and month.
+type PayInput struct {
+	MonthlySalary      models.Decimal // full-month salary in the config currency
+	WorkingDays        int            // working days in the month
+	PaidDays           int            // working days the employee was employed
+	Dependents         int
+	Overtime           []OvertimePay
+	StandardDayMinutes int // length of a normal working day, for the hourly wage
+}
+
+// CalculatePay computes one payslip's figures from cfg alone, without any
+// database access, so it can be checked against fixed tax tables. Employee
+// and period fields are left for the caller to fill in.
+func CalculatePay(cfg *PayrollConfig, in PayInput) *models.Payslip {
+	places := models.CurrencyMinorUnits(cfg.Currency)
+	round := func(d models.Decimal) models.Decimal { return d.Round(places) }
+
+	ratio := models.NewDecimalFromInt(1)
+	if in.WorkingDays > 0 && in.PaidDays < in.WorkingDays {
+		ratio, _ = models.NewDecimalFromInt(int64(in.PaidDays)).Div(models.NewDecimalFromInt(int64(in.WorkingDays)))
+	}
+
+	p := &models.Payslip{
+		Currency:    cfg.Currency,
+		BaseSalary:  round(in.MonthlySalary),
+		WorkingDays: in.WorkingDays,
+		PaidDays:    in.PaidDays,
+	}
+	addLine := func(kind, code, label string, amount models.Decimal) {
+		p.Lines = append(p.Lines, models.PayslipLine{Kind: kind, Code: code, Label: label, Amount: amount})
+	}
+
+	base := round(in.MonthlySalary.Mul(ratio))
+	addLine(models.PayslipLineEarning, "BASE", "Base salary", base)
+	gross := base
+	taxable := base
+
+	for _, a := range cfg.Allowances {
+		amount := a.Amount
+		if a.Prorate {
+			amount = amount.Mul(ratio)
+		}
+		amount = round(amount)
+		addLine(models.PayslipLineEarning, a.Code, a.Label, amount)
+		gross = gross.Add(amount)
+		if a.Taxable {
+			taxable = taxable.Add(amount)
+		}
+	}
+
+	// Only the normal pay for overtime hours is taxable; the premium on top
+	// of it is exempt from personal income tax.
+	if len(in.Overtime) > 0 && in.WorkingDays > 0 && in.StandardDayMinutes > 0 {
+		perMinute, _ := in.MonthlySalary.Div(models.NewDecimalFromInt(int64(in.WorkingDays * in.StandardDayMinutes)))
+		for _, o := range in.Overtime {
+			percent := o.Rate.Mul(models.NewDecimalFromInt(100)).Round(0).String()
+			normal := perMinute.Mul(models.NewDecimalFromInt(int64(o.Minutes)))
+			amount := round(normal.Mul(o.Rate))
+			addLine(models.PayslipLineEarning, "OT"+percent, "Overtime "+percent+"%", amount)
+			gross = gross.Add(amount)
+			taxable = taxable.Add(round(normal))
+		}
+	}
+	p.Gross = gross
+
+	for _, c := range cfg.SocialInsurance {
+		contributionBase := base
+		if c.Cap != nil && contributionBase.Cmp(*c.Cap) > 0 {
+			contributionBase = *c.Cap
+		}
+		amount := round(contributionBase.Mul(c.Rate))
+		addLine(models.PayslipLineContribution, c.Code, c.Label, amount)
+		p.SocialInsurance = p.SocialInsurance.Add(amount)
+	}
+
+	relief := cfg.PersonalRelief.Add(cfg.DependentRelief.Mul(models.NewDecimalFromInt(int64(in.Dependents))))
+	taxable = taxable.Sub(p.SocialInsurance).Sub(relief)
+	if taxable.Sign() < 0 {
+		taxable = models.Decimal{}
+	}
+	p.TaxableIncome = round(taxable)
+	p.IncomeTax = round(progressiveTax(cfg.TaxBrackets, p.TaxableIncome))
+	addLine(models.PayslipLineTax, "PIT", "Personal income tax", p.IncomeTax)
+
+	for _, d := range cfg.Deductions {
+		amount := d.Amount
+		if d.Prorate {
+			amount = amount.Mul(ratio)
+		}
+		amount = round(amount)
+		addLine(models.PayslipLineDeduction, d.Code, d.Label, amount)
+		p.Deductions = p.Deductions.Add(amount)
+	}
+
+	p.Net = p.Gross.Sub(p.SocialInsurance).Sub(p.IncomeTax).Sub(p.Deductions)
+	return p
+}
+
+func progressiveTax(brackets []TaxBracket, income models.Decimal) models.Decimal {
+	tax := models.Decimal{}
+	lower := models.Decimal{}
+	for _, b := range brackets {
+		if income.Cmp(lower) <= 0 {
+			break
+		}
+		upper := income
+		if b.UpTo != nil && b.UpTo.Cmp(income) < 0 {
+			upper = *b.UpTo
+		}
+		tax = tax.Add(upper.Sub(lower).Mul(b.Rate))
+		if b.UpTo == nil {
+			break
+		}
+		lower = *b.UpTo
+	}
+	return tax
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"testing"
+
+	"app/internal/models"
+)
+
+func TestProgressiveTax(t *testing.T) {
+	brackets := DefaultPayrollConfig().TaxBrackets
+	tests := []struct {
+		income string
+		want   string
+	}{
+		{"0", "0"},
+		{"1000000", "50000"},
+		{"5000000", "250000"},
+		{"5000001", "250000.1"},
+		{"10000000", "750000"},
+		{"18000000", "1950000"},
+		{"32000000", "4750000"},
+		{"52000000", "9750000"},
+		{"80000000", "18150000"},
+		{"100000000", "25150000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.income, func(t *testing.T) {
+			got := progressiveTax(brackets, models.MustParseDecimal(tt.income))
+			if got.Cmp(models.MustParseDecimal(tt.want)) != 0 {
+				t.Errorf("tax on %s = %s, want %s", tt.income, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculatePay(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         PayInput
+		baseSalary string
+		gross      string
+		insurance  string
+		taxable    string
+		incomeTax  string
+		net        string
+	}{
+		{
+			name:       "full month",
+			in:         PayInput{MonthlySalary: models.MustParseDecimal("30000000"), WorkingDays: 22, PaidDays: 22},
+			baseSalary: "30000000",
+			gross:      "30730000",
+			insurance:  "3150000",
+			taxable:    "15850000",
+			incomeTax:  "1627500",
+			net:        "25952500",
+		},
+		{
+			name:       "dependent relief",
+			in:         PayInput{MonthlySalary: models.MustParseDecimal("30000000"), WorkingDays: 22, PaidDays: 22, Dependents: 2},
+			baseSalary: "30000000",
+			gross:      "30730000",
+			insurance:  "3150000",
+			taxable:    "7050000",
+			incomeTax:  "455000",
+			net:        "27125000",
+		},
+		{
+			name:       "relief above income leaves nothing taxable",
+			in:         PayInput{MonthlySalary: models.MustParseDecimal("15000000"), WorkingDays: 22, PaidDays: 22, Dependents: 1},
+			baseSalary: "15000000",
+			gross:      "15730000",
+			insurance:  "1575000",
+			taxable:    "0",
+			incomeTax:  "0",
+			net:        "14155000",
+		},
+		{
+			name:       "half month prorates salary and allowance",
+			in:         PayInput{MonthlySalary: models.MustParseDecimal("30000000"), WorkingDays: 22, PaidDays: 11},
+			baseSalary: "30000000",
+			gross:      "15365000",
+			insurance:  "1575000",
+			taxable:    "2425000",
+			incomeTax:  "121250",
+			net:        "13668750",
+		},
+		{
+			name:       "proration rounds each line to whole dong",
+			in:         PayInput{MonthlySalary: models.MustParseDecimal("10000000"), WorkingDays: 22, PaidDays: 7},
+			baseSalary: "10000000",
+			gross:      "3414091",
+			insurance:  "334090",
+			taxable:    "0",
+			incomeTax:  "0",
+			net:        "3080001",
+		},
+		{
+			name:       "insurance caps apply above the contribution base",
+			in:         PayInput{MonthlySalary: models.MustParseDecimal("60000000"), WorkingDays: 22, PaidDays: 22},
+			baseSalary: "60000000",
+			gross:      "60730000",
+			insurance:  "5046000",
+			taxable:    "43954000",
+			incomeTax:  "7738500",
+			net:        "47945500",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := CalculatePay(DefaultPayrollConfig(), tt.in)
+			for _, c := range []struct {
+				field string
+				got   models.Decimal
+				want  string
+			}{
+				{"baseSalary", p.BaseSalary, tt.baseSalary},
+				{"gross", p.Gross, tt.gross},
+				{"socialInsurance", p.SocialInsurance, tt.insurance},
+				{"taxableIncome", p.TaxableIncome, tt.taxable},
+				{"incomeTax", p.IncomeTax, tt.incomeTax},
+				{"net", p.Net, tt.net},
+			} {
+				if c.got.Cmp(models.MustParseDecimal(c.want)) != 0 {
+					t.Errorf("%s = %s, want %s", c.field, c.got, c.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+const periodLayout = "2006-01"
+
+type PayrollService struct {
+	repo         repositories.PayrollRepository
+	employeeRepo repositories.EmployeeRepository
+	compRepo     repositories.CompensationRepository
+	overtimeRepo repositories.OvertimeRepository
+	detailsRepo  repositories.PersonalDetailsRepository
+	rates        *ExchangeRateService
+	calendars    *CalendarService
+	cfg          *PayrollConfig
+	overtime     *OvertimeRules
+}
+
+func NewPayrollService(repo repositories.PayrollRepository, employeeRepo repositories.EmployeeRepository, compRepo repositories.CompensationRepository, overtimeRepo repositories.OvertimeRepository, detailsRepo repositories.PersonalDetailsRepository, rates *ExchangeRateService, calendars *CalendarService, cfg *PayrollConfig, overtime *OvertimeRules) *PayrollService {
+	return &PayrollService{
+		repo:         repo,
+		employeeRepo: employeeRepo,
+		compRepo:     compRepo,
+		overtimeRepo: overtimeRepo,
+		detailsRepo:  detailsRepo,
+		rates:        rates,
+		calendars:    calendars,
+		cfg:          cfg,
+		overtime:     overtime,
+	}
+}
+
+// ParsePeriod parses "YYYY-MM" into the first and last day of that month.
+func ParsePeriod(period string) (start, end time.Time, err error) {
+	start, err = time.Parse(periodLayout, period)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("period must be YYYY-MM")
+	}
+	return start, start.AddDate(0, 1, -1), nil
+}
+
+func (s *PayrollService) ListRuns(ctx context.Context, limit, offset int) ([]*models.PayrollRun, int64, error) {
+	return s.repo.ListRuns(ctx, limit, offset)
+}
+
+func (s *PayrollService) GetRun(ctx context.Context, id int64) (*models.PayrollRun, []*models.Payslip, error) {
+	run, err := s.repo.FindRunByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	payslips, err := s.repo.ListPayslipsByRun(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return run, payslips, nil
+}
+
+// Run computes and stores the payroll of period. A period can only be run
+// once: the resulting payslips are immutable.
+func (s *PayrollService) Run(ctx context.Context, period string) (*models.PayrollRun, []*models.Payslip, error) {
+	start, end, err := ParsePeriod(period)
+	if err != nil {
+		return nil, nil, err
+	}
+	if start.After(time.Now()) {
+		return nil, nil, errors.New("cannot run payroll for a future period")
+	}
+	if _, err := s.repo.FindRunByPeriod(ctx, period); err == nil {
+		return nil, nil, fmt.Errorf("payroll for %s has already been run", period)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, err
+	}
+
+	employees, err := s.employeeRepo.ListEmployedBetween(ctx, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	approved, err := s.overtimeRepo.ListApprovedByPeriod(ctx, period)
+	if err != nil {
+		return nil, nil, err
+	}
+	overtime := map[int64][]*models.OvertimeItem{}
+	for _, o := range approved {
+		overtime[o.EmployeeID] = append(overtime[o.EmployeeID], o)
+	}
+	dependents, err := s.detailsRepo.TaxDependentCounts(ctx, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	run := &models.PayrollRun{Period: period, Currency: s.cfg.Currency}
+	var payslips []*models.Payslip
+	calendars := map[int64]*WorkingCalendar{}
+	for _, e := range employees {
+		cal, ok := calendars[e.DepartmentID]
+		if !ok {
+			cal, err = s.calendars.ForDepartment(ctx, e.DepartmentID, start, end)
+			if err != nil {
+				return nil, nil, err
+			}
+			calendars[e.DepartmentID] = cal
+		}
+		p, err := s.payslip(ctx, e, cal, overtime[e.ID], dependents[e.ID], start, end)
+		if err != nil {
+			return nil, nil, fmt.Errorf("employee %d: %v", e.ID, err)
+		}
+		if p == nil {
+			continue
+		}
+		p.Period = period
+		payslips = append(payslips, p)
+		run.TotalGross = run.TotalGross.Add(p.Gross)
+		run.TotalNet = run.TotalNet.Add(p.Net)
+	}
+	run.EmployeeCount = len(payslips)
+
+	if err := s.repo.CreateRun(ctx, run, payslips); err != nil {
+		return nil, nil, err
+	}
+	return run, payslips, nil
+}
+
+// payslip prorates e's salary over the working days of [start, end] they
+// were employed, per their department's calendar, and adds their approved
+// overtime. dependents is the number of dependents registered for tax relief
+// in the month. Employees without a salary on record get no payslip.
+func (s *PayrollService) payslip(ctx context.Context, e *models.Employee, cal *WorkingCalendar, overtime []*models.OvertimeItem, dependents int, start, end time.Time) (*models.Payslip, error) {
+	from, to := start, end
+	if e.HireDate.After(from) {
+		from = truncateToDate(e.HireDate)
+	}
+	if e.TerminationDate != nil && e.TerminationDate.Before(to) {
+		to = truncateToDate(*e.TerminationDate)
+	}
+
+	comp, err := s.compRepo.FindEffective(ctx, e.ID, to)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	salary, err := s.rates.Convert(ctx, comp.Amount, comp.Currency, s.cfg.Currency, end)
+	if err != nil {
+		return nil, err
+	}
+
+	p := CalculatePay(s.cfg, PayInput{
+		MonthlySalary:      salary,
+		WorkingDays:        cal.WorkingDays(start, end),
+		PaidDays:           cal.WorkingDays(from, to),
+		Dependents:         dependents,
+		Overtime:           overtimeByRate(overtime),
+		StandardDayMinutes: s.overtime.StandardDayMinutes,
+	})
+	p.EmployeeID = e.ID
+	p.EmployeeName = e.Name
+	p.DepartmentID = e.DepartmentID
+	return p, nil
+}
+
+// overtimeByRate totals overtime minutes per rate, in the order the rates
+// first appear.
+func overtimeByRate(items []*models.OvertimeItem) []OvertimePay {
+	var out []OvertimePay
+	for _, o := range items {
+		i := 0
+		for i < len(out) && out[i].Rate.Cmp(o.Rate) != 0 {
+			i++
+		}
+		if i == len(out) {
+			out = append(out, OvertimePay{Rate: o.Rate})
+		}
+		out[i].Minutes += o.Minutes
+	}
+	return out
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"app/internal/models"
+	"app/internal/pdf"
+	"app/internal/repositories"
+)
+
+// CompanyInfo is printed in the payslip header.
+type CompanyInfo struct {
+	Name    string
+	Address string
+}
+
+type PayslipService struct {
+	repo    repositories.PayrollRepository
+	company CompanyInfo
+}
+
+func NewPayslipService(repo repositories.PayrollRepository, company CompanyInfo) *PayslipService {
+	if company.Name == "" {
+		company.Name = "Employee Management System"
+	}
+	return &PayslipService{
+		repo:    repo,
+		company: company,
+	}
+}
+
+func (s *PayslipService) ListByEmployee(ctx context.Context, employeeID int64) ([]*models.Payslip, error) {
+	return s.repo.ListPayslipsByEmployee(ctx, employeeID)
+}
+
+// Get returns the employee's payslip for period with its year-to-date totals.
+func (s *PayslipService) Get(ctx context.Context, employeeID int64, period string) (*models.Payslip, *models.PayslipTotals, error) {
+	if _, _, err := ParsePeriod(period); err != nil {
+		return nil, nil, err
+	}
+	p, err := s.repo.FindPayslip(ctx, employeeID, period)
+	if err != nil {
+		return nil, nil, err
+	}
+	ytd, err := s.repo.YearToDate(ctx, employeeID, period)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p, ytd, nil
+}
+
+func (s *PayslipService) ListByDepartment(ctx context.Context, departmentID int64, period string) ([]*models.Payslip, error) {
+	if _, _, err := ParsePeriod(period); err != nil {
+		return nil, err
+	}
+	return s.repo.ListPayslipsByDepartment(ctx, departmentID, period)
+}
+
+func (s *PayslipService) YearToDate(ctx context.Context, p *models.Payslip) (*models.PayslipTotals, error) {
+	return s.repo.YearToDate(ctx, p.EmployeeID, p.Period)
+}
+
+// RenderPDF lays out one payslip on an A4 page.
+func (s *PayslipService) RenderPDF(p *models.Payslip, ytd *models.PayslipTotals) []byte {
+	doc := pdf.New()
+	page := doc.AddPage()
+
+	const left, right = 50.0, pdf.PageWidth - 50
+	y := pdf.PageHeight - 60
+
+	page.Text(left, y, 16, true, s.company.Name)
+	if s.company.Address != "" {
+		y -= 16
+		page.Text(left, y, 9, false, s.company.Address)
+	}
+	page.TextRight(right, pdf.PageHeight-60, 16, true, "PAYSLIP")
+	page.TextRight(right, pdf.PageHeight-76, 10, false, periodTitle(p.Period))
+	y -= 18
+	page.Line(left, y, right, y, 1)
+
+	y -= 22
+	info := [][2]string{
+		{"Employee", p.EmployeeName},
+		{"Employee ID", fmt.Sprintf("%d", p.EmployeeID)},
+		{"Department ID", fmt.Sprintf("%d", p.DepartmentID)},
+		{"Period", p.Period},
+		{"Working days", fmt.Sprintf("%d / %d", p.PaidDays, p.WorkingDays)},
+		{"Currency", p.Currency},
+	}
+	for i, kv := range info {
+		x := left
+		if i%2 == 1 {
+			x = pdf.PageWidth / 2
+		}
+		page.Text(x, y, 9, true, kv[0])
+		page.Text(x+80, y, 9, false, kv[1])
+		if i%2 == 1 {
+			y -= 14
+		}
+	}
+
+	section := func(title string, lines []models.PayslipLine, totalLabel string, total models.Decimal) {
+		y -= 16
+		page.FillRect(left, y-4, right-left, 16, 0.9)
+		page.Text(left+4, y, 10, true, title)
+		page.TextRight(right-4, y, 10, true, "Amount")
+		y -= 18
+		for _, l := range lines {
+			page.Text(left+4, y, 9, false, l.Label)
+			page.TextRight(right-4, y, 9, false, formatMoney(l.Amount, p.Currency))
+			y -= 13
+		}
+		page.Line(left, y+8, right, y+8, 0.5)
+		y -= 4
+		page.Text(left+4, y, 9, true, totalLabel)
+		page.TextRight(right-4, y, 9, true, formatMoney(total, p.Currency))
+		y -= 10
+	}
+
+	var earnings, deductions []models.PayslipLine
+	for _, l := range p.Lines {
+		if l.Kind == models.PayslipLineEarning {
+			earnings = append(earnings, l)
+		} else {
+			deductions = append(deductions, l)
+		}
+	}
+	section("Earnings", earnings, "Gross pay", p.Gross)
+	section("Deductions", deductions, "Total deductions", p.Gross.Sub(p.Net))
+
+	y -= 14
+	page.FillRect(left, y-6, right-left, 20, 0.8)
+	page.Text(left+4, y, 12, true, "Net pay")
+	page.TextRight(right-4, y, 12, true, formatMoney(p.Net, p.Currency)+" "+p.Currency)
+
+	if ytd != nil {
+		y -= 34
+		page.Text(left, y, 10, true, "Year to date")
+		y -= 16
+		for _, kv := range []struct {
+			label  string
+			amount models.Decimal
+		}{
+			{"Gross pay", ytd.Gross},
+			{"Social insurance", ytd.SocialInsurance},
+			{"Income tax", ytd.IncomeTax},
+			{"Other deductions", ytd.Deductions},
+			{"Net pay", ytd.Net},
+		} {
+			page.Text(left+4, y, 9, false, kv.label)
+			page.TextRight(right-4, y, 9, false, formatMoney(kv.amount, p.Currency))
+			y -= 13
+		}
+	}
+
+	page.Line(left, 50, right, 50, 0.5)
+	page.Text(left, 38, 7, false, fmt.Sprintf("Generated %s. This payslip is computer generated and requires no signature.", time.Now().Format("2006-01-02 15:04")))
+	return doc.Bytes()
+}
+
+func periodTitle(period string) string {
+	t, err := time.Parse(periodLayout, period)
+	if err != nil {
+		return period
+	}
+	return t.Format("January 2006")
+}
+
+// formatMoney prints d with thousands separators in the currency's minor
+// units, e.g. 30,730,000 VND or 1,250.50 USD.
+func formatMoney(d models.Decimal, currency string) string {
+	s := d.StringFixed(models.CurrencyMinorUnits(currency))
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i:]
+	}
+	var b strings.Builder
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	return sign + b.String() + frac
+}
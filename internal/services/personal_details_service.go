@@ -0,0 +1,329 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+var (
+	countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+	phonePattern       = regexp.MustCompile(`^\+?[0-9]{8,15}$`)
+	// Vietnamese personal tax codes have 10 digits; citizen ID numbers,
+	// which replace them, have 12.
+	taxIDPattern = regexp.MustCompile(`^[0-9]{10}([0-9]{2})?$`)
+
+	minDateOfBirth = time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// ErrDetailConflict is returned when a record clashes with another of the
+// employee's records while being stored.
+var ErrDetailConflict = repositories.ErrDetailConflict
+
+// ValidationError is a request rejected as invalid, e.g. bad personal
+// details or an unknown listing filter, as opposed to one that failed to be
+// stored or read.
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string { return e.Err.Error() }
+
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+type PersonalDetailsService struct {
+	repo         repositories.PersonalDetailsRepository
+	employeeRepo repositories.EmployeeRepository
+}
+
+func NewPersonalDetailsService(repo repositories.PersonalDetailsRepository, employeeRepo repositories.EmployeeRepository) *PersonalDetailsService {
+	return &PersonalDetailsService{
+		repo:         repo,
+		employeeRepo: employeeRepo,
+	}
+}
+
+// checkDateOfBirth rejects dates of birth in the future or before 1900.
+func checkDateOfBirth(dob, today time.Time) error {
+	if dob.After(today) {
+		return errors.New("dateOfBirth must not be in the future")
+	}
+	if dob.Before(minDateOfBirth) {
+		return errors.New("dateOfBirth must not be before 1900-01-01")
+	}
+	return nil
+}
+
+// trimOptional trims v, turning blank values into nil.
+func trimOptional(v *string) *string {
+	if v == nil {
+		return nil
+	}
+	s := strings.TrimSpace(*v)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// normalizePhone strips spaces and punctuation from a phone number, so
+// "+84 90-123 4567" is stored as "+84901234567".
+func normalizePhone(field, number string) (string, error) {
+	n := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, number)
+	if n == "" {
+		return "", errors.New(field + " is required")
+	}
+	if !phonePattern.MatchString(n) {
+		return "", errors.New(field + " must have 8 to 15 digits and may start with +")
+	}
+	return n, nil
+}
+
+func (s *PersonalDetailsService) ListAddresses(ctx context.Context, employeeID int64) ([]*models.EmployeeAddress, error) {
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAddresses(ctx, employeeID)
+}
+
+// CreateAddress adds an address. Making it primary unsets the previous
+// primary address.
+func (s *PersonalDetailsService) CreateAddress(ctx context.Context, a *models.EmployeeAddress) error {
+	if _, err := s.employeeRepo.FindByID(ctx, a.EmployeeID); err != nil {
+		return err
+	}
+	if err := validateAddress(a); err != nil {
+		return &ValidationError{err}
+	}
+	return s.repo.CreateAddress(ctx, a)
+}
+
+func (s *PersonalDetailsService) UpdateAddress(ctx context.Context, a *models.EmployeeAddress) error {
+	if err := validateAddress(a); err != nil {
+		return &ValidationError{err}
+	}
+	return s.repo.UpdateAddress(ctx, a)
+}
+
+func (s *PersonalDetailsService) DeleteAddress(ctx context.Context, employeeID, id int64) error {
+	return s.repo.DeleteAddress(ctx, employeeID, id)
+}
+
+func validateAddress(a *models.EmployeeAddress) error {
+	if !models.IsValidAddressKind(a.Kind) {
+		return errors.New("kind must be permanent, temporary or mailing")
+	}
+	a.Line1 = strings.TrimSpace(a.Line1)
+	if a.Line1 == "" {
+		return errors.New("line1 is required")
+	}
+	a.City = strings.TrimSpace(a.City)
+	if a.City == "" {
+		return errors.New("city is required")
+	}
+	a.Line2 = trimOptional(a.Line2)
+	a.Region = trimOptional(a.Region)
+	a.PostalCode = trimOptional(a.PostalCode)
+	a.Country = strings.ToUpper(strings.TrimSpace(a.Country))
+	if a.Country == "" {
+		a.Country = "VN"
+	}
+	if !countryCodePattern.MatchString(a.Country) {
+		return errors.New("country must be a two-letter ISO 3166-1 code")
+	}
+	return nil
+}
+
+func (s *PersonalDetailsService) ListPhones(ctx context.Context, employeeID int64) ([]*models.EmployeePhone, error) {
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListPhones(ctx, employeeID)
+}
+
+// CreatePhone adds a phone number. Making it primary unsets the previous
+// primary number.
+func (s *PersonalDetailsService) CreatePhone(ctx context.Context, p *models.EmployeePhone) error {
+	if err := s.validatePhone(ctx, p); err != nil {
+		return err
+	}
+	return s.repo.CreatePhone(ctx, p)
+}
+
+func (s *PersonalDetailsService) UpdatePhone(ctx context.Context, p *models.EmployeePhone) error {
+	if err := s.validatePhone(ctx, p); err != nil {
+		return err
+	}
+	return s.repo.UpdatePhone(ctx, p)
+}
+
+func (s *PersonalDetailsService) DeletePhone(ctx context.Context, employeeID, id int64) error {
+	return s.repo.DeletePhone(ctx, employeeID, id)
+}
+
+// validatePhone also rejects a number the employee already has on another
+// record. It returns sql.ErrNoRows when the employee does not exist.
+func (s *PersonalDetailsService) validatePhone(ctx context.Context, p *models.EmployeePhone) error {
+	if !models.IsValidPhoneKind(p.Kind) {
+		return &ValidationError{errors.New("kind must be mobile, home or work")}
+	}
+	number, err := normalizePhone("number", p.Number)
+	if err != nil {
+		return &ValidationError{err}
+	}
+	p.Number = number
+
+	existing, err := s.ListPhones(ctx, p.EmployeeID)
+	if err != nil {
+		return err
+	}
+	for _, e := range existing {
+		if e.Number == p.Number && e.ID != p.ID {
+			return &ValidationError{fmt.Errorf("number %s is already recorded with id %d", p.Number, e.ID)}
+		}
+	}
+	return nil
+}
+
+func (s *PersonalDetailsService) ListEmergencyContacts(ctx context.Context, employeeID int64) ([]*models.EmergencyContact, error) {
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListEmergencyContacts(ctx, employeeID)
+}
+
+// CreateEmergencyContact adds a contact. Priority defaults to after the
+// employee's existing contacts.
+func (s *PersonalDetailsService) CreateEmergencyContact(ctx context.Context, c *models.EmergencyContact) error {
+	existing, err := s.ListEmergencyContacts(ctx, c.EmployeeID)
+	if err != nil {
+		return err
+	}
+	if c.Priority == 0 {
+		c.Priority = 1
+		for _, e := range existing {
+			if e.Priority >= c.Priority {
+				c.Priority = e.Priority + 1
+			}
+		}
+	}
+	if err := validateEmergencyContact(c); err != nil {
+		return &ValidationError{err}
+	}
+	return s.repo.CreateEmergencyContact(ctx, c)
+}
+
+func (s *PersonalDetailsService) UpdateEmergencyContact(ctx context.Context, c *models.EmergencyContact) error {
+	if c.Priority == 0 {
+		c.Priority = 1
+	}
+	if err := validateEmergencyContact(c); err != nil {
+		return &ValidationError{err}
+	}
+	return s.repo.UpdateEmergencyContact(ctx, c)
+}
+
+func (s *PersonalDetailsService) DeleteEmergencyContact(ctx context.Context, employeeID, id int64) error {
+	return s.repo.DeleteEmergencyContact(ctx, employeeID, id)
+}
+
+func validateEmergencyContact(c *models.EmergencyContact) error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	c.Relationship = strings.TrimSpace(c.Relationship)
+	if c.Relationship == "" {
+		return errors.New("relationship is required")
+	}
+	phone, err := normalizePhone("phone", c.Phone)
+	if err != nil {
+		return err
+	}
+	c.Phone = phone
+	c.Email = trimOptional(c.Email)
+	if c.Email != nil {
+		if addr, err := mail.ParseAddress(*c.Email); err != nil || addr.Address != *c.Email {
+			return errors.New("email is not a valid address")
+		}
+	}
+	if c.Priority < 1 {
+		return errors.New("priority must be at least 1")
+	}
+	return nil
+}
+
+func (s *PersonalDetailsService) ListDependents(ctx context.Context, employeeID int64) ([]*models.Dependent, error) {
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListDependents(ctx, employeeID)
+}
+
+// CreateDependent adds a dependent. Setting reliefFrom registers them for
+// the payroll dependent relief, which needs their tax id.
+func (s *PersonalDetailsService) CreateDependent(ctx context.Context, d *models.Dependent) error {
+	if _, err := s.employeeRepo.FindByID(ctx, d.EmployeeID); err != nil {
+		return err
+	}
+	if err := validateDependent(d); err != nil {
+		return &ValidationError{err}
+	}
+	return s.repo.CreateDependent(ctx, d)
+}
+
+func (s *PersonalDetailsService) UpdateDependent(ctx context.Context, d *models.Dependent) error {
+	if err := validateDependent(d); err != nil {
+		return &ValidationError{err}
+	}
+	return s.repo.UpdateDependent(ctx, d)
+}
+
+func (s *PersonalDetailsService) DeleteDependent(ctx context.Context, employeeID, id int64) error {
+	return s.repo.DeleteDependent(ctx, employeeID, id)
+}
+
+func validateDependent(d *models.Dependent) error {
+	d.Name = strings.TrimSpace(d.Name)
+	if d.Name == "" {
+		return errors.New("name is required")
+	}
+	if !models.IsValidDependentRelationship(d.Relationship) {
+		return errors.New("relationship must be child, spouse, parent or other")
+	}
+	if d.DateOfBirth != nil {
+		if err := checkDateOfBirth(*d.DateOfBirth, time.Now()); err != nil {
+			return err
+		}
+	}
+	d.TaxID = trimOptional(d.TaxID)
+	if d.TaxID != nil && !taxIDPattern.MatchString(*d.TaxID) {
+		return errors.New("taxId must have 10 or 12 digits")
+	}
+	if d.ReliefFrom == nil {
+		if d.ReliefTo != nil {
+			return errors.New("reliefTo requires reliefFrom")
+		}
+		return nil
+	}
+	if d.TaxID == nil {
+		return errors.New("taxId is required to register a dependent for tax relief")
+	}
+	if d.ReliefTo != nil && d.ReliefTo.Before(*d.ReliefFrom) {
+		return errors.New("reliefTo must not be before reliefFrom")
+	}
+	return nil
+}
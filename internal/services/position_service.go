@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+type PositionService struct {
+	repo repositories.PositionRepository
+}
+
+func NewPositionService(repo repositories.PositionRepository) *PositionService {
+	return &PositionService{
+		repo: repo,
+	}
+}
+
+func (s *PositionService) FindAll(ctx context.Context, limit, offset int, keyword, jobFamily *string) ([]*models.Position, int64, error) {
+	return s.repo.FindAll(ctx, limit, offset, keyword, jobFamily)
+}
+
+func (s *PositionService) GetByID(ctx context.Context, id int64) (*models.Position, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *PositionService) Create(ctx context.Context, p *models.Position) error {
+	if err := normalizePosition(p); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, p)
+}
+
+func (s *PositionService) Update(ctx context.Context, p *models.Position) error {
+	if err := normalizePosition(p); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, p)
+}
+
+// Delete refuses to remove a position that employees still hold; merge it
+// into another position instead.
+func (s *PositionService) Delete(ctx context.Context, id int64) error {
+	n, err := s.repo.CountEmployees(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return errors.New("position is or was held by employees; merge it into another position instead")
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// Merge folds duplicate positions (e.g. "Leaderx", "lead") into targetID.
+func (s *PositionService) Merge(ctx context.Context, targetID int64, sourceIDs []int64) error {
+	if len(sourceIDs) == 0 {
+		return errors.New("sourceIds is required")
+	}
+	if _, err := s.repo.FindByID(ctx, targetID); err != nil {
+		return errors.New("position not found")
+	}
+	for _, id := range sourceIDs {
+		if id == targetID {
+			return errors.New("a position cannot be merged into itself")
+		}
+		if _, err := s.repo.FindByID(ctx, id); err != nil {
+			return errors.New("source position not found")
+		}
+	}
+	return s.repo.Merge(ctx, targetID, sourceIDs)
+}
+
+func normalizePosition(p *models.Position) error {
+	p.Code = strings.ToUpper(strings.TrimSpace(p.Code))
+	p.Title = strings.TrimSpace(p.Title)
+	if p.Code == "" {
+		return errors.New("code is required")
+	}
+	if p.Title == "" {
+		return errors.New("title is required")
+	}
+	if p.Level != nil && *p.Level < 0 {
+		return errors.New("level must not be negative")
+	}
+	if p.JobFamily != nil {
+		family := strings.TrimSpace(*p.JobFamily)
+		p.JobFamily = &family
+		if family == "" {
+			p.JobFamily = nil
+		}
+	}
+	return nil
+}
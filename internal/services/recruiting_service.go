@@ -0,0 +1,495 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+type RecruitingService struct {
+	repo         repositories.RecruitingRepository
+	employeeRepo repositories.EmployeeRepository
+	deptRepo     repositories.DepartmentRepository
+	positionRepo repositories.PositionRepository
+	employees    *EmployeeService
+	details      *PersonalDetailsService
+	tx           repositories.Transactor
+}
+
+func NewRecruitingService(repo repositories.RecruitingRepository, employeeRepo repositories.EmployeeRepository, deptRepo repositories.DepartmentRepository, positionRepo repositories.PositionRepository, employees *EmployeeService, details *PersonalDetailsService, tx repositories.Transactor) *RecruitingService {
+	return &RecruitingService{
+		repo:         repo,
+		employeeRepo: employeeRepo,
+		deptRepo:     deptRepo,
+		positionRepo: positionRepo,
+		employees:    employees,
+		details:      details,
+		tx:           tx,
+	}
+}
+
+func (s *RecruitingService) ListStages(ctx context.Context) ([]*models.PipelineStage, error) {
+	return s.repo.ListStages(ctx)
+}
+
+func validateStage(st *models.PipelineStage) error {
+	st.Name = strings.TrimSpace(st.Name)
+	if st.Name == "" {
+		return errors.New("name is required")
+	}
+	if st.Position <= 0 {
+		return errors.New("position must be positive")
+	}
+	return nil
+}
+
+func (s *RecruitingService) CreateStage(ctx context.Context, st *models.PipelineStage) error {
+	if err := validateStage(st); err != nil {
+		return err
+	}
+	return s.repo.CreateStage(ctx, st)
+}
+
+// UpdateStage renames or reorders a stage.
+func (s *RecruitingService) UpdateStage(ctx context.Context, st *models.PipelineStage) error {
+	if _, err := s.repo.FindStage(ctx, st.ID); err != nil {
+		return err
+	}
+	if err := validateStage(st); err != nil {
+		return err
+	}
+	return s.repo.UpdateStage(ctx, st)
+}
+
+// DeleteStage removes a stage no candidate has ever been in.
+func (s *RecruitingService) DeleteStage(ctx context.Context, id int64) error {
+	if _, err := s.repo.FindStage(ctx, id); err != nil {
+		return err
+	}
+	used, err := s.repo.StageInUse(ctx, id)
+	if err != nil {
+		return err
+	}
+	if used {
+		return errors.New("stage has candidates or history; rename it instead")
+	}
+	return s.repo.DeleteStage(ctx, id)
+}
+
+func (s *RecruitingService) ListRequisitions(ctx context.Context, departmentID *int64, status string) ([]*models.JobRequisition, error) {
+	if status != "" && !models.IsValidRequisitionStatus(status) {
+		return nil, errors.New("status must be open, on_hold, filled or cancelled")
+	}
+	return s.repo.ListRequisitions(ctx, departmentID, status)
+}
+
+func (s *RecruitingService) GetRequisition(ctx context.Context, id int64) (*models.JobRequisition, error) {
+	return s.repo.FindRequisition(ctx, id)
+}
+
+func (s *RecruitingService) validateRequisition(ctx context.Context, jr *models.JobRequisition) error {
+	if _, err := s.deptRepo.FindByID(ctx, jr.DepartmentID); err != nil {
+		return errors.New("department not found")
+	}
+	if jr.PositionID != nil {
+		p, err := s.positionRepo.FindByID(ctx, *jr.PositionID)
+		if err != nil {
+			return errors.New("position not found")
+		}
+		if strings.TrimSpace(jr.Title) == "" {
+			jr.Title = p.Title
+		}
+	}
+	jr.Title = strings.TrimSpace(jr.Title)
+	if jr.Title == "" {
+		return errors.New("title or positionId is required")
+	}
+	if jr.Openings <= 0 {
+		return errors.New("openings must be positive")
+	}
+	if jr.HiringManagerID != nil {
+		if _, err := s.employeeRepo.FindByID(ctx, *jr.HiringManagerID); err != nil {
+			return errors.New("hiring manager not found")
+		}
+	}
+	if jr.SalaryMin != nil && jr.SalaryMax != nil && jr.SalaryMin.Cmp(*jr.SalaryMax) > 0 {
+		return errors.New("salaryMin cannot exceed salaryMax")
+	}
+	if jr.SalaryCurrency != nil && !models.IsSupportedCurrency(*jr.SalaryCurrency) {
+		return errors.New("unsupported currency " + *jr.SalaryCurrency)
+	}
+	return nil
+}
+
+func (s *RecruitingService) CreateRequisition(ctx context.Context, jr *models.JobRequisition) error {
+	if jr.OpenedOn.IsZero() {
+		jr.OpenedOn = time.Now()
+	}
+	jr.OpenedOn = truncateToDate(jr.OpenedOn)
+	jr.Status = models.RequisitionStatusOpen
+	if err := s.validateRequisition(ctx, jr); err != nil {
+		return err
+	}
+	return s.repo.CreateRequisition(ctx, jr)
+}
+
+// UpdateRequisition edits a requisition, including putting it on hold,
+// reopening or cancelling it. It stays in its department; a filled
+// requisition reopens only if openings are raised.
+func (s *RecruitingService) UpdateRequisition(ctx context.Context, jr *models.JobRequisition) error {
+	current, err := s.repo.FindRequisition(ctx, jr.ID)
+	if err != nil {
+		return err
+	}
+	jr.DepartmentID = current.DepartmentID
+	jr.OpenedOn = current.OpenedOn
+	jr.CreatedAt = current.CreatedAt
+	jr.Hired = current.Hired
+	if jr.Status == "" {
+		jr.Status = current.Status
+	}
+	if !models.IsValidRequisitionStatus(jr.Status) {
+		return errors.New("status must be open, on_hold, filled or cancelled")
+	}
+	if err := s.validateRequisition(ctx, jr); err != nil {
+		return err
+	}
+	if jr.Openings < jr.Hired {
+		return fmt.Errorf("%d candidates were already hired", jr.Hired)
+	}
+	if jr.Status == models.RequisitionStatusOpen && jr.Hired >= jr.Openings {
+		jr.Status = models.RequisitionStatusFilled
+	}
+
+	switch jr.Status {
+	case models.RequisitionStatusFilled, models.RequisitionStatusCancelled:
+		jr.ClosedOn = current.ClosedOn
+		if jr.ClosedOn == nil {
+			today := truncateToDate(time.Now())
+			jr.ClosedOn = &today
+		}
+	default:
+		jr.ClosedOn = nil
+	}
+	return s.repo.UpdateRequisition(ctx, jr)
+}
+
+func (s *RecruitingService) ListCandidates(ctx context.Context, requisitionID int64, stageID *int64, status string) ([]*models.Candidate, error) {
+	if _, err := s.repo.FindRequisition(ctx, requisitionID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListCandidates(ctx, requisitionID, stageID, status)
+}
+
+func (s *RecruitingService) GetCandidate(ctx context.Context, id int64) (*models.Candidate, []*models.CandidateStageChange, []*models.InterviewFeedback, error) {
+	c, err := s.repo.FindCandidate(ctx, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	history, err := s.repo.ListStageChanges(ctx, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	feedback, err := s.repo.ListFeedback(ctx, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return c, history, feedback, nil
+}
+
+func validateCandidate(c *models.Candidate) error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	if c.OfferedSalary != nil && c.OfferedSalary.Sign() < 0 {
+		return errors.New("offeredSalary must not be negative")
+	}
+	if c.OfferedCurrency != nil && !models.IsSupportedCurrency(*c.OfferedCurrency) {
+		return errors.New("unsupported currency " + *c.OfferedCurrency)
+	}
+	return nil
+}
+
+// AddCandidate puts a new candidate for an open requisition in the first
+// pipeline stage.
+func (s *RecruitingService) AddCandidate(ctx context.Context, c *models.Candidate) error {
+	jr, err := s.repo.FindRequisition(ctx, c.RequisitionID)
+	if err != nil {
+		return err
+	}
+	if jr.Status != models.RequisitionStatusOpen {
+		return fmt.Errorf("requisition is %s", jr.Status)
+	}
+	if err := validateCandidate(c); err != nil {
+		return err
+	}
+	stages, err := s.repo.ListStages(ctx)
+	if err != nil {
+		return err
+	}
+	if len(stages) == 0 {
+		return errors.New("no pipeline stages are configured")
+	}
+	c.StageID = stages[0].ID
+	c.StageName = stages[0].Name
+	c.Status = models.CandidateStatusActive
+	return s.repo.CreateCandidate(ctx, c)
+}
+
+// UpdateCandidate edits contact details and the offer.
+func (s *RecruitingService) UpdateCandidate(ctx context.Context, c *models.Candidate) error {
+	current, err := s.repo.FindCandidate(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+	if err := validateCandidate(c); err != nil {
+		return err
+	}
+	c.RequisitionID = current.RequisitionID
+	c.StageID = current.StageID
+	c.StageName = current.StageName
+	c.Status = current.Status
+	c.EmployeeID = current.EmployeeID
+	c.CreatedAt = current.CreatedAt
+	return s.repo.UpdateCandidate(ctx, c)
+}
+
+func (s *RecruitingService) activeCandidate(ctx context.Context, id int64) (*models.Candidate, error) {
+	c, err := s.repo.FindCandidate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c.Status != models.CandidateStatusActive {
+		return nil, fmt.Errorf("candidate is %s", c.Status)
+	}
+	return c, nil
+}
+
+// MoveToStage moves an active candidate to another stage, forwards or
+// back.
+func (s *RecruitingService) MoveToStage(ctx context.Context, id, stageID int64, note *string) (*models.Candidate, error) {
+	c, err := s.activeCandidate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	st, err := s.repo.FindStage(ctx, stageID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("stage does not exist")
+		}
+		return nil, err
+	}
+	if st.ID == c.StageID {
+		return nil, fmt.Errorf("candidate is already in %s", st.Name)
+	}
+	from := c.StageID
+	c.StageID = st.ID
+	c.StageName = st.Name
+	if err := s.repo.MoveCandidate(ctx, c, from, note); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Close ends an active candidacy as rejected or withdrawn.
+func (s *RecruitingService) Close(ctx context.Context, id int64, status string, note *string) (*models.Candidate, error) {
+	if status != models.CandidateStatusRejected && status != models.CandidateStatusWithdrawn {
+		return nil, errors.New("status must be rejected or withdrawn")
+	}
+	c, err := s.activeCandidate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.Status = status
+	if note != nil {
+		c.Note = note
+	}
+	if err := s.repo.UpdateCandidate(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// AddFeedback records an interviewer's verdict on the candidate's current
+// stage.
+func (s *RecruitingService) AddFeedback(ctx context.Context, f *models.InterviewFeedback) error {
+	c, err := s.repo.FindCandidate(ctx, f.CandidateID)
+	if err != nil {
+		return err
+	}
+	if f.Rating < models.MinReviewRating || f.Rating > models.MaxReviewRating {
+		return fmt.Errorf("rating must be between %d and %d", models.MinReviewRating, models.MaxReviewRating)
+	}
+	if !models.IsValidRecommendation(f.Recommendation) {
+		return errors.New("recommendation must be strong_yes, yes, no or strong_no")
+	}
+	interviewer, err := s.employeeRepo.FindByID(ctx, f.InterviewerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("interviewer does not exist")
+		}
+		return err
+	}
+	if f.InterviewedOn.IsZero() {
+		f.InterviewedOn = time.Now()
+	}
+	f.InterviewedOn = truncateToDate(f.InterviewedOn)
+	f.StageID = c.StageID
+	f.StageName = c.StageName
+	f.InterviewerName = interviewer.Name
+	return s.repo.CreateFeedback(ctx, f)
+}
+
+// OfferAcceptance holds what is only known when the offer is signed. Nil
+// fields fall back to what the candidate and requisition recorded.
+type OfferAcceptance struct {
+	HireDate         *time.Time
+	ProbationEndDate *time.Time
+	ManagerID        *int64
+	Email            *string
+	Salary           *models.Decimal
+	SalaryCurrency   *string
+	CustomFields     models.CustomFieldValues
+}
+
+// AcceptOffer hires a candidate in the last pipeline stage: it creates the
+// employee in the requisition's department and position with the offered
+// salary, reporting to the hiring manager, and fills the requisition once
+// every opening is taken. The candidate's phone becomes the employee's
+// primary mobile number. Like CreateEmployee it answers with any salary
+// band warning.
+//
+// Claiming the candidate and opening, creating the employee, linking them
+// and copying the phone run in one transaction: either all of it happens or
+// none does, so a retried or concurrent acceptance cannot hire them twice.
+func (s *RecruitingService) AcceptOffer(ctx context.Context, id int64, offer OfferAcceptance) (*models.Candidate, *models.Employee, string, error) {
+	c, err := s.activeCandidate(ctx, id)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	stages, err := s.repo.ListStages(ctx)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if len(stages) == 0 || stages[len(stages)-1].ID != c.StageID {
+		return nil, nil, "", errors.New("the candidate must reach the last pipeline stage before accepting an offer")
+	}
+	jr, err := s.repo.FindRequisition(ctx, c.RequisitionID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if jr.Status != models.RequisitionStatusOpen {
+		return nil, nil, "", fmt.Errorf("requisition is %s", jr.Status)
+	}
+
+	e := &models.Employee{
+		Name:             c.Name,
+		Email:            c.Email,
+		DepartmentID:     jr.DepartmentID,
+		ManagerID:        jr.HiringManagerID,
+		PositionID:       jr.PositionID,
+		Salary:           c.OfferedSalary,
+		SalaryCurrency:   c.OfferedCurrency,
+		ProbationEndDate: offer.ProbationEndDate,
+		CustomFields:     offer.CustomFields,
+	}
+	if e.SalaryCurrency == nil {
+		e.SalaryCurrency = jr.SalaryCurrency
+	}
+	if offer.Email != nil {
+		e.Email = offer.Email
+	}
+	if offer.ManagerID != nil {
+		e.ManagerID = offer.ManagerID
+	}
+	if offer.Salary != nil {
+		e.Salary = offer.Salary
+	}
+	if offer.SalaryCurrency != nil {
+		e.SalaryCurrency = offer.SalaryCurrency
+	}
+	switch {
+	case offer.HireDate != nil:
+		e.HireDate = *offer.HireDate
+	case c.ExpectedStartDate != nil:
+		e.HireDate = *c.ExpectedStartDate
+	}
+
+	var phone string
+	if c.Phone != nil {
+		if phone, err = normalizePhone("candidate phone", *c.Phone); err != nil {
+			return nil, nil, "", err
+		}
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.ClaimCandidate(ctx, c); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("the candidate is already hired or the requisition has no opening left")
+			}
+			return err
+		}
+		if err := s.employees.CreateEmployee(ctx, e); err != nil {
+			return err
+		}
+		if err := s.repo.HireCandidate(ctx, c, e.ID); err != nil {
+			return err
+		}
+		if phone == "" {
+			return nil
+		}
+		p := &models.EmployeePhone{EmployeeID: e.ID, Kind: models.PhoneKindMobile, Number: phone, IsPrimary: true}
+		if err := s.details.CreatePhone(ctx, p); err != nil {
+			return fmt.Errorf("copy candidate phone: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return c, e, s.employees.SalaryBandWarning(ctx, e), nil
+}
+
+// Headcount counts the department's staff by employment status and lists
+// its open requisitions with the openings still to fill.
+func (s *RecruitingService) Headcount(ctx context.Context, departmentID int64) (*models.DepartmentHeadcount, error) {
+	if _, err := s.deptRepo.FindByID(ctx, departmentID); err != nil {
+		return nil, err
+	}
+	employees, err := s.employeeRepo.FindByDepartmentID(ctx, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	requisitions, err := s.repo.ListRequisitions(ctx, &departmentID, models.RequisitionStatusOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	hc := &models.DepartmentHeadcount{
+		DepartmentID:     departmentID,
+		ByStatus:         map[string]int{},
+		OpenRequisitions: requisitions,
+	}
+	for _, e := range employees {
+		switch e.Status {
+		case models.EmploymentStatusTerminated:
+			continue
+		case models.EmploymentStatusProbation, models.EmploymentStatusActive, models.EmploymentStatusOnLeave:
+			hc.Employed++
+		}
+		hc.ByStatus[e.Status]++
+	}
+	for _, jr := range requisitions {
+		if jr.Openings > jr.Hired {
+			hc.OpenSlots += jr.Openings - jr.Hired
+		}
+	}
+	return hc, nil
+}
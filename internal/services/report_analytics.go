@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"app/internal/models"
+)
+
+// MaxReportMonths caps the period of the monthly headcount and turnover
+// reports.
+const MaxReportMonths = 120
+
+// TenureBuckets and AgeBuckets list the distribution bands in display order.
+var (
+	TenureBuckets = []string{"<1", "1-3", "3-5", "5-10", "10+"}
+	AgeBuckets    = []string{"<25", "25-34", "35-44", "45-54", "55+", "unknown"}
+)
+
+// MonthHeadcount is the company's staff movement in one month with the
+// per-department breakdown.
+type MonthHeadcount struct {
+	Month       time.Time
+	Opening     int64
+	Hires       int64
+	Leavers     int64
+	Closing     int64
+	Attrition   models.Decimal
+	Departments []*models.MonthlyHeadcount
+}
+
+// Turnover summarises hires and leavers over a period. AverageHeadcount is
+// the mean of the monthly averages of opening and closing headcount and
+// Attrition is leavers as a percentage of it.
+type Turnover struct {
+	From             time.Time
+	To               time.Time
+	Hires            int64
+	Leavers          int64
+	AverageHeadcount models.Decimal
+	Attrition        models.Decimal
+	Months           []*MonthHeadcount
+}
+
+// Distribution is one department's employees spread over the buckets of a
+// tenure or age distribution. Average is over employees with a known value.
+type Distribution struct {
+	DepartmentID   int64
+	DepartmentName string
+	Employees      int64
+	Average        models.Decimal
+	Buckets        map[string]int64
+}
+
+// Headcount reports staff, hires and leavers per month between the months
+// of from and to.
+func (s *ReportService) Headcount(ctx context.Context, from, to time.Time, departmentID *int64) ([]*MonthHeadcount, error) {
+	if to.Before(from) {
+		return nil, errors.New("to must not be before from")
+	}
+	if months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) + 1; months > MaxReportMonths {
+		return nil, errors.New("the period cannot exceed 120 months")
+	}
+
+	rows, err := s.repo.MonthlyHeadcount(ctx, from, to, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*MonthHeadcount
+	for _, row := range rows {
+		if len(out) == 0 || !out[len(out)-1].Month.Equal(row.Month) {
+			out = append(out, &MonthHeadcount{Month: row.Month})
+		}
+		m := out[len(out)-1]
+		m.Opening += row.Opening
+		m.Hires += row.Hires
+		m.Leavers += row.Leavers
+		m.Closing += row.Closing
+		m.Departments = append(m.Departments, row)
+	}
+	for _, m := range out {
+		m.Attrition = attritionRate(m.Leavers, averageHeadcount(m.Opening, m.Closing))
+	}
+	return out, nil
+}
+
+// Turnover reports hires against leavers and the attrition rate between the
+// months of from and to, with the monthly figures behind them.
+func (s *ReportService) Turnover(ctx context.Context, from, to time.Time, departmentID *int64) (*Turnover, error) {
+	months, err := s.Headcount(ctx, from, to, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Turnover{From: from, To: to, Months: months}
+	var sum models.Decimal
+	for _, m := range months {
+		t.Hires += m.Hires
+		t.Leavers += m.Leavers
+		sum = sum.Add(averageHeadcount(m.Opening, m.Closing))
+	}
+	if len(months) > 0 {
+		avg, _ := sum.Div(models.NewDecimalFromInt(int64(len(months))))
+		t.AverageHeadcount = avg.Round(2)
+	}
+	t.Attrition = attritionRate(t.Leavers, t.AverageHeadcount)
+	return t, nil
+}
+
+// Tenure spreads the staff employed on asOf over tenure bands in years.
+func (s *ReportService) Tenure(ctx context.Context, asOf time.Time, departmentID *int64) ([]*Distribution, error) {
+	buckets, err := s.repo.TenureDistribution(ctx, truncateToDate(asOf), departmentID)
+	if err != nil {
+		return nil, err
+	}
+	// Totals are days of tenure; averages are reported in years.
+	return distributions(buckets, TenureBuckets, models.MustParseDecimal("365.25")), nil
+}
+
+// AgeDistribution spreads the staff employed on asOf over age bands.
+func (s *ReportService) AgeDistribution(ctx context.Context, asOf time.Time, departmentID *int64) ([]*Distribution, error) {
+	buckets, err := s.repo.AgeDistribution(ctx, truncateToDate(asOf), departmentID)
+	if err != nil {
+		return nil, err
+	}
+	return distributions(buckets, AgeBuckets, models.NewDecimalFromInt(1)), nil
+}
+
+// distributions groups buckets by department, filling in empty bands, and
+// averages Total divided by unit over the employees outside "unknown".
+func distributions(buckets []*models.DistributionBucket, bands []string, unit models.Decimal) []*Distribution {
+	var out []*Distribution
+	byDept := map[int64]*Distribution{}
+	known := map[int64]int64{}
+	totals := map[int64]int64{}
+	for _, b := range buckets {
+		d, ok := byDept[b.DepartmentID]
+		if !ok {
+			d = &Distribution{
+				DepartmentID:   b.DepartmentID,
+				DepartmentName: b.DepartmentName,
+				Buckets:        map[string]int64{},
+			}
+			for _, band := range bands {
+				d.Buckets[band] = 0
+			}
+			byDept[b.DepartmentID] = d
+			out = append(out, d)
+		}
+		d.Employees += b.Employees
+		d.Buckets[b.Bucket] += b.Employees
+		if b.Bucket != "unknown" {
+			known[b.DepartmentID] += b.Employees
+			totals[b.DepartmentID] += b.Total
+		}
+	}
+
+	for _, d := range out {
+		if n := known[d.DepartmentID]; n > 0 {
+			avg, _ := models.NewDecimalFromInt(totals[d.DepartmentID]).Div(models.NewDecimalFromInt(n).Mul(unit))
+			d.Average = avg.Round(1)
+		}
+	}
+	return out
+}
+
+func averageHeadcount(opening, closing int64) models.Decimal {
+	avg, _ := models.NewDecimalFromInt(opening + closing).Div(models.NewDecimalFromInt(2))
+	return avg
+}
+
+// attritionRate is leavers as a percentage of the average headcount, or
+// zero without staff.
+func attritionRate(leavers int64, average models.Decimal) models.Decimal {
+	if average.Sign() <= 0 {
+		return models.Decimal{}
+	}
+	rate, _ := models.NewDecimalFromInt(leavers * 100).Div(average)
+	return rate.Round(2)
+}
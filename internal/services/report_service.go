@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+type ReportService struct {
+	repo             repositories.ReportRepository
+	rates            *ExchangeRateService
+	fiscalStartMonth time.Month
+	minGroupSize     int
+}
+
+func NewReportService(repo repositories.ReportRepository, rates *ExchangeRateService, fiscalStartMonth time.Month, minGroupSize int) *ReportService {
+	return &ReportService{
+		repo:             repo,
+		rates:            rates,
+		fiscalStartMonth: fiscalStartMonth,
+		minGroupSize:     minGroupSize,
+	}
+}
+
+type DepartmentSalarySummary struct {
+	DepartmentID   int64
+	DepartmentName string
+	Employees      int64
+	Total          models.Decimal
+	Average        models.Decimal
+	// ByCurrency keeps the unconverted totals so the normalization is auditable.
+	ByCurrency map[string]models.Decimal
+}
+
+// SalarySummary totals current monthly salaries per department, normalized
+// to currency with today's exchange rates.
+func (s *ReportService) SalarySummary(ctx context.Context, currency string) ([]*DepartmentSalarySummary, error) {
+	totals, err := s.repo.SalaryTotalsByDepartment(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	today := truncateToDate(time.Now())
+	places := models.CurrencyMinorUnits(currency)
+	var out []*DepartmentSalarySummary
+	byDept := map[int64]*DepartmentSalarySummary{}
+	for _, t := range totals {
+		sum, ok := byDept[t.DepartmentID]
+		if !ok {
+			sum = &DepartmentSalarySummary{
+				DepartmentID:   t.DepartmentID,
+				DepartmentName: t.DepartmentName,
+				ByCurrency:     map[string]models.Decimal{},
+			}
+			byDept[t.DepartmentID] = sum
+			out = append(out, sum)
+		}
+		converted, err := s.rates.Convert(ctx, t.Total, t.Currency, currency, today)
+		if err != nil {
+			return nil, err
+		}
+		sum.Employees += t.Employees
+		sum.Total = sum.Total.Add(converted)
+		sum.ByCurrency[t.Currency] = t.Total
+	}
+
+	for _, sum := range out {
+		if sum.Employees > 0 {
+			avg, _ := sum.Total.Div(models.NewDecimalFromInt(sum.Employees))
+			sum.Average = avg.Round(places)
+		}
+		sum.Total = sum.Total.Round(places)
+	}
+	return out, nil
+}
+
+// CompensationBands measures employees against their salary band. Unless
+// includeWithin is set only employees below or above their band are returned.
+func (s *ReportService) CompensationBands(ctx context.Context, departmentID *int64, includeWithin bool) ([]*models.BandPlacement, error) {
+	placements, err := s.repo.BandPlacements(ctx, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := truncateToDate(time.Now())
+	var out []*models.BandPlacement
+	for _, p := range placements {
+		converted, err := s.rates.Convert(ctx, p.Salary, p.SalaryCurrency, p.Band.Currency, today)
+		if err != nil {
+			return nil, err
+		}
+		p.BandSalary = converted.Round(models.CurrencyMinorUnits(p.Band.Currency))
+		p.Status, p.CompaRatio = p.Band.Place(converted)
+		if p.Status == models.BandStatusWithin && !includeWithin {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// HeadcountBudget compares each department's plan for fiscalYear with its
+// headcount and annualised salary cost today (or at the end of a past year)
+// and with the forecast for the end of the year, which accounts for hires,
+// terminations and transfers already scheduled. Salaries are converted to
+// the plan's currency, or the default currency without a plan.
+func (s *ReportService) HeadcountBudget(ctx context.Context, fiscalYear int, departmentID *int64) ([]*models.HeadcountBudget, error) {
+	depts, err := s.repo.DepartmentPlans(ctx, fiscalYear, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := models.FiscalYearPeriod(fiscalYear, s.fiscalStartMonth)
+	asOf := truncateToDate(time.Now())
+	if asOf.After(end) {
+		asOf = end
+	}
+	members, err := s.repo.PlanMembers(ctx, asOf, end)
+	if err != nil {
+		return nil, err
+	}
+
+	months := models.NewDecimalFromInt(12)
+	var out []*models.HeadcountBudget
+	for _, d := range depts {
+		b := &models.HeadcountBudget{
+			DepartmentID:   d.ID,
+			DepartmentName: d.Name,
+			FiscalYear:     fiscalYear,
+			PeriodStart:    start,
+			PeriodEnd:      end,
+			AsOf:           asOf,
+			Currency:       models.DefaultCurrency,
+		}
+		if len(d.Plans) > 0 {
+			b.Plan = d.Plans[0]
+			b.Currency = b.Plan.Currency
+		}
+
+		for _, m := range members {
+			endDept := m.DepartmentID
+			if m.TransferTo != nil {
+				endDept = *m.TransferTo
+			}
+			if m.DepartmentID != d.ID && endDept != d.ID {
+				continue
+			}
+
+			// Terminations take effect the day after the last working day.
+			employedNow := !m.HireDate.After(asOf) && (m.TerminationDate == nil || !m.TerminationDate.Before(asOf))
+			leaves := m.TerminationDate != nil && m.TerminationDate.Before(end)
+
+			if m.DepartmentID == d.ID && employedNow {
+				b.ActualHeadcount++
+				if m.Salary != nil && m.SalaryCurrency != nil {
+					cost, err := s.rates.Convert(ctx, m.Salary.Mul(months), *m.SalaryCurrency, b.Currency, asOf)
+					if err != nil {
+						return nil, err
+					}
+					b.ActualSalaryCost = b.ActualSalaryCost.Add(cost)
+				}
+			}
+			if m.DepartmentID == d.ID && m.HireDate.After(asOf) {
+				b.ScheduledHires++
+			}
+			if m.DepartmentID == d.ID && leaves && !m.TerminationDate.Before(asOf) {
+				b.ScheduledLeavers++
+			}
+			if m.DepartmentID != endDept && !leaves {
+				if m.DepartmentID == d.ID {
+					b.TransfersOut++
+				} else {
+					b.TransfersIn++
+				}
+			}
+
+			if endDept == d.ID && !leaves {
+				b.ForecastHeadcount++
+				if m.EndSalary != nil && m.EndSalaryCurrency != nil {
+					cost, err := s.rates.Convert(ctx, m.EndSalary.Mul(months), *m.EndSalaryCurrency, b.Currency, asOf)
+					if err != nil {
+						return nil, err
+					}
+					b.ForecastSalaryCost = b.ForecastSalaryCost.Add(cost)
+				}
+			}
+		}
+
+		places := models.CurrencyMinorUnits(b.Currency)
+		b.ActualSalaryCost = b.ActualSalaryCost.Round(places)
+		b.ForecastSalaryCost = b.ForecastSalaryCost.Round(places)
+		if b.Plan != nil {
+			headcount := b.ActualHeadcount - b.Plan.PlannedHeadcount
+			forecast := b.ForecastHeadcount - b.Plan.PlannedHeadcount
+			salary := b.ActualSalaryCost.Sub(b.Plan.SalaryBudget)
+			forecastSalary := b.ForecastSalaryCost.Sub(b.Plan.SalaryBudget)
+			b.HeadcountVariance = &headcount
+			b.ForecastHeadcountVariance = &forecast
+			b.SalaryVariance = &salary
+			b.ForecastSalaryVariance = &forecastSalary
+		}
+		out = append(out, b)
+	}
+	return out, nil
+}
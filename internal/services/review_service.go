@@ -0,0 +1,341 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+type ReviewService struct {
+	repo         repositories.ReviewRepository
+	employeeRepo repositories.EmployeeRepository
+}
+
+func NewReviewService(repo repositories.ReviewRepository, employeeRepo repositories.EmployeeRepository) *ReviewService {
+	return &ReviewService{
+		repo:         repo,
+		employeeRepo: employeeRepo,
+	}
+}
+
+func (s *ReviewService) ListCycles(ctx context.Context) ([]*models.ReviewCycle, error) {
+	return s.repo.ListCycles(ctx)
+}
+
+func (s *ReviewService) GetCycle(ctx context.Context, id int64) (*models.ReviewCycle, error) {
+	return s.repo.FindCycle(ctx, id)
+}
+
+// CreateCycle adds a cycle as a draft; goals can be set before it opens.
+func (s *ReviewService) CreateCycle(ctx context.Context, c *models.ReviewCycle) error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	c.PeriodStart = truncateToDate(c.PeriodStart)
+	c.PeriodEnd = truncateToDate(c.PeriodEnd)
+	if c.PeriodEnd.Before(c.PeriodStart) {
+		return errors.New("periodEnd cannot be before periodStart")
+	}
+	c.Status = models.ReviewCycleDraft
+	return s.repo.CreateCycle(ctx, c)
+}
+
+// OpenCycle starts a draft cycle, creating a review for every current
+// employee with their manager, or their department head, as reviewer. It
+// returns how many reviews were created.
+func (s *ReviewService) OpenCycle(ctx context.Context, id int64) (*models.ReviewCycle, int, error) {
+	c, err := s.repo.FindCycle(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	if c.Status != models.ReviewCycleDraft {
+		return nil, 0, fmt.Errorf("cycle is already %s", c.Status)
+	}
+	n, err := s.repo.OpenCycle(ctx, c)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, 0, errors.New("cycle is no longer a draft")
+		}
+		return nil, 0, err
+	}
+	return c, n, nil
+}
+
+// CloseCycle ends a cycle; its reviews and goals become read-only.
+func (s *ReviewService) CloseCycle(ctx context.Context, id int64) (*models.ReviewCycle, error) {
+	c, err := s.repo.FindCycle(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c.Status != models.ReviewCycleOpen {
+		return nil, fmt.Errorf("only an open cycle can be closed; cycle is %s", c.Status)
+	}
+	if err := s.repo.CloseCycle(ctx, c); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("cycle is no longer open")
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *ReviewService) Dashboard(ctx context.Context, cycleID int64) (*models.ReviewCycle, []*models.ReviewCompletion, error) {
+	c, err := s.repo.FindCycle(ctx, cycleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	completion, err := s.repo.Completion(ctx, cycleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, completion, nil
+}
+
+func (s *ReviewService) ListReviews(ctx context.Context, cycleID int64, reviewerID, departmentID *int64) ([]*models.PerformanceReview, error) {
+	if _, err := s.repo.FindCycle(ctx, cycleID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListReviews(ctx, cycleID, reviewerID, departmentID)
+}
+
+func (s *ReviewService) EmployeeReviews(ctx context.Context, employeeID int64) ([]*models.PerformanceReview, error) {
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListReviewsByEmployee(ctx, employeeID)
+}
+
+func (s *ReviewService) GetReview(ctx context.Context, id int64) (*models.PerformanceReview, error) {
+	return s.repo.FindReview(ctx, id)
+}
+
+// openReview loads a review whose cycle is still open.
+func (s *ReviewService) openReview(ctx context.Context, id int64) (*models.PerformanceReview, error) {
+	pr, err := s.repo.FindReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.repo.FindCycle(ctx, pr.CycleID)
+	if err != nil {
+		return nil, err
+	}
+	if c.Status != models.ReviewCycleOpen {
+		return nil, fmt.Errorf("cycle %s is %s", c.Name, c.Status)
+	}
+	return pr, nil
+}
+
+func validateRating(rating int) error {
+	if rating < models.MinReviewRating || rating > models.MaxReviewRating {
+		return fmt.Errorf("rating must be between %d and %d", models.MinReviewRating, models.MaxReviewRating)
+	}
+	return nil
+}
+
+// SubmitSelf records the employee's self-review. It can be resubmitted
+// until the manager has reviewed.
+func (s *ReviewService) SubmitSelf(ctx context.Context, id int64, rating int, comment *string) (*models.PerformanceReview, error) {
+	if err := validateRating(rating); err != nil {
+		return nil, err
+	}
+	pr, err := s.openReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pr.ManagerSubmittedAt != nil {
+		return nil, errors.New("the self-review cannot change after the manager review")
+	}
+	now := time.Now()
+	pr.SelfRating = &rating
+	pr.SelfComment = comment
+	pr.SelfSubmittedAt = &now
+	if err := s.repo.UpdateReview(ctx, pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// SubmitManager records the assigned reviewer's rating. It can be
+// resubmitted until the review is calibrated.
+func (s *ReviewService) SubmitManager(ctx context.Context, id, reviewerID int64, rating int, comment *string) (*models.PerformanceReview, error) {
+	if err := validateRating(rating); err != nil {
+		return nil, err
+	}
+	pr, err := s.openReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pr.ReviewerID == nil {
+		return nil, errors.New("review has no reviewer assigned")
+	}
+	if *pr.ReviewerID != reviewerID {
+		return nil, errors.New("only the assigned reviewer can submit the manager review")
+	}
+	if pr.CalibratedAt != nil {
+		return nil, errors.New("review is already calibrated")
+	}
+	now := time.Now()
+	pr.ManagerRating = &rating
+	pr.ManagerComment = comment
+	pr.ManagerSubmittedAt = &now
+	if err := s.repo.UpdateReview(ctx, pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// Calibrate sets the final rating once the manager has reviewed.
+func (s *ReviewService) Calibrate(ctx context.Context, id int64, rating int, note *string) (*models.PerformanceReview, error) {
+	if err := validateRating(rating); err != nil {
+		return nil, err
+	}
+	pr, err := s.openReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pr.ManagerSubmittedAt == nil {
+		return nil, errors.New("the manager review must be submitted before calibration")
+	}
+	now := time.Now()
+	pr.CalibratedRating = &rating
+	pr.CalibrationNote = note
+	pr.CalibratedAt = &now
+	if err := s.repo.UpdateReview(ctx, pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// SetReviewer reassigns a review, e.g. when the manager has left or the
+// review was created without one.
+func (s *ReviewService) SetReviewer(ctx context.Context, id, reviewerID int64) (*models.PerformanceReview, error) {
+	pr, err := s.openReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if reviewerID == pr.EmployeeID {
+		return nil, errors.New("an employee cannot review themselves")
+	}
+	if pr.ManagerSubmittedAt != nil {
+		return nil, errors.New("the manager review is already submitted")
+	}
+	reviewer, err := s.employeeRepo.FindByID(ctx, reviewerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("reviewer does not exist")
+		}
+		return nil, err
+	}
+	if reviewer.Status == models.EmploymentStatusTerminated {
+		return nil, errors.New("reviewer is no longer employed")
+	}
+	pr.ReviewerID = &reviewer.ID
+	pr.ReviewerName = &reviewer.Name
+	if err := s.repo.UpdateReview(ctx, pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+func (s *ReviewService) ListGoals(ctx context.Context, employeeID int64, cycleID *int64) ([]*models.ReviewGoal, error) {
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListGoals(ctx, employeeID, cycleID)
+}
+
+func validateGoal(g *models.ReviewGoal) error {
+	g.Title = strings.TrimSpace(g.Title)
+	if g.Title == "" {
+		return errors.New("title is required")
+	}
+	if g.Weight <= 0 {
+		return errors.New("weight must be positive")
+	}
+	if g.Progress < 0 || g.Progress > 100 {
+		return errors.New("progress must be between 0 and 100")
+	}
+	return nil
+}
+
+// writableCycle rejects goal changes once a cycle is closed.
+func (s *ReviewService) writableCycle(ctx context.Context, cycleID int64) error {
+	c, err := s.repo.FindCycle(ctx, cycleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("review cycle does not exist")
+		}
+		return err
+	}
+	if c.Status == models.ReviewCycleClosed {
+		return fmt.Errorf("cycle %s is closed", c.Name)
+	}
+	return nil
+}
+
+func (s *ReviewService) CreateGoal(ctx context.Context, g *models.ReviewGoal) error {
+	if g.Weight == 0 {
+		g.Weight = 1
+	}
+	if err := validateGoal(g); err != nil {
+		return err
+	}
+	if _, err := s.employeeRepo.FindByID(ctx, g.EmployeeID); err != nil {
+		return err
+	}
+	if err := s.writableCycle(ctx, g.CycleID); err != nil {
+		return err
+	}
+	return s.repo.CreateGoal(ctx, g)
+}
+
+// UpdateGoal changes a goal's definition or progress. The cycle cannot be
+// changed.
+func (s *ReviewService) UpdateGoal(ctx context.Context, g *models.ReviewGoal) error {
+	existing, err := s.repo.FindGoal(ctx, g.ID)
+	if err != nil {
+		return err
+	}
+	if err := validateGoal(g); err != nil {
+		return err
+	}
+	if err := s.writableCycle(ctx, existing.CycleID); err != nil {
+		return err
+	}
+	g.CycleID = existing.CycleID
+	g.EmployeeID = existing.EmployeeID
+	g.CreatedAt = existing.CreatedAt
+	return s.repo.UpdateGoal(ctx, g)
+}
+
+func (s *ReviewService) DeleteGoal(ctx context.Context, id int64) error {
+	g, err := s.repo.FindGoal(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.writableCycle(ctx, g.CycleID); err != nil {
+		return err
+	}
+	return s.repo.DeleteGoal(ctx, id)
+}
+
+// GoalProgress is the weighted average progress of goals, 0 when there
+// are none.
+func GoalProgress(goals []*models.ReviewGoal) int {
+	total, weights := 0, 0
+	for _, g := range goals {
+		total += g.Progress * g.Weight
+		weights += g.Weight
+	}
+	if weights == 0 {
+		return 0
+	}
+	return total / weights
+}
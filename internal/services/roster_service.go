@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+// DefaultMinRestHours is the rest required between two shifts (Labour Code,
+// art. 109) when ROSTER_MIN_REST_HOURS is not set.
+const DefaultMinRestHours = 12
+
+// ErrRosterConflict is returned when new assignments conflict and the
+// caller did not allow conflicts.
+var ErrRosterConflict = errors.New("roster has conflicts")
+
+type RosterService struct {
+	repo         repositories.RosterRepository
+	shiftRepo    repositories.ShiftRepository
+	employeeRepo repositories.EmployeeRepository
+	deptRepo     repositories.DepartmentRepository
+	leaveRepo    repositories.LeaveRepository
+	loc          *time.Location
+	minRest      time.Duration
+}
+
+func NewRosterService(repo repositories.RosterRepository, shiftRepo repositories.ShiftRepository, employeeRepo repositories.EmployeeRepository, deptRepo repositories.DepartmentRepository, leaveRepo repositories.LeaveRepository, loc *time.Location, minRestHours int) *RosterService {
+	if minRestHours <= 0 {
+		minRestHours = DefaultMinRestHours
+	}
+	return &RosterService{
+		repo:         repo,
+		shiftRepo:    shiftRepo,
+		employeeRepo: employeeRepo,
+		deptRepo:     deptRepo,
+		leaveRepo:    leaveRepo,
+		loc:          loc,
+		minRest:      time.Duration(minRestHours) * time.Hour,
+	}
+}
+
+// RosterWeek is a department's plan for the week starting WeekStart
+// (a Monday).
+type RosterWeek struct {
+	WeekStart   time.Time
+	Employees   []*models.Employee
+	Shifts      map[int64]*models.Shift
+	Assignments []*models.RosterAssignment
+	Conflicts   []models.RosterConflict
+}
+
+// WeekStart returns the Monday of the week containing d.
+func WeekStart(d time.Time) time.Time {
+	d = truncateToDate(d)
+	return d.AddDate(0, 0, -((int(d.Weekday()) + 6) % 7))
+}
+
+func (s *RosterService) Week(ctx context.Context, departmentID int64, day time.Time) (*RosterWeek, error) {
+	if _, err := s.deptRepo.FindByID(ctx, departmentID); err != nil {
+		return nil, err
+	}
+	start := WeekStart(day)
+	end := start.AddDate(0, 0, 6)
+
+	employees, err := s.employeeRepo.FindByDepartmentID(ctx, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	// The days around the week are loaded so rest periods across its edges
+	// are checked too.
+	assignments, err := s.repo.ListByDepartment(ctx, departmentID, start.AddDate(0, 0, -1), end.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	shifts, err := s.shiftsByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	leave, err := s.leaveRepo.ListApprovedByDepartment(ctx, departmentID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	week := &RosterWeek{WeekStart: start, Employees: employees, Shifts: shifts}
+	for _, c := range detectRosterConflicts(assignments, shifts, leave, s.loc, s.minRest) {
+		if !c.Date.Before(start) && !c.Date.After(end) {
+			week.Conflicts = append(week.Conflicts, c)
+		}
+	}
+	for _, a := range assignments {
+		if !a.WorkDate.Before(start) && !a.WorkDate.After(end) {
+			week.Assignments = append(week.Assignments, a)
+		}
+	}
+	return week, nil
+}
+
+func (s *RosterService) shiftsByID(ctx context.Context) (map[int64]*models.Shift, error) {
+	list, err := s.shiftRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shifts := make(map[int64]*models.Shift, len(list))
+	for _, sh := range list {
+		shifts[sh.ID] = sh
+	}
+	return shifts, nil
+}
+
+// Assign adds assignments to a department's roster. Conflicts involving
+// the new assignments are returned; unless allowConflicts is set they also
+// cause ErrRosterConflict and nothing is saved.
+func (s *RosterService) Assign(ctx context.Context, departmentID int64, assignments []*models.RosterAssignment, allowConflicts bool) ([]models.RosterConflict, error) {
+	if _, err := s.deptRepo.FindByID(ctx, departmentID); err != nil {
+		return nil, err
+	}
+	if len(assignments) == 0 {
+		return nil, errors.New("assignments are required")
+	}
+	shifts, err := s.shiftsByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var from, to time.Time
+	members := map[int64]*models.Employee{}
+	for i, a := range assignments {
+		a.WorkDate = truncateToDate(a.WorkDate)
+		if _, ok := shifts[a.ShiftID]; !ok {
+			return nil, fmt.Errorf("assignment %d: shift %d not found", i+1, a.ShiftID)
+		}
+		e, ok := members[a.EmployeeID]
+		if !ok {
+			if e, err = s.employeeRepo.FindByID(ctx, a.EmployeeID); err != nil {
+				return nil, fmt.Errorf("assignment %d: employee %d not found", i+1, a.EmployeeID)
+			}
+			members[e.ID] = e
+		}
+		if e.DepartmentID != departmentID {
+			return nil, fmt.Errorf("assignment %d: employee %d is not in this department", i+1, a.EmployeeID)
+		}
+		if a.WorkDate.Before(e.HireDate) || (e.TerminationDate != nil && a.WorkDate.After(*e.TerminationDate)) {
+			return nil, fmt.Errorf("assignment %d: employee %d is not employed on %s", i+1, a.EmployeeID, a.WorkDate.Format("2006-01-02"))
+		}
+		if from.IsZero() || a.WorkDate.Before(from) {
+			from = a.WorkDate
+		}
+		if a.WorkDate.After(to) {
+			to = a.WorkDate
+		}
+	}
+
+	existing, err := s.repo.ListByDepartment(ctx, departmentID, from.AddDate(0, 0, -1), to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	leave, err := s.leaveRepo.ListApprovedByDepartment(ctx, departmentID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	isNew := map[*models.RosterAssignment]bool{}
+	for _, a := range assignments {
+		isNew[a] = true
+	}
+	var conflicts []models.RosterConflict
+	for _, c := range detectRosterConflicts(append(existing, assignments...), shifts, leave, s.loc, s.minRest) {
+		if isNew[c.Assignment] || isNew[c.Other] {
+			conflicts = append(conflicts, c)
+		}
+	}
+	if len(conflicts) > 0 && !allowConflicts {
+		return conflicts, ErrRosterConflict
+	}
+	return conflicts, s.repo.CreateBatch(ctx, assignments)
+}
+
+func (s *RosterService) Delete(ctx context.Context, id int64) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// detectRosterConflicts checks each employee's assignments in time order,
+// each against the earlier shift that ends last: overlapping shifts are
+// double bookings, a gap shorter than minRest is a rest period violation,
+// and any shift on a day of approved leave clashes with that leave.
+func detectRosterConflicts(assignments []*models.RosterAssignment, shifts map[int64]*models.Shift, leave []*models.LeaveRequest, loc *time.Location, minRest time.Duration) []models.RosterConflict {
+	type slot struct {
+		a          *models.RosterAssignment
+		start, end time.Time
+	}
+	byEmployee := map[int64][]slot{}
+	for _, a := range assignments {
+		sh, ok := shifts[a.ShiftID]
+		if !ok {
+			continue
+		}
+		start, end := sh.Bounds(a.WorkDate, loc)
+		byEmployee[a.EmployeeID] = append(byEmployee[a.EmployeeID], slot{a, start, end})
+	}
+
+	var employeeIDs []int64
+	for id := range byEmployee {
+		employeeIDs = append(employeeIDs, id)
+	}
+	sort.Slice(employeeIDs, func(i, j int) bool { return employeeIDs[i] < employeeIDs[j] })
+
+	var conflicts []models.RosterConflict
+	for _, id := range employeeIDs {
+		slots := byEmployee[id]
+		sort.Slice(slots, func(i, j int) bool { return slots[i].start.Before(slots[j].start) })
+		latest := slots[0]
+		for i := 1; i < len(slots); i++ {
+			prev, cur := latest, slots[i]
+			if cur.end.After(latest.end) {
+				latest = cur
+			}
+			switch gap := cur.start.Sub(prev.end); {
+			case gap < 0:
+				conflicts = append(conflicts, models.RosterConflict{
+					Kind: models.RosterConflictDoubleBooking, EmployeeID: id, Date: cur.a.WorkDate,
+					Assignment: cur.a, Other: prev.a,
+					Message: fmt.Sprintf("shift on %s overlaps another shift starting %s", cur.a.WorkDate.Format("2006-01-02"), prev.start.Format("2006-01-02 15:04")),
+				})
+			case gap < minRest:
+				conflicts = append(conflicts, models.RosterConflict{
+					Kind: models.RosterConflictRestPeriod, EmployeeID: id, Date: cur.a.WorkDate,
+					Assignment: cur.a, Other: prev.a,
+					Message: fmt.Sprintf("only %s rest before the shift on %s, %s required", gap, cur.a.WorkDate.Format("2006-01-02"), minRest),
+				})
+			}
+		}
+		for _, sl := range slots {
+			for _, l := range leave {
+				if l.EmployeeID == id && !sl.a.WorkDate.Before(l.StartDate) && !sl.a.WorkDate.After(l.EndDate) {
+					conflicts = append(conflicts, models.RosterConflict{
+						Kind: models.RosterConflictOnLeave, EmployeeID: id, Date: sl.a.WorkDate,
+						Assignment: sl.a,
+						Message:    fmt.Sprintf("employee is on approved leave on %s", sl.a.WorkDate.Format("2006-01-02")),
+					})
+				}
+			}
+		}
+	}
+	return conflicts
+}
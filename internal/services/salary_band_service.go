@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+// Salary band policies, chosen with SALARY_BAND_POLICY.
+const (
+	BandPolicyOff    = "off"
+	BandPolicyWarn   = "warn"
+	BandPolicyReject = "reject"
+)
+
+type SalaryBandService struct {
+	repo         repositories.SalaryBandRepository
+	positionRepo repositories.PositionRepository
+	deptRepo     repositories.DepartmentRepository
+	rates        *ExchangeRateService
+	policy       string
+}
+
+func NewSalaryBandService(repo repositories.SalaryBandRepository, positionRepo repositories.PositionRepository, deptRepo repositories.DepartmentRepository, rates *ExchangeRateService, policy string) *SalaryBandService {
+	switch policy {
+	case BandPolicyOff, BandPolicyReject:
+	default:
+		policy = BandPolicyWarn
+	}
+	return &SalaryBandService{
+		repo:         repo,
+		positionRepo: positionRepo,
+		deptRepo:     deptRepo,
+		rates:        rates,
+		policy:       policy,
+	}
+}
+
+func (s *SalaryBandService) Policy() string {
+	return s.policy
+}
+
+func (s *SalaryBandService) List(ctx context.Context, positionID *int64) ([]*models.SalaryBand, error) {
+	return s.repo.List(ctx, positionID)
+}
+
+func (s *SalaryBandService) Save(ctx context.Context, b *models.SalaryBand) error {
+	if _, err := s.positionRepo.FindByID(ctx, b.PositionID); err != nil {
+		return errors.New("position not found")
+	}
+	if b.DepartmentID != nil {
+		if _, err := s.deptRepo.FindByID(ctx, *b.DepartmentID); err != nil {
+			return errors.New("department not found")
+		}
+	}
+	b.Currency = strings.ToUpper(strings.TrimSpace(b.Currency))
+	if b.Currency == "" {
+		b.Currency = models.DefaultCurrency
+	}
+	if !models.IsSupportedCurrency(b.Currency) {
+		return errors.New("unsupported currency " + b.Currency)
+	}
+	if b.Min.Sign() < 0 || b.Min.Cmp(b.Mid) > 0 || b.Mid.Cmp(b.Max) > 0 {
+		return errors.New("band must satisfy 0 <= min <= mid <= max")
+	}
+	return s.repo.Upsert(ctx, b)
+}
+
+// Check places e's salary in the band for its position and department. It
+// returns nil when there is nothing to check: no position, no salary, or no
+// band defined for the position.
+func (s *SalaryBandService) Check(ctx context.Context, e *models.Employee) (*models.BandPlacement, error) {
+	if e.PositionID == nil || e.Salary == nil {
+		return nil, nil
+	}
+	band, err := s.repo.FindApplicable(ctx, *e.PositionID, e.DepartmentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	currency := derefCurrency(e.SalaryCurrency)
+	p := &models.BandPlacement{
+		EmployeeID:     e.ID,
+		EmployeeName:   e.Name,
+		DepartmentID:   e.DepartmentID,
+		PositionID:     *e.PositionID,
+		Salary:         *e.Salary,
+		SalaryCurrency: currency,
+		Band:           *band,
+	}
+	if err := s.place(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// place converts the salary to the band currency and fills Status and CompaRatio.
+func (s *SalaryBandService) place(ctx context.Context, p *models.BandPlacement) error {
+	converted, err := s.rates.Convert(ctx, p.Salary, p.SalaryCurrency, p.Band.Currency, truncateToDate(time.Now()))
+	if err != nil {
+		return err
+	}
+	p.BandSalary = converted.Round(models.CurrencyMinorUnits(p.Band.Currency))
+	p.Status, p.CompaRatio = p.Band.Place(converted)
+	return nil
+}
+
+// bandViolation describes p as a human readable warning, or "" when in band.
+func bandViolation(p *models.BandPlacement) string {
+	if p == nil || p.Status == models.BandStatusWithin {
+		return ""
+	}
+	return fmt.Sprintf("salary %s %s is %s the band %s-%s %s (compa-ratio %s)",
+		p.BandSalary, p.Band.Currency, p.Status, p.Band.Min, p.Band.Max, p.Band.Currency, p.CompaRatio)
+}
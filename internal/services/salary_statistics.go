@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"app/internal/models"
+)
+
+// DefaultSalaryStatsMinGroupSize is the smallest group whose salary
+// statistics are reported unless configured otherwise.
+const DefaultSalaryStatsMinGroupSize = 5
+
+// SalaryAttributes lists what salary statistics can be grouped by.
+var SalaryAttributes = []string{"department", "position", "status", "age", "tenure"}
+
+// PayGapGroup compares one group's pay with the reference group. Gaps are
+// percentages of the reference figure, negative when the group earns less,
+// and nil when the group is suppressed.
+type PayGapGroup struct {
+	Stats       *models.SalaryStatistics
+	MeanGap     *models.Decimal
+	MedianGap   *models.Decimal
+	IsReference bool
+}
+
+type PayGap struct {
+	Attribute string
+	Currency  string
+	Reference *models.SalaryStatistics
+	Groups    []*PayGapGroup
+}
+
+// SalaryStatistics reports current salaries per group of attribute in
+// currency. minGroupSize may raise the configured privacy threshold but not
+// lower it; zero uses the configured one.
+func (s *ReportService) SalaryStatistics(ctx context.Context, attribute, currency string, departmentID *int64, minGroupSize int) ([]*models.SalaryStatistics, error) {
+	if !isSalaryAttribute(attribute) {
+		return nil, fmt.Errorf("attribute must be one of %v", SalaryAttributes)
+	}
+	return s.salaryStatistics(ctx, attribute, currency, departmentID, minGroupSize)
+}
+
+// PayGap compares mean and median pay of each group of attribute with the
+// group whose key is reference, or with everyone when reference is empty.
+func (s *ReportService) PayGap(ctx context.Context, attribute, reference, currency string, departmentID *int64, minGroupSize int) (*PayGap, error) {
+	groups, err := s.SalaryStatistics(ctx, attribute, currency, departmentID, minGroupSize)
+	if err != nil {
+		return nil, err
+	}
+
+	gap := &PayGap{Attribute: attribute, Currency: currency}
+	if reference == "" {
+		all, err := s.salaryStatistics(ctx, "all", currency, departmentID, minGroupSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(all) > 0 {
+			gap.Reference = all[0]
+		}
+	} else {
+		for _, g := range groups {
+			if g.Key == reference {
+				gap.Reference = g
+			}
+		}
+		if gap.Reference == nil {
+			return nil, errors.New("reference group " + reference + " has no employees")
+		}
+	}
+	if gap.Reference != nil && gap.Reference.Suppressed {
+		return nil, errors.New("the reference group is suppressed by the privacy threshold")
+	}
+
+	for _, g := range groups {
+		pg := &PayGapGroup{Stats: g, IsReference: reference != "" && g.Key == reference}
+		if !g.Suppressed && gap.Reference != nil {
+			pg.MeanGap = percentGap(*g.Mean, *gap.Reference.Mean)
+			pg.MedianGap = percentGap(*g.Median, *gap.Reference.Median)
+		}
+		gap.Groups = append(gap.Groups, pg)
+	}
+	return gap, nil
+}
+
+func (s *ReportService) salaryStatistics(ctx context.Context, attribute, currency string, departmentID *int64, minGroupSize int) ([]*models.SalaryStatistics, error) {
+	if minGroupSize == 0 {
+		minGroupSize = s.minGroupSize
+	}
+	if minGroupSize < s.minGroupSize {
+		return nil, fmt.Errorf("minGroupSize cannot be below %d", s.minGroupSize)
+	}
+
+	currencies, err := s.repo.SalaryCurrencies(ctx, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	today := truncateToDate(time.Now())
+	rates := map[string]models.Decimal{}
+	for _, c := range currencies {
+		rate, err := s.rates.Convert(ctx, models.NewDecimalFromInt(1), c, currency, today)
+		if err != nil {
+			return nil, err
+		}
+		rates[c] = rate
+	}
+
+	stats, err := s.repo.SalaryStatistics(ctx, attribute, rates, departmentID, minGroupSize)
+	if err != nil {
+		return nil, err
+	}
+	suppressSmallGroups(stats)
+	places := models.CurrencyMinorUnits(currency)
+	for _, st := range stats {
+		for _, v := range []*models.Decimal{st.Min, st.Max, st.Mean, st.Median, st.P10, st.P25, st.P75, st.P90, st.StdDev} {
+			if v != nil {
+				*v = v.Round(places)
+			}
+		}
+	}
+
+	switch attribute {
+	case "age":
+		sortByBucket(stats, AgeBuckets)
+	case "tenure":
+		sortByBucket(stats, TenureBuckets)
+	}
+	return stats, nil
+}
+
+func isSalaryAttribute(attribute string) bool {
+	for _, a := range SalaryAttributes {
+		if a == attribute {
+			return true
+		}
+	}
+	return false
+}
+
+// suppressSmallGroups hides the figures and the size of every group below
+// the privacy threshold. When exactly one group is below it, the smallest
+// other group is suppressed as well: otherwise the hidden group's total
+// would follow from the overall mean and size minus the visible groups.
+func suppressSmallGroups(stats []*models.SalaryStatistics) {
+	var suppressed int
+	var smallest *models.SalaryStatistics
+	for _, st := range stats {
+		if st.Suppressed {
+			suppressed++
+		} else if smallest == nil || st.Employees < smallest.Employees {
+			smallest = st
+		}
+	}
+	if suppressed == 1 && smallest != nil {
+		smallest.Suppressed = true
+	}
+	for _, st := range stats {
+		if st.Suppressed {
+			*st = models.SalaryStatistics{Key: st.Key, Label: st.Label, Suppressed: true}
+		}
+	}
+}
+
+// sortByBucket orders groups keyed by distribution band in band order.
+func sortByBucket(stats []*models.SalaryStatistics, bands []string) {
+	order := map[string]int{}
+	for i, b := range bands {
+		order[b] = i
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		return order[stats[i].Key] < order[stats[j].Key]
+	})
+}
+
+// percentGap is (value - reference) / reference in percent, rounded to two
+// places, or nil when the reference is zero.
+func percentGap(value, reference models.Decimal) *models.Decimal {
+	if reference.IsZero() {
+		return nil
+	}
+	gap, _ := value.Sub(reference).Mul(models.NewDecimalFromInt(100)).Div(reference)
+	gap = gap.Round(2)
+	return &gap
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"testing"
+
+	"app/internal/models"
+)
+
+func salaryGroup(key string, employees int64, mean string, suppressed bool) *models.SalaryStatistics {
+	st := &models.SalaryStatistics{Key: key, Label: key, Employees: employees, Suppressed: suppressed}
+	if !suppressed {
+		m := models.MustParseDecimal(mean)
+		st.Mean = &m
+	}
+	return st
+}
+
+// TestSuppressSmallGroupsResistsDifferencing plays the attacker: with the
+// overall mean and size known, it subtracts every visible group from the
+// total and checks that what is left does not isolate the hidden employee.
+func TestSuppressSmallGroupsResistsDifferencing(t *testing.T) {
+	// One employee earning 90 in "c"; everyone else is visible.
+	stats := []*models.SalaryStatistics{
+		salaryGroup("a", 6, "10", false),
+		salaryGroup("b", 5, "20", false),
+		salaryGroup("c", 1, "", true),
+		salaryGroup("d", 8, "30", false),
+	}
+	const total, employees = 6*10 + 5*20 + 90 + 8*30, 20
+
+	suppressSmallGroups(stats)
+
+	remainingTotal := models.NewDecimalFromInt(total)
+	remainingEmployees := int64(employees)
+	for _, st := range stats {
+		if st.Suppressed {
+			if st.Employees != 0 || st.Mean != nil || st.Min != nil || st.Max != nil || st.Median != nil {
+				t.Errorf("suppressed group %s still reports figures: %+v", st.Key, st)
+			}
+			continue
+		}
+		remainingTotal = remainingTotal.Sub(st.Mean.Mul(models.NewDecimalFromInt(st.Employees)))
+		remainingEmployees -= st.Employees
+	}
+	if remainingEmployees < 2 {
+		t.Fatalf("the hidden salaries can be recovered: %s over %d employees", remainingTotal, remainingEmployees)
+	}
+	if !stats[1].Suppressed {
+		t.Errorf("expected the smallest visible group b to be suppressed too")
+	}
+	if stats[0].Suppressed || stats[3].Suppressed {
+		t.Errorf("only the smallest visible group should be suppressed")
+	}
+}
+
+func TestSuppressSmallGroups(t *testing.T) {
+	tests := []struct {
+		name       string
+		stats      []*models.SalaryStatistics
+		suppressed []string
+	}{
+		{
+			name: "nothing below the threshold",
+			stats: []*models.SalaryStatistics{
+				salaryGroup("a", 5, "10", false),
+				salaryGroup("b", 7, "20", false),
+			},
+		},
+		{
+			name: "two hidden groups need no extra suppression",
+			stats: []*models.SalaryStatistics{
+				salaryGroup("a", 5, "10", false),
+				salaryGroup("b", 2, "", true),
+				salaryGroup("c", 1, "", true),
+			},
+			suppressed: []string{"b", "c"},
+		},
+		{
+			name: "a single group stays hidden",
+			stats: []*models.SalaryStatistics{
+				salaryGroup("all", 3, "", true),
+			},
+			suppressed: []string{"all"},
+		},
+		{
+			name: "ties suppress the first smallest group",
+			stats: []*models.SalaryStatistics{
+				salaryGroup("a", 9, "10", false),
+				salaryGroup("b", 6, "20", false),
+				salaryGroup("c", 6, "30", false),
+				salaryGroup("d", 4, "", true),
+			},
+			suppressed: []string{"b", "d"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suppressSmallGroups(tt.stats)
+			var got []string
+			for _, st := range tt.stats {
+				if st.Suppressed {
+					got = append(got, st.Key)
+				}
+			}
+			if len(got) != len(tt.suppressed) {
+				t.Fatalf("suppressed %v, want %v", got, tt.suppressed)
+			}
+			for i := range got {
+				if got[i] != tt.suppressed[i] {
+					t.Fatalf("suppressed %v, want %v", got, tt.suppressed)
+				}
+			}
+		})
+	}
+}
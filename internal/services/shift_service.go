@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+type ShiftService struct {
+	repo     repositories.ShiftRepository
+	deptRepo repositories.DepartmentRepository
+}
+
+func NewShiftService(repo repositories.ShiftRepository, deptRepo repositories.DepartmentRepository) *ShiftService {
+	return &ShiftService{
+		repo:     repo,
+		deptRepo: deptRepo,
+	}
+}
+
+func (s *ShiftService) List(ctx context.Context) ([]*models.Shift, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *ShiftService) GetByID(ctx context.Context, id int64) (*models.Shift, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *ShiftService) validate(sh *models.Shift) error {
+	sh.Name = strings.TrimSpace(sh.Name)
+	if sh.Name == "" {
+		return errors.New("name is required")
+	}
+	if _, err := models.ParseShiftClock(sh.StartTime); err != nil {
+		return errors.New("startTime must be HH:MM")
+	}
+	if _, err := models.ParseShiftClock(sh.EndTime); err != nil {
+		return errors.New("endTime must be HH:MM")
+	}
+	if sh.BreakMinutes < 0 || sh.GraceMinutes < 0 {
+		return errors.New("breakMinutes and graceMinutes must not be negative")
+	}
+	if sh.ScheduledMinutes() <= 0 {
+		return errors.New("the break must be shorter than the shift")
+	}
+	return nil
+}
+
+func (s *ShiftService) Create(ctx context.Context, sh *models.Shift) error {
+	if err := s.validate(sh); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, sh)
+}
+
+func (s *ShiftService) Update(ctx context.Context, sh *models.Shift) error {
+	if err := s.validate(sh); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, sh)
+}
+
+// SetDepartmentDefault sets the shift department members are expected to
+// work when nothing more specific is scheduled; nil clears it.
+func (s *ShiftService) SetDepartmentDefault(ctx context.Context, departmentID int64, shiftID *int64) error {
+	if shiftID != nil {
+		if _, err := s.repo.FindByID(ctx, *shiftID); err != nil {
+			return errors.New("shift not found")
+		}
+	}
+	return s.deptRepo.SetDefaultShift(ctx, departmentID, shiftID)
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+type SkillService struct {
+	repo         repositories.SkillRepository
+	employeeRepo repositories.EmployeeRepository
+}
+
+func NewSkillService(repo repositories.SkillRepository, employeeRepo repositories.EmployeeRepository) *SkillService {
+	return &SkillService{
+		repo:         repo,
+		employeeRepo: employeeRepo,
+	}
+}
+
+func (s *SkillService) List(ctx context.Context, keyword string) ([]*models.Skill, error) {
+	return s.repo.List(ctx, keyword)
+}
+
+// Create adds a skill to the catalog; names are unique ignoring case.
+func (s *SkillService) Create(ctx context.Context, sk *models.Skill) error {
+	sk.Name = strings.TrimSpace(sk.Name)
+	if sk.Name == "" {
+		return errors.New("name is required")
+	}
+	if existing, err := s.repo.FindByName(ctx, sk.Name); err == nil {
+		return fmt.Errorf("skill %q already exists with id %d", existing.Name, existing.ID)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return s.repo.Create(ctx, sk)
+}
+
+// EmployeeSkills returns the employee's skills, strongest first, and the
+// endorsements they received.
+func (s *SkillService) EmployeeSkills(ctx context.Context, employeeID int64) ([]*models.EmployeeSkill, []*models.SkillEndorsement, error) {
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, nil, err
+	}
+	skills, err := s.repo.ListByEmployees(ctx, []int64{employeeID})
+	if err != nil {
+		return nil, nil, err
+	}
+	endorsements, err := s.repo.ListEndorsements(ctx, employeeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return skills, endorsements, nil
+}
+
+// resolve finds a catalog skill by id, or by name when skillID is 0.
+func (s *SkillService) resolve(ctx context.Context, skillID int64, name string) (*models.Skill, error) {
+	if skillID != 0 {
+		sk, err := s.repo.FindByID(ctx, skillID)
+		if err != nil {
+			return nil, errors.New("skill not found")
+		}
+		return sk, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, errors.New("skillId or skill is required")
+	}
+	sk, err := s.repo.FindByName(ctx, strings.TrimSpace(name))
+	if err != nil {
+		return nil, fmt.Errorf("unknown skill %q; add it to the catalog via /skills", name)
+	}
+	return sk, nil
+}
+
+// SetLevel records the employee's proficiency in a skill, replacing any
+// earlier level. Endorsements are kept.
+func (s *SkillService) SetLevel(ctx context.Context, employeeID, skillID int64, name string, level int) (*models.EmployeeSkill, error) {
+	if level < models.MinSkillLevel || level > models.MaxSkillLevel {
+		return nil, fmt.Errorf("level must be between %d and %d", models.MinSkillLevel, models.MaxSkillLevel)
+	}
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	sk, err := s.resolve(ctx, skillID, name)
+	if err != nil {
+		return nil, err
+	}
+	es := &models.EmployeeSkill{EmployeeID: employeeID, SkillID: sk.ID, Level: level}
+	if err := s.repo.SetLevel(ctx, es); err != nil {
+		return nil, err
+	}
+	return s.repo.FindEmployeeSkill(ctx, employeeID, sk.ID)
+}
+
+func (s *SkillService) RemoveSkill(ctx context.Context, employeeID, skillID int64) error {
+	return s.repo.RemoveSkill(ctx, employeeID, skillID)
+}
+
+// Endorse records a colleague vouching for the employee's skill. Everyone
+// can endorse a skill once, but not their own.
+func (s *SkillService) Endorse(ctx context.Context, employeeID, skillID, endorserID int64, comment *string) (*models.SkillEndorsement, error) {
+	if _, err := s.repo.FindEmployeeSkill(ctx, employeeID, skillID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("the employee has not recorded this skill")
+		}
+		return nil, err
+	}
+	if endorserID == employeeID {
+		return nil, errors.New("employees cannot endorse their own skills")
+	}
+	endorser, err := s.employeeRepo.FindByID(ctx, endorserID)
+	if err != nil {
+		return nil, errors.New("endorser not found")
+	}
+	if endorser.Status == models.EmploymentStatusTerminated || endorser.Status == models.EmploymentStatusCandidate {
+		return nil, errors.New("only current employees can endorse skills")
+	}
+	existing, err := s.repo.ListEndorsements(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range existing {
+		if e.SkillID == skillID && e.EndorserID == endorserID {
+			return nil, errors.New("already endorsed by this employee")
+		}
+	}
+
+	e := &models.SkillEndorsement{
+		EmployeeID:   employeeID,
+		SkillID:      skillID,
+		EndorserID:   endorserID,
+		EndorserName: endorser.Name,
+		Comment:      comment,
+	}
+	return e, s.repo.Endorse(ctx, e)
+}
+
+// Search lists the employees matching filter, which must ask for at least
+// one skill, with the skills of each of them keyed by employee id.
+func (s *SkillService) Search(ctx context.Context, limit, offset int, filter models.EmployeeFilter) ([]*models.Employee, int64, map[int64][]*models.EmployeeSkill, error) {
+	if len(filter.Skills) == 0 {
+		return nil, 0, nil, errors.New("at least one skill is required, e.g. ?skill=Go:3")
+	}
+	employees, total, err := s.employeeRepo.List(ctx, limit, offset, filter)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	ids := make([]int64, 0, len(employees))
+	for _, e := range employees {
+		ids = append(ids, e.ID)
+	}
+	skills, err := s.repo.ListByEmployees(ctx, ids)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	byEmployee := make(map[int64][]*models.EmployeeSkill, len(employees))
+	for _, es := range skills {
+		byEmployee[es.EmployeeID] = append(byEmployee[es.EmployeeID], es)
+	}
+	return employees, total, byEmployee, nil
+}
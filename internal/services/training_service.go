@@ -0,0 +1,313 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"app/internal/models"
+	"app/internal/repositories"
+)
+
+// DefaultCertificationNoticeDays is how far ahead a certification counts as
+// expiring when CERTIFICATION_EXPIRY_NOTICE_DAYS is not set.
+const DefaultCertificationNoticeDays = 30
+
+type TrainingService struct {
+	repo         repositories.TrainingRepository
+	employeeRepo repositories.EmployeeRepository
+	deptRepo     repositories.DepartmentRepository
+	noticeDays   int
+}
+
+func NewTrainingService(repo repositories.TrainingRepository, employeeRepo repositories.EmployeeRepository, deptRepo repositories.DepartmentRepository, noticeDays int) *TrainingService {
+	if noticeDays <= 0 {
+		noticeDays = DefaultCertificationNoticeDays
+	}
+	return &TrainingService{
+		repo:         repo,
+		employeeRepo: employeeRepo,
+		deptRepo:     deptRepo,
+		noticeDays:   noticeDays,
+	}
+}
+
+func (s *TrainingService) NoticeDays() int {
+	return s.noticeDays
+}
+
+func (s *TrainingService) ListCertificationTypes(ctx context.Context) ([]*models.CertificationType, error) {
+	return s.repo.ListCertificationTypes(ctx)
+}
+
+func (s *TrainingService) CreateCertificationType(ctx context.Context, c *models.CertificationType) error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	if c.ValidityMonths != nil && *c.ValidityMonths <= 0 {
+		return errors.New("validityMonths must be positive, or null if the certification does not expire")
+	}
+	return s.repo.CreateCertificationType(ctx, c)
+}
+
+func (s *TrainingService) RequiredCertifications(ctx context.Context, departmentID int64) ([]*models.CertificationType, error) {
+	if _, err := s.deptRepo.FindByID(ctx, departmentID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRequired(ctx, departmentID)
+}
+
+// SetRequiredCertifications replaces the certifications every member of
+// the department must hold.
+func (s *TrainingService) SetRequiredCertifications(ctx context.Context, departmentID int64, certificationTypeIDs []int64) ([]*models.CertificationType, error) {
+	if _, err := s.deptRepo.FindByID(ctx, departmentID); err != nil {
+		return nil, err
+	}
+	for _, id := range certificationTypeIDs {
+		if _, err := s.repo.FindCertificationType(ctx, id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("certification type %d does not exist", id)
+			}
+			return nil, err
+		}
+	}
+	if err := s.repo.SetRequired(ctx, departmentID, certificationTypeIDs); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRequired(ctx, departmentID)
+}
+
+func (s *TrainingService) ListCourses(ctx context.Context) ([]*models.TrainingCourse, error) {
+	return s.repo.ListCourses(ctx)
+}
+
+func (s *TrainingService) GetCourse(ctx context.Context, id int64) (*models.TrainingCourse, error) {
+	return s.repo.FindCourse(ctx, id)
+}
+
+func (s *TrainingService) CreateCourse(ctx context.Context, c *models.TrainingCourse) error {
+	c.Code = strings.TrimSpace(c.Code)
+	c.Title = strings.TrimSpace(c.Title)
+	if c.Code == "" {
+		return errors.New("code is required")
+	}
+	if c.Title == "" {
+		return errors.New("title is required")
+	}
+	if c.DurationHours != nil && c.DurationHours.Sign() <= 0 {
+		return errors.New("durationHours must be positive")
+	}
+	if c.CertificationTypeID != nil {
+		if _, err := s.repo.FindCertificationType(ctx, *c.CertificationTypeID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("certification type does not exist")
+			}
+			return err
+		}
+	}
+	return s.repo.CreateCourse(ctx, c)
+}
+
+func (s *TrainingService) ListEnrolments(ctx context.Context, courseID int64) ([]*models.CourseEnrolment, error) {
+	if _, err := s.repo.FindCourse(ctx, courseID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListEnrolmentsByCourse(ctx, courseID)
+}
+
+// Enrol signs an employee up for a course. An employee can hold only one
+// open enrolment per course at a time.
+func (s *TrainingService) Enrol(ctx context.Context, courseID, employeeID int64, enrolledOn time.Time) (*models.CourseEnrolment, error) {
+	if _, err := s.repo.FindCourse(ctx, courseID); err != nil {
+		return nil, err
+	}
+	e, err := s.employeeRepo.FindByID(ctx, employeeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("employee does not exist")
+		}
+		return nil, err
+	}
+	if e.Status == models.EmploymentStatusTerminated {
+		return nil, errors.New("cannot enrol a terminated employee")
+	}
+
+	open, err := s.repo.ListEnrolmentsByEmployee(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	for _, en := range open {
+		if en.CourseID == courseID && en.Status == models.EnrolmentStatusEnrolled {
+			return nil, errors.New("employee is already enrolled in this course")
+		}
+	}
+
+	en := &models.CourseEnrolment{
+		CourseID:   courseID,
+		EmployeeID: employeeID,
+		Status:     models.EnrolmentStatusEnrolled,
+		EnrolledOn: truncateToDate(enrolledOn),
+	}
+	if err := s.repo.CreateEnrolment(ctx, en); err != nil {
+		return nil, err
+	}
+	return s.repo.FindEnrolment(ctx, en.ID)
+}
+
+// Complete closes an open enrolment as completed or failed. Passing a
+// course that grants a certification issues it on the completion date,
+// valid for the certification type's validity period; the new certificate
+// is returned alongside the enrolment.
+func (s *TrainingService) Complete(ctx context.Context, id int64, completedOn time.Time, passed bool, score *models.Decimal) (*models.CourseEnrolment, *models.EmployeeCertification, error) {
+	en, err := s.repo.FindEnrolment(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if en.Status != models.EnrolmentStatusEnrolled {
+		return nil, nil, fmt.Errorf("enrolment is already %s", en.Status)
+	}
+	completedOn = truncateToDate(completedOn)
+	if completedOn.Before(en.EnrolledOn) {
+		return nil, nil, errors.New("completedOn cannot be before the enrolment date")
+	}
+
+	en.CompletedOn = &completedOn
+	en.Score = score
+	en.Status = models.EnrolmentStatusFailed
+
+	var cert *models.EmployeeCertification
+	if passed {
+		en.Status = models.EnrolmentStatusCompleted
+		course, err := s.repo.FindCourse(ctx, en.CourseID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if course.CertificationTypeID != nil {
+			ct, err := s.repo.FindCertificationType(ctx, *course.CertificationTypeID)
+			if err != nil {
+				return nil, nil, err
+			}
+			cert = &models.EmployeeCertification{
+				EmployeeID:          en.EmployeeID,
+				CertificationTypeID: ct.ID,
+				CertificationName:   ct.Name,
+				IssuedOn:            completedOn,
+				ExpiresOn:           certificationExpiry(ct, completedOn),
+				EnrolmentID:         &en.ID,
+			}
+		}
+	}
+
+	if err := s.repo.FinishEnrolment(ctx, en, cert); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, errors.New("enrolment is no longer open")
+		}
+		return nil, nil, err
+	}
+	return en, cert, nil
+}
+
+func (s *TrainingService) Cancel(ctx context.Context, id int64) (*models.CourseEnrolment, error) {
+	en, err := s.repo.FindEnrolment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if en.Status != models.EnrolmentStatusEnrolled {
+		return nil, fmt.Errorf("enrolment is already %s", en.Status)
+	}
+	en.Status = models.EnrolmentStatusCancelled
+	if err := s.repo.FinishEnrolment(ctx, en, nil); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("enrolment is no longer open")
+		}
+		return nil, err
+	}
+	return en, nil
+}
+
+func certificationExpiry(ct *models.CertificationType, issuedOn time.Time) *time.Time {
+	if ct.ValidityMonths == nil {
+		return nil
+	}
+	expires := issuedOn.AddDate(0, *ct.ValidityMonths, 0)
+	return &expires
+}
+
+func (s *TrainingService) EmployeeEnrolments(ctx context.Context, employeeID int64) ([]*models.CourseEnrolment, error) {
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListEnrolmentsByEmployee(ctx, employeeID)
+}
+
+func (s *TrainingService) EmployeeCertifications(ctx context.Context, employeeID int64) ([]*models.EmployeeCertification, error) {
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListCertificationsByEmployee(ctx, employeeID)
+}
+
+// AddCertification records a certificate obtained outside the company's
+// courses. ExpiresOn defaults to the certification type's validity period.
+func (s *TrainingService) AddCertification(ctx context.Context, c *models.EmployeeCertification) error {
+	if _, err := s.employeeRepo.FindByID(ctx, c.EmployeeID); err != nil {
+		return err
+	}
+	ct, err := s.repo.FindCertificationType(ctx, c.CertificationTypeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("certification type does not exist")
+		}
+		return err
+	}
+	c.IssuedOn = truncateToDate(c.IssuedOn)
+	if c.ExpiresOn == nil {
+		c.ExpiresOn = certificationExpiry(ct, c.IssuedOn)
+	} else if c.ExpiresOn.Before(c.IssuedOn) {
+		return errors.New("expiresOn cannot be before issuedOn")
+	}
+	c.CertificationName = ct.Name
+	return s.repo.CreateCertification(ctx, c)
+}
+
+// ComplianceStatus classifies a certificate against today: missing when
+// the employee holds none, expired once past its expiry date, expiring
+// when it lapses within days, valid otherwise.
+func ComplianceStatus(issuedOn, expiresOn *time.Time, today time.Time, days int) string {
+	today = truncateToDate(today)
+	switch {
+	case issuedOn == nil:
+		return models.ComplianceMissing
+	case expiresOn == nil:
+		return models.ComplianceValid
+	case expiresOn.Before(today):
+		return models.ComplianceExpired
+	case !expiresOn.After(today.AddDate(0, 0, days)):
+		return models.ComplianceExpiring
+	}
+	return models.ComplianceValid
+}
+
+// Compliance checks every current member of the department, or of every
+// department when departmentID is nil, against the certifications their
+// department requires.
+func (s *TrainingService) Compliance(ctx context.Context, departmentID *int64, today time.Time, days int) ([]*models.CertificationCompliance, error) {
+	if departmentID != nil {
+		if _, err := s.deptRepo.FindByID(ctx, *departmentID); err != nil {
+			return nil, err
+		}
+	}
+	today = truncateToDate(today)
+	items, err := s.repo.Compliance(ctx, departmentID, today)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range items {
+		c.Status = ComplianceStatus(c.IssuedOn, c.ExpiresOn, today, days)
+	}
+	return items, nil
+}
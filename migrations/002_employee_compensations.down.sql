@@ -0,0 +1,15 @@
+-- Rollback: restore the salary column from the latest effective compensation
+
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS salary NUMERIC(12,2);
+
+UPDATE employees e
+SET salary = c.amount
+FROM (
+  SELECT DISTINCT ON (employee_id) employee_id, amount
+  FROM employee_compensations
+  WHERE effective_date <= CURRENT_DATE
+  ORDER BY employee_id, effective_date DESC, id DESC
+) c
+WHERE c.employee_id = e.id;
+
+DROP TABLE IF EXISTS employee_compensations;
@@ -0,0 +1,42 @@
+-- =========================
+-- Employee compensation history
+-- =========================
+CREATE TABLE IF NOT EXISTS employee_compensations (
+  id              BIGSERIAL PRIMARY KEY,
+  employee_id     BIGINT NOT NULL,
+  effective_date  DATE NOT NULL,
+  amount          NUMERIC(12,2) NOT NULL,
+  currency        CHAR(3) NOT NULL DEFAULT 'VND',
+  reason          TEXT NOT NULL,
+  approved_by     BIGINT,
+  note            TEXT,
+  created_at      TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_compensation_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_compensation_approver
+    FOREIGN KEY (approved_by)
+    REFERENCES employees(id)
+    ON DELETE SET NULL,
+
+  CONSTRAINT chk_compensation_reason
+    CHECK (reason IN ('hire', 'promotion', 'annual_review', 'correction')),
+
+  CONSTRAINT chk_compensation_amount
+    CHECK (amount >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_employee_compensations_employee_effective
+ON employee_compensations(employee_id, effective_date DESC);
+
+-- Seed history from the salary column, then drop it: the current salary is
+-- now the latest compensation entry already in effect.
+INSERT INTO employee_compensations (employee_id, effective_date, amount, reason)
+SELECT id, created_at::date, salary, 'hire'
+FROM employees
+WHERE salary IS NOT NULL;
+
+ALTER TABLE employees DROP COLUMN IF EXISTS salary;
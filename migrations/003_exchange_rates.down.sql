@@ -0,0 +1,4 @@
+-- Rollback: drop exchange rates
+
+DROP INDEX IF EXISTS idx_employee_compensations_currency;
+DROP TABLE IF EXISTS exchange_rates;
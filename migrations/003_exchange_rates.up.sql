@@ -0,0 +1,25 @@
+-- =========================
+-- Exchange rates
+-- =========================
+-- One row per currency pair and day: 1 base_currency = rate quote_currency
+-- from effective_date until a newer row for the same pair takes over.
+CREATE TABLE IF NOT EXISTS exchange_rates (
+  id              BIGSERIAL PRIMARY KEY,
+  base_currency   CHAR(3) NOT NULL,
+  quote_currency  CHAR(3) NOT NULL,
+  rate            NUMERIC(24,10) NOT NULL,
+  effective_date  DATE NOT NULL,
+  created_at      TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT uq_exchange_rates_pair_date
+    UNIQUE (base_currency, quote_currency, effective_date),
+
+  CONSTRAINT chk_exchange_rates_rate
+    CHECK (rate > 0),
+
+  CONSTRAINT chk_exchange_rates_pair
+    CHECK (base_currency <> quote_currency)
+);
+
+CREATE INDEX IF NOT EXISTS idx_employee_compensations_currency
+ON employee_compensations(currency);
@@ -0,0 +1,9 @@
+-- Rollback: drop tables in correct order (child -> parent)
+
+DROP TABLE IF EXISTS salary_bands;
+
+ALTER TABLE employees DROP CONSTRAINT IF EXISTS fk_employee_position;
+DROP INDEX IF EXISTS idx_employees_position_id;
+ALTER TABLE employees DROP COLUMN IF EXISTS position_id;
+
+DROP TABLE IF EXISTS positions;
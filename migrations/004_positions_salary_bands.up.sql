@@ -0,0 +1,54 @@
+-- =========================
+-- Positions
+-- =========================
+CREATE TABLE IF NOT EXISTS positions (
+  id          BIGSERIAL PRIMARY KEY,
+  code        TEXT NOT NULL UNIQUE,
+  title       TEXT NOT NULL,
+  level       INT,
+  created_at  TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at  TIMESTAMP NOT NULL DEFAULT now()
+);
+
+ALTER TABLE employees
+  ADD COLUMN IF NOT EXISTS position_id BIGINT,
+  ADD CONSTRAINT fk_employee_position
+    FOREIGN KEY (position_id)
+    REFERENCES positions(id)
+    ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_employees_position_id
+ON employees(position_id);
+
+-- =========================
+-- Salary bands
+-- =========================
+-- department_id NULL is the company-wide band for a position; a row for a
+-- specific department overrides it for that department's employees.
+CREATE TABLE IF NOT EXISTS salary_bands (
+  id             BIGSERIAL PRIMARY KEY,
+  position_id    BIGINT NOT NULL,
+  department_id  BIGINT,
+  min_amount     NUMERIC(12,2) NOT NULL,
+  mid_amount     NUMERIC(12,2) NOT NULL,
+  max_amount     NUMERIC(12,2) NOT NULL,
+  currency       CHAR(3) NOT NULL DEFAULT 'VND',
+  created_at     TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at     TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_salary_band_position
+    FOREIGN KEY (position_id)
+    REFERENCES positions(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_salary_band_department
+    FOREIGN KEY (department_id)
+    REFERENCES departments(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT chk_salary_band_range
+    CHECK (min_amount <= mid_amount AND mid_amount <= max_amount)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_salary_bands_position_department
+ON salary_bands(position_id, COALESCE(department_id, 0));
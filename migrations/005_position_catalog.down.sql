@@ -0,0 +1,11 @@
+-- Rollback: restore free-text position from the catalog title
+
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS position TEXT;
+
+UPDATE employees e
+SET position = p.title
+FROM positions p
+WHERE p.id = e.position_id;
+
+DROP TABLE IF EXISTS position_aliases;
+ALTER TABLE positions DROP COLUMN IF EXISTS job_family;
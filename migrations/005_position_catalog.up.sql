@@ -0,0 +1,47 @@
+-- =========================
+-- Position catalog
+-- =========================
+ALTER TABLE positions ADD COLUMN IF NOT EXISTS job_family TEXT;
+
+-- Free-text spellings that resolve to a catalog position, stored lowercased.
+CREATE TABLE IF NOT EXISTS position_aliases (
+  alias        TEXT PRIMARY KEY,
+  position_id  BIGINT NOT NULL,
+  created_at   TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_position_alias_position
+    FOREIGN KEY (position_id)
+    REFERENCES positions(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_position_aliases_position_id
+ON position_aliases(position_id);
+
+-- One catalog entry per distinct free-text position (ignoring case and
+-- surrounding spaces). Near duplicates such as "Leader" / "lead" end up as
+-- separate entries; merge them afterwards with POST /positions/{id}/merge.
+INSERT INTO positions (code, title)
+SELECT DISTINCT ON (lower(btrim(position)))
+  upper(btrim(regexp_replace(btrim(position), '[^A-Za-z0-9]+', '_', 'g'), '_')),
+  btrim(position)
+FROM employees
+WHERE position IS NOT NULL AND btrim(position) <> ''
+ORDER BY lower(btrim(position)), position
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO position_aliases (alias, position_id)
+SELECT DISTINCT lower(btrim(e.position)), p.id
+FROM employees e
+JOIN positions p
+  ON p.code = upper(btrim(regexp_replace(btrim(e.position), '[^A-Za-z0-9]+', '_', 'g'), '_'))
+WHERE e.position IS NOT NULL AND btrim(e.position) <> ''
+ON CONFLICT (alias) DO NOTHING;
+
+UPDATE employees e
+SET position_id = a.position_id
+FROM position_aliases a
+WHERE e.position_id IS NULL
+  AND a.alias = lower(btrim(e.position));
+
+ALTER TABLE employees DROP COLUMN IF EXISTS position;
@@ -0,0 +1,10 @@
+-- Rollback: drop tables in correct order (child -> parent)
+
+DROP TABLE IF EXISTS payslip_lines;
+DROP TABLE IF EXISTS payslips;
+DROP TABLE IF EXISTS payroll_runs;
+DROP FUNCTION IF EXISTS reject_payroll_change();
+
+ALTER TABLE employees
+  DROP COLUMN IF EXISTS termination_date,
+  DROP COLUMN IF EXISTS hire_date;
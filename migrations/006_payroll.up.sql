@@ -0,0 +1,98 @@
+-- =========================
+-- Employment dates used for proration
+-- =========================
+ALTER TABLE employees
+  ADD COLUMN IF NOT EXISTS hire_date DATE,
+  ADD COLUMN IF NOT EXISTS termination_date DATE;
+
+UPDATE employees SET hire_date = created_at::date WHERE hire_date IS NULL;
+
+ALTER TABLE employees
+  ALTER COLUMN hire_date SET NOT NULL,
+  ALTER COLUMN hire_date SET DEFAULT CURRENT_DATE;
+
+-- =========================
+-- Payroll runs & payslips
+-- =========================
+CREATE TABLE IF NOT EXISTS payroll_runs (
+  id              BIGSERIAL PRIMARY KEY,
+  period          CHAR(7) NOT NULL UNIQUE, -- YYYY-MM
+  currency        CHAR(3) NOT NULL,
+  employee_count  INT NOT NULL,
+  total_gross     NUMERIC(16,2) NOT NULL,
+  total_net       NUMERIC(16,2) NOT NULL,
+  created_at      TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS payslips (
+  id                BIGSERIAL PRIMARY KEY,
+  payroll_run_id    BIGINT NOT NULL,
+  employee_id       BIGINT NOT NULL,
+  employee_name     TEXT NOT NULL,
+  department_id     BIGINT NOT NULL,
+  period            CHAR(7) NOT NULL,
+  currency          CHAR(3) NOT NULL,
+  base_salary       NUMERIC(14,2) NOT NULL,
+  working_days      INT NOT NULL,
+  paid_days         INT NOT NULL,
+  gross             NUMERIC(14,2) NOT NULL,
+  social_insurance  NUMERIC(14,2) NOT NULL,
+  taxable_income    NUMERIC(14,2) NOT NULL,
+  income_tax        NUMERIC(14,2) NOT NULL,
+  deductions        NUMERIC(14,2) NOT NULL,
+  net               NUMERIC(14,2) NOT NULL,
+  created_at        TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_payslip_run
+    FOREIGN KEY (payroll_run_id)
+    REFERENCES payroll_runs(id)
+    ON DELETE RESTRICT,
+
+  CONSTRAINT fk_payslip_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE RESTRICT,
+
+  CONSTRAINT uq_payslips_employee_period
+    UNIQUE (employee_id, period)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payslips_run_id
+ON payslips(payroll_run_id);
+
+-- kind: earning | contribution | tax | deduction
+CREATE TABLE IF NOT EXISTS payslip_lines (
+  id          BIGSERIAL PRIMARY KEY,
+  payslip_id  BIGINT NOT NULL,
+  kind        TEXT NOT NULL,
+  code        TEXT NOT NULL,
+  label       TEXT NOT NULL,
+  amount      NUMERIC(14,2) NOT NULL,
+
+  CONSTRAINT fk_payslip_line_payslip
+    FOREIGN KEY (payslip_id)
+    REFERENCES payslips(id)
+    ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_payslip_lines_payslip_id
+ON payslip_lines(payslip_id);
+
+-- Payroll records are immutable once written.
+CREATE OR REPLACE FUNCTION reject_payroll_change() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION '% is immutable', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_payroll_runs_immutable
+BEFORE UPDATE OR DELETE ON payroll_runs
+FOR EACH ROW EXECUTE FUNCTION reject_payroll_change();
+
+CREATE TRIGGER trg_payslips_immutable
+BEFORE UPDATE OR DELETE ON payslips
+FOR EACH ROW EXECUTE FUNCTION reject_payroll_change();
+
+CREATE TRIGGER trg_payslip_lines_immutable
+BEFORE UPDATE OR DELETE ON payslip_lines
+FOR EACH ROW EXECUTE FUNCTION reject_payroll_change();
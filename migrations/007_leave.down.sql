@@ -0,0 +1,11 @@
+-- Rollback: drop tables in correct order (child -> parent)
+
+DROP TABLE IF EXISTS leave_requests;
+DROP TABLE IF EXISTS leave_accrual_policies;
+DROP TABLE IF EXISTS leave_types;
+
+ALTER TABLE departments DROP CONSTRAINT IF EXISTS fk_department_head;
+ALTER TABLE departments DROP COLUMN IF EXISTS head_employee_id;
+
+ALTER TABLE employees DROP CONSTRAINT IF EXISTS fk_employee_manager;
+ALTER TABLE employees DROP COLUMN IF EXISTS manager_id;
@@ -0,0 +1,107 @@
+-- =========================
+-- Reporting lines
+-- =========================
+ALTER TABLE employees
+  ADD COLUMN IF NOT EXISTS manager_id BIGINT,
+  ADD CONSTRAINT fk_employee_manager
+    FOREIGN KEY (manager_id)
+    REFERENCES employees(id)
+    ON DELETE SET NULL;
+
+ALTER TABLE departments
+  ADD COLUMN IF NOT EXISTS head_employee_id BIGINT,
+  ADD CONSTRAINT fk_department_head
+    FOREIGN KEY (head_employee_id)
+    REFERENCES employees(id)
+    ON DELETE SET NULL;
+
+-- =========================
+-- Leave types & accrual
+-- =========================
+CREATE TABLE IF NOT EXISTS leave_types (
+  id                BIGSERIAL PRIMARY KEY,
+  code              TEXT NOT NULL UNIQUE,
+  name              TEXT NOT NULL,
+  paid              BOOLEAN NOT NULL DEFAULT true,
+  requires_balance  BOOLEAN NOT NULL DEFAULT true,
+  created_at        TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- Entitlement per year: the policy with the highest min_years_of_service
+-- the employee has reached applies.
+CREATE TABLE IF NOT EXISTS leave_accrual_policies (
+  id                    BIGSERIAL PRIMARY KEY,
+  leave_type_id         BIGINT NOT NULL,
+  min_years_of_service  INT NOT NULL DEFAULT 0,
+  days_per_year         NUMERIC(5,1) NOT NULL,
+
+  CONSTRAINT fk_accrual_leave_type
+    FOREIGN KEY (leave_type_id)
+    REFERENCES leave_types(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT uq_accrual_type_years
+    UNIQUE (leave_type_id, min_years_of_service)
+);
+
+INSERT INTO leave_types (code, name, paid, requires_balance) VALUES
+  ('ANNUAL', 'Annual leave', true, true),
+  ('SICK', 'Sick leave', true, true),
+  ('UNPAID', 'Unpaid leave', false, false)
+ON CONFLICT (code) DO NOTHING;
+
+-- Labour Code: 12 days, plus one day for every 5 years of service.
+INSERT INTO leave_accrual_policies (leave_type_id, min_years_of_service, days_per_year)
+SELECT t.id, p.years, p.days
+FROM leave_types t
+JOIN (VALUES
+  ('ANNUAL', 0, 12), ('ANNUAL', 5, 13), ('ANNUAL', 10, 14), ('ANNUAL', 15, 15),
+  ('SICK', 0, 30)
+) AS p(code, years, days) ON p.code = t.code
+ON CONFLICT (leave_type_id, min_years_of_service) DO NOTHING;
+
+-- =========================
+-- Leave requests
+-- =========================
+CREATE TABLE IF NOT EXISTS leave_requests (
+  id             BIGSERIAL PRIMARY KEY,
+  employee_id    BIGINT NOT NULL,
+  leave_type_id  BIGINT NOT NULL,
+  start_date     DATE NOT NULL,
+  end_date       DATE NOT NULL,
+  days           NUMERIC(5,1) NOT NULL,
+  status         TEXT NOT NULL DEFAULT 'pending',
+  reason         TEXT,
+  approver_id    BIGINT,
+  decided_at     TIMESTAMP,
+  decision_note  TEXT,
+  created_at     TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at     TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_leave_request_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_leave_request_type
+    FOREIGN KEY (leave_type_id)
+    REFERENCES leave_types(id)
+    ON DELETE RESTRICT,
+
+  CONSTRAINT fk_leave_request_approver
+    FOREIGN KEY (approver_id)
+    REFERENCES employees(id)
+    ON DELETE SET NULL,
+
+  CONSTRAINT chk_leave_request_dates
+    CHECK (end_date >= start_date),
+
+  CONSTRAINT chk_leave_request_status
+    CHECK (status IN ('pending', 'approved', 'rejected', 'cancelled'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_leave_requests_employee_dates
+ON leave_requests(employee_id, start_date, end_date);
+
+CREATE INDEX IF NOT EXISTS idx_leave_requests_status
+ON leave_requests(status);
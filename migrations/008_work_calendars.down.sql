@@ -0,0 +1,7 @@
+-- Rollback: drop tables in correct order (child -> parent)
+
+ALTER TABLE departments DROP CONSTRAINT IF EXISTS fk_department_calendar;
+ALTER TABLE departments DROP COLUMN IF EXISTS calendar_id;
+
+DROP TABLE IF EXISTS calendar_days;
+DROP TABLE IF EXISTS work_calendars;
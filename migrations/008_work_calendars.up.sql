@@ -0,0 +1,50 @@
+-- =========================
+-- Working calendars
+-- =========================
+-- weekend_days uses Go/Postgres DOW numbering: 0 = Sunday ... 6 = Saturday.
+CREATE TABLE IF NOT EXISTS work_calendars (
+  id            BIGSERIAL PRIMARY KEY,
+  name          TEXT NOT NULL UNIQUE,
+  country       CHAR(2),
+  weekend_days  INT[] NOT NULL DEFAULT '{0,6}',
+  is_default    BOOLEAN NOT NULL DEFAULT false,
+  created_at    TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at    TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- At most one calendar applies to departments without their own.
+CREATE UNIQUE INDEX IF NOT EXISTS uq_work_calendars_default
+ON work_calendars(is_default) WHERE is_default;
+
+-- Exceptions to the weekly pattern: public holidays and company days off
+-- are non-working, working_day marks a weekend day worked in lieu.
+CREATE TABLE IF NOT EXISTS calendar_days (
+  id           BIGSERIAL PRIMARY KEY,
+  calendar_id  BIGINT NOT NULL,
+  date         DATE NOT NULL,
+  kind         TEXT NOT NULL DEFAULT 'holiday',
+  name         TEXT NOT NULL,
+  created_at   TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_calendar_day_calendar
+    FOREIGN KEY (calendar_id)
+    REFERENCES work_calendars(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT uq_calendar_day
+    UNIQUE (calendar_id, date),
+
+  CONSTRAINT chk_calendar_day_kind
+    CHECK (kind IN ('holiday', 'day_off', 'working_day'))
+);
+
+ALTER TABLE departments
+  ADD COLUMN IF NOT EXISTS calendar_id BIGINT,
+  ADD CONSTRAINT fk_department_calendar
+    FOREIGN KEY (calendar_id)
+    REFERENCES work_calendars(id)
+    ON DELETE SET NULL;
+
+INSERT INTO work_calendars (name, country, weekend_days, is_default)
+VALUES ('Vietnam', 'VN', '{0,6}', true)
+ON CONFLICT (name) DO NOTHING;
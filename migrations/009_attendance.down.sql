@@ -0,0 +1,8 @@
+-- Rollback: drop tables in correct order (child -> parent)
+
+DROP TABLE IF EXISTS attendance_records;
+
+ALTER TABLE departments DROP CONSTRAINT IF EXISTS fk_department_default_shift;
+ALTER TABLE departments DROP COLUMN IF EXISTS default_shift_id;
+
+DROP TABLE IF EXISTS shifts;
@@ -0,0 +1,63 @@
+-- =========================
+-- Shifts
+-- =========================
+-- A shift whose end_time is not after start_time ends on the next day.
+CREATE TABLE IF NOT EXISTS shifts (
+  id             BIGSERIAL PRIMARY KEY,
+  name           TEXT NOT NULL UNIQUE,
+  start_time     TIME NOT NULL,
+  end_time       TIME NOT NULL,
+  break_minutes  INT NOT NULL DEFAULT 0,
+  grace_minutes  INT NOT NULL DEFAULT 0,
+  created_at     TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at     TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT chk_shift_minutes
+    CHECK (break_minutes >= 0 AND grace_minutes >= 0)
+);
+
+ALTER TABLE departments
+  ADD COLUMN IF NOT EXISTS default_shift_id BIGINT,
+  ADD CONSTRAINT fk_department_default_shift
+    FOREIGN KEY (default_shift_id)
+    REFERENCES shifts(id)
+    ON DELETE SET NULL;
+
+-- =========================
+-- Attendance
+-- =========================
+CREATE TABLE IF NOT EXISTS attendance_records (
+  id           BIGSERIAL PRIMARY KEY,
+  employee_id  BIGINT NOT NULL,
+  work_date    DATE NOT NULL,
+  clock_in     TIMESTAMPTZ NOT NULL,
+  clock_out    TIMESTAMPTZ,
+  source       TEXT NOT NULL DEFAULT 'clock',
+  shift_id     BIGINT,
+  note         TEXT,
+  created_at   TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at   TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_attendance_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_attendance_shift
+    FOREIGN KEY (shift_id)
+    REFERENCES shifts(id)
+    ON DELETE SET NULL,
+
+  CONSTRAINT chk_attendance_times
+    CHECK (clock_out IS NULL OR clock_out > clock_in),
+
+  CONSTRAINT chk_attendance_source
+    CHECK (source IN ('clock', 'manual'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_attendance_employee_date
+ON attendance_records(employee_id, work_date);
+
+-- Only one open clock-in per employee.
+CREATE UNIQUE INDEX IF NOT EXISTS uq_attendance_open
+ON attendance_records(employee_id) WHERE clock_out IS NULL;
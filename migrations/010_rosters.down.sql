@@ -0,0 +1,3 @@
+-- Rollback
+
+DROP TABLE IF EXISTS roster_assignments;
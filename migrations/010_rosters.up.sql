@@ -0,0 +1,29 @@
+-- =========================
+-- Weekly rosters
+-- =========================
+-- An employee works shift_id on work_date; overnight shifts belong to the
+-- date they start on.
+CREATE TABLE IF NOT EXISTS roster_assignments (
+  id           BIGSERIAL PRIMARY KEY,
+  employee_id  BIGINT NOT NULL,
+  shift_id     BIGINT NOT NULL,
+  work_date    DATE NOT NULL,
+  note         TEXT,
+  created_at   TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_roster_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_roster_shift
+    FOREIGN KEY (shift_id)
+    REFERENCES shifts(id)
+    ON DELETE RESTRICT,
+
+  CONSTRAINT uq_roster_assignment
+    UNIQUE (employee_id, work_date, shift_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_roster_assignments_date
+ON roster_assignments(work_date);
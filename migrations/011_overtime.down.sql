@@ -0,0 +1,3 @@
+-- Rollback
+
+DROP TABLE IF EXISTS overtime_items;
@@ -0,0 +1,41 @@
+-- =========================
+-- Overtime items
+-- =========================
+-- One row per employee and work date, computed from attendance by the
+-- overtime rules. minutes is payable overtime after the monthly cap;
+-- capped_minutes is what the cap cut off. Approved items feed the payroll
+-- run of their period.
+-- status: pending | approved | rejected
+CREATE TABLE IF NOT EXISTS overtime_items (
+  id              BIGSERIAL PRIMARY KEY,
+  employee_id     BIGINT NOT NULL,
+  period          CHAR(7) NOT NULL, -- YYYY-MM
+  work_date       DATE NOT NULL,
+  day_kind        TEXT NOT NULL,    -- weekday | weekend | holiday
+  worked_minutes  INT NOT NULL,
+  minutes         INT NOT NULL,
+  capped_minutes  INT NOT NULL DEFAULT 0,
+  rate            NUMERIC(4,2) NOT NULL,
+  status          TEXT NOT NULL DEFAULT 'pending',
+  approver_id     BIGINT,
+  decided_at      TIMESTAMP,
+  decision_note   TEXT,
+  created_at      TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at      TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_overtime_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_overtime_approver
+    FOREIGN KEY (approver_id)
+    REFERENCES employees(id)
+    ON DELETE SET NULL,
+
+  CONSTRAINT uq_overtime_employee_date
+    UNIQUE (employee_id, work_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_overtime_items_period
+ON overtime_items(period, status);
@@ -0,0 +1,9 @@
+-- Rollback: drop tables in correct order (child -> parent)
+
+DROP TABLE IF EXISTS employment_events;
+
+ALTER TABLE employees DROP CONSTRAINT IF EXISTS chk_employee_status;
+ALTER TABLE employees
+  DROP COLUMN IF EXISTS termination_reason,
+  DROP COLUMN IF EXISTS probation_end_date,
+  DROP COLUMN IF EXISTS status;
@@ -0,0 +1,63 @@
+-- =========================
+-- Employment status
+-- =========================
+-- status: candidate | probation | active | on_leave | terminated
+ALTER TABLE employees
+  ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'active',
+  ADD COLUMN IF NOT EXISTS probation_end_date DATE,
+  ADD COLUMN IF NOT EXISTS termination_reason TEXT;
+
+UPDATE employees SET status = 'terminated'
+WHERE termination_date IS NOT NULL AND termination_date < CURRENT_DATE;
+
+ALTER TABLE employees
+  ADD CONSTRAINT chk_employee_status
+    CHECK (status IN ('candidate', 'probation', 'active', 'on_leave', 'terminated'));
+
+-- =========================
+-- Employment history
+-- =========================
+-- kind: hire | status_change | transfer | termination | rehire
+-- Events dated in the future stay pending (applied_at NULL) until a
+-- background job applies them on their effective date.
+CREATE TABLE IF NOT EXISTS employment_events (
+  id                  BIGSERIAL PRIMARY KEY,
+  employee_id         BIGINT NOT NULL,
+  kind                TEXT NOT NULL,
+  effective_date      DATE NOT NULL,
+  from_status         TEXT,
+  to_status           TEXT,
+  from_department_id  BIGINT,
+  to_department_id    BIGINT,
+  to_manager_id       BIGINT,
+  reason              TEXT,
+  applied_at          TIMESTAMP,
+  created_at          TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_employment_event_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_employment_event_to_department
+    FOREIGN KEY (to_department_id)
+    REFERENCES departments(id)
+    ON DELETE RESTRICT,
+
+  CONSTRAINT fk_employment_event_to_manager
+    FOREIGN KEY (to_manager_id)
+    REFERENCES employees(id)
+    ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_employment_events_employee
+ON employment_events(employee_id, effective_date);
+
+CREATE INDEX IF NOT EXISTS idx_employment_events_pending
+ON employment_events(effective_date)
+WHERE applied_at IS NULL;
+
+-- Existing employees start their history with a hire.
+INSERT INTO employment_events (employee_id, kind, effective_date, to_status, to_department_id, applied_at)
+SELECT id, 'hire', hire_date, 'active', department_id, now()
+FROM employees;
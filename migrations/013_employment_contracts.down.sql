@@ -0,0 +1,4 @@
+-- Rollback: drop tables in correct order (child -> parent)
+
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS employment_contracts;
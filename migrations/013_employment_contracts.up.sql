@@ -0,0 +1,66 @@
+-- =========================
+-- Employment contracts
+-- =========================
+-- contract_type: fixed_term | indefinite | internship
+-- Indefinite contracts have no end date; the others must have one.
+CREATE TABLE IF NOT EXISTS employment_contracts (
+  id               BIGSERIAL PRIMARY KEY,
+  employee_id      BIGINT NOT NULL,
+  contract_number  TEXT,
+  contract_type    TEXT NOT NULL,
+  start_date       DATE NOT NULL,
+  end_date         DATE,
+  signed_date      DATE,
+  document_ref     TEXT,
+  note             TEXT,
+  created_at       TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at       TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_contract_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT chk_contract_type
+    CHECK (contract_type IN ('fixed_term', 'indefinite', 'internship')),
+
+  CONSTRAINT chk_contract_end_date
+    CHECK ((contract_type = 'indefinite') = (end_date IS NULL)),
+
+  CONSTRAINT chk_contract_dates
+    CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_employment_contracts_employee
+ON employment_contracts(employee_id, start_date);
+
+CREATE INDEX IF NOT EXISTS idx_employment_contracts_end_date
+ON employment_contracts(end_date)
+WHERE end_date IS NOT NULL;
+
+-- =========================
+-- Notifications
+-- =========================
+-- dedupe_key keeps background checks from raising the same notification
+-- twice, e.g. contract_expiring:12:30.
+CREATE TABLE IF NOT EXISTS notifications (
+  id           BIGSERIAL PRIMARY KEY,
+  kind         TEXT NOT NULL,
+  employee_id  BIGINT,
+  message      TEXT NOT NULL,
+  dedupe_key   TEXT,
+  read_at      TIMESTAMP,
+  created_at   TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_notification_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT uq_notification_dedupe_key
+    UNIQUE (dedupe_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_unread
+ON notifications(created_at)
+WHERE read_at IS NULL;
@@ -0,0 +1,6 @@
+-- Rollback: drop tables in correct order (child -> parent)
+
+DROP TABLE IF EXISTS checklist_tasks;
+DROP TABLE IF EXISTS checklists;
+DROP TABLE IF EXISTS checklist_template_tasks;
+DROP TABLE IF EXISTS checklist_templates;
@@ -0,0 +1,108 @@
+-- =========================
+-- Checklist templates
+-- =========================
+-- kind: onboarding | offboarding
+-- A template without department_id is the company-wide fallback for
+-- departments that have none of their own.
+CREATE TABLE IF NOT EXISTS checklist_templates (
+  id             BIGSERIAL PRIMARY KEY,
+  department_id  BIGINT,
+  kind           TEXT NOT NULL,
+  name           TEXT NOT NULL,
+  created_at     TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at     TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_checklist_template_department
+    FOREIGN KEY (department_id)
+    REFERENCES departments(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT chk_checklist_template_kind
+    CHECK (kind IN ('onboarding', 'offboarding'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_checklist_templates_department_kind
+ON checklist_templates(COALESCE(department_id, 0), kind);
+
+-- assignee_role: employee | manager | department_head, resolved when the
+-- checklist is created; assignee_id names a fixed person (e.g. IT) instead.
+-- due_offset_days counts from the hire date or the last working day.
+CREATE TABLE IF NOT EXISTS checklist_template_tasks (
+  id               BIGSERIAL PRIMARY KEY,
+  template_id      BIGINT NOT NULL,
+  position         INT NOT NULL,
+  title            TEXT NOT NULL,
+  assignee_role    TEXT,
+  assignee_id      BIGINT,
+  due_offset_days  INT NOT NULL DEFAULT 0,
+
+  CONSTRAINT fk_checklist_template_task_template
+    FOREIGN KEY (template_id)
+    REFERENCES checklist_templates(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_checklist_template_task_assignee
+    FOREIGN KEY (assignee_id)
+    REFERENCES employees(id)
+    ON DELETE SET NULL,
+
+  CONSTRAINT chk_checklist_template_task_role
+    CHECK (assignee_role IS NULL OR assignee_role IN ('employee', 'manager', 'department_head'))
+);
+
+-- =========================
+-- Checklists
+-- =========================
+CREATE TABLE IF NOT EXISTS checklists (
+  id            BIGSERIAL PRIMARY KEY,
+  employee_id   BIGINT NOT NULL,
+  template_id   BIGINT,
+  kind          TEXT NOT NULL,
+  anchor_date   DATE NOT NULL,
+  created_at    TIMESTAMP NOT NULL DEFAULT now(),
+  completed_at  TIMESTAMP,
+
+  CONSTRAINT fk_checklist_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_checklist_template
+    FOREIGN KEY (template_id)
+    REFERENCES checklist_templates(id)
+    ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_checklists_employee
+ON checklists(employee_id);
+
+CREATE TABLE IF NOT EXISTS checklist_tasks (
+  id            BIGSERIAL PRIMARY KEY,
+  checklist_id  BIGINT NOT NULL,
+  position      INT NOT NULL,
+  title         TEXT NOT NULL,
+  assignee_id   BIGINT,
+  due_date      DATE NOT NULL,
+  completed_at  TIMESTAMP,
+  completed_by  BIGINT,
+  note          TEXT,
+
+  CONSTRAINT fk_checklist_task_checklist
+    FOREIGN KEY (checklist_id)
+    REFERENCES checklists(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_checklist_task_assignee
+    FOREIGN KEY (assignee_id)
+    REFERENCES employees(id)
+    ON DELETE SET NULL,
+
+  CONSTRAINT fk_checklist_task_completed_by
+    FOREIGN KEY (completed_by)
+    REFERENCES employees(id)
+    ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_checklist_tasks_open
+ON checklist_tasks(due_date)
+WHERE completed_at IS NULL;
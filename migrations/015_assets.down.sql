@@ -0,0 +1,4 @@
+-- Rollback: drop tables in correct order (child -> parent)
+
+DROP TABLE IF EXISTS asset_assignments;
+DROP TABLE IF EXISTS assets;
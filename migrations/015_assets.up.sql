@@ -0,0 +1,63 @@
+-- =========================
+-- Assets
+-- =========================
+-- kind: laptop | phone | badge | monitor | other
+-- status: available | assigned | retired | lost
+CREATE TABLE IF NOT EXISTS assets (
+  id             BIGSERIAL PRIMARY KEY,
+  asset_tag      TEXT NOT NULL,
+  kind           TEXT NOT NULL,
+  name           TEXT NOT NULL,
+  serial_number  TEXT,
+  status         TEXT NOT NULL DEFAULT 'available',
+  purchase_date  DATE,
+  note           TEXT,
+  created_at     TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at     TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT uq_asset_tag UNIQUE (asset_tag),
+  CONSTRAINT uq_asset_serial_number UNIQUE (serial_number),
+
+  CONSTRAINT chk_asset_kind
+    CHECK (kind IN ('laptop', 'phone', 'badge', 'monitor', 'other')),
+
+  CONSTRAINT chk_asset_status
+    CHECK (status IN ('available', 'assigned', 'retired', 'lost'))
+);
+
+-- =========================
+-- Asset assignments
+-- =========================
+-- One row per hand-over; returned_on stays NULL while the employee holds
+-- the asset, so an asset has at most one open assignment.
+CREATE TABLE IF NOT EXISTS asset_assignments (
+  id              BIGSERIAL PRIMARY KEY,
+  asset_id        BIGINT NOT NULL,
+  employee_id     BIGINT NOT NULL,
+  assigned_on     DATE NOT NULL,
+  returned_on     DATE,
+  condition_out   TEXT,
+  condition_in    TEXT,
+  note            TEXT,
+  created_at      TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_asset_assignment_asset
+    FOREIGN KEY (asset_id)
+    REFERENCES assets(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_asset_assignment_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE RESTRICT,
+
+  CONSTRAINT chk_asset_assignment_dates
+    CHECK (returned_on IS NULL OR returned_on >= assigned_on)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_asset_assignments_open
+ON asset_assignments(asset_id)
+WHERE returned_on IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_asset_assignments_employee
+ON asset_assignments(employee_id, assigned_on);
@@ -0,0 +1,5 @@
+-- Rollback: drop tables in correct order (child -> parent)
+
+DROP TABLE IF EXISTS skill_endorsements;
+DROP TABLE IF EXISTS employee_skills;
+DROP TABLE IF EXISTS skills;
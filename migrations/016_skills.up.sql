@@ -0,0 +1,67 @@
+-- =========================
+-- Skill catalog
+-- =========================
+CREATE TABLE IF NOT EXISTS skills (
+  id          BIGSERIAL PRIMARY KEY,
+  name        TEXT NOT NULL,
+  category    TEXT,
+  created_at  TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_skills_name
+ON skills(LOWER(name));
+
+-- =========================
+-- Employee skills
+-- =========================
+-- level: 1 (basic) .. 5 (expert)
+CREATE TABLE IF NOT EXISTS employee_skills (
+  employee_id  BIGINT NOT NULL,
+  skill_id     BIGINT NOT NULL,
+  level        SMALLINT NOT NULL,
+  updated_at   TIMESTAMP NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (employee_id, skill_id),
+
+  CONSTRAINT fk_employee_skill_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_employee_skill_skill
+    FOREIGN KEY (skill_id)
+    REFERENCES skills(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT chk_employee_skill_level
+    CHECK (level BETWEEN 1 AND 5)
+);
+
+CREATE INDEX IF NOT EXISTS idx_employee_skills_skill
+ON employee_skills(skill_id, level);
+
+-- =========================
+-- Endorsements
+-- =========================
+CREATE TABLE IF NOT EXISTS skill_endorsements (
+  employee_id  BIGINT NOT NULL,
+  skill_id     BIGINT NOT NULL,
+  endorser_id  BIGINT NOT NULL,
+  comment      TEXT,
+  created_at   TIMESTAMP NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (employee_id, skill_id, endorser_id),
+
+  CONSTRAINT fk_skill_endorsement_employee_skill
+    FOREIGN KEY (employee_id, skill_id)
+    REFERENCES employee_skills(employee_id, skill_id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_skill_endorsement_endorser
+    FOREIGN KEY (endorser_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT chk_skill_endorsement_self
+    CHECK (endorser_id <> employee_id)
+);
@@ -0,0 +1,7 @@
+-- Rollback: drop tables in correct order (child -> parent)
+
+DROP TABLE IF EXISTS employee_certifications;
+DROP TABLE IF EXISTS course_enrolments;
+DROP TABLE IF EXISTS training_courses;
+DROP TABLE IF EXISTS department_required_certifications;
+DROP TABLE IF EXISTS certification_types;
@@ -0,0 +1,126 @@
+-- =========================
+-- Certifications
+-- =========================
+-- validity_months NULL means the certification does not expire.
+CREATE TABLE IF NOT EXISTS certification_types (
+  id               BIGSERIAL PRIMARY KEY,
+  name             TEXT NOT NULL,
+  validity_months  INT,
+  created_at       TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT chk_certification_type_validity
+    CHECK (validity_months IS NULL OR validity_months > 0)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_certification_types_name
+ON certification_types(LOWER(name));
+
+-- Certifications every member of the department must hold.
+CREATE TABLE IF NOT EXISTS department_required_certifications (
+  department_id          BIGINT NOT NULL,
+  certification_type_id  BIGINT NOT NULL,
+
+  PRIMARY KEY (department_id, certification_type_id),
+
+  CONSTRAINT fk_required_certification_department
+    FOREIGN KEY (department_id)
+    REFERENCES departments(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_required_certification_type
+    FOREIGN KEY (certification_type_id)
+    REFERENCES certification_types(id)
+    ON DELETE CASCADE
+);
+
+-- =========================
+-- Training courses
+-- =========================
+-- Completing a course that grants a certification issues it to the
+-- employee.
+CREATE TABLE IF NOT EXISTS training_courses (
+  id                     BIGSERIAL PRIMARY KEY,
+  code                   TEXT NOT NULL,
+  title                  TEXT NOT NULL,
+  provider               TEXT,
+  duration_hours         NUMERIC(6,2),
+  certification_type_id  BIGINT,
+  created_at             TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at             TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT uq_training_course_code UNIQUE (code),
+
+  CONSTRAINT fk_training_course_certification
+    FOREIGN KEY (certification_type_id)
+    REFERENCES certification_types(id)
+    ON DELETE SET NULL
+);
+
+-- status: enrolled | completed | failed | cancelled
+CREATE TABLE IF NOT EXISTS course_enrolments (
+  id            BIGSERIAL PRIMARY KEY,
+  course_id     BIGINT NOT NULL,
+  employee_id   BIGINT NOT NULL,
+  status        TEXT NOT NULL DEFAULT 'enrolled',
+  enrolled_on   DATE NOT NULL,
+  completed_on  DATE,
+  score         NUMERIC(5,2),
+  created_at    TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at    TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_course_enrolment_course
+    FOREIGN KEY (course_id)
+    REFERENCES training_courses(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_course_enrolment_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT chk_course_enrolment_status
+    CHECK (status IN ('enrolled', 'completed', 'failed', 'cancelled'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_course_enrolments_open
+ON course_enrolments(course_id, employee_id)
+WHERE status = 'enrolled';
+
+CREATE INDEX IF NOT EXISTS idx_course_enrolments_employee
+ON course_enrolments(employee_id);
+
+-- =========================
+-- Employee certifications
+-- =========================
+CREATE TABLE IF NOT EXISTS employee_certifications (
+  id                     BIGSERIAL PRIMARY KEY,
+  employee_id            BIGINT NOT NULL,
+  certification_type_id  BIGINT NOT NULL,
+  issued_on              DATE NOT NULL,
+  expires_on             DATE,
+  certificate_number     TEXT,
+  document_ref           TEXT,
+  enrolment_id           BIGINT,
+  created_at             TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_employee_certification_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_employee_certification_type
+    FOREIGN KEY (certification_type_id)
+    REFERENCES certification_types(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_employee_certification_enrolment
+    FOREIGN KEY (enrolment_id)
+    REFERENCES course_enrolments(id)
+    ON DELETE SET NULL,
+
+  CONSTRAINT chk_employee_certification_dates
+    CHECK (expires_on IS NULL OR expires_on >= issued_on)
+);
+
+CREATE INDEX IF NOT EXISTS idx_employee_certifications_employee
+ON employee_certifications(employee_id, certification_type_id, expires_on);
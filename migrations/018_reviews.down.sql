@@ -0,0 +1,5 @@
+-- Rollback: drop tables in correct order (child -> parent)
+
+DROP TABLE IF EXISTS review_goals;
+DROP TABLE IF EXISTS performance_reviews;
+DROP TABLE IF EXISTS review_cycles;
@@ -0,0 +1,109 @@
+-- =========================
+-- Review cycles
+-- =========================
+-- status: draft | open | closed. Opening a cycle creates a review for every
+-- current employee; reviews can only be submitted while it is open.
+CREATE TABLE IF NOT EXISTS review_cycles (
+  id            BIGSERIAL PRIMARY KEY,
+  name          TEXT NOT NULL,
+  period_start  DATE NOT NULL,
+  period_end    DATE NOT NULL,
+  status        TEXT NOT NULL DEFAULT 'draft',
+  created_at    TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at    TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT uq_review_cycle_name UNIQUE (name),
+
+  CONSTRAINT chk_review_cycle_status
+    CHECK (status IN ('draft', 'open', 'closed')),
+
+  CONSTRAINT chk_review_cycle_period
+    CHECK (period_end >= period_start)
+);
+
+-- =========================
+-- Performance reviews
+-- =========================
+-- Ratings run from 1 to 5. The calibrated rating is the final one, agreed
+-- after comparing managers' ratings across the department.
+CREATE TABLE IF NOT EXISTS performance_reviews (
+  id                    BIGSERIAL PRIMARY KEY,
+  cycle_id              BIGINT NOT NULL,
+  employee_id           BIGINT NOT NULL,
+  reviewer_id           BIGINT,
+  self_rating           INT,
+  self_comment          TEXT,
+  self_submitted_at     TIMESTAMP,
+  manager_rating        INT,
+  manager_comment       TEXT,
+  manager_submitted_at  TIMESTAMP,
+  calibrated_rating     INT,
+  calibration_note      TEXT,
+  calibrated_at         TIMESTAMP,
+  created_at            TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at            TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT uq_performance_review UNIQUE (cycle_id, employee_id),
+
+  CONSTRAINT fk_performance_review_cycle
+    FOREIGN KEY (cycle_id)
+    REFERENCES review_cycles(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_performance_review_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_performance_review_reviewer
+    FOREIGN KEY (reviewer_id)
+    REFERENCES employees(id)
+    ON DELETE SET NULL,
+
+  CONSTRAINT chk_performance_review_ratings
+    CHECK (
+      (self_rating IS NULL OR self_rating BETWEEN 1 AND 5) AND
+      (manager_rating IS NULL OR manager_rating BETWEEN 1 AND 5) AND
+      (calibrated_rating IS NULL OR calibrated_rating BETWEEN 1 AND 5)
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_performance_reviews_reviewer
+ON performance_reviews(reviewer_id);
+
+-- =========================
+-- Goals
+-- =========================
+-- An employee's goals (OKRs) for a cycle. weight is the goal's share of
+-- the overall progress; progress is 0-100 percent.
+CREATE TABLE IF NOT EXISTS review_goals (
+  id           BIGSERIAL PRIMARY KEY,
+  cycle_id     BIGINT NOT NULL,
+  employee_id  BIGINT NOT NULL,
+  title        TEXT NOT NULL,
+  description  TEXT,
+  weight       INT NOT NULL DEFAULT 1,
+  progress     INT NOT NULL DEFAULT 0,
+  due_date     DATE,
+  created_at   TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at   TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_review_goal_cycle
+    FOREIGN KEY (cycle_id)
+    REFERENCES review_cycles(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_review_goal_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT chk_review_goal_weight
+    CHECK (weight > 0),
+
+  CONSTRAINT chk_review_goal_progress
+    CHECK (progress BETWEEN 0 AND 100)
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_goals_employee
+ON review_goals(employee_id, cycle_id);
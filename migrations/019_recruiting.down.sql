@@ -0,0 +1,7 @@
+-- Rollback: drop tables in correct order (child -> parent)
+
+DROP TABLE IF EXISTS interview_feedback;
+DROP TABLE IF EXISTS candidate_stage_changes;
+DROP TABLE IF EXISTS candidates;
+DROP TABLE IF EXISTS job_requisitions;
+DROP TABLE IF EXISTS pipeline_stages;
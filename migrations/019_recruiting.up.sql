@@ -0,0 +1,175 @@
+-- =========================
+-- Pipeline stages
+-- =========================
+-- Ordered stages every candidate moves through; offers are accepted from
+-- the last one.
+CREATE TABLE IF NOT EXISTS pipeline_stages (
+  id          BIGSERIAL PRIMARY KEY,
+  name        TEXT NOT NULL,
+  position    INT NOT NULL,
+  created_at  TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT uq_pipeline_stage_name UNIQUE (name),
+  CONSTRAINT uq_pipeline_stage_position UNIQUE (position)
+);
+
+INSERT INTO pipeline_stages (name, position) VALUES
+  ('Applied', 1),
+  ('Screening', 2),
+  ('Interview', 3),
+  ('Offer', 4)
+ON CONFLICT (name) DO NOTHING;
+
+-- =========================
+-- Job requisitions
+-- =========================
+-- status: open | on_hold | filled | cancelled. A requisition is filled
+-- once as many candidates as openings have accepted an offer.
+CREATE TABLE IF NOT EXISTS job_requisitions (
+  id                 BIGSERIAL PRIMARY KEY,
+  department_id      BIGINT NOT NULL,
+  position_id        BIGINT,
+  title              TEXT NOT NULL,
+  openings           INT NOT NULL DEFAULT 1,
+  status             TEXT NOT NULL DEFAULT 'open',
+  hiring_manager_id  BIGINT,
+  salary_min         NUMERIC(15,2),
+  salary_max         NUMERIC(15,2),
+  salary_currency    TEXT,
+  description        TEXT,
+  opened_on          DATE NOT NULL,
+  closed_on          DATE,
+  created_at         TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at         TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_requisition_department
+    FOREIGN KEY (department_id)
+    REFERENCES departments(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_requisition_position
+    FOREIGN KEY (position_id)
+    REFERENCES positions(id)
+    ON DELETE SET NULL,
+
+  CONSTRAINT fk_requisition_hiring_manager
+    FOREIGN KEY (hiring_manager_id)
+    REFERENCES employees(id)
+    ON DELETE SET NULL,
+
+  CONSTRAINT chk_requisition_status
+    CHECK (status IN ('open', 'on_hold', 'filled', 'cancelled')),
+
+  CONSTRAINT chk_requisition_openings
+    CHECK (openings > 0),
+
+  CONSTRAINT chk_requisition_salary_range
+    CHECK (salary_min IS NULL OR salary_max IS NULL OR salary_min <= salary_max)
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_requisitions_department
+ON job_requisitions(department_id, status);
+
+-- =========================
+-- Candidates
+-- =========================
+-- status: active | hired | rejected | withdrawn. employee_id is the
+-- employee created when the candidate accepted the offer.
+CREATE TABLE IF NOT EXISTS candidates (
+  id                   BIGSERIAL PRIMARY KEY,
+  requisition_id       BIGINT NOT NULL,
+  name                 TEXT NOT NULL,
+  email                TEXT,
+  phone                TEXT,
+  source               TEXT,
+  stage_id             BIGINT NOT NULL,
+  status               TEXT NOT NULL DEFAULT 'active',
+  offered_salary       NUMERIC(15,2),
+  offered_currency     TEXT,
+  expected_start_date  DATE,
+  employee_id          BIGINT,
+  note                 TEXT,
+  created_at           TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at           TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_candidate_requisition
+    FOREIGN KEY (requisition_id)
+    REFERENCES job_requisitions(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_candidate_stage
+    FOREIGN KEY (stage_id)
+    REFERENCES pipeline_stages(id),
+
+  CONSTRAINT fk_candidate_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE SET NULL,
+
+  CONSTRAINT chk_candidate_status
+    CHECK (status IN ('active', 'hired', 'rejected', 'withdrawn'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_candidates_requisition
+ON candidates(requisition_id, status);
+
+CREATE TABLE IF NOT EXISTS candidate_stage_changes (
+  id             BIGSERIAL PRIMARY KEY,
+  candidate_id   BIGINT NOT NULL,
+  from_stage_id  BIGINT,
+  to_stage_id    BIGINT NOT NULL,
+  note           TEXT,
+  changed_at     TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_stage_change_candidate
+    FOREIGN KEY (candidate_id)
+    REFERENCES candidates(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_stage_change_from
+    FOREIGN KEY (from_stage_id)
+    REFERENCES pipeline_stages(id),
+
+  CONSTRAINT fk_stage_change_to
+    FOREIGN KEY (to_stage_id)
+    REFERENCES pipeline_stages(id)
+);
+
+-- =========================
+-- Interview feedback
+-- =========================
+-- recommendation: strong_yes | yes | no | strong_no
+CREATE TABLE IF NOT EXISTS interview_feedback (
+  id              BIGSERIAL PRIMARY KEY,
+  candidate_id    BIGINT NOT NULL,
+  stage_id        BIGINT NOT NULL,
+  interviewer_id  BIGINT NOT NULL,
+  rating          INT NOT NULL,
+  recommendation  TEXT NOT NULL,
+  comment         TEXT,
+  interviewed_on  DATE NOT NULL,
+  created_at      TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_feedback_candidate
+    FOREIGN KEY (candidate_id)
+    REFERENCES candidates(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_feedback_stage
+    FOREIGN KEY (stage_id)
+    REFERENCES pipeline_stages(id),
+
+  CONSTRAINT fk_feedback_interviewer
+    FOREIGN KEY (interviewer_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT chk_feedback_rating
+    CHECK (rating BETWEEN 1 AND 5),
+
+  CONSTRAINT chk_feedback_recommendation
+    CHECK (recommendation IN ('strong_yes', 'yes', 'no', 'strong_no'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_interview_feedback_candidate
+ON interview_feedback(candidate_id);
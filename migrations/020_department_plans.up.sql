@@ -0,0 +1,25 @@
+-- =========================
+-- Headcount & budget plans
+-- =========================
+-- One plan per department and fiscal year: the headcount it may have at
+-- year end and the annual salary budget, in currency.
+CREATE TABLE IF NOT EXISTS department_plans (
+  department_id      BIGINT NOT NULL,
+  fiscal_year        INT NOT NULL,
+  planned_headcount  INT NOT NULL,
+  salary_budget      NUMERIC(15,2) NOT NULL,
+  currency           TEXT NOT NULL DEFAULT 'VND',
+  note               TEXT,
+  created_at         TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at         TIMESTAMP NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (department_id, fiscal_year),
+
+  CONSTRAINT fk_department_plan_department
+    FOREIGN KEY (department_id)
+    REFERENCES departments(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT chk_department_plan_values
+    CHECK (planned_headcount >= 0 AND salary_budget >= 0)
+);
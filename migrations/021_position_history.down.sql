@@ -0,0 +1,9 @@
+-- Rollback: drop tables in correct order (child -> parent)
+
+DROP INDEX IF EXISTS idx_employment_events_applied;
+
+DELETE FROM employment_events WHERE kind = 'position_change';
+
+ALTER TABLE employment_events
+  DROP CONSTRAINT IF EXISTS fk_employment_event_to_position,
+  DROP COLUMN IF EXISTS to_position_id;
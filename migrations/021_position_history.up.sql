@@ -0,0 +1,24 @@
+-- =========================
+-- Position history
+-- =========================
+-- Hires, rehires and position_change events record the position the
+-- employee holds from their effective date (NULL: none), so the
+-- organisation can be reconstructed as of a past date. Positions still
+-- referenced by history are merged rather than deleted.
+ALTER TABLE employment_events
+  ADD COLUMN IF NOT EXISTS to_position_id BIGINT,
+  ADD CONSTRAINT fk_employment_event_to_position
+    FOREIGN KEY (to_position_id)
+    REFERENCES positions(id)
+    ON DELETE RESTRICT;
+
+-- Earlier position changes were not recorded; assume the current position
+-- was held since the hire.
+UPDATE employment_events ev
+SET to_position_id = e.position_id
+FROM employees e
+WHERE e.id = ev.employee_id AND ev.kind IN ('hire', 'rehire');
+
+CREATE INDEX IF NOT EXISTS idx_employment_events_applied
+ON employment_events(employee_id, effective_date DESC)
+WHERE applied_at IS NOT NULL;
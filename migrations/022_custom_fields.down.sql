@@ -0,0 +1,7 @@
+-- Rollback: drop tables in correct order (child -> parent)
+
+DROP INDEX IF EXISTS idx_employees_custom_fields;
+
+ALTER TABLE employees DROP COLUMN IF EXISTS custom_fields;
+
+DROP TABLE IF EXISTS custom_field_definitions;
@@ -0,0 +1,27 @@
+-- =========================
+-- Custom fields
+-- =========================
+-- Admin-defined extra employee attributes. type: string | number | date |
+-- enum; enum values must be one of options. Values live in
+-- employees.custom_fields keyed by field_key.
+CREATE TABLE IF NOT EXISTS custom_field_definitions (
+  id          BIGSERIAL PRIMARY KEY,
+  field_key   TEXT NOT NULL,
+  label       TEXT NOT NULL,
+  type        TEXT NOT NULL,
+  options     TEXT[] NOT NULL DEFAULT '{}',
+  required    BOOLEAN NOT NULL DEFAULT false,
+  created_at  TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at  TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT uq_custom_field_key UNIQUE (field_key),
+
+  CONSTRAINT chk_custom_field_type
+    CHECK (type IN ('string', 'number', 'date', 'enum'))
+);
+
+ALTER TABLE employees
+  ADD COLUMN IF NOT EXISTS custom_fields JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_employees_custom_fields
+ON employees USING GIN (custom_fields);
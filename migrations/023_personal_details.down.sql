@@ -0,0 +1,26 @@
+-- Rollback: drop tables in correct order (child -> parent)
+
+DROP TABLE IF EXISTS employee_dependents;
+
+DROP TABLE IF EXISTS emergency_contacts;
+
+DROP TABLE IF EXISTS employee_phones;
+
+DROP TABLE IF EXISTS employee_addresses;
+
+ALTER TABLE employees
+  ADD COLUMN IF NOT EXISTS age INT;
+
+-- Estimated dates of birth give back the age as it was recorded;
+-- corrected ones give the current age.
+UPDATE employees
+SET age = CASE
+    WHEN date_of_birth_estimated THEN legacy_age
+    ELSE date_part('year', age(CURRENT_DATE, date_of_birth))::int
+  END
+WHERE date_of_birth IS NOT NULL;
+
+ALTER TABLE employees
+  DROP COLUMN IF EXISTS legacy_age,
+  DROP COLUMN IF EXISTS date_of_birth_estimated,
+  DROP COLUMN IF EXISTS date_of_birth;
@@ -0,0 +1,143 @@
+-- =========================
+-- Date of birth
+-- =========================
+-- Replaces the stored age, which went stale. Existing ages become an
+-- estimated date of birth: 1 January of the year implied by the age when
+-- the employee was last updated, flagged by date_of_birth_estimated until
+-- HR enters the real date. The recorded age is kept in legacy_age so the
+-- down migration can restore it.
+ALTER TABLE employees
+  ADD COLUMN IF NOT EXISTS date_of_birth DATE,
+  ADD COLUMN IF NOT EXISTS date_of_birth_estimated BOOLEAN NOT NULL DEFAULT false,
+  ADD COLUMN IF NOT EXISTS legacy_age INT;
+
+UPDATE employees
+SET date_of_birth = make_date(date_part('year', updated_at)::int - age, 1, 1),
+    date_of_birth_estimated = true,
+    legacy_age = age
+WHERE age IS NOT NULL AND date_of_birth IS NULL;
+
+ALTER TABLE employees DROP COLUMN IF EXISTS age;
+
+-- =========================
+-- Addresses
+-- =========================
+-- kind: permanent | temporary | mailing; country is ISO 3166-1 alpha-2.
+CREATE TABLE IF NOT EXISTS employee_addresses (
+  id           BIGSERIAL PRIMARY KEY,
+  employee_id  BIGINT NOT NULL,
+  kind         TEXT NOT NULL,
+  line1        TEXT NOT NULL,
+  line2        TEXT,
+  city         TEXT NOT NULL,
+  region       TEXT,
+  postal_code  TEXT,
+  country      CHAR(2) NOT NULL DEFAULT 'VN',
+  is_primary   BOOLEAN NOT NULL DEFAULT false,
+  created_at   TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at   TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_address_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT chk_address_kind
+    CHECK (kind IN ('permanent', 'temporary', 'mailing'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_employee_addresses_employee
+ON employee_addresses(employee_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_employee_addresses_primary
+ON employee_addresses(employee_id)
+WHERE is_primary;
+
+-- =========================
+-- Phone numbers
+-- =========================
+-- kind: mobile | home | work; number holds digits with an optional
+-- leading +.
+CREATE TABLE IF NOT EXISTS employee_phones (
+  id           BIGSERIAL PRIMARY KEY,
+  employee_id  BIGINT NOT NULL,
+  kind         TEXT NOT NULL,
+  number       TEXT NOT NULL,
+  is_primary   BOOLEAN NOT NULL DEFAULT false,
+  created_at   TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at   TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_phone_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT uq_employee_phone UNIQUE (employee_id, number),
+
+  CONSTRAINT chk_phone_kind
+    CHECK (kind IN ('mobile', 'home', 'work'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_employee_phones_primary
+ON employee_phones(employee_id)
+WHERE is_primary;
+
+-- =========================
+-- Emergency contacts
+-- =========================
+-- Lower priority is called first.
+CREATE TABLE IF NOT EXISTS emergency_contacts (
+  id            BIGSERIAL PRIMARY KEY,
+  employee_id   BIGINT NOT NULL,
+  name          TEXT NOT NULL,
+  relationship  TEXT NOT NULL,
+  phone         TEXT NOT NULL,
+  email         TEXT,
+  priority      INT NOT NULL DEFAULT 1,
+  created_at    TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at    TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_emergency_contact_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT chk_emergency_contact_priority
+    CHECK (priority >= 1)
+);
+
+CREATE INDEX IF NOT EXISTS idx_emergency_contacts_employee
+ON emergency_contacts(employee_id, priority);
+
+-- =========================
+-- Dependents
+-- =========================
+-- relationship: child | spouse | parent | other. A dependent with
+-- relief_from counts towards the payroll dependent relief from that month
+-- until relief_to.
+CREATE TABLE IF NOT EXISTS employee_dependents (
+  id             BIGSERIAL PRIMARY KEY,
+  employee_id    BIGINT NOT NULL,
+  name           TEXT NOT NULL,
+  relationship   TEXT NOT NULL,
+  date_of_birth  DATE,
+  tax_id         TEXT,
+  relief_from    DATE,
+  relief_to      DATE,
+  created_at     TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at     TIMESTAMP NOT NULL DEFAULT now(),
+
+  CONSTRAINT fk_dependent_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT chk_dependent_relationship
+    CHECK (relationship IN ('child', 'spouse', 'parent', 'other')),
+
+  CONSTRAINT chk_dependent_relief_dates
+    CHECK (relief_to IS NULL OR (relief_from IS NOT NULL AND relief_to >= relief_from))
+);
+
+CREATE INDEX IF NOT EXISTS idx_employee_dependents_employee
+ON employee_dependents(employee_id);